@@ -1,5 +1,12 @@
 package main
 
-func main() {
+import (
+	"go-link/analytics/internal/infrastructure"
+	"log"
+)
 
+func main() {
+	if err := infrastructure.Run(); err != nil {
+		log.Fatalf("server failed to start: %v", err)
+	}
 }
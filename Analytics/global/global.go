@@ -0,0 +1,15 @@
+package global
+
+import (
+	"go-link/common/pkg/common/cache"
+	"go-link/common/pkg/database/elasticsearch"
+	"go-link/common/pkg/logger"
+	"go-link/common/pkg/settings"
+)
+
+var (
+	Config        settings.Config
+	LoggerZap     *logger.LoggerZap
+	ElasticClient elasticsearch.ElasticClient
+	Redis         cache.CacheEngine
+)
@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/cache"
+)
+
+// deleteMatching deletes the keys matching pattern and returns how many there were
+func deleteMatching(ctx context.Context, redis cache.CacheEngine, pattern string) (int64, error) {
+	keys, err := redis.Keys(ctx, pattern)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	if err := redis.DeleteBulk(ctx, keys); err != nil {
+		return 0, err
+	}
+	return int64(len(keys)), nil
+}
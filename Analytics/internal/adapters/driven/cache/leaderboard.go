@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+)
+
+type leaderboardCache struct {
+	redis cache.CacheEngine
+}
+
+func NewLeaderboardRepository(redis cache.CacheEngine) ports.LeaderboardRepository {
+	return &leaderboardCache{
+		redis: redis,
+	}
+}
+
+func (l *leaderboardCache) getKey(window, scope string, bucket time.Time) string {
+	return constant.LeaderboardPrefix + window + ":" + scope + ":" + strconv.FormatInt(bucket.Unix(), 10)
+}
+
+// Save rewrites the bucket's sorted set, so links that fell out of the bucket's top do not linger
+func (l *leaderboardCache) Save(ctx context.Context, window, scope string, bucket time.Time, counts map[string]uint64, ttl time.Duration) error {
+	key := l.getKey(window, scope, bucket)
+	if err := l.redis.Delete(ctx, key); err != nil {
+		return err
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	members := make([]*cache.ZMember, 0, len(counts))
+	for member, count := range counts {
+		members = append(members, &cache.ZMember{Score: float64(count), Member: member})
+	}
+	if err := l.redis.ZAdd(ctx, key, members...); err != nil {
+		return err
+	}
+	return l.redis.Expire(ctx, key, ttl)
+}
+
+func (l *leaderboardCache) Load(ctx context.Context, window, scope string, bucket time.Time) (map[string]uint64, error) {
+	members, err := l.redis.ZRangeWithScores(ctx, l.getKey(window, scope, bucket), 0, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]uint64, len(members))
+	for _, m := range members {
+		if key, ok := m.Member.(string); ok {
+			counts[key] = uint64(m.Score)
+		}
+	}
+	return counts, nil
+}
+
+// Erase removes a link's or a tenant's entries from every saved bucket: the tenant's own board and its members of the global one.
+// Visitors are not ranked, so a visitor erasure changes nothing.
+func (l *leaderboardCache) Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error) {
+	tenant := strconv.Itoa(target.TenantID)
+
+	var own, global func(member string) bool
+	switch target.Scope {
+	case entity.ErasureLink:
+		member := mapper.LeaderboardMember(target.TenantID, target.ShortCode)
+		own = func(m string) bool { return m == target.ShortCode }
+		global = func(m string) bool { return m == member }
+	case entity.ErasureTenant:
+		own = func(string) bool { return true }
+		global = func(m string) bool { return strings.HasPrefix(m, tenant+":") }
+	default:
+		return 0, nil
+	}
+
+	removed, err := l.removeMembers(ctx, tenant, own)
+	if err != nil {
+		return removed, err
+	}
+	n, err := l.removeMembers(ctx, constant.LeaderboardGlobalScope, global)
+	return removed + n, err
+}
+
+// removeMembers removes the matching members from every bucket of the scope and returns how many it removed;
+// a bucket left empty is deleted with its last member
+func (l *leaderboardCache) removeMembers(ctx context.Context, scope string, match func(member string) bool) (int64, error) {
+	keys, err := l.redis.Keys(ctx, constant.LeaderboardPrefix+"*:"+scope+":*")
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int64
+	for _, key := range keys {
+		members, err := l.redis.ZRange(ctx, key, 0, -1)
+		if err != nil {
+			return removed, err
+		}
+
+		var matched []string
+		for _, m := range members {
+			if match(m) {
+				matched = append(matched, m)
+			}
+		}
+		n, err := l.redis.ZRem(ctx, key, matched...)
+		if err != nil {
+			return removed, err
+		}
+		removed += n
+	}
+	return removed, nil
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go-link/common/pkg/common/cache"
+	"go-link/common/pkg/database/redis"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type liveTicketStore struct {
+	redis cache.CacheEngine
+}
+
+func NewLiveTicketStore(redis cache.CacheEngine) ports.LiveTicketStore {
+	return &liveTicketStore{
+		redis: redis,
+	}
+}
+
+func (s *liveTicketStore) getKey(id string) string {
+	return constant.LiveTicketPrefix + id
+}
+
+func (s *liveTicketStore) Save(ctx context.Context, id string, ticket *entity.LiveTicket, ttl time.Duration) error {
+	return s.redis.Set(ctx, s.getKey(id), ticket, ttl)
+}
+
+// Take only hands out the ticket to the caller whose delete removed it, so two requests racing with one ticket get one stream
+func (s *liveTicketStore) Take(ctx context.Context, id string) (*entity.LiveTicket, error) {
+	data, _, err := s.redis.Get(ctx, s.getKey(id))
+	if err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	taken, err := s.redis.CompareAndDelete(ctx, s.getKey(id), json.RawMessage(data))
+	if err != nil {
+		return nil, err
+	}
+	if !taken {
+		return nil, nil
+	}
+
+	ticket := &entity.LiveTicket{}
+	if err := json.Unmarshal(data, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/ports"
+)
+
+type jobLock struct {
+	redis cache.CacheEngine
+}
+
+func NewJobLock(redis cache.CacheEngine) ports.JobLock {
+	return &jobLock{
+		redis: redis,
+	}
+}
+
+func (l *jobLock) getKey(key string) string {
+	return constant.JobLockPrefix + key
+}
+
+func (l *jobLock) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return l.redis.SetNX(ctx, l.getKey(key), 1, ttl)
+}
+
+func (l *jobLock) Release(ctx context.Context, key string) error {
+	return l.redis.Delete(ctx, l.getKey(key))
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+// radiusUnit is the unit GeoRadius is queried in
+const radiusUnit = "km"
+
+type placeCache struct {
+	redis cache.CacheEngine
+}
+
+func NewPlaceRepository(redis cache.CacheEngine) ports.PlaceRepository {
+	return &placeCache{
+		redis: redis,
+	}
+}
+
+func (p *placeCache) getKey(tenantID int, shortCode string) string {
+	return constant.PlaceIndexPrefix + strconv.Itoa(tenantID) + ":" + shortCode
+}
+
+func (p *placeCache) Add(ctx context.Context, tenantID int, shortCode string, places []*entity.Place) error {
+	if len(places) == 0 {
+		return nil
+	}
+
+	locations := make([]*cache.GeoLocation, len(places))
+	for i, place := range places {
+		locations[i] = &cache.GeoLocation{
+			Member:    place.Key,
+			Latitude:  place.Location.Latitude,
+			Longitude: place.Location.Longitude,
+		}
+	}
+
+	key := p.getKey(tenantID, shortCode)
+	if err := p.redis.GeoAdd(ctx, key, locations...); err != nil {
+		return err
+	}
+	return p.redis.Expire(ctx, key, constant.PlaceIndexTTL)
+}
+
+// Erase deletes the geo index of a link or of every link of a tenant.
+// Cities are not tied to visitors, so a visitor erasure leaves the index alone.
+func (p *placeCache) Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error) {
+	switch target.Scope {
+	case entity.ErasureLink:
+		return deleteMatching(ctx, p.redis, p.getKey(target.TenantID, target.ShortCode))
+	case entity.ErasureTenant:
+		return deleteMatching(ctx, p.redis, constant.PlaceIndexPrefix+strconv.Itoa(target.TenantID)+":*")
+	default:
+		return 0, nil
+	}
+}
+
+// Within searches the circle around the box and keeps the places inside the box itself
+func (p *placeCache) Within(ctx context.Context, tenantID int, shortCode string, box *entity.BoundingBox) ([]*entity.Place, error) {
+	center := box.Center()
+	radius := box.RadiusKm() + constant.PlaceSearchMarginKm
+
+	locations, err := p.redis.GeoRadius(ctx, p.getKey(tenantID, shortCode), center.Longitude, center.Latitude, radius, radiusUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	places := make([]*entity.Place, 0, len(locations))
+	for _, loc := range locations {
+		point := entity.GeoPoint{Latitude: loc.Latitude, Longitude: loc.Longitude}
+		if box.Contains(point) {
+			places = append(places, &entity.Place{Key: loc.Member, Location: point})
+		}
+	}
+	return places, nil
+}
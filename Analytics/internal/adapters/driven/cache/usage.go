@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go-link/common/pkg/common/cache"
+	"go-link/common/pkg/database/redis"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type usageCache struct {
+	redis cache.CacheEngine
+}
+
+func NewUsageCache(redis cache.CacheEngine) ports.UsageCache {
+	return &usageCache{
+		redis: redis,
+	}
+}
+
+func (u *usageCache) linkKey(tenantID int, shortCode string) string {
+	return constant.UsageCachePrefix + "link:" + strconv.Itoa(tenantID) + ":" + shortCode
+}
+
+func (u *usageCache) usageKey(tenantID int, from, to time.Time) string {
+	return constant.UsageCachePrefix + "usage:" + strconv.Itoa(tenantID) + ":" +
+		strconv.FormatInt(from.UnixMilli(), 10) + ":" + strconv.FormatInt(to.UnixMilli(), 10)
+}
+
+func (u *usageCache) GetLinks(ctx context.Context, tenantID int, shortCodes []string) (map[string]*entity.LinkStats, error) {
+	found := make(map[string]*entity.LinkStats, len(shortCodes))
+	for _, code := range shortCodes {
+		stats := &entity.LinkStats{}
+		if err := cache.HandleHitCache(ctx, stats, u.redis, u.linkKey(tenantID, code)); err != nil {
+			if errors.Is(err, redis.ErrKeyNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		found[code] = stats
+	}
+	return found, nil
+}
+
+func (u *usageCache) SaveLinks(ctx context.Context, stats []*entity.LinkStats, ttl time.Duration) error {
+	values := make(map[string]any, len(stats))
+	for _, s := range stats {
+		values[u.linkKey(s.TenantID, s.ShortCode)] = s
+	}
+	return u.redis.BatchSet(ctx, values, ttl)
+}
+
+func (u *usageCache) GetUsage(ctx context.Context, tenantID int, from, to time.Time) (*entity.TenantUsage, error) {
+	usage := &entity.TenantUsage{}
+	if err := cache.HandleHitCache(ctx, usage, u.redis, u.usageKey(tenantID, from, to)); err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (u *usageCache) SaveUsage(ctx context.Context, usage *entity.TenantUsage, ttl time.Duration) error {
+	return u.redis.Set(ctx, u.usageKey(usage.TenantID, usage.From, usage.To), usage, ttl)
+}
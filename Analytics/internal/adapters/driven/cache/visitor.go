@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go-link/common/pkg/common/cache"
+	"go-link/common/pkg/database/redis"
+	"go-link/common/pkg/datastructs/hll"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type visitorCache struct {
+	redis cache.CacheEngine
+}
+
+func NewVisitorRepository(redis cache.CacheEngine) ports.VisitorRepository {
+	return &visitorCache{
+		redis: redis,
+	}
+}
+
+func (v *visitorCache) getKey(tenantID int, shortCode string, day time.Time) string {
+	return constant.VisitorSketchPrefix + strconv.Itoa(tenantID) + ":" + shortCode + ":" + day.UTC().Format(constant.VisitorDayLayout)
+}
+
+// Add reads, updates and writes back the day's sketch. Clicks are partitioned by short code,
+// so one consumer owns a link's sketches and the read-modify-write does not race;
+// a batch replayed after a rebalance only re-adds visitors already counted.
+func (v *visitorCache) Add(ctx context.Context, tenantID int, shortCode string, day time.Time, hashes []uint64) error {
+	key := v.getKey(tenantID, shortCode, day)
+
+	sketch, err := v.load(ctx, key)
+	if err != nil {
+		return err
+	}
+	if sketch == nil {
+		if sketch, err = hll.New(hll.DefaultPrecision); err != nil {
+			return err
+		}
+	}
+
+	for _, h := range hashes {
+		sketch.Add(h)
+	}
+
+	return v.redis.Set(ctx, key, sketch, constant.VisitorSketchTTL)
+}
+
+func (v *visitorCache) Get(ctx context.Context, tenantID int, shortCode string, days []time.Time) ([]*hll.Sketch, error) {
+	sketches := make([]*hll.Sketch, len(days))
+	for i, day := range days {
+		sketch, err := v.load(ctx, v.getKey(tenantID, shortCode, day))
+		if err != nil {
+			return nil, err
+		}
+		sketches[i] = sketch
+	}
+	return sketches, nil
+}
+
+// Erase deletes every daily sketch of a link or a tenant. A sketch can't forget one visitor
+// and holds nothing that identifies them, so a visitor erasure leaves sketches alone.
+func (v *visitorCache) Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error) {
+	switch target.Scope {
+	case entity.ErasureLink:
+		return deleteMatching(ctx, v.redis, constant.VisitorSketchPrefix+strconv.Itoa(target.TenantID)+":"+target.ShortCode+":*")
+	case entity.ErasureTenant:
+		return deleteMatching(ctx, v.redis, constant.VisitorSketchPrefix+strconv.Itoa(target.TenantID)+":*")
+	default:
+		return 0, nil
+	}
+}
+
+// load returns the stored sketch, or nil when the key does not exist
+func (v *visitorCache) load(ctx context.Context, key string) (*hll.Sketch, error) {
+	sketch := &hll.Sketch{}
+	if err := cache.HandleHitCache(ctx, sketch, v.redis, key); err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sketch, nil
+}
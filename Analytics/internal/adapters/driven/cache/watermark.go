@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"go-link/common/pkg/common/cache"
+	"go-link/common/pkg/database/redis"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type rollupWatermark struct {
+	redis cache.CacheEngine
+}
+
+func NewRollupWatermarkRepository(redis cache.CacheEngine) ports.RollupWatermarkRepository {
+	return &rollupWatermark{
+		redis: redis,
+	}
+}
+
+func (w *rollupWatermark) Get(ctx context.Context) (*entity.RollupWatermark, error) {
+	watermark := &entity.RollupWatermark{}
+	if err := cache.HandleHitCache(ctx, watermark, w.redis, constant.RollupWatermarkKey); err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return watermark, nil
+}
+
+// Save keeps the watermark without expiry; every compaction run replaces it
+func (w *rollupWatermark) Save(ctx context.Context, watermark *entity.RollupWatermark) error {
+	return w.redis.Set(ctx, constant.RollupWatermarkKey, watermark, 0)
+}
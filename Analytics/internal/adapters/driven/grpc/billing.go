@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	billingv1 "go-link/common/gen/go/billing/v1"
+	"go-link/common/pkg/grpc"
+	"go-link/common/pkg/settings"
+
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type billingClientAdapter struct {
+	client billingv1.BillingServiceClient
+}
+
+func NewBillingClient(cfg settings.GRPCService) (ports.BillingClient, error) {
+	conn, err := grpc.NewClientConn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &billingClientAdapter{
+		client: billingv1.NewBillingServiceClient(conn),
+	}, nil
+}
+
+// GetRetention maps a tenant without a subscription to no plan rather than an error
+func (a *billingClientAdapter) GetRetention(ctx context.Context, tenantID int) (*entity.RetentionPolicy, error) {
+	resp, err := a.client.GetTenantRetention(ctx, &billingv1.GetTenantRetentionRequest{
+		TenantId: int64(tenantID),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entity.RetentionPolicy{
+		TenantID:     tenantID,
+		RawDays:      int(resp.RawRetentionDays),
+		HourlyMonths: int(resp.HourlyRetentionMonths),
+	}, nil
+}
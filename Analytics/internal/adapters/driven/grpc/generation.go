@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	generationv1 "go-link/common/gen/go/generation/v1"
+	"go-link/common/pkg/grpc"
+	"go-link/common/pkg/settings"
+
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type generationClientAdapter struct {
+	client generationv1.GenerationServiceClient
+}
+
+func NewGenerationClient(cfg settings.GRPCService) (ports.GenerationClient, error) {
+	conn, err := grpc.NewClientConn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &generationClientAdapter{
+		client: generationv1.NewGenerationServiceClient(conn),
+	}, nil
+}
+
+// GetCollection maps a collection Generation doesn't know for the tenant to nil rather than an error
+func (a *generationClientAdapter) GetCollection(ctx context.Context, tenantID int, collectionID string) (*entity.Collection, error) {
+	resp, err := a.client.ListCollectionLinks(ctx, &generationv1.ListCollectionLinksRequest{
+		TenantId:     int64(tenantID),
+		CollectionId: collectionID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	shortCodes := resp.LinkIds
+	if shortCodes == nil {
+		// An empty collection must still narrow stats down to no links
+		shortCodes = []string{}
+	}
+	return &entity.Collection{
+		ID:         collectionID,
+		TenantID:   tenantID,
+		ShortCodes: shortCodes,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-link/common/pkg/mq/kafka"
+
+	"go-link/analytics/internal/ports"
+
+	notificationv1 "github.com/huynhanx03/GoLink/events-contract/notification/v1"
+	"github.com/huynhanx03/GoLink/events-contract/topics"
+)
+
+type notificationPublisher struct {
+	producer kafka.SyncProducer
+}
+
+// NewNotificationPublisher creates a publisher for reports and alerts sent through Notification.
+// It publishes synchronously so a message is only treated as sent once the broker has it.
+func NewNotificationPublisher(producer kafka.SyncProducer) ports.NotificationPublisher {
+	return &notificationPublisher{
+		producer: producer,
+	}
+}
+
+// Publish keys messages by recipient so one recipient's messages stay in order
+func (p *notificationPublisher) Publish(ctx context.Context, evt *notificationv1.NotificationSendEvent) error {
+	value, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+
+	key := evt.Recipient.UserID
+	if evt.Recipient.Email != "" {
+		key = evt.Recipient.Email
+	}
+	_, _, err = p.producer.Publish(ctx, topics.NotificationSend, []byte(key), value)
+	return err
+}
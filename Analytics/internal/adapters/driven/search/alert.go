@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/search/models"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type AlertSettingsRepository struct {
+	repo *elasticsearch.BaseRepository[models.AlertSettings, string]
+}
+
+// NewAlertSettingsRepository creates a new instance of AlertSettingsRepository
+func NewAlertSettingsRepository() ports.AlertSettingsRepository {
+	return &AlertSettingsRepository{
+		repo: elasticsearch.NewBaseRepository[models.AlertSettings, string](global.ElasticClient, models.AlertSettingsIndexName),
+	}
+}
+
+func (r *AlertSettingsRepository) Save(ctx context.Context, settings *entity.AlertSettings) error {
+	return r.repo.Index(ctx, models.FromAlertSettingsEntity(settings))
+}
+
+func (r *AlertSettingsRepository) Get(ctx context.Context, tenantID int) (*entity.AlertSettings, error) {
+	doc, err := r.repo.Get(ctx, models.AlertSettingsID(tenantID))
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
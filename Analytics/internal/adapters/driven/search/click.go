@@ -0,0 +1,398 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/search/models"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type ClickRepository struct {
+	repo *elasticsearch.BaseRepository[models.Click, string]
+}
+
+// NewClickRepository creates a new instance of ClickRepository
+func NewClickRepository() ports.ClickRepository {
+	return &ClickRepository{
+		repo: elasticsearch.NewBaseRepository[models.Click, string](global.ElasticClient, models.ClickIndexName),
+	}
+}
+
+// SaveBulk indexes the clicks in one bulk request, skipping event IDs already indexed
+func (r *ClickRepository) SaveBulk(ctx context.Context, clicks []*entity.Click) (int, error) {
+	docs := make([]*models.Click, len(clicks))
+	for i, c := range clicks {
+		docs[i] = models.FromClickEntity(c)
+	}
+	return r.repo.CreateBulkIfAbsent(ctx, docs)
+}
+
+// CountTracked counts clicks that carried a click ID, per group
+func (r *ClickRepository) CountTracked(ctx context.Context, filter *entity.ConversionFilter) (map[string]int64, error) {
+	field, ok := models.GroupFields[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown group %q", filter.GroupBy)
+	}
+
+	tracked := map[string]any{"exists": map[string]any{"field": models.ClickIDField}}
+	query := map[string]any{
+		"query": tenantRangeQuery(filter.TenantID, models.TimestampField, filter.From, filter.To, tracked),
+		"aggs": map[string]any{
+			groupsAgg: termsAgg(field, filter.Limit, nil),
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups struct {
+		Buckets []struct {
+			Key      string `json:"key"`
+			DocCount int64  `json:"doc_count"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[groupsAgg]; ok {
+		if err := json.Unmarshal(raw, &groups); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	counts := make(map[string]int64, len(groups.Buckets))
+	for _, b := range groups.Buckets {
+		counts[b.Key] = b.DocCount
+	}
+	return counts, nil
+}
+
+// TimeSeries counts clicks per calendar bucket in the query's time zone, including empty buckets
+func (r *ClickRepository) TimeSeries(ctx context.Context, q *entity.TimeSeriesQuery) (*entity.TimeSeries, error) {
+	query := map[string]any{
+		"query": clickFilterQuery(q.Filter, q.From, q.To),
+		"aggs": map[string]any{
+			seriesAgg: map[string]any{
+				"date_histogram": map[string]any{
+					"field":             models.TimestampField,
+					"calendar_interval": q.Interval,
+					"time_zone":         q.Location.String(),
+					"min_doc_count":     0,
+					"extended_bounds": map[string]any{
+						"min": q.From.UnixMilli(),
+						"max": q.To.UnixMilli() - 1,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var series struct {
+		Buckets []struct {
+			Key      int64 `json:"key"`
+			DocCount int64 `json:"doc_count"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[seriesAgg]; ok {
+		if err := json.Unmarshal(raw, &series); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	points := make([]*entity.TimeSeriesPoint, len(series.Buckets))
+	for i, b := range series.Buckets {
+		points[i] = &entity.TimeSeriesPoint{
+			Time:   time.UnixMilli(b.Key).In(q.Location),
+			Clicks: b.DocCount,
+		}
+	}
+	return &entity.TimeSeries{From: q.From, To: q.To, Points: points}, nil
+}
+
+// Breakdown returns the most clicked values of a dimension and the total the shares are taken of
+func (r *ClickRepository) Breakdown(ctx context.Context, q *entity.BreakdownQuery) (*entity.Breakdown, error) {
+	field, ok := models.DimensionFields[q.Dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown dimension %q", q.Dimension)
+	}
+
+	query := map[string]any{
+		"query": clickFilterQuery(q.Filter, q.From, q.To),
+		"aggs": map[string]any{
+			groupsAgg: termsAgg(field, q.Limit, nil),
+			totalAgg:  map[string]any{"value_count": map[string]any{"field": models.TimestampField}},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups struct {
+		SumOtherDocCount int64 `json:"sum_other_doc_count"`
+		Buckets          []struct {
+			Key      string `json:"key"`
+			DocCount int64  `json:"doc_count"`
+		} `json:"buckets"`
+	}
+	var total struct {
+		Value int64 `json:"value"`
+	}
+	for name, dst := range map[string]any{groupsAgg: &groups, totalAgg: &total} {
+		if raw, ok := aggs[name]; ok {
+			if err := json.Unmarshal(raw, dst); err != nil {
+				return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+			}
+		}
+	}
+
+	rows := make([]*entity.BreakdownRow, len(groups.Buckets))
+	for i, b := range groups.Buckets {
+		rows[i] = &entity.BreakdownRow{Key: b.Key, Clicks: b.DocCount}
+	}
+	return &entity.Breakdown{Rows: rows, Other: groups.SumOtherDocCount, Total: total.Value}, nil
+}
+
+// PlaceClicks counts clicks of the given cities, keeping the most clicked ones
+func (r *ClickRepository) PlaceClicks(ctx context.Context, q *entity.GeoQuery, keys []string) (map[string]int64, error) {
+	query := map[string]any{
+		"query": clickFilterQuery(q.Filter, q.From, q.To),
+		"aggs": map[string]any{
+			groupsAgg: map[string]any{"terms": map[string]any{
+				"field":   models.CityKeyField,
+				"size":    q.Limit,
+				"include": keys,
+			}},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups struct {
+		Buckets []struct {
+			Key      string `json:"key"`
+			DocCount int64  `json:"doc_count"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[groupsAgg]; ok {
+		if err := json.Unmarshal(raw, &groups); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	counts := make(map[string]int64, len(groups.Buckets))
+	for _, b := range groups.Buckets {
+		counts[b.Key] = b.DocCount
+	}
+	return counts, nil
+}
+
+// Scan sorts on timestamp with the document ID as tie-breaker, so search_after resumes exactly after the last click
+// even when several share a millisecond
+func (r *ClickRepository) Scan(ctx context.Context, filter *entity.ClickFilter, from, to time.Time, after *entity.Click, size int) ([]*entity.Click, error) {
+	query := map[string]any{
+		"query": clickFilterQuery(filter, from, to),
+		"size":  size,
+		"sort": []any{
+			map[string]any{models.TimestampField: "asc"},
+			map[string]any{models.IDField: "asc"},
+		},
+	}
+	if after != nil {
+		query["search_after"] = []any{after.Timestamp.UnixMilli(), after.EventID}
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := r.repo.Search(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	clicks := make([]*entity.Click, len(docs))
+	for i, d := range docs {
+		clicks[i] = d.ToEntity()
+	}
+	return clicks, nil
+}
+
+// SumHours counts clicks per link per UTC hour, with bot clicks counted apart
+func (r *ClickRepository) SumHours(ctx context.Context, from, to time.Time, after *entity.Rollup, size int) ([]*entity.Rollup, error) {
+	query := map[string]any{
+		"query": map[string]any{"range": map[string]any{models.TimestampField: map[string]any{
+			"gte": from.UTC().Format(time.RFC3339Nano),
+			"lt":  to.UTC().Format(time.RFC3339Nano),
+		}}},
+		"aggs": map[string]any{
+			pagesAgg: rollupAgg(models.TimestampField, "1h", after, size, map[string]any{
+				botsAgg: map[string]any{"filter": map[string]any{"term": map[string]any{models.BotField: true}}},
+			}),
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var pages struct {
+		Buckets []struct {
+			Key      rollupKey `json:"key"`
+			DocCount int64     `json:"doc_count"`
+			Bots     struct {
+				DocCount int64 `json:"doc_count"`
+			} `json:"bots"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[pagesAgg]; ok {
+		if err := json.Unmarshal(raw, &pages); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	rollups := make([]*entity.Rollup, len(pages.Buckets))
+	for i, b := range pages.Buckets {
+		rollups[i] = &entity.Rollup{
+			TenantID:   b.Key.TenantID,
+			ShortCode:  b.Key.ShortCode,
+			Resolution: entity.ResolutionHour,
+			Bucket:     time.UnixMilli(b.Key.Bucket).UTC(),
+			Clicks:     b.DocCount - b.Bots.DocCount,
+			BotClicks:  b.Bots.DocCount,
+		}
+	}
+	return rollups, nil
+}
+
+// Totals counts all clicks and the bot ones apart, keeping the latest timestamp
+func (r *ClickRepository) Totals(ctx context.Context, q *entity.TotalsQuery) (map[string]*entity.ClickTotals, error) {
+	query := map[string]any{
+		"query": totalsQuery(q, models.TimestampField),
+		"aggs": totalsAgg(q, map[string]any{
+			botsAgg:   map[string]any{"filter": map[string]any{"term": map[string]any{models.BotField: true}}},
+			boundsMax: map[string]any{"max": map[string]any{"field": models.TimestampField}},
+		}),
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := totalsBuckets(aggs)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]*entity.ClickTotals, len(buckets))
+	for _, raw := range buckets {
+		var b struct {
+			Key      string `json:"key"`
+			DocCount int64  `json:"doc_count"`
+			Bots     struct {
+				DocCount int64 `json:"doc_count"`
+			} `json:"bots"`
+			Last struct {
+				Value *float64 `json:"value"`
+			} `json:"last"`
+		}
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+		totals[b.Key] = &entity.ClickTotals{
+			Clicks:      b.DocCount - b.Bots.DocCount,
+			BotClicks:   b.Bots.DocCount,
+			LastClickAt: msTime(b.Last.Value),
+		}
+	}
+	return totals, nil
+}
+
+// Oldest reads the earliest click timestamp of the tenant, or across all tenants
+func (r *ClickRepository) Oldest(ctx context.Context, tenantID int) (time.Time, error) {
+	query := map[string]any{
+		"query": tenantQuery(tenantID),
+		"aggs": map[string]any{
+			boundsMin: map[string]any{"min": map[string]any{"field": models.TimestampField}},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return decodeTimeAgg(aggs, boundsMin)
+}
+
+// DeleteBefore removes a tenant's clicks made before the cutoff
+func (r *ClickRepository) DeleteBefore(ctx context.Context, tenantID int, before time.Time) (int64, error) {
+	body, err := json.Marshal(map[string]any{"query": tenantBeforeQuery(tenantID, models.TimestampField, before)})
+	if err != nil {
+		return 0, err
+	}
+	return r.repo.DeleteByQuery(ctx, bytes.NewReader(body))
+}
+
+// Erase removes every click of the target
+func (r *ClickRepository) Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error) {
+	body, err := json.Marshal(map[string]any{"query": erasureQuery(target)})
+	if err != nil {
+		return 0, err
+	}
+	return r.repo.DeleteByQuery(ctx, bytes.NewReader(body))
+}
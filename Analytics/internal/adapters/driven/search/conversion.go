@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/search/models"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type ConversionRepository struct {
+	repo *elasticsearch.BaseRepository[models.Conversion, string]
+}
+
+// NewConversionRepository creates a new instance of ConversionRepository
+func NewConversionRepository() ports.ConversionRepository {
+	return &ConversionRepository{
+		repo: elasticsearch.NewBaseRepository[models.Conversion, string](global.ElasticClient, models.ConversionIndexName),
+	}
+}
+
+// Create stores the conversion unless its ID is already taken
+func (r *ConversionRepository) Create(ctx context.Context, conversion *entity.Conversion) (bool, error) {
+	return r.repo.CreateIfAbsent(ctx, models.FromConversionEntity(conversion))
+}
+
+// Stats aggregates conversions, converted clicks and value per group, for clicks made inside the filter's range
+func (r *ConversionRepository) Stats(ctx context.Context, filter *entity.ConversionFilter) ([]*entity.ConversionStats, error) {
+	field, ok := models.GroupFields[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown group %q", filter.GroupBy)
+	}
+
+	query := map[string]any{
+		"query": tenantRangeQuery(filter.TenantID, models.ClickedAtField, filter.From, filter.To),
+		"aggs": map[string]any{
+			groupsAgg: termsAgg(field, filter.Limit, map[string]any{
+				"value":  map[string]any{"sum": map[string]any{"field": models.ValueField}},
+				"clicks": map[string]any{"cardinality": map[string]any{"field": models.ClickIDField}},
+			}),
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups struct {
+		Buckets []struct {
+			Key      string `json:"key"`
+			DocCount int64  `json:"doc_count"`
+			Value    struct {
+				Value float64 `json:"value"`
+			} `json:"value"`
+			Clicks struct {
+				Value int64 `json:"value"`
+			} `json:"clicks"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[groupsAgg]; ok {
+		if err := json.Unmarshal(raw, &groups); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	stats := make([]*entity.ConversionStats, len(groups.Buckets))
+	for i, b := range groups.Buckets {
+		stats[i] = &entity.ConversionStats{
+			Key:             b.Key,
+			Conversions:     b.DocCount,
+			ConvertedClicks: b.Clicks.Value,
+			Value:           b.Value.Value,
+		}
+	}
+	return stats, nil
+}
+
+// Erase removes the target's conversions; they hold no visitor data, so a visitor erasure removes none
+func (r *ConversionRepository) Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error) {
+	if target.Scope == entity.ErasureVisitor {
+		return 0, nil
+	}
+
+	body, err := json.Marshal(map[string]any{"query": erasureQuery(target)})
+	if err != nil {
+		return 0, err
+	}
+	return r.repo.DeleteByQuery(ctx, bytes.NewReader(body))
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/search/models"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type ExportRepository struct {
+	repo *elasticsearch.BaseRepository[models.Export, string]
+}
+
+// NewExportRepository creates a new instance of ExportRepository
+func NewExportRepository() ports.ExportRepository {
+	return &ExportRepository{
+		repo: elasticsearch.NewBaseRepository[models.Export, string](global.ElasticClient, models.ExportIndexName),
+	}
+}
+
+func (r *ExportRepository) Save(ctx context.Context, job *entity.ExportJob) error {
+	return r.repo.Index(ctx, models.FromExportEntity(job))
+}
+
+func (r *ExportRepository) Get(ctx context.Context, id string) (*entity.ExportJob, error) {
+	doc, err := r.repo.Get(ctx, id)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+func (r *ExportRepository) ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]*entity.ExportJob, error) {
+	query := map[string]any{
+		"query": map[string]any{"bool": map[string]any{
+			"should": []any{
+				map[string]any{"term": map[string]any{models.StatusField: entity.ExportStatusPending}},
+				map[string]any{"bool": map[string]any{"filter": []any{
+					map[string]any{"term": map[string]any{models.StatusField: entity.ExportStatusRunning}},
+					map[string]any{"range": map[string]any{models.StartedAtField: map[string]any{
+						"lt": staleBefore.UTC().Format(time.RFC3339Nano),
+					}}},
+				}}},
+			},
+			"minimum_should_match": 1,
+		}},
+		"size": limit,
+		"sort": []any{map[string]any{models.CreatedAtField: "asc"}},
+	}
+	return r.search(ctx, query)
+}
+
+func (r *ExportRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.ExportJob, error) {
+	query := map[string]any{
+		"query": map[string]any{"bool": map[string]any{"filter": []any{
+			map[string]any{"term": map[string]any{models.StatusField: entity.ExportStatusDone}},
+			map[string]any{"range": map[string]any{models.ExpiresAtField: map[string]any{
+				"lte": now.UTC().Format(time.RFC3339Nano),
+			}}},
+		}}},
+		"size": limit,
+		"sort": []any{map[string]any{models.ExpiresAtField: "asc"}},
+	}
+	return r.search(ctx, query)
+}
+
+func (r *ExportRepository) search(ctx context.Context, query map[string]any) ([]*entity.ExportJob, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := r.repo.Search(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*entity.ExportJob, len(docs))
+	for i, d := range docs {
+		jobs[i] = d.ToEntity()
+	}
+	return jobs, nil
+}
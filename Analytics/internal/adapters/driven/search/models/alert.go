@@ -0,0 +1,87 @@
+package models
+
+import (
+	"strconv"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+const AlertSettingsIndexName = "alert_settings"
+
+// AlertSettingsMapping stores one alert settings document per tenant, keyed by tenant ID
+const AlertSettingsMapping = `{
+  "mappings": {
+    "properties": {
+      "id":                {"type": "keyword"},
+      "tenant_id":         {"type": "integer"},
+      "user_id":           {"type": "integer"},
+      "name":              {"type": "keyword", "index": false},
+      "email":             {"type": "keyword", "index": false},
+      "enabled":           {"type": "boolean"},
+      "channels":          {"type": "keyword"},
+      "spike_factor":      {"type": "float"},
+      "spike_min_clicks":  {"type": "long"},
+      "drop_min_baseline": {"type": "float"},
+      "drop_minutes":      {"type": "integer"},
+      "created_at":        {"type": "date"},
+      "updated_at":        {"type": "date"}
+    }
+  }
+}`
+
+type AlertSettings struct {
+	*elasticsearch.BaseModel[string]
+	TenantID        int      `json:"tenant_id"`
+	UserID          int      `json:"user_id"`
+	Name            string   `json:"name,omitempty"`
+	Email           string   `json:"email,omitempty"`
+	Enabled         bool     `json:"enabled"`
+	Channels        []string `json:"channels"`
+	SpikeFactor     float64  `json:"spike_factor"`
+	SpikeMinClicks  uint64   `json:"spike_min_clicks"`
+	DropMinBaseline float64  `json:"drop_min_baseline"`
+	DropMinutes     int      `json:"drop_minutes"`
+}
+
+// AlertSettingsID is the document ID of a tenant's settings
+func AlertSettingsID(tenantID int) string {
+	return strconv.Itoa(tenantID)
+}
+
+func FromAlertSettingsEntity(e *entity.AlertSettings) *AlertSettings {
+	base := elasticsearch.NewBaseModel(AlertSettingsID(e.TenantID))
+	if !e.UpdatedAt.IsZero() {
+		base.UpdatedAt = e.UpdatedAt
+	}
+	return &AlertSettings{
+		BaseModel:       &base,
+		TenantID:        e.TenantID,
+		UserID:          e.UserID,
+		Name:            e.Name,
+		Email:           e.Email,
+		Enabled:         e.Enabled,
+		Channels:        e.Channels,
+		SpikeFactor:     e.SpikeFactor,
+		SpikeMinClicks:  e.SpikeMinClicks,
+		DropMinBaseline: e.DropMinBaseline,
+		DropMinutes:     e.DropMinutes,
+	}
+}
+
+func (d *AlertSettings) ToEntity() *entity.AlertSettings {
+	return &entity.AlertSettings{
+		TenantID:        d.TenantID,
+		UserID:          d.UserID,
+		Name:            d.Name,
+		Email:           d.Email,
+		Enabled:         d.Enabled,
+		Channels:        d.Channels,
+		SpikeFactor:     d.SpikeFactor,
+		SpikeMinClicks:  d.SpikeMinClicks,
+		DropMinBaseline: d.DropMinBaseline,
+		DropMinutes:     d.DropMinutes,
+		UpdatedAt:       d.UpdatedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+const (
+	ClickIndexName = "clicks"
+
+	TimestampField      = "timestamp"
+	BotField            = "bot"
+	DeviceField         = "device"
+	BrowserField        = "browser"
+	OSField             = "os"
+	ReferrerDomainField = "referrer_domain"
+	SourceField         = "source"
+	MediumField         = "medium"
+	LanguageField       = "language"
+	CountryField        = "country"
+	CityKeyField        = "city_key"
+)
+
+// ClickMapping is the click event index; clicks carry the same grouping fields as conversions
+const ClickMapping = `{
+  "mappings": {
+    "properties": {
+      "id":              {"type": "keyword"},
+      "click_id":        {"type": "keyword"},
+      "tenant_id":       {"type": "integer"},
+      "short_code":      {"type": "keyword"},
+      "variant":         {"type": "keyword"},
+      "campaign":        {"type": "keyword"},
+      "timestamp":       {"type": "date"},
+      "ip_hash":         {"type": "keyword"},
+      "traffic":         {"type": "keyword"},
+      "bot":             {"type": "boolean"},
+      "user_agent":      {"type": "keyword", "index": false, "doc_values": false},
+      "browser":         {"type": "keyword"},
+      "browser_version": {"type": "keyword"},
+      "os":              {"type": "keyword"},
+      "device":          {"type": "keyword"},
+      "referrer":        {"type": "keyword", "index": false, "doc_values": false},
+      "referrer_domain": {"type": "keyword"},
+      "source":          {"type": "keyword"},
+      "medium":          {"type": "keyword"},
+      "language":        {"type": "keyword"},
+      "country":         {"type": "keyword"},
+      "region":          {"type": "keyword"},
+      "city":            {"type": "keyword"},
+      "city_key":        {"type": "keyword"},
+      "location":        {"type": "geo_point"},
+      "created_at":      {"type": "date"},
+      "updated_at":      {"type": "date"}
+    }
+  }
+}`
+
+type Click struct {
+	*elasticsearch.BaseModel[string]
+	ClickID        string    `json:"click_id,omitempty"` // only set for links with conversion tracking
+	TenantID       int       `json:"tenant_id"`
+	ShortCode      string    `json:"short_code"`
+	Variant        string    `json:"variant"`
+	Campaign       string    `json:"campaign"`
+	Timestamp      time.Time `json:"timestamp"`
+	IPHash         string    `json:"ip_hash"`
+	Traffic        string    `json:"traffic"`
+	Bot            bool      `json:"bot"`
+	UserAgent      string    `json:"user_agent"`
+	Browser        string    `json:"browser"`
+	BrowserVersion string    `json:"browser_version"`
+	OS             string    `json:"os"`
+	Device         string    `json:"device"`
+	Referrer       string    `json:"referrer"`
+	ReferrerDomain string    `json:"referrer_domain"`
+	Source         string    `json:"source"`
+	Medium         string    `json:"medium"`
+	Language       string    `json:"language"`
+	Country        string    `json:"country,omitempty"`
+	Region         string    `json:"region,omitempty"`
+	City           string    `json:"city,omitempty"`
+	CityKey        string    `json:"city_key,omitempty"`
+	Location       *GeoPoint `json:"location,omitempty"`
+}
+
+// GeoPoint is the object form of a geo_point field
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// DimensionFields maps a breakdown dimension to the document field holding it
+var DimensionFields = map[string]string{
+	entity.DimensionReferrer: ReferrerDomainField,
+	entity.DimensionSource:   SourceField,
+	entity.DimensionMedium:   MediumField,
+	entity.DimensionDevice:   DeviceField,
+	entity.DimensionBrowser:  BrowserField,
+	entity.DimensionOS:       OSField,
+	entity.DimensionLanguage: LanguageField,
+	entity.DimensionCountry:  CountryField,
+	entity.DimensionCity:     CityKeyField,
+	entity.DimensionLink:     ShortCodeField,
+}
+
+// FromClickEntity keys the document by event ID, so a redelivered event maps onto the same document
+func FromClickEntity(e *entity.Click) *Click {
+	base := elasticsearch.NewBaseModel(e.EventID)
+	doc := &Click{
+		BaseModel:      &base,
+		ClickID:        e.ClickID,
+		TenantID:       e.TenantID,
+		ShortCode:      e.ShortCode,
+		Variant:        e.Variant,
+		Campaign:       e.Campaign,
+		Timestamp:      e.Timestamp,
+		IPHash:         e.IPHash,
+		Traffic:        e.Traffic,
+		Bot:            e.Bot,
+		UserAgent:      e.UserAgent,
+		Browser:        e.Browser,
+		BrowserVersion: e.BrowserVersion,
+		OS:             e.OS,
+		Device:         e.Device,
+		Referrer:       e.Referrer,
+		ReferrerDomain: e.ReferrerDomain,
+		Source:         e.Source,
+		Medium:         e.Medium,
+		Language:       e.Language,
+		Country:        e.Country,
+		Region:         e.Region,
+		City:           e.City,
+		CityKey:        e.CityKey,
+	}
+	if e.Location != nil {
+		doc.Location = &GeoPoint{Lat: e.Location.Latitude, Lon: e.Location.Longitude}
+	}
+	return doc
+}
+
+func (d *Click) ToEntity() *entity.Click {
+	e := &entity.Click{
+		EventID:        d.ID,
+		ClickID:        d.ClickID,
+		TenantID:       d.TenantID,
+		ShortCode:      d.ShortCode,
+		Variant:        d.Variant,
+		Campaign:       d.Campaign,
+		Timestamp:      d.Timestamp,
+		IPHash:         d.IPHash,
+		Traffic:        d.Traffic,
+		Bot:            d.Bot,
+		UserAgent:      d.UserAgent,
+		Browser:        d.Browser,
+		BrowserVersion: d.BrowserVersion,
+		OS:             d.OS,
+		Device:         d.Device,
+		Referrer:       d.Referrer,
+		ReferrerDomain: d.ReferrerDomain,
+		Source:         d.Source,
+		Medium:         d.Medium,
+		Language:       d.Language,
+		Country:        d.Country,
+		Region:         d.Region,
+		City:           d.City,
+		CityKey:        d.CityKey,
+	}
+	if d.Location != nil {
+		e.Location = &entity.GeoPoint{Latitude: d.Location.Lat, Longitude: d.Location.Lon}
+	}
+	return e
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+const (
+	ConversionIndexName = "conversions"
+
+	ClickIDField     = "click_id"
+	TenantIDField    = "tenant_id"
+	ShortCodeField   = "short_code"
+	VariantField     = "variant"
+	CampaignField    = "campaign"
+	ValueField       = "value"
+	ClickedAtField   = "clicked_at"
+	ConvertedAtField = "converted_at"
+)
+
+// ConversionMapping keeps the grouping fields as keywords so they can be aggregated on
+const ConversionMapping = `{
+  "mappings": {
+    "properties": {
+      "id":           {"type": "keyword"},
+      "click_id":     {"type": "keyword"},
+      "tenant_id":    {"type": "integer"},
+      "short_code":   {"type": "keyword"},
+      "variant":      {"type": "keyword"},
+      "campaign":     {"type": "keyword"},
+      "event":        {"type": "keyword"},
+      "order_id":     {"type": "keyword"},
+      "value":        {"type": "double"},
+      "currency":     {"type": "keyword"},
+      "source":       {"type": "keyword"},
+      "clicked_at":   {"type": "date"},
+      "converted_at": {"type": "date"},
+      "created_at":   {"type": "date"},
+      "updated_at":   {"type": "date"}
+    }
+  }
+}`
+
+// GroupFields maps a report dimension to the document field holding it
+var GroupFields = map[string]string{
+	entity.GroupByLink:     ShortCodeField,
+	entity.GroupByCampaign: CampaignField,
+	entity.GroupByVariant:  VariantField,
+}
+
+type Conversion struct {
+	*elasticsearch.BaseModel[string]
+	ClickID     string    `json:"click_id"`
+	TenantID    int       `json:"tenant_id"`
+	ShortCode   string    `json:"short_code"`
+	Variant     string    `json:"variant"`
+	Campaign    string    `json:"campaign"`
+	Event       string    `json:"event"`
+	OrderID     string    `json:"order_id"`
+	Value       float64   `json:"value"`
+	Currency    string    `json:"currency"`
+	Source      string    `json:"source"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ConvertedAt time.Time `json:"converted_at"`
+}
+
+func FromConversionEntity(e *entity.Conversion) *Conversion {
+	base := elasticsearch.NewBaseModel(e.ID)
+	return &Conversion{
+		BaseModel:   &base,
+		ClickID:     e.ClickID,
+		TenantID:    e.TenantID,
+		ShortCode:   e.ShortCode,
+		Variant:     e.Variant,
+		Campaign:    e.Campaign,
+		Event:       e.Event,
+		OrderID:     e.OrderID,
+		Value:       e.Value,
+		Currency:    e.Currency,
+		Source:      e.Source,
+		ClickedAt:   e.ClickedAt,
+		ConvertedAt: e.ConvertedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+const (
+	ExportIndexName = "exports"
+
+	IDField        = "id"
+	StatusField    = "status"
+	StartedAtField = "started_at"
+	ExpiresAtField = "expires_at"
+	CreatedAtField = "created_at"
+)
+
+// ExportMapping stores export jobs; the filter is only read back, never searched
+const ExportMapping = `{
+  "mappings": {
+    "properties": {
+      "id":          {"type": "keyword"},
+      "tenant_id":   {"type": "integer"},
+      "created_by":  {"type": "integer"},
+      "format":      {"type": "keyword"},
+      "filter":      {"type": "object", "enabled": false},
+      "from":        {"type": "date"},
+      "to":          {"type": "date"},
+      "status":      {"type": "keyword"},
+      "rows":        {"type": "long"},
+      "size":        {"type": "long"},
+      "error":       {"type": "keyword", "index": false, "doc_values": false},
+      "started_at":  {"type": "date"},
+      "finished_at": {"type": "date"},
+      "expires_at":  {"type": "date"},
+      "created_at":  {"type": "date"},
+      "updated_at":  {"type": "date"}
+    }
+  }
+}`
+
+type Export struct {
+	*elasticsearch.BaseModel[string]
+	TenantID   int          `json:"tenant_id"`
+	CreatedBy  int          `json:"created_by"`
+	Format     string       `json:"format"`
+	Filter     *ClickFilter `json:"filter,omitempty"`
+	From       time.Time    `json:"from"`
+	To         time.Time    `json:"to"`
+	Status     string       `json:"status"`
+	Rows       int64        `json:"rows"`
+	Size       int64        `json:"size"`
+	Error      string       `json:"error,omitempty"`
+	StartedAt  *time.Time   `json:"started_at,omitempty"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+}
+
+func FromExportEntity(e *entity.ExportJob) *Export {
+	base := elasticsearch.NewBaseModel(e.ID)
+	if !e.CreatedAt.IsZero() {
+		base.CreatedAt = e.CreatedAt
+	}
+	return &Export{
+		BaseModel:  &base,
+		TenantID:   e.TenantID,
+		CreatedBy:  e.CreatedBy,
+		Format:     e.Format,
+		Filter:     FromClickFilterEntity(e.Filter),
+		From:       e.From,
+		To:         e.To,
+		Status:     e.Status,
+		Rows:       e.Rows,
+		Size:       e.Size,
+		Error:      e.Error,
+		StartedAt:  optionalTime(e.StartedAt),
+		FinishedAt: optionalTime(e.FinishedAt),
+		ExpiresAt:  optionalTime(e.ExpiresAt),
+	}
+}
+
+func (d *Export) ToEntity() *entity.ExportJob {
+	return &entity.ExportJob{
+		ID:         d.ID,
+		TenantID:   d.TenantID,
+		CreatedBy:  d.CreatedBy,
+		Format:     d.Format,
+		Filter:     d.Filter.ToEntity(d.TenantID),
+		From:       d.From,
+		To:         d.To,
+		Status:     d.Status,
+		Rows:       d.Rows,
+		Size:       d.Size,
+		Error:      d.Error,
+		CreatedAt:  d.CreatedAt,
+		StartedAt:  timeValue(d.StartedAt),
+		FinishedAt: timeValue(d.FinishedAt),
+		ExpiresAt:  timeValue(d.ExpiresAt),
+	}
+}
+
+// optionalTime leaves unset times out of the document, so range queries do not match the zero time
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,58 @@
+package models
+
+import "go-link/analytics/internal/core/entity"
+
+// ClickFilter is a stored click filter, kept on export jobs and report schedules
+type ClickFilter struct {
+	ShortCode      string `json:"short_code,omitempty"`
+	Campaign       string `json:"campaign,omitempty"`
+	Variant        string `json:"variant,omitempty"`
+	Device         string `json:"device,omitempty"`
+	Browser        string `json:"browser,omitempty"`
+	OS             string `json:"os,omitempty"`
+	ReferrerDomain string `json:"referrer_domain,omitempty"`
+	Source         string `json:"source,omitempty"`
+	Medium         string `json:"medium,omitempty"`
+	Country        string `json:"country,omitempty"`
+	IncludeBots    bool   `json:"include_bots,omitempty"`
+}
+
+func FromClickFilterEntity(f *entity.ClickFilter) *ClickFilter {
+	if f == nil {
+		return nil
+	}
+	return &ClickFilter{
+		ShortCode:      f.ShortCode,
+		Campaign:       f.Campaign,
+		Variant:        f.Variant,
+		Device:         f.Device,
+		Browser:        f.Browser,
+		OS:             f.OS,
+		ReferrerDomain: f.ReferrerDomain,
+		Source:         f.Source,
+		Medium:         f.Medium,
+		Country:        f.Country,
+		IncludeBots:    f.IncludeBots,
+	}
+}
+
+// ToEntity scopes the stored filter to the tenant it belongs to
+func (f *ClickFilter) ToEntity(tenantID int) *entity.ClickFilter {
+	if f == nil {
+		return &entity.ClickFilter{TenantID: tenantID}
+	}
+	return &entity.ClickFilter{
+		TenantID:       tenantID,
+		ShortCode:      f.ShortCode,
+		Campaign:       f.Campaign,
+		Variant:        f.Variant,
+		Device:         f.Device,
+		Browser:        f.Browser,
+		OS:             f.OS,
+		ReferrerDomain: f.ReferrerDomain,
+		Source:         f.Source,
+		Medium:         f.Medium,
+		Country:        f.Country,
+		IncludeBots:    f.IncludeBots,
+	}
+}
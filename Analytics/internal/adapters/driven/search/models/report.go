@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+const (
+	ReportScheduleIndexName = "report_schedules"
+
+	NextRunAtField = "next_run_at"
+)
+
+// ReportScheduleMapping stores scheduled report definitions
+const ReportScheduleMapping = `{
+  "mappings": {
+    "properties": {
+      "id":          {"type": "keyword"},
+      "tenant_id":   {"type": "integer"},
+      "created_by":  {"type": "integer"},
+      "name":        {"type": "keyword"},
+      "frequency":   {"type": "keyword"},
+      "time_zone":   {"type": "keyword"},
+      "recipients":  {"type": "keyword"},
+      "filter":      {"type": "object", "enabled": false},
+      "last_run_at": {"type": "date"},
+      "next_run_at": {"type": "date"},
+      "created_at":  {"type": "date"},
+      "updated_at":  {"type": "date"}
+    }
+  }
+}`
+
+type ReportSchedule struct {
+	*elasticsearch.BaseModel[string]
+	TenantID   int          `json:"tenant_id"`
+	CreatedBy  int          `json:"created_by"`
+	Name       string       `json:"name"`
+	Frequency  string       `json:"frequency"`
+	TimeZone   string       `json:"time_zone"`
+	Recipients []string     `json:"recipients"`
+	Filter     *ClickFilter `json:"filter,omitempty"`
+	LastRunAt  *time.Time   `json:"last_run_at,omitempty"`
+	NextRunAt  time.Time    `json:"next_run_at"`
+}
+
+func FromReportScheduleEntity(e *entity.ReportSchedule) *ReportSchedule {
+	base := elasticsearch.NewBaseModel(e.ID)
+	if !e.CreatedAt.IsZero() {
+		base.CreatedAt = e.CreatedAt
+	}
+	return &ReportSchedule{
+		BaseModel:  &base,
+		TenantID:   e.TenantID,
+		CreatedBy:  e.CreatedBy,
+		Name:       e.Name,
+		Frequency:  e.Frequency,
+		TimeZone:   e.Location.String(),
+		Recipients: e.Recipients,
+		Filter:     FromClickFilterEntity(e.Filter),
+		LastRunAt:  optionalTime(e.LastRunAt),
+		NextRunAt:  e.NextRunAt,
+	}
+}
+
+// ToEntity falls back to UTC if the stored time zone is no longer known to the host
+func (d *ReportSchedule) ToEntity() *entity.ReportSchedule {
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return &entity.ReportSchedule{
+		ID:         d.ID,
+		TenantID:   d.TenantID,
+		CreatedBy:  d.CreatedBy,
+		Name:       d.Name,
+		Frequency:  d.Frequency,
+		Location:   loc,
+		Recipients: d.Recipients,
+		Filter:     d.Filter.ToEntity(d.TenantID),
+		CreatedAt:  d.CreatedAt,
+		LastRunAt:  timeValue(d.LastRunAt),
+		NextRunAt:  d.NextRunAt,
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+const (
+	RollupHourlyIndexName = "clicks_hourly"
+	RollupDailyIndexName  = "clicks_daily"
+
+	BucketField    = "bucket"
+	ClicksField    = "clicks"
+	BotClicksField = "bot_clicks"
+	VisitorsField  = "visitors"
+	IPHashField    = "ip_hash"
+)
+
+// RollupIndexNames maps a resolution to the index holding its rollups
+var RollupIndexNames = map[string]string{
+	entity.ResolutionHour: RollupHourlyIndexName,
+	entity.ResolutionDay:  RollupDailyIndexName,
+}
+
+// RollupMapping is shared by the hourly and daily rollup indices
+const RollupMapping = `{
+  "mappings": {
+    "properties": {
+      "id":         {"type": "keyword"},
+      "tenant_id":  {"type": "integer"},
+      "short_code": {"type": "keyword"},
+      "bucket":     {"type": "date"},
+      "clicks":     {"type": "long"},
+      "bot_clicks": {"type": "long"},
+      "visitors":   {"type": "long"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`
+
+type Rollup struct {
+	*elasticsearch.BaseModel[string]
+	TenantID  int       `json:"tenant_id"`
+	ShortCode string    `json:"short_code"`
+	Bucket    time.Time `json:"bucket"`
+	Clicks    int64     `json:"clicks"`
+	BotClicks int64     `json:"bot_clicks"`
+	Visitors  uint64    `json:"visitors,omitempty"`
+}
+
+// FromRollupEntity keys the document by link and bucket, so rolling a bucket up again overwrites it
+func FromRollupEntity(e *entity.Rollup) *Rollup {
+	base := elasticsearch.NewBaseModel(fmt.Sprintf("%d:%s:%d", e.TenantID, e.ShortCode, e.Bucket.Unix()))
+	return &Rollup{
+		BaseModel: &base,
+		TenantID:  e.TenantID,
+		ShortCode: e.ShortCode,
+		Bucket:    e.Bucket.UTC(),
+		Clicks:    e.Clicks,
+		BotClicks: e.BotClicks,
+		Visitors:  e.Visitors,
+	}
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/internal/adapters/driven/search/models"
+	"go-link/analytics/internal/core/entity"
+)
+
+// Names of the aggregations queries read back
+const (
+	groupsAgg = "groups"
+	seriesAgg = "series"
+	totalAgg  = "total"
+	pagesAgg  = "pages"
+	botsAgg   = "bots"
+	boundsMin = "first"
+	boundsMax = "last"
+)
+
+// tenantRangeQuery keeps one tenant's documents whose timeField falls in [from, to)
+func tenantRangeQuery(tenantID int, timeField string, from, to time.Time, extra ...map[string]any) map[string]any {
+	filters := []any{
+		map[string]any{"term": map[string]any{models.TenantIDField: tenantID}},
+		map[string]any{"range": map[string]any{timeField: map[string]any{
+			"gte": from.UTC().Format(time.RFC3339Nano),
+			"lt":  to.UTC().Format(time.RFC3339Nano),
+		}}},
+	}
+	for _, f := range extra {
+		filters = append(filters, f)
+	}
+
+	return map[string]any{"bool": map[string]any{"filter": filters}}
+}
+
+// tenantQuery keeps one tenant's documents, or every document when tenantID is 0
+func tenantQuery(tenantID int) map[string]any {
+	if tenantID == 0 {
+		return map[string]any{"match_all": map[string]any{}}
+	}
+	return map[string]any{"term": map[string]any{models.TenantIDField: tenantID}}
+}
+
+// tenantBeforeQuery keeps one tenant's documents whose timeField is before the cutoff
+func tenantBeforeQuery(tenantID int, timeField string, before time.Time) map[string]any {
+	return map[string]any{"bool": map[string]any{"filter": []any{
+		map[string]any{"term": map[string]any{models.TenantIDField: tenantID}},
+		map[string]any{"range": map[string]any{timeField: map[string]any{"lt": before.UTC().Format(time.RFC3339Nano)}}},
+	}}}
+}
+
+// erasureQuery keeps the documents of the target's tenant, narrowed to its link or visitor.
+// Documents without a visitor field never match a visitor erasure.
+func erasureQuery(target *entity.ErasureTarget) map[string]any {
+	filters := []any{
+		map[string]any{"term": map[string]any{models.TenantIDField: target.TenantID}},
+	}
+	switch target.Scope {
+	case entity.ErasureLink:
+		filters = append(filters, map[string]any{"term": map[string]any{models.ShortCodeField: target.ShortCode}})
+	case entity.ErasureVisitor:
+		filters = append(filters, map[string]any{"term": map[string]any{models.IPHashField: target.VisitorHash}})
+	}
+	return map[string]any{"bool": map[string]any{"filter": filters}}
+}
+
+// rollupAgg pages through every link and bucket of timeField at the interval, resuming after the given rollup
+func rollupAgg(timeField, interval string, after *entity.Rollup, size int, sub map[string]any) map[string]any {
+	composite := map[string]any{
+		"size": size,
+		"sources": []any{
+			map[string]any{models.TenantIDField: map[string]any{"terms": map[string]any{"field": models.TenantIDField}}},
+			map[string]any{models.ShortCodeField: map[string]any{"terms": map[string]any{"field": models.ShortCodeField}}},
+			map[string]any{models.BucketField: map[string]any{"date_histogram": map[string]any{
+				"field":             timeField,
+				"calendar_interval": interval,
+			}}},
+		},
+	}
+	if after != nil {
+		composite["after"] = map[string]any{
+			models.TenantIDField:  after.TenantID,
+			models.ShortCodeField: after.ShortCode,
+			models.BucketField:    after.Bucket.UnixMilli(),
+		}
+	}
+	return map[string]any{"composite": composite, "aggs": sub}
+}
+
+// rollupKey is the key of a rollupAgg bucket
+type rollupKey struct {
+	TenantID  int    `json:"tenant_id"`
+	ShortCode string `json:"short_code"`
+	Bucket    int64  `json:"bucket"`
+}
+
+// totalsQuery keeps the query's documents whose timeField falls in its range, narrowed to its links when it names any
+func totalsQuery(q *entity.TotalsQuery, timeField string) map[string]any {
+	filters := []any{
+		map[string]any{"term": map[string]any{models.TenantIDField: q.TenantID}},
+	}
+
+	bounds := map[string]any{}
+	if !q.From.IsZero() {
+		bounds["gte"] = q.From.UTC().Format(time.RFC3339Nano)
+	}
+	if !q.To.IsZero() {
+		bounds["lt"] = q.To.UTC().Format(time.RFC3339Nano)
+	}
+	if len(bounds) > 0 {
+		filters = append(filters, map[string]any{"range": map[string]any{timeField: bounds}})
+	}
+
+	if len(q.ShortCodes) > 0 {
+		filters = append(filters, map[string]any{"terms": map[string]any{models.ShortCodeField: q.ShortCodes}})
+	}
+	return map[string]any{"bool": map[string]any{"filter": filters}}
+}
+
+// totalsAgg runs sub once per link when the query names links, or once over every matching document otherwise
+func totalsAgg(q *entity.TotalsQuery, sub map[string]any) map[string]any {
+	if len(q.ShortCodes) > 0 {
+		return map[string]any{groupsAgg: termsAgg(models.ShortCodeField, len(q.ShortCodes), sub)}
+	}
+	return map[string]any{totalAgg: map[string]any{"filter": map[string]any{"match_all": map[string]any{}}, "aggs": sub}}
+}
+
+// totalsBuckets returns the buckets of a totalsAgg; the single bucket of a tenant-wide one has no key
+func totalsBuckets(aggs map[string]json.RawMessage) ([]json.RawMessage, error) {
+	if raw, ok := aggs[totalAgg]; ok {
+		return []json.RawMessage{raw}, nil
+	}
+
+	var groups struct {
+		Buckets []json.RawMessage `json:"buckets"`
+	}
+	if raw, ok := aggs[groupsAgg]; ok {
+		if err := json.Unmarshal(raw, &groups); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+	return groups.Buckets, nil
+}
+
+// msTime reads the value of a min or max aggregation over a date field; it is zero when no document had the field
+func msTime(value *float64) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(*value)).UTC()
+}
+
+func termsAgg(field string, size int, sub map[string]any) map[string]any {
+	agg := map[string]any{"terms": map[string]any{"field": field, "size": size}}
+	if sub != nil {
+		agg["aggs"] = sub
+	}
+	return agg
+}
+
+// clickFilterQuery keeps the clicks in [from, to) that match every set field of the filter.
+// Bot clicks are left out unless the filter asks for them.
+func clickFilterQuery(f *entity.ClickFilter, from, to time.Time) map[string]any {
+	terms := []struct {
+		field string
+		value string
+	}{
+		{models.ShortCodeField, f.ShortCode},
+		{models.CampaignField, f.Campaign},
+		{models.VariantField, f.Variant},
+		{models.DeviceField, f.Device},
+		{models.BrowserField, f.Browser},
+		{models.OSField, f.OS},
+		{models.ReferrerDomainField, f.ReferrerDomain},
+		{models.SourceField, f.Source},
+		{models.MediumField, f.Medium},
+		{models.CountryField, f.Country},
+	}
+
+	var extra []map[string]any
+	for _, t := range terms {
+		if t.value != "" {
+			extra = append(extra, map[string]any{"term": map[string]any{t.field: t.value}})
+		}
+	}
+	if f.ShortCodes != nil {
+		extra = append(extra, map[string]any{"terms": map[string]any{models.ShortCodeField: f.ShortCodes}})
+	}
+	if !f.IncludeBots {
+		extra = append(extra, map[string]any{"term": map[string]any{models.BotField: false}})
+	}
+
+	return tenantRangeQuery(f.TenantID, models.TimestampField, from, to, extra...)
+}
+
+// decodeTimeAgg reads a min or max aggregation over a date field; it is zero when no document had the field
+func decodeTimeAgg(aggs map[string]json.RawMessage, name string) (time.Time, error) {
+	var value struct {
+		Value *float64 `json:"value"`
+	}
+	if raw, ok := aggs[name]; ok {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return time.Time{}, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+	return msTime(value.Value), nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+func TestClickFilterQueryLinks(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name       string
+		shortCodes []string
+		want       string
+	}{
+		{"every link", nil, ""},
+		{"empty collection", []string{}, `{"terms":{"short_code":[]}}`},
+		{"collection", []string{"a", "b"}, `{"terms":{"short_code":["a","b"]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(clickFilterQuery(&entity.ClickFilter{TenantID: 1, ShortCodes: tt.shortCodes}, from, to))
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			got := string(body)
+			if tt.want == "" {
+				if strings.Contains(got, `"terms"`) {
+					t.Errorf("expected no link filter, got %s", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("expected %s in %s", tt.want, got)
+			}
+		})
+	}
+}
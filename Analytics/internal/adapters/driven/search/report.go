@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/search/models"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type ReportRepository struct {
+	repo *elasticsearch.BaseRepository[models.ReportSchedule, string]
+}
+
+// NewReportRepository creates a new instance of ReportRepository
+func NewReportRepository() ports.ReportRepository {
+	return &ReportRepository{
+		repo: elasticsearch.NewBaseRepository[models.ReportSchedule, string](global.ElasticClient, models.ReportScheduleIndexName),
+	}
+}
+
+func (r *ReportRepository) Save(ctx context.Context, schedule *entity.ReportSchedule) error {
+	return r.repo.Index(ctx, models.FromReportScheduleEntity(schedule))
+}
+
+func (r *ReportRepository) Get(ctx context.Context, id string) (*entity.ReportSchedule, error) {
+	doc, err := r.repo.Get(ctx, id)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+func (r *ReportRepository) Delete(ctx context.Context, id string) error {
+	return r.repo.Delete(ctx, id)
+}
+
+func (r *ReportRepository) ListByTenant(ctx context.Context, tenantID int) ([]*entity.ReportSchedule, error) {
+	query := map[string]any{
+		"query": map[string]any{"term": map[string]any{models.TenantIDField: tenantID}},
+		"size":  constant.ReportMaxPerTenant,
+		"sort":  []any{map[string]any{models.CreatedAtField: "asc"}},
+	}
+	return r.search(ctx, query)
+}
+
+func (r *ReportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ReportSchedule, error) {
+	query := map[string]any{
+		"query": map[string]any{"range": map[string]any{models.NextRunAtField: map[string]any{
+			"lte": now.UTC().Format(time.RFC3339Nano),
+		}}},
+		"size": limit,
+		"sort": []any{map[string]any{models.NextRunAtField: "asc"}},
+	}
+	return r.search(ctx, query)
+}
+
+func (r *ReportRepository) Count(ctx context.Context, tenantID int) (int64, error) {
+	query := map[string]any{
+		"query": map[string]any{"term": map[string]any{models.TenantIDField: tenantID}},
+		"aggs": map[string]any{
+			totalAgg: map[string]any{"value_count": map[string]any{"field": models.IDField}},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return 0, err
+	}
+
+	aggs, err := r.repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	var total struct {
+		Value int64 `json:"value"`
+	}
+	if raw, ok := aggs[totalAgg]; ok {
+		if err := json.Unmarshal(raw, &total); err != nil {
+			return 0, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+	return total.Value, nil
+}
+
+func (r *ReportRepository) search(ctx context.Context, query map[string]any) ([]*entity.ReportSchedule, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := r.repo.Search(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*entity.ReportSchedule, len(docs))
+	for i, d := range docs {
+		schedules[i] = d.ToEntity()
+	}
+	return schedules, nil
+}
@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/search/models"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+type RollupRepository struct {
+	repos map[string]*elasticsearch.BaseRepository[models.Rollup, string]
+}
+
+// NewRollupRepository creates a new instance of RollupRepository over the hourly and daily indices
+func NewRollupRepository() ports.RollupRepository {
+	repos := make(map[string]*elasticsearch.BaseRepository[models.Rollup, string], len(models.RollupIndexNames))
+	for resolution, index := range models.RollupIndexNames {
+		repos[resolution] = elasticsearch.NewBaseRepository[models.Rollup, string](global.ElasticClient, index)
+	}
+	return &RollupRepository{
+		repos: repos,
+	}
+}
+
+func (r *RollupRepository) repo(resolution string) (*elasticsearch.BaseRepository[models.Rollup, string], error) {
+	repo, ok := r.repos[resolution]
+	if !ok {
+		return nil, fmt.Errorf("unknown resolution %q", resolution)
+	}
+	return repo, nil
+}
+
+// SaveBulk indexes the rollups of each resolution in one bulk request, overwriting buckets rolled up before
+func (r *RollupRepository) SaveBulk(ctx context.Context, rollups []*entity.Rollup) error {
+	docs := make(map[string][]*models.Rollup)
+	for _, ru := range rollups {
+		docs[ru.Resolution] = append(docs[ru.Resolution], models.FromRollupEntity(ru))
+	}
+
+	for resolution, batch := range docs {
+		repo, err := r.repo(resolution)
+		if err != nil {
+			return err
+		}
+		if err := repo.CreateBulk(ctx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bounds reads the first and last bucket of a resolution, for one tenant or for all of them
+func (r *RollupRepository) Bounds(ctx context.Context, resolution string, tenantID int) (time.Time, time.Time, error) {
+	repo, err := r.repo(resolution)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	query := map[string]any{
+		"query": tenantQuery(tenantID),
+		"aggs": map[string]any{
+			boundsMin: map[string]any{"min": map[string]any{"field": models.BucketField}},
+			boundsMax: map[string]any{"max": map[string]any{"field": models.BucketField}},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	aggs, err := repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	first, err := decodeTimeAgg(aggs, boundsMin)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	last, err := decodeTimeAgg(aggs, boundsMax)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return first, last, nil
+}
+
+// SumDays adds hourly rollups up per link per UTC day
+func (r *RollupRepository) SumDays(ctx context.Context, from, to time.Time, after *entity.Rollup, size int) ([]*entity.Rollup, error) {
+	query := map[string]any{
+		"query": map[string]any{"range": map[string]any{models.BucketField: map[string]any{
+			"gte": from.UTC().Format(time.RFC3339Nano),
+			"lt":  to.UTC().Format(time.RFC3339Nano),
+		}}},
+		"aggs": map[string]any{
+			pagesAgg: rollupAgg(models.BucketField, "1d", after, size, map[string]any{
+				models.ClicksField:    map[string]any{"sum": map[string]any{"field": models.ClicksField}},
+				models.BotClicksField: map[string]any{"sum": map[string]any{"field": models.BotClicksField}},
+			}),
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repos[entity.ResolutionHour].Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	type sum struct {
+		Value float64 `json:"value"`
+	}
+	var pages struct {
+		Buckets []struct {
+			Key       rollupKey `json:"key"`
+			Clicks    sum       `json:"clicks"`
+			BotClicks sum       `json:"bot_clicks"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[pagesAgg]; ok {
+		if err := json.Unmarshal(raw, &pages); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	rollups := make([]*entity.Rollup, len(pages.Buckets))
+	for i, b := range pages.Buckets {
+		rollups[i] = &entity.Rollup{
+			TenantID:   b.Key.TenantID,
+			ShortCode:  b.Key.ShortCode,
+			Resolution: entity.ResolutionDay,
+			Bucket:     time.UnixMilli(b.Key.Bucket).UTC(),
+			Clicks:     int64(b.Clicks.Value),
+			BotClicks:  int64(b.BotClicks.Value),
+		}
+	}
+	return rollups, nil
+}
+
+// Totals sums the rollups of a resolution, taking the start of the latest bucket as the last click
+func (r *RollupRepository) Totals(ctx context.Context, resolution string, q *entity.TotalsQuery) (map[string]*entity.ClickTotals, error) {
+	repo, err := r.repo(resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]any{
+		"query": totalsQuery(q, models.BucketField),
+		"aggs": totalsAgg(q, map[string]any{
+			models.ClicksField:    map[string]any{"sum": map[string]any{"field": models.ClicksField}},
+			models.BotClicksField: map[string]any{"sum": map[string]any{"field": models.BotClicksField}},
+			boundsMax:             map[string]any{"max": map[string]any{"field": models.BucketField}},
+		}),
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := totalsBuckets(aggs)
+	if err != nil {
+		return nil, err
+	}
+
+	type sum struct {
+		Value float64 `json:"value"`
+	}
+	totals := make(map[string]*entity.ClickTotals, len(buckets))
+	for _, raw := range buckets {
+		var b struct {
+			Key       string `json:"key"`
+			Clicks    sum    `json:"clicks"`
+			BotClicks sum    `json:"bot_clicks"`
+			Last      struct {
+				Value *float64 `json:"value"`
+			} `json:"last"`
+		}
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+		totals[b.Key] = &entity.ClickTotals{
+			Clicks:      int64(b.Clicks.Value),
+			BotClicks:   int64(b.BotClicks.Value),
+			LastClickAt: msTime(b.Last.Value),
+		}
+	}
+	return totals, nil
+}
+
+// Series sums the rollups per bucket of the resolution; the visitors of several links are summed, not merged
+func (r *RollupRepository) Series(ctx context.Context, resolution string, q *entity.TotalsQuery) ([]*entity.Rollup, error) {
+	repo, err := r.repo(resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]any{
+		"query": totalsQuery(q, models.BucketField),
+		"aggs": map[string]any{
+			seriesAgg: map[string]any{
+				"date_histogram": map[string]any{
+					"field":             models.BucketField,
+					"calendar_interval": resolution,
+					"min_doc_count":     1,
+				},
+				"aggs": map[string]any{
+					models.ClicksField:    map[string]any{"sum": map[string]any{"field": models.ClicksField}},
+					models.BotClicksField: map[string]any{"sum": map[string]any{"field": models.BotClicksField}},
+					models.VisitorsField:  map[string]any{"sum": map[string]any{"field": models.VisitorsField}},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	type sum struct {
+		Value float64 `json:"value"`
+	}
+	var series struct {
+		Buckets []struct {
+			Key       int64 `json:"key"`
+			Clicks    sum   `json:"clicks"`
+			BotClicks sum   `json:"bot_clicks"`
+			Visitors  sum   `json:"visitors"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[seriesAgg]; ok {
+		if err := json.Unmarshal(raw, &series); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	rollups := make([]*entity.Rollup, len(series.Buckets))
+	for i, b := range series.Buckets {
+		rollups[i] = &entity.Rollup{
+			TenantID:   q.TenantID,
+			Resolution: resolution,
+			Bucket:     time.UnixMilli(b.Key).UTC(),
+			Clicks:     int64(b.Clicks.Value),
+			BotClicks:  int64(b.BotClicks.Value),
+			Visitors:   uint64(b.Visitors.Value),
+		}
+		if len(q.ShortCodes) == 1 {
+			rollups[i].ShortCode = q.ShortCodes[0]
+		}
+	}
+	return rollups, nil
+}
+
+// TopLinks sums the rollups of the resolution per link, most clicked first
+func (r *RollupRepository) TopLinks(ctx context.Context, resolution string, q *entity.TotalsQuery, includeBots bool, size int) ([]*entity.Rollup, error) {
+	repo, err := r.repo(resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	weight := map[string]any{"sum": map[string]any{"field": models.ClicksField}}
+	if includeBots {
+		weight = map[string]any{"sum": map[string]any{"script": map[string]any{
+			"source": fmt.Sprintf("doc['%s'].value + doc['%s'].value", models.ClicksField, models.BotClicksField),
+		}}}
+	}
+
+	query := map[string]any{
+		"query": totalsQuery(q, models.BucketField),
+		"aggs": map[string]any{
+			groupsAgg: map[string]any{
+				"terms": map[string]any{
+					"field": models.ShortCodeField,
+					"size":  size,
+					"order": map[string]any{totalAgg: "desc"},
+				},
+				"aggs": map[string]any{
+					totalAgg:              weight,
+					models.ClicksField:    map[string]any{"sum": map[string]any{"field": models.ClicksField}},
+					models.BotClicksField: map[string]any{"sum": map[string]any{"field": models.BotClicksField}},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := repo.Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	type sum struct {
+		Value float64 `json:"value"`
+	}
+	var groups struct {
+		Buckets []struct {
+			Key       string `json:"key"`
+			Clicks    sum    `json:"clicks"`
+			BotClicks sum    `json:"bot_clicks"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[groupsAgg]; ok {
+		if err := json.Unmarshal(raw, &groups); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	rollups := make([]*entity.Rollup, len(groups.Buckets))
+	for i, b := range groups.Buckets {
+		rollups[i] = &entity.Rollup{
+			TenantID:   q.TenantID,
+			ShortCode:  b.Key,
+			Resolution: resolution,
+			Clicks:     int64(b.Clicks.Value),
+			BotClicks:  int64(b.BotClicks.Value),
+		}
+	}
+	return rollups, nil
+}
+
+// Tenants pages through the tenants that have hourly rollups in ascending order
+func (r *RollupRepository) Tenants(ctx context.Context, after int, size int) ([]int, error) {
+	composite := map[string]any{
+		"size": size,
+		"sources": []any{
+			map[string]any{models.TenantIDField: map[string]any{"terms": map[string]any{"field": models.TenantIDField}}},
+		},
+	}
+	if after != 0 {
+		composite["after"] = map[string]any{models.TenantIDField: after}
+	}
+	query := map[string]any{
+		"aggs": map[string]any{pagesAgg: map[string]any{"composite": composite}},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aggs, err := r.repos[entity.ResolutionHour].Aggregate(ctx, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var pages struct {
+		Buckets []struct {
+			Key struct {
+				TenantID int `json:"tenant_id"`
+			} `json:"key"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggs[pagesAgg]; ok {
+		if err := json.Unmarshal(raw, &pages); err != nil {
+			return nil, fmt.Errorf("%w: %v", elasticsearch.ErrDecodeFailed, err)
+		}
+	}
+
+	tenants := make([]int, len(pages.Buckets))
+	for i, b := range pages.Buckets {
+		tenants[i] = b.Key.TenantID
+	}
+	return tenants, nil
+}
+
+// DeleteBefore removes a tenant's rollups of a resolution whose bucket starts before the cutoff
+func (r *RollupRepository) DeleteBefore(ctx context.Context, resolution string, tenantID int, before time.Time) (int64, error) {
+	repo, err := r.repo(resolution)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := json.Marshal(map[string]any{"query": tenantBeforeQuery(tenantID, models.BucketField, before)})
+	if err != nil {
+		return 0, err
+	}
+	return repo.DeleteByQuery(ctx, bytes.NewReader(body))
+}
+
+// Erase removes the target's rollups at every resolution; rollups count visitors without naming them,
+// so a visitor erasure removes none
+func (r *RollupRepository) Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error) {
+	if target.Scope == entity.ErasureVisitor {
+		return 0, nil
+	}
+
+	body, err := json.Marshal(map[string]any{"query": erasureQuery(target)})
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, repo := range r.repos {
+		deleted, err := repo.DeleteByQuery(ctx, bytes.NewReader(body))
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+	}
+	return total, nil
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+// exportStorage keeps export files on a local or mounted volume, one directory per tenant.
+// Every replica that serves downloads must see the same directory.
+type exportStorage struct {
+	dir string
+}
+
+// NewExportStorage creates a storage rooted at dir
+func NewExportStorage(dir string) ports.ExportStorage {
+	return &exportStorage{dir: dir}
+}
+
+func (s *exportStorage) Path(job *entity.ExportJob) string {
+	return filepath.Join(s.dir, strconv.Itoa(job.TenantID), job.ID+"."+job.Format)
+}
+
+// Create writes to a temporary file next to the final one, renamed into place on commit,
+// so a download never sees a half-written file
+func (s *exportStorage) Create(job *entity.ExportJob) (ports.ExportWriter, error) {
+	path := s.Path(job)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+job.ID+"-*.tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bufio.NewWriter(file)
+	w := &fileWriter{file: file, buf: buf, path: path}
+
+	switch job.Format {
+	case entity.ExportFormatCSV:
+		w.encoder, err = newCSVEncoder(buf)
+	case entity.ExportFormatNDJSON:
+		w.encoder = newNDJSONEncoder(buf)
+	case entity.ExportFormatParquet:
+		w.encoder, err = newParquetEncoder(buf)
+	default:
+		err = errors.New("unknown export format " + job.Format)
+	}
+	if err != nil {
+		w.Abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (s *exportStorage) Remove(job *entity.ExportJob) error {
+	if err := os.Remove(s.Path(job)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// clickEncoder writes clicks in one file format
+type clickEncoder interface {
+	Encode(click *entity.Click) error
+	// Close writes whatever the format needs after the last row
+	Close() error
+}
+
+type fileWriter struct {
+	file    *os.File
+	buf     *bufio.Writer
+	encoder clickEncoder
+	path    string
+}
+
+func (w *fileWriter) Write(click *entity.Click) error {
+	return w.encoder.Encode(click)
+}
+
+func (w *fileWriter) Commit() (int64, error) {
+	if err := w.encoder.Close(); err != nil {
+		w.Abort()
+		return 0, err
+	}
+	if err := w.buf.Flush(); err != nil {
+		w.Abort()
+		return 0, err
+	}
+
+	info, err := w.file.Stat()
+	if err != nil {
+		w.Abort()
+		return 0, err
+	}
+	if err := w.file.Close(); err != nil {
+		_ = os.Remove(w.file.Name())
+		return 0, err
+	}
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		_ = os.Remove(w.file.Name())
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (w *fileWriter) Abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.file.Name())
+}
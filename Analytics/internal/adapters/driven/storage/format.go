@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"go-link/common/pkg/encoding/parquet"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+// exportColumns are the fields exported for every click, in file order.
+// IP hashes, raw user agents and full referrers stay out of exports.
+var exportColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "timestamp", Type: parquet.Timestamp},
+	{Name: "short_code", Type: parquet.String},
+	{Name: "campaign", Type: parquet.String},
+	{Name: "variant", Type: parquet.String},
+	{Name: "traffic", Type: parquet.String},
+	{Name: "bot", Type: parquet.Bool},
+	{Name: "browser", Type: parquet.String},
+	{Name: "browser_version", Type: parquet.String},
+	{Name: "os", Type: parquet.String},
+	{Name: "device", Type: parquet.String},
+	{Name: "referrer_domain", Type: parquet.String},
+	{Name: "source", Type: parquet.String},
+	{Name: "medium", Type: parquet.String},
+	{Name: "language", Type: parquet.String},
+	{Name: "country", Type: parquet.String},
+	{Name: "region", Type: parquet.String},
+	{Name: "city", Type: parquet.String},
+	{Name: "latitude", Type: parquet.Double},
+	{Name: "longitude", Type: parquet.Double},
+}
+
+// exportRow lays a click out in exportColumns order; clicks that could not be located get 0, 0
+func exportRow(c *entity.Click) []any {
+	var lat, lon float64
+	if c.Location != nil {
+		lat, lon = c.Location.Latitude, c.Location.Longitude
+	}
+	return []any{
+		c.EventID, c.Timestamp, c.ShortCode, c.Campaign, c.Variant, c.Traffic, c.Bot,
+		c.Browser, c.BrowserVersion, c.OS, c.Device, c.ReferrerDomain, c.Source, c.Medium,
+		c.Language, c.Country, c.Region, c.City, lat, lon,
+	}
+}
+
+type csvEncoder struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVEncoder(w io.Writer) (clickEncoder, error) {
+	e := &csvEncoder{w: csv.NewWriter(w), record: make([]string, len(exportColumns))}
+	for i, c := range exportColumns {
+		e.record[i] = c.Name
+	}
+	return e, e.w.Write(e.record)
+}
+
+func (e *csvEncoder) Encode(click *entity.Click) error {
+	for i, v := range exportRow(click) {
+		switch v := v.(type) {
+		case string:
+			e.record[i] = v
+		case time.Time:
+			e.record[i] = v.UTC().Format(time.RFC3339Nano)
+		case bool:
+			e.record[i] = strconv.FormatBool(v)
+		case float64:
+			e.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonClick is one line of an NDJSON export; it has the same fields as the other formats
+type ndjsonClick struct {
+	ID             string    `json:"id"`
+	Timestamp      time.Time `json:"timestamp"`
+	ShortCode      string    `json:"short_code"`
+	Campaign       string    `json:"campaign"`
+	Variant        string    `json:"variant"`
+	Traffic        string    `json:"traffic"`
+	Bot            bool      `json:"bot"`
+	Browser        string    `json:"browser"`
+	BrowserVersion string    `json:"browser_version"`
+	OS             string    `json:"os"`
+	Device         string    `json:"device"`
+	ReferrerDomain string    `json:"referrer_domain"`
+	Source         string    `json:"source"`
+	Medium         string    `json:"medium"`
+	Language       string    `json:"language"`
+	Country        string    `json:"country"`
+	Region         string    `json:"region"`
+	City           string    `json:"city"`
+	Latitude       *float64  `json:"latitude,omitempty"`
+	Longitude      *float64  `json:"longitude,omitempty"`
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) clickEncoder {
+	return &ndjsonEncoder{enc: json.NewEncoder(w)}
+}
+
+// Encode writes one JSON object per line; json.Encoder ends each value with a newline
+func (e *ndjsonEncoder) Encode(c *entity.Click) error {
+	line := &ndjsonClick{
+		ID:             c.EventID,
+		Timestamp:      c.Timestamp.UTC(),
+		ShortCode:      c.ShortCode,
+		Campaign:       c.Campaign,
+		Variant:        c.Variant,
+		Traffic:        c.Traffic,
+		Bot:            c.Bot,
+		Browser:        c.Browser,
+		BrowserVersion: c.BrowserVersion,
+		OS:             c.OS,
+		Device:         c.Device,
+		ReferrerDomain: c.ReferrerDomain,
+		Source:         c.Source,
+		Medium:         c.Medium,
+		Language:       c.Language,
+		Country:        c.Country,
+		Region:         c.Region,
+		City:           c.City,
+	}
+	if c.Location != nil {
+		line.Latitude, line.Longitude = &c.Location.Latitude, &c.Location.Longitude
+	}
+	return e.enc.Encode(line)
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
+
+type parquetEncoder struct {
+	w *parquet.Writer
+}
+
+func newParquetEncoder(w io.Writer) (clickEncoder, error) {
+	pw, err := parquet.NewWriter(w, exportColumns, parquet.DefaultRowGroupSize)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetEncoder{w: pw}, nil
+}
+
+func (e *parquetEncoder) Encode(click *entity.Click) error {
+	return e.w.Write(exportRow(click))
+}
+
+func (e *parquetEncoder) Close() error {
+	return e.w.Close()
+}
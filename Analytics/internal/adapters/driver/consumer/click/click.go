@@ -0,0 +1,78 @@
+package click
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/mq/kafka"
+	"go-link/common/pkg/utils"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+	"github.com/huynhanx03/GoLink/events-contract/topics"
+)
+
+type ClickConsumer struct {
+	consumer     kafka.ConsumerGroup
+	clickService ports.ClickService
+}
+
+func NewClickConsumer(cfg *kafka.Config, clickService ports.ClickService) (ports.ClickConsumer, error) {
+	c, err := kafka.NewConsumer(cfg, constant.ConsumerGroupClickIngest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClickConsumer{
+		consumer:     c,
+		clickService: clickService,
+	}, nil
+}
+
+// Start starts the consumer
+func (c *ClickConsumer) Start(ctx context.Context) error {
+	batchSize := global.Config.Kafka.ConsumerBatchSize
+	if batchSize <= 0 {
+		batchSize = constant.ClickBatchSize
+	}
+
+	batchInterval := utils.ToDurationMs(global.Config.Kafka.ConsumerBatchInterval)
+	if batchInterval <= 0 {
+		batchInterval = constant.ClickBatchInterval
+	}
+
+	errHandler := func(err error) {
+		global.LoggerZap.Error("ClickConsumer error", zap.Error(err))
+	}
+
+	global.LoggerZap.Info("Starting Click Consumer (Batch Mode)", zap.String("topic", topics.LinkClicked))
+	return c.consumer.StartBatch(ctx, []string{topics.LinkClicked}, c.handleBatch, kafka.BatchConfig{
+		Size:     batchSize,
+		Interval: batchInterval,
+	}, errHandler)
+}
+
+func (c *ClickConsumer) Stop() error {
+	return c.consumer.Close()
+}
+
+// handleBatch decodes the batch and ingests it in one go; an error leaves the offsets
+// uncommitted so the whole batch is redelivered, which event-ID keying makes safe.
+func (c *ClickConsumer) handleBatch(ctx context.Context, msgs []*kafka.Message) error {
+	events := make([]*linkv1.LinkClickedEvent, 0, len(msgs))
+	for _, msg := range msgs {
+		var evt linkv1.LinkClickedEvent
+		if err := json.Unmarshal(msg.Value, &evt); err != nil {
+			global.LoggerZap.Warn("Skipping malformed click event", zap.Error(err))
+			continue
+		}
+		events = append(events, &evt)
+	}
+
+	return c.clickService.Ingest(ctx, events)
+}
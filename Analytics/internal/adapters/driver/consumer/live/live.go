@@ -0,0 +1,69 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/mq/kafka"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+	"github.com/huynhanx03/GoLink/events-contract/topics"
+)
+
+type LiveConsumer struct {
+	consumer           kafka.Consumer
+	liveService        ports.LiveService
+	leaderboardService ports.LeaderboardService
+	anomalyService     ports.AnomalyService
+}
+
+// NewLiveConsumer reads every partition from the newest offset, outside any consumer group, so this replica sees
+// every click from the moment it starts rather than the share of partitions the ingest group gives it,
+// and leaves no group behind when it goes away; that full view also lets any replica
+// answer leaderboard queries and judge any link's click rate.
+func NewLiveConsumer(cfg *kafka.Config, liveService ports.LiveService, leaderboardService ports.LeaderboardService, anomalyService ports.AnomalyService) (ports.LiveConsumer, error) {
+	c, err := kafka.NewPartitionConsumer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LiveConsumer{
+		consumer:           c,
+		liveService:        liveService,
+		leaderboardService: leaderboardService,
+		anomalyService:     anomalyService,
+	}, nil
+}
+
+// Start starts the consumer
+func (c *LiveConsumer) Start(ctx context.Context) error {
+	errHandler := func(err error) {
+		global.LoggerZap.Error("LiveConsumer error", zap.Error(err))
+	}
+
+	global.LoggerZap.Info("Starting Live Consumer", zap.String("topic", topics.LinkClicked))
+	return c.consumer.Start(ctx, []string{topics.LinkClicked}, c.handle, errHandler)
+}
+
+func (c *LiveConsumer) Stop() error {
+	return c.consumer.Close()
+}
+
+// handle never fails: a click missed by a live stream is not worth redelivering
+func (c *LiveConsumer) handle(ctx context.Context, _, value []byte) error {
+	var evt linkv1.LinkClickedEvent
+	if err := json.Unmarshal(value, &evt); err != nil {
+		global.LoggerZap.Warn("Skipping malformed click event", zap.Error(err))
+		return nil
+	}
+
+	c.liveService.Publish(ctx, &evt)
+	c.leaderboardService.Record(ctx, &evt)
+	c.anomalyService.Record(ctx, &evt)
+	return nil
+}
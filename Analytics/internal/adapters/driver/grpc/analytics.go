@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	analyticsv1 "go-link/common/gen/go/analytics/v1"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type AnalyticsServer struct {
+	analyticsv1.UnimplementedAnalyticsServiceServer
+	usageService ports.UsageService
+	hub          *service.LiveHub
+}
+
+func NewAnalyticsServer(usageService ports.UsageService, hub *service.LiveHub) *AnalyticsServer {
+	return &AnalyticsServer{
+		usageService: usageService,
+		hub:          hub,
+	}
+}
+
+func (s *AnalyticsServer) GetLinkStats(ctx context.Context, req *analyticsv1.GetLinkStatsRequest) (*analyticsv1.GetLinkStatsResponse, error) {
+	res, err := s.usageService.GetLinkStats(ctx, &dto.GetLinkStatsRequest{
+		TenantID:  int(req.TenantId),
+		ShortCode: req.ShortCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &analyticsv1.GetLinkStatsResponse{
+		Stats:         toLinkStatsProto(res.Stats),
+		AsOf:          res.AsOf.UnixMilli(),
+		MaxAgeSeconds: int64(res.MaxAge / time.Second),
+	}, nil
+}
+
+func (s *AnalyticsServer) BatchGetLinkStats(ctx context.Context, req *analyticsv1.BatchGetLinkStatsRequest) (*analyticsv1.BatchGetLinkStatsResponse, error) {
+	res, err := s.usageService.BatchGetLinkStats(ctx, &dto.BatchGetLinkStatsRequest{
+		TenantID:   int(req.TenantId),
+		ShortCodes: req.ShortCodes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*analyticsv1.LinkStats, len(res.Stats))
+	for i, st := range res.Stats {
+		stats[i] = toLinkStatsProto(st)
+	}
+	return &analyticsv1.BatchGetLinkStatsResponse{
+		Stats:         stats,
+		AsOf:          res.AsOf.UnixMilli(),
+		MaxAgeSeconds: int64(res.MaxAge / time.Second),
+	}, nil
+}
+
+func (s *AnalyticsServer) GetTenantUsage(ctx context.Context, req *analyticsv1.GetTenantUsageRequest) (*analyticsv1.GetTenantUsageResponse, error) {
+	res, err := s.usageService.GetTenantUsage(ctx, &dto.GetTenantUsageRequest{
+		TenantID: int(req.TenantId),
+		From:     time.UnixMilli(req.From),
+		To:       time.UnixMilli(req.To),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &analyticsv1.GetTenantUsageResponse{
+		TenantId:      int64(res.TenantID),
+		From:          res.From.UnixMilli(),
+		To:            res.To.UnixMilli(),
+		Clicks:        res.Clicks,
+		BotClicks:     res.BotClicks,
+		Complete:      res.Complete,
+		AsOf:          res.AsOf.UnixMilli(),
+		MaxAgeSeconds: int64(res.MaxAge / time.Second),
+	}, nil
+}
+
+// WatchCounters streams the counters of a live client, like the dashboard stream without the clicks themselves.
+// Any replica can serve it since every replica reads every click.
+func (s *AnalyticsServer) WatchCounters(req *analyticsv1.WatchCountersRequest, stream grpc.ServerStreamingServer[analyticsv1.Counter]) error {
+	ctx := stream.Context()
+	tenantID := int(req.TenantId)
+	if err := service.AuthorizeTenant(ctx, tenantID); err != nil {
+		return err
+	}
+
+	client := s.hub.Register(mapper.ToLiveFilter(tenantID, &dto.LiveStreamRequest{
+		ShortCode:   req.ShortCode,
+		Campaign:    req.Campaign,
+		IncludeBots: req.IncludeBots,
+	}))
+	defer s.hub.Unregister(client)
+
+	ticker := time.NewTicker(constant.LiveCounterInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case now := <-ticker.C:
+			counter := client.Tick(now)
+			if err := stream.Send(&analyticsv1.Counter{
+				Time:       counter.Time.UnixMilli(),
+				Clicks:     counter.Clicks,
+				LastMinute: counter.Window,
+			}); err != nil {
+				return err
+			}
+
+		case _, open := <-client.Chan:
+			// Only the counters are sent; the clicks are read off and dropped
+			if !open {
+				return nil
+			}
+		}
+	}
+}
+
+func toLinkStatsProto(s *dto.LinkStatsResponse) *analyticsv1.LinkStats {
+	return &analyticsv1.LinkStats{
+		ShortCode:      s.ShortCode,
+		Clicks:         s.Clicks,
+		BotClicks:      s.BotClicks,
+		UniqueVisitors: s.UniqueVisitors,
+		LastClickedAt:  unixMilli(s.LastClickAt),
+	}
+}
+
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
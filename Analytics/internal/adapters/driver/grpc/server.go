@@ -0,0 +1,17 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+
+	analyticsv1 "go-link/common/gen/go/analytics/v1"
+
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+// V1Routes registers the analytics service routes
+func V1Routes(usageService ports.UsageService, hub *service.LiveHub) func(srv *grpc.Server) {
+	return func(srv *grpc.Server) {
+		analyticsv1.RegisterAnalyticsServiceServer(srv, NewAnalyticsServer(usageService, hub))
+	}
+}
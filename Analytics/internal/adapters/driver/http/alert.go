@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/ports"
+)
+
+type AlertHandler interface {
+	GetSettings(ctx context.Context, req *dto.GetAlertSettingsRequest) (*dto.AlertSettingsResponse, error)
+	UpdateSettings(ctx context.Context, req *dto.UpdateAlertSettingsRequest) (*dto.AlertSettingsResponse, error)
+}
+
+type alertHandler struct {
+	handler.BaseHandler
+	anomalyService ports.AnomalyService
+}
+
+func NewAlertHandler(anomalyService ports.AnomalyService) AlertHandler {
+	return &alertHandler{
+		anomalyService: anomalyService,
+	}
+}
+
+// GetSettings returns the tenant's anomaly alert settings, or the defaults if none were saved
+func (h *alertHandler) GetSettings(ctx context.Context, req *dto.GetAlertSettingsRequest) (*dto.AlertSettingsResponse, error) {
+	return h.anomalyService.GetSettings(ctx, req)
+}
+
+// UpdateSettings replaces the tenant's anomaly alert settings
+func (h *alertHandler) UpdateSettings(ctx context.Context, req *dto.UpdateAlertSettingsRequest) (*dto.AlertSettingsResponse, error) {
+	return h.anomalyService.UpdateSettings(ctx, req)
+}
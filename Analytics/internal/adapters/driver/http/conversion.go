@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/handler"
+	"go-link/common/pkg/common/http/response"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+// transparentGIF is a 1x1 transparent GIF served by the conversion pixel
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+type ConversionHandler interface {
+	Postback(c *gin.Context)
+	Pixel(c *gin.Context)
+	Report(ctx context.Context, req *dto.ConversionReportRequest) (*dto.ConversionReportResponse, error)
+	PostbackKey(ctx context.Context, req *dto.PostbackKeyRequest) (*dto.PostbackKeyResponse, error)
+}
+
+type conversionHandler struct {
+	handler.BaseHandler
+	conversionService ports.ConversionService
+}
+
+func NewConversionHandler(conversionService ports.ConversionService) ConversionHandler {
+	return &conversionHandler{
+		conversionService: conversionService,
+	}
+}
+
+// Postback records a server-to-server conversion sent as query or form parameters
+func (h *conversionHandler) Postback(c *gin.Context) {
+	res, err := h.record(c, entity.ConversionSourcePostback)
+	if err != nil {
+		response.ErrorResponse(c, response.CodeInternalServer, err)
+		return
+	}
+
+	response.SuccessResponse(c, response.CodeSuccess, res)
+}
+
+// Pixel records a conversion from an image tag on the tenant's confirmation page.
+// It always answers with the GIF so the page never shows a broken image; the status tells what happened.
+func (h *conversionHandler) Pixel(c *gin.Context) {
+	status := http.StatusOK
+	if _, err := h.record(c, entity.ConversionSourcePixel); err != nil {
+		status = http.StatusInternalServerError
+		var appErr *apperr.AppError
+		if errors.As(err, &appErr) && appErr.HTTPStatus != 0 {
+			status = appErr.HTTPStatus
+		}
+		global.LoggerZap.Debug("Rejected conversion pixel", zap.Error(err))
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "image/gif", transparentGIF)
+}
+
+// Report returns conversion rates for the caller's tenant
+func (h *conversionHandler) Report(ctx context.Context, req *dto.ConversionReportRequest) (*dto.ConversionReportResponse, error) {
+	return h.conversionService.Report(ctx, req)
+}
+
+// PostbackKey returns the caller's postback signing key
+func (h *conversionHandler) PostbackKey(ctx context.Context, req *dto.PostbackKeyRequest) (*dto.PostbackKeyResponse, error) {
+	return h.conversionService.PostbackKey(ctx, req)
+}
+
+func (h *conversionHandler) record(c *gin.Context, source string) (*dto.ConversionResponse, error) {
+	if err := c.Request.ParseForm(); err != nil {
+		return nil, apperr.New(response.CodeParamInvalid, err.Error(), http.StatusBadRequest, err)
+	}
+
+	return h.conversionService.Record(c.Request.Context(), &dto.ConversionPostbackRequest{
+		Params: c.Request.Form,
+		Source: source,
+	})
+}
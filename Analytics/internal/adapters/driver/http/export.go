@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"go-link/common/pkg/common/http/handler"
+	"go-link/common/pkg/common/http/response"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/ports"
+)
+
+type ExportHandler interface {
+	Create(ctx context.Context, req *dto.CreateExportRequest) (*dto.ExportResponse, error)
+	Get(ctx context.Context, req *dto.GetExportRequest) (*dto.ExportResponse, error)
+	Download(c *gin.Context)
+}
+
+type exportHandler struct {
+	handler.BaseHandler
+	exportService ports.ExportService
+}
+
+func NewExportHandler(exportService ports.ExportService) ExportHandler {
+	return &exportHandler{
+		exportService: exportService,
+	}
+}
+
+// Create queues an export of the caller's clicks
+func (h *exportHandler) Create(ctx context.Context, req *dto.CreateExportRequest) (*dto.ExportResponse, error) {
+	return h.exportService.Create(ctx, req)
+}
+
+// Get returns an export's progress and, once done, its download link
+func (h *exportHandler) Get(ctx context.Context, req *dto.GetExportRequest) (*dto.ExportResponse, error) {
+	return h.exportService.Get(ctx, req)
+}
+
+// Download streams the file of a signed download link as an attachment
+func (h *exportHandler) Download(c *gin.Context) {
+	req, err := parseQuery[dto.DownloadExportRequest](c)
+	if err != nil {
+		response.ErrorResponse(c, response.CodeParamInvalid, err)
+		return
+	}
+
+	file, err := h.exportService.Download(c.Request.Context(), req)
+	if err != nil {
+		response.ErrorResponse(c, response.CodeInternalServer, err)
+		return
+	}
+
+	c.FileAttachment(file.Path, file.Name)
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/ports"
+)
+
+type LeaderboardHandler interface {
+	TenantTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error)
+	GlobalTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error)
+}
+
+type leaderboardHandler struct {
+	handler.BaseHandler
+	leaderboardService ports.LeaderboardService
+}
+
+func NewLeaderboardHandler(leaderboardService ports.LeaderboardService) LeaderboardHandler {
+	return &leaderboardHandler{
+		leaderboardService: leaderboardService,
+	}
+}
+
+// TenantTop returns the caller's most clicked links in a rolling window
+func (h *leaderboardHandler) TenantTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error) {
+	return h.leaderboardService.TenantTop(ctx, req)
+}
+
+// GlobalTop returns the most clicked links of all tenants in a rolling window
+func (h *leaderboardHandler) GlobalTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error) {
+	return h.leaderboardService.GlobalTop(ctx, req)
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"go-link/common/pkg/common/http/response"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+// LiveHandler handles the real-time click stream endpoints.
+type LiveHandler struct {
+	hub         *service.LiveHub
+	liveService ports.LiveService
+}
+
+// NewLiveHandler creates a new LiveHandler.
+func NewLiveHandler(hub *service.LiveHub, liveService ports.LiveService) *LiveHandler {
+	return &LiveHandler{hub: hub, liveService: liveService}
+}
+
+// Ticket handles POST /analytics/live/tickets
+// Issues the single-use ticket the caller opens the stream with.
+func (h *LiveHandler) Ticket(ctx context.Context, req *dto.CreateLiveTicketRequest) (*dto.LiveTicketResponse, error) {
+	return h.liveService.IssueTicket(ctx, req)
+}
+
+// Stream handles GET /analytics/live
+// Redeems the ticket, registers the tenant it was issued to as a live client and streams matching clicks,
+// plus a counter every second, until the client disconnects.
+func (h *LiveHandler) Stream(c *gin.Context) {
+	req, err := parseQuery[dto.LiveStreamRequest](c)
+	if err != nil {
+		response.ErrorResponse(c, response.CodeParamInvalid, err)
+		return
+	}
+
+	ticket, err := h.liveService.RedeemTicket(c.Request.Context(), req.Ticket)
+	if err != nil {
+		response.ErrorResponse(c, response.CodeUnauthorized, err)
+		return
+	}
+
+	// Register this connection in the live hub.
+	client := h.hub.Register(mapper.ToLiveFilter(ticket.TenantID, req))
+	defer h.hub.Unregister(client)
+
+	// Set SSE-specific response headers.
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable nginx buffering.
+
+	// Send an initial "connected" event so the client knows the stream is live.
+	fmt.Fprintf(c.Writer, "event: %s\ndata: {}\n\n", constant.LiveEventConnected)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(constant.LiveCounterInterval)
+	defer ticker.Stop()
+
+	ctx := c.Request.Context()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Client disconnected.
+			return
+
+		case now := <-ticker.C:
+			// Counters double as the heartbeat that keeps proxies from closing an idle stream.
+			if !writeEvent(c, constant.LiveEventCounter, mapper.ToLiveCounterResponse(client.Tick(now))) {
+				return
+			}
+
+		case click, open := <-client.Chan:
+			if !open {
+				// Hub unregistered this client — channel closed.
+				return
+			}
+
+			if !writeEvent(c, constant.LiveEventClick, mapper.ToLiveClickResponse(click)) {
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes one server-sent event and reports whether the client is still there
+func writeEvent(c *gin.Context, event string, payload any) bool {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return true
+	}
+
+	if _, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		// Client gone (broken pipe etc.).
+		return false
+	}
+
+	c.Writer.Flush()
+	return true
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/handler"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/common/http/validation"
+)
+
+// WrapQuery converts a generic handler whose request comes in the URI and query string to a Gin handler
+func WrapQuery[T any, R any](h handler.HandlerFunc[T, R]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := parseQuery[T](c)
+		if err != nil {
+			response.ErrorResponse(c, response.CodeParamInvalid, err)
+			return
+		}
+
+		res, err := h(c.Request.Context(), req)
+		if err != nil {
+			response.ErrorResponse(c, response.CodeInternalServer, err)
+			return
+		}
+
+		response.SuccessResponse(c, response.CodeSuccess, res)
+	}
+}
+
+// parseQuery parses and validates a request carried in the URI and query string, rejecting a malformed query
+func parseQuery[T any](c *gin.Context) (*T, error) {
+	var req T
+
+	// Try to bind URI params (optional, ignore error if no tags)
+	_ = c.ShouldBindUri(&req)
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, apperr.New(response.CodeParamInvalid, err.Error(), 0, err)
+	}
+
+	if ok, msg := validation.IsRequestValid(req); !ok {
+		return nil, apperr.New(response.CodeValidationFailed, string(msg), 0, nil)
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/ports"
+)
+
+type ReportHandler interface {
+	Create(ctx context.Context, req *dto.CreateReportRequest) (*dto.ReportResponse, error)
+	List(ctx context.Context, req *dto.ListReportsRequest) (*dto.ListReportsResponse, error)
+	Delete(ctx context.Context, req *dto.DeleteReportRequest) (*dto.ReportResponse, error)
+}
+
+type reportHandler struct {
+	handler.BaseHandler
+	reportService ports.ReportService
+}
+
+func NewReportHandler(reportService ports.ReportService) ReportHandler {
+	return &reportHandler{
+		reportService: reportService,
+	}
+}
+
+// Create schedules a report email
+func (h *reportHandler) Create(ctx context.Context, req *dto.CreateReportRequest) (*dto.ReportResponse, error) {
+	return h.reportService.Create(ctx, req)
+}
+
+// List returns the caller's scheduled reports
+func (h *reportHandler) List(ctx context.Context, req *dto.ListReportsRequest) (*dto.ListReportsResponse, error) {
+	return h.reportService.List(ctx, req)
+}
+
+// Delete stops a scheduled report
+func (h *reportHandler) Delete(ctx context.Context, req *dto.DeleteReportRequest) (*dto.ReportResponse, error) {
+	return nil, h.reportService.Delete(ctx, req)
+}
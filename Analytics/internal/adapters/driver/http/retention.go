@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/ports"
+)
+
+type RetentionHandler interface {
+	Erase(ctx context.Context, req *dto.EraseRequest) (*dto.EraseResponse, error)
+	EraseTenant(ctx context.Context, req *dto.EraseTenantRequest) (*dto.EraseResponse, error)
+}
+
+type retentionHandler struct {
+	handler.BaseHandler
+	retentionService ports.RetentionService
+}
+
+func NewRetentionHandler(retentionService ports.RetentionService) RetentionHandler {
+	return &retentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// Erase removes the caller's analytics for a link, a visitor or the whole tenant
+func (h *retentionHandler) Erase(ctx context.Context, req *dto.EraseRequest) (*dto.EraseResponse, error) {
+	return h.retentionService.Erase(ctx, req)
+}
+
+// EraseTenant removes the analytics of a deleted tenant
+func (h *retentionHandler) EraseTenant(ctx context.Context, req *dto.EraseTenantRequest) (*dto.EraseResponse, error) {
+	return h.retentionService.EraseTenant(ctx, req)
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/ports"
+)
+
+type StatsHandler interface {
+	TimeSeries(ctx context.Context, req *dto.TimeSeriesRequest) (*dto.TimeSeriesReportResponse, error)
+	UniqueVisitors(ctx context.Context, req *dto.UniqueVisitorsRequest) (*dto.UniqueVisitorsResponse, error)
+	Breakdown(ctx context.Context, req *dto.BreakdownRequest) (*dto.BreakdownResponse, error)
+	Geo(ctx context.Context, req *dto.GeoRequest) (*dto.GeoResponse, error)
+}
+
+type statsHandler struct {
+	handler.BaseHandler
+	statsService ports.StatsService
+}
+
+func NewStatsHandler(statsService ports.StatsService) StatsHandler {
+	return &statsHandler{
+		statsService: statsService,
+	}
+}
+
+// TimeSeries returns clicks over time for a link, a collection or the caller's whole tenant
+func (h *statsHandler) TimeSeries(ctx context.Context, req *dto.TimeSeriesRequest) (*dto.TimeSeriesReportResponse, error) {
+	return h.statsService.TimeSeries(ctx, req)
+}
+
+// UniqueVisitors returns the estimated distinct visitors of a link
+func (h *statsHandler) UniqueVisitors(ctx context.Context, req *dto.UniqueVisitorsRequest) (*dto.UniqueVisitorsResponse, error) {
+	return h.statsService.UniqueVisitors(ctx, req)
+}
+
+// Breakdown returns the top values of a dimension for a link, a collection or the caller's whole tenant
+func (h *statsHandler) Breakdown(ctx context.Context, req *dto.BreakdownRequest) (*dto.BreakdownResponse, error) {
+	return h.statsService.Breakdown(ctx, req)
+}
+
+// Geo returns where a link was clicked from inside a bounding box
+func (h *statsHandler) Geo(ctx context.Context, req *dto.GeoRequest) (*dto.GeoResponse, error) {
+	return h.statsService.Geo(ctx, req)
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/ports"
+)
+
+type anomalyWorker struct {
+	anomalyService ports.AnomalyService
+	interval       time.Duration
+	stopChan       chan struct{}
+}
+
+// NewAnomalyWorker creates a worker that closes each click-rate interval and raises alerts on anomalies.
+func NewAnomalyWorker(anomalyService ports.AnomalyService) ports.AnomalyWorker {
+	return &anomalyWorker{
+		anomalyService: anomalyService,
+		interval:       constant.AnomalyInterval,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+func (w *anomalyWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting anomaly worker", zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.anomalyService.Evaluate(ctx); err != nil {
+				global.LoggerZap.Error("Failed to evaluate click anomalies", zap.Error(err))
+			}
+		case <-w.stopChan:
+			global.LoggerZap.Info("Anomaly worker stopped")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *anomalyWorker) Stop() {
+	close(w.stopChan)
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/ports"
+)
+
+type compactionWorker struct {
+	retentionService ports.RetentionService
+	interval         time.Duration
+	stopChan         chan struct{}
+}
+
+// NewCompactionWorker creates a worker that downsamples old clicks and applies retention.
+func NewCompactionWorker(retentionService ports.RetentionService) ports.CompactionWorker {
+	return &compactionWorker{
+		retentionService: retentionService,
+		interval:         constant.CompactionInterval,
+		stopChan:         make(chan struct{}),
+	}
+}
+
+func (w *compactionWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting compaction worker", zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.retentionService.Compact(ctx); err != nil {
+				global.LoggerZap.Error("Failed to compact clicks", zap.Error(err))
+			}
+		case <-w.stopChan:
+			global.LoggerZap.Info("Compaction worker stopped")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *compactionWorker) Stop() {
+	close(w.stopChan)
+}
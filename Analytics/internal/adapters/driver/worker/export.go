@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/ports"
+)
+
+type exportWorker struct {
+	exportService   ports.ExportService
+	interval        time.Duration
+	cleanupInterval time.Duration
+	stopChan        chan struct{}
+}
+
+// NewExportWorker creates a worker that runs queued exports and removes expired files.
+func NewExportWorker(exportService ports.ExportService) ports.ExportWorker {
+	return &exportWorker{
+		exportService:   exportService,
+		interval:        constant.ExportPollInterval,
+		cleanupInterval: constant.ExportCleanupInterval,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+func (w *exportWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting export worker", zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(w.cleanupInterval)
+	defer cleanup.Stop()
+
+	// Stop cancels a running export, which puts it back in the queue
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.exportService.RunPending(ctx); err != nil && ctx.Err() == nil {
+				global.LoggerZap.Error("Failed to run exports", zap.Error(err))
+			}
+		case <-cleanup.C:
+			if err := w.exportService.RemoveExpired(ctx); err != nil && ctx.Err() == nil {
+				global.LoggerZap.Error("Failed to remove expired exports", zap.Error(err))
+			}
+		case <-ctx.Done():
+			select {
+			case <-w.stopChan:
+				global.LoggerZap.Info("Export worker stopped")
+				return nil
+			default:
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (w *exportWorker) Stop() {
+	close(w.stopChan)
+}
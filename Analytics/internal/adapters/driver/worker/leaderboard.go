@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/ports"
+)
+
+type leaderboardWorker struct {
+	leaderboardService ports.LeaderboardService
+	interval           time.Duration
+	stopChan           chan struct{}
+}
+
+// NewLeaderboardWorker creates a worker that periodically saves leaderboard buckets to Redis.
+func NewLeaderboardWorker(leaderboardService ports.LeaderboardService) ports.LeaderboardWorker {
+	return &leaderboardWorker{
+		leaderboardService: leaderboardService,
+		interval:           constant.LeaderboardFlushInterval,
+		stopChan:           make(chan struct{}),
+	}
+}
+
+func (w *leaderboardWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting leaderboard worker", zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.run(ctx)
+		case <-w.stopChan:
+			// Save what changed since the last tick before going away
+			w.run(context.Background())
+			global.LoggerZap.Info("Leaderboard worker stopped")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *leaderboardWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *leaderboardWorker) run(ctx context.Context) {
+	if err := w.leaderboardService.Flush(ctx); err != nil {
+		global.LoggerZap.Error("Failed to flush leaderboards", zap.Error(err))
+	}
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/ports"
+)
+
+type reportWorker struct {
+	reportService ports.ReportService
+	interval      time.Duration
+	stopChan      chan struct{}
+}
+
+// NewReportWorker creates a worker that sends scheduled reports once their period has ended.
+func NewReportWorker(reportService ports.ReportService) ports.ReportWorker {
+	return &reportWorker{
+		reportService: reportService,
+		interval:      constant.ReportPollInterval,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+func (w *reportWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting report worker", zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.reportService.RunDue(ctx); err != nil {
+				global.LoggerZap.Error("Failed to send reports", zap.Error(err))
+			}
+		case <-w.stopChan:
+			global.LoggerZap.Info("Report worker stopped")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *reportWorker) Stop() {
+	close(w.stopChan)
+}
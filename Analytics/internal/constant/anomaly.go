@@ -0,0 +1,41 @@
+package constant
+
+import "time"
+
+const (
+	// AnomalyInterval is the bucket click rates are measured over
+	AnomalyInterval = time.Minute
+	// AnomalyAlpha weighs the newest interval in a link's EWMA baseline; 0.05 averages roughly the last 20 minutes
+	AnomalyAlpha = 0.05
+	// AnomalyWarmup is how many intervals a link is watched before it can raise alerts
+	AnomalyWarmup = 30
+	// AnomalyForgetBelow drops idle links whose baseline has decayed under this many clicks per interval
+	AnomalyForgetBelow = 0.01
+	// AnomalyMaxLinks bounds the links watched per replica; new links are ignored above it
+	AnomalyMaxLinks = 200_000
+
+	// AnomalyCooldown is the least time between two alerts of one kind for one link
+	AnomalyCooldown = time.Hour
+	// AnomalyLockPrefix names the lock that lets one replica raise an alert
+	AnomalyLockPrefix = "anomaly:"
+	// AlertSettingsCacheTTL is how long tenant settings are kept in memory before being read again
+	AlertSettingsCacheTTL = time.Minute
+
+	// Defaults for tenants that have not tuned their thresholds
+	AlertDefaultSpikeFactor     = 5.0
+	AlertDefaultSpikeMinClicks  = 100
+	AlertDefaultDropMinBaseline = 1.0
+	AlertDefaultDropMinutes     = 15
+
+	// NotificationTypeAnomaly selects the link-anomaly template in Notification
+	NotificationTypeAnomaly  = "link-anomaly"
+	NotificationPriorityHigh = "high"
+)
+
+// Lowest thresholds a tenant may set; the settings request validates against the same values
+const (
+	AlertMinSpikeFactor     = 2.0
+	AlertMinSpikeClicks     = 10
+	AlertMinDropMinutes     = 5
+	AlertMinDropMinBaseline = 0.1
+)
@@ -0,0 +1,10 @@
+package constant
+
+import "time"
+
+const (
+	ConsumerGroupClickIngest = "analytics-click-ingest"
+
+	ClickBatchSize     = 500
+	ClickBatchInterval = 1 * time.Second
+)
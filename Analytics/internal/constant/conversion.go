@@ -0,0 +1,19 @@
+package constant
+
+import "time"
+
+const (
+	ConversionAttributionWindow  = 30 * 24 * time.Hour
+	ConversionDefaultEvent       = "conversion"
+	ConversionReportDefaultLimit = 50
+	ConversionReportMaxLimit     = 500
+	ConversionMaxFieldLength     = 128
+)
+
+// Conversion postback parameters, next to security.ClickIDParam and security.PostbackParamSignature
+const (
+	PostbackParamEvent    = "event"
+	PostbackParamValue    = "value"
+	PostbackParamCurrency = "currency"
+	PostbackParamOrderID  = "order_id"
+)
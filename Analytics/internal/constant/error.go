@@ -0,0 +1,51 @@
+package constant
+
+const (
+	MsgTenantRequired        = "tenant is required"
+	MsgUserRequired          = "user is required"
+	MsgConversionNotEnabled  = "conversion tracking is not configured"
+	MsgInvalidClickID        = "invalid click id"
+	MsgInvalidPostback       = "invalid postback signature"
+	MsgClickTooOld           = "click is outside the attribution window"
+	MsgInvalidConversionData = "invalid conversion value or currency"
+)
+
+const (
+	MsgInvalidTimeZone = "invalid time zone"
+	MsgTooManyBuckets  = "range is too long for the interval; use a coarser interval"
+	MsgRangeTooLong    = "date range is too long"
+)
+
+const (
+	MsgExportNotFound     = "export not found"
+	MsgExportExpired      = "export has expired"
+	MsgExportRangeTooLong = "export range is too long"
+	MsgExportTooLarge     = "export matches too many clicks; narrow the range or filter"
+	MsgInvalidDownload    = "invalid or expired download link"
+	MsgExportNotEnabled   = "exports are not configured"
+)
+
+const (
+	MsgInvalidLiveTicket = "invalid or expired stream ticket"
+)
+
+const (
+	MsgReportNotFound = "report not found"
+	MsgTooManyReports = "report limit reached"
+)
+
+const (
+	MsgAlertEmailRequired = "an email address is required for the email channel"
+)
+
+const (
+	MsgTenantMismatch     = "tenant does not match the caller"
+	MsgShortCodeRequired  = "short code is required"
+	MsgTooManyShortCodes  = "too many short codes"
+	MsgInvalidUsagePeriod = "invalid usage period"
+)
+
+const (
+	MsgCollectionNotFound  = "collection not found"
+	MsgGetCollectionFailed = "failed to get collection links"
+)
@@ -0,0 +1,40 @@
+package constant
+
+import "time"
+
+const (
+	// JobLockPrefix keys the lock of a background job shared by the replicas: prefix + job key
+	JobLockPrefix = "analytics:lock:"
+
+	// ExportLockPrefix and ReportLockPrefix name the job a lock belongs to
+	ExportLockPrefix = "export:"
+	ReportLockPrefix = "report:"
+)
+
+const (
+	// ExportPollInterval is how often workers look for queued exports
+	ExportPollInterval = 5 * time.Second
+	// ExportCleanupInterval is how often expired export files are removed
+	ExportCleanupInterval = 10 * time.Minute
+	// ExportPollBatch is how many jobs one poll picks up
+	ExportPollBatch = 10
+	// ExportCleanupBatch is how many expired files one cleanup removes
+	ExportCleanupBatch = 100
+
+	// ExportLockTTL bounds how long a job may run; a running job older than this is assumed abandoned and retried
+	ExportLockTTL = 30 * time.Minute
+	// ExportPageSize is how many clicks are read per search while writing a file
+	ExportPageSize = 1000
+	// ExportMaxRows caps one file; narrower ranges or filters are needed beyond it
+	ExportMaxRows = 5_000_000
+	// ExportMaxRange caps the date range of one export
+	ExportMaxRange = 366 * 24 * time.Hour
+
+	// ExportFileTTL is how long a finished file is kept
+	ExportFileTTL = 7 * 24 * time.Hour
+	// ExportDefaultLinkTTL is how long a download link stays valid when export.link_ttl is not set
+	ExportDefaultLinkTTL = time.Hour
+
+	// ExportDownloadPath is the download route; the job ID replaces %s
+	ExportDownloadPath = "/analytics/exports/%s/download"
+)
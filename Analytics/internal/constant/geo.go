@@ -0,0 +1,16 @@
+package constant
+
+import "time"
+
+const (
+	// PlaceIndexPrefix keys a link's geo index of the cities it was clicked from: prefix + tenant:code
+	PlaceIndexPrefix = "analytics:geo:"
+
+	// PlaceIndexTTL is refreshed on every click, so the index outlives the clicks it points at
+	PlaceIndexTTL = 400 * 24 * time.Hour
+
+	// PlaceSearchMarginKm widens the search circle past the box corners to absorb geohash rounding
+	PlaceSearchMarginKm = 1.0
+
+	GeoDefaultLimit = 100
+)
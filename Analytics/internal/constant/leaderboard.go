@@ -0,0 +1,24 @@
+package constant
+
+import "time"
+
+const (
+	// LeaderboardPrefix keys one bucket of a window's snapshot: prefix + window:scope:bucket start (Unix seconds)
+	LeaderboardPrefix      = "analytics:top:"
+	LeaderboardGlobalScope = "global"
+
+	// LeaderboardCapacity is how many links a bucket tracks, well above the largest page so the top entries stay exact
+	LeaderboardCapacity     = 200
+	LeaderboardDefaultLimit = 10
+
+	// LeaderboardFlushInterval is how often changed buckets are written to Redis
+	LeaderboardFlushInterval = 10 * time.Second
+
+	// Windows are rolled at bucket resolution: the open bucket plus the ones before it
+	LeaderboardHourBucket  = 5 * time.Minute
+	LeaderboardHourBuckets = 12
+	LeaderboardDayBucket   = time.Hour
+	LeaderboardDayBuckets  = 24
+	LeaderboardWeekBucket  = 6 * time.Hour
+	LeaderboardWeekBuckets = 28
+)
@@ -0,0 +1,27 @@
+package constant
+
+import "time"
+
+const (
+	// LiveMaxEventAge drops clicks that reach a replica too late to be live, e.g. while the consumer lags behind
+	LiveMaxEventAge = 10 * time.Second
+
+	// LiveTicketPrefix keys a stream ticket: prefix + ticket
+	LiveTicketPrefix = "analytics:live:ticket:"
+	// LiveTicketTTL is how long a stream ticket may wait to be used; EventSource opens the stream right after getting one
+	LiveTicketTTL = 30 * time.Second
+
+	// LiveClientBuffer is how many clicks a slow stream may lag before clicks are dropped; counters still include them
+	LiveClientBuffer = 64
+
+	// LiveCounterInterval is how often streams get a counter, over a rolling window of LiveCounterWindow ticks
+	LiveCounterInterval = time.Second
+	LiveCounterWindow   = 60
+)
+
+// Server-sent event names of the live stream
+const (
+	LiveEventConnected = "connected"
+	LiveEventClick     = "click"
+	LiveEventCounter   = "counter"
+)
@@ -0,0 +1,22 @@
+package constant
+
+import "time"
+
+const (
+	// ReportPollInterval is how often workers look for reports whose period has ended
+	ReportPollInterval = time.Minute
+	// ReportPollBatch is how many reports one poll sends at most
+	ReportPollBatch = 50
+	// ReportLockTTL keeps other replicas off a report run while it is being sent
+	ReportLockTTL = 10 * time.Minute
+
+	// ReportMaxPerTenant caps the schedules of one tenant
+	ReportMaxPerTenant = 20
+	// ReportTopLimit is how many links, sources and countries a report lists
+	ReportTopLimit = 5
+
+	// NotificationTypeReport selects the analytics-report email template in Notification
+	NotificationTypeReport   = "analytics-report"
+	NotificationChannelEmail = "email"
+	NotificationPriorityLow  = "low"
+)
@@ -0,0 +1,26 @@
+package constant
+
+import "time"
+
+const (
+	// CompactionLockKey names the lock that lets one replica compact at a time
+	CompactionLockKey = "compaction"
+	// CompactionInterval is how often the compaction job runs
+	CompactionInterval = time.Hour
+	// CompactionLockTTL bounds one compaction run
+	CompactionLockTTL = 50 * time.Minute
+
+	// RollupDelay leaves recent hours alone so clicks still in flight land before their hour is rolled up
+	RollupDelay = 2 * time.Hour
+	// RollupHourlyOverlap and RollupDailyOverlap are rolled up again on every run to take in clicks that arrived late
+	RollupHourlyOverlap = 6 * time.Hour
+	RollupDailyOverlap  = 2 * 24 * time.Hour
+	// RollupPageSize is how many rollups one aggregation page returns
+	RollupPageSize = 1000
+	// RollupTenantPageSize is how many tenants one retention page covers
+	RollupTenantPageSize = 500
+
+	// Retention of tenants whose plan does not set it, or that have no plan
+	RetentionDefaultRawDays      = 90
+	RetentionDefaultHourlyMonths = 13
+)
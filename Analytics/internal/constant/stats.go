@@ -0,0 +1,16 @@
+package constant
+
+import "time"
+
+const (
+	// TimeSeriesMaxPoints caps the buckets one series may return, e.g. a day of minutes or four years of days
+	TimeSeriesMaxPoints = 1500
+
+	// Without an explicit interval, spans up to these lengths use minute and hour buckets, longer ones use days
+	TimeSeriesMinuteSpan = 3 * time.Hour
+	TimeSeriesHourSpan   = 3 * 24 * time.Hour
+
+	BreakdownDefaultLimit = 10
+	// BreakdownUnknownKey labels clicks without a value, e.g. no referrer or an unrecognised browser
+	BreakdownUnknownKey = "(unknown)"
+)
@@ -0,0 +1,26 @@
+package constant
+
+import "time"
+
+const (
+	// UsageCachePrefix keys cached link stats and tenant usage: prefix + link:tenant:code or usage:tenant:from:to
+	UsageCachePrefix = "analytics:usage:"
+	// RollupWatermarkKey holds where the hourly and daily rollups end, written by every compaction run
+	RollupWatermarkKey = "analytics:rollup:watermark"
+
+	// LinkStatsCacheTTL is how long link stats are served from the cache, and how long callers may keep them
+	LinkStatsCacheTTL = time.Minute
+	// UsageCacheTTL is how long usage of a period that can still change is cached; CompleteUsageCacheTTL applies once it cannot
+	UsageCacheTTL         = time.Minute
+	CompleteUsageCacheTTL = 24 * time.Hour
+
+	// LinkStatsVisitorDays is how many UTC days, today included, link stats count distinct visitors over
+	LinkStatsVisitorDays = 30
+	// LinkStatsMaxBatch caps the links of one batch lookup
+	LinkStatsMaxBatch = 100
+	// LinkStatsConcurrency is how many links of a batch have their visitors counted at once
+	LinkStatsConcurrency = 8
+
+	// UsageMaxRange caps the period one usage lookup covers
+	UsageMaxRange = 366 * 24 * time.Hour
+)
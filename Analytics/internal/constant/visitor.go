@@ -0,0 +1,15 @@
+package constant
+
+import "time"
+
+const (
+	// VisitorSketchPrefix keys a link's unique-visitor sketch for one UTC day: prefix + tenant:code:yyyy-mm-dd
+	VisitorSketchPrefix = "analytics:uv:"
+	VisitorDayLayout    = "2006-01-02"
+
+	// VisitorSketchTTL keeps daily sketches long enough to compare a range with the same one a year earlier
+	VisitorSketchTTL = 400 * 24 * time.Hour
+
+	// UniqueVisitorsMaxDays caps how many daily sketches one query merges
+	UniqueVisitorsMaxDays = 366
+)
@@ -0,0 +1,26 @@
+package dto
+
+type GetAlertSettingsRequest struct{}
+
+// UpdateAlertSettingsRequest replaces the tenant's alert settings; the minimums match constant.AlertMin*
+type UpdateAlertSettingsRequest struct {
+	Enabled         bool     `json:"enabled"`
+	Channels        []string `json:"channels" validate:"required_if=Enabled true,max=3,dive,oneof=email in_app webhook"`
+	Email           string   `json:"email" validate:"omitempty,email"`
+	Name            string   `json:"name" validate:"omitempty,max=100"`
+	SpikeFactor     float64  `json:"spike_factor" validate:"omitempty,min=2,max=1000"`
+	SpikeMinClicks  uint64   `json:"spike_min_clicks" validate:"omitempty,min=10"`
+	DropMinBaseline float64  `json:"drop_min_baseline" validate:"omitempty,min=0.1"`
+	DropMinutes     *int     `json:"drop_minutes" validate:"omitempty,min=0,max=1440"` // 0 turns drop alerts off
+}
+
+type AlertSettingsResponse struct {
+	Enabled         bool     `json:"enabled"`
+	Channels        []string `json:"channels"`
+	Email           string   `json:"email,omitempty"`
+	Name            string   `json:"name,omitempty"`
+	SpikeFactor     float64  `json:"spike_factor"`
+	SpikeMinClicks  uint64   `json:"spike_min_clicks"`
+	DropMinBaseline float64  `json:"drop_min_baseline"`
+	DropMinutes     int      `json:"drop_minutes"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+)
+
+// ConversionPostbackRequest carries the raw postback parameters, since the signature covers all of them.
+type ConversionPostbackRequest struct {
+	Params url.Values
+	Source string
+}
+
+type ConversionResponse struct {
+	ID        string `json:"id"`
+	Duplicate bool   `json:"duplicate"`
+}
+
+type ConversionReportRequest struct {
+	From    time.Time `form:"from" validate:"required"`
+	To      time.Time `form:"to" validate:"required,gtfield=From"`
+	GroupBy string    `form:"group_by" validate:"omitempty,oneof=link campaign variant"`
+	Limit   int       `form:"limit" validate:"omitempty,min=1,max=500"`
+}
+
+type ConversionStatsResponse struct {
+	Key             string  `json:"key"`
+	Clicks          int64   `json:"clicks"`
+	Conversions     int64   `json:"conversions"`
+	ConvertedClicks int64   `json:"converted_clicks"`
+	ConversionRate  float64 `json:"conversion_rate"`
+	Value           float64 `json:"value"`
+}
+
+type ConversionReportResponse struct {
+	GroupBy string                     `json:"group_by"`
+	From    time.Time                  `json:"from"`
+	To      time.Time                  `json:"to"`
+	Rows    []*ConversionStatsResponse `json:"rows"`
+}
+
+type PostbackKeyRequest struct{}
+
+// PostbackKeyResponse holds the base64url-encoded HMAC-SHA256 key the tenant signs postbacks with
+type PostbackKeyResponse struct {
+	Key string `json:"key"`
+}
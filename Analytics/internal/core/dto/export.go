@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// CreateExportRequest asks for the raw clicks of [From, To), optionally of one link, in a file
+type CreateExportRequest struct {
+	Format    string    `json:"format" validate:"required,oneof=csv ndjson parquet"`
+	From      time.Time `json:"from" validate:"required"`
+	To        time.Time `json:"to" validate:"required,gtfield=From"`
+	ShortCode string    `json:"short_code" validate:"omitempty,max=64"`
+	ClickFilterRequest
+}
+
+type GetExportRequest struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+// DownloadExportRequest carries a signed download link; the signature stands in for a session
+type DownloadExportRequest struct {
+	ID        string `uri:"id" validate:"required"`
+	Expiry    int64  `form:"exp" validate:"required"`
+	Signature string `form:"sig" validate:"required"`
+}
+
+// ExportResponse reports a job's progress; DownloadURL is only set once the file is ready and expires before it does
+type ExportResponse struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	Format      string     `json:"format"`
+	From        time.Time  `json:"from"`
+	To          time.Time  `json:"to"`
+	Rows        int64      `json:"rows"`
+	Size        int64      `json:"size"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
+
+// ExportFile is a file ready to be sent
+type ExportFile struct {
+	Path string
+	Name string
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// CreateLiveTicketRequest asks for a stream ticket for the caller
+type CreateLiveTicketRequest struct{}
+
+// LiveTicketResponse is a single-use ticket that opens one live stream, in place of the caller's token
+type LiveTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LiveStreamRequest filters a live stream; with no filter it carries every click of the tenant.
+// EventSource cannot send headers, so the stream is opened with a ticket rather than the caller's token.
+type LiveStreamRequest struct {
+	Ticket      string `form:"ticket" validate:"required,max=64"`
+	ShortCode   string `form:"link" validate:"omitempty,max=64"`
+	Campaign    string `form:"campaign" validate:"omitempty,max=128"`
+	IncludeBots bool   `form:"include_bots"`
+}
+
+// LiveClickResponse is a click as pushed to dashboards; it leaves out the visitor hash and raw User-Agent
+type LiveClickResponse struct {
+	ShortCode      string    `json:"short_code"`
+	Campaign       string    `json:"campaign,omitempty"`
+	Variant        string    `json:"variant,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+	Bot            bool      `json:"bot"`
+	Device         string    `json:"device"`
+	Browser        string    `json:"browser"`
+	OS             string    `json:"os"`
+	ReferrerDomain string    `json:"referrer_domain,omitempty"`
+	Source         string    `json:"source"`
+	Medium         string    `json:"medium"`
+	Country        string    `json:"country,omitempty"`
+	City           string    `json:"city,omitempty"`
+}
+
+// LiveCounterResponse is the stream's clicks in the last second and over the last minute
+type LiveCounterResponse struct {
+	Time       time.Time `json:"time"`
+	Clicks     int64     `json:"clicks"`
+	LastMinute int64     `json:"last_minute"`
+}
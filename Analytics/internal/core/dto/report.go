@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// CreateReportRequest schedules a summary email after every day, week or month in the given time zone
+type CreateReportRequest struct {
+	Name       string   `json:"name" validate:"required,max=100"`
+	Frequency  string   `json:"frequency" validate:"required,oneof=daily weekly monthly"`
+	TimeZone   string   `json:"time_zone" validate:"omitempty,max=64"`
+	Recipients []string `json:"recipients" validate:"required,min=1,max=10,dive,email"`
+	ShortCode  string   `json:"short_code" validate:"omitempty,max=64"`
+	ClickFilterRequest
+}
+
+type ListReportsRequest struct{}
+
+type DeleteReportRequest struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+type ReportResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Frequency  string     `json:"frequency"`
+	TimeZone   string     `json:"time_zone"`
+	Recipients []string   `json:"recipients"`
+	ShortCode  string     `json:"short_code,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+	NextRunAt  time.Time  `json:"next_run_at"`
+}
+
+type ListReportsResponse struct {
+	Reports []*ReportResponse `json:"reports"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+// EraseRequest erases the caller's tenant data: one link's, one visitor's, or all of it
+type EraseRequest struct {
+	Scope       string `json:"scope" validate:"required,oneof=link tenant visitor"`
+	ShortCode   string `json:"short_code" validate:"required_if=Scope link"`
+	VisitorHash string `json:"visitor_hash" validate:"required_if=Scope visitor,omitempty,hexadecimal"`
+}
+
+// EraseTenantRequest erases all data of a deleted tenant
+type EraseTenantRequest struct {
+	TenantID int `uri:"id" validate:"required,min=1"`
+}
+
+type EraseResponse struct {
+	Clicks             int64 `json:"clicks"`
+	Conversions        int64 `json:"conversions"`
+	Rollups            int64 `json:"rollups"`
+	VisitorSketches    int64 `json:"visitor_sketches"`
+	PlaceIndexes       int64 `json:"place_indexes"`
+	LeaderboardEntries int64 `json:"leaderboard_entries"`
+}
@@ -0,0 +1,159 @@
+package dto
+
+import "time"
+
+// ClickFilterRequest holds the filters every stats endpoint accepts; exports and reports take them in the body
+type ClickFilterRequest struct {
+	Campaign    string `form:"campaign" json:"campaign" validate:"omitempty,max=128"`
+	Variant     string `form:"variant" json:"variant" validate:"omitempty,max=64"`
+	Device      string `form:"device" json:"device" validate:"omitempty,oneof=desktop mobile tablet bot unknown"`
+	Browser     string `form:"browser" json:"browser" validate:"omitempty,max=64"`
+	OS          string `form:"os" json:"os" validate:"omitempty,max=64"`
+	Referrer    string `form:"referrer" json:"referrer" validate:"omitempty,max=253"`
+	Source      string `form:"source" json:"source" validate:"omitempty,max=128"`
+	Medium      string `form:"medium" json:"medium" validate:"omitempty,max=64"`
+	Country     string `form:"country" json:"country" validate:"omitempty,len=2,alpha"`
+	IncludeBots bool   `form:"include_bots" json:"include_bots"`
+}
+
+// TimeSeriesRequest covers the per-link, per-collection and tenant-wide series;
+// ShortCode is only bound on the link route and Collection on the collection route
+type TimeSeriesRequest struct {
+	ShortCode  string    `uri:"id"`
+	Collection string    `uri:"collection"`
+	From       time.Time `form:"from" validate:"required"`
+	To         time.Time `form:"to" validate:"required,gtfield=From"`
+	Interval   string    `form:"interval" validate:"omitempty,oneof=minute hour day"`
+	TimeZone   string    `form:"tz" validate:"omitempty,max=64"`
+	Compare    string    `form:"compare" validate:"omitempty,oneof=previous year"`
+	ClickFilterRequest
+}
+
+type TimeSeriesPointResponse struct {
+	Time   time.Time `json:"time"`
+	Clicks int64     `json:"clicks"`
+}
+
+type TimeSeriesResponse struct {
+	From   time.Time                  `json:"from"`
+	To     time.Time                  `json:"to"`
+	Total  int64                      `json:"total"`
+	Points []*TimeSeriesPointResponse `json:"points"`
+}
+
+// TimeSeriesReportResponse holds the requested period and, when asked for, the period it is compared with.
+// Change is the relative change of the total, omitted when the comparison period had no clicks.
+type TimeSeriesReportResponse struct {
+	Interval string              `json:"interval"`
+	TimeZone string              `json:"time_zone"`
+	Current  *TimeSeriesResponse `json:"current"`
+	Previous *TimeSeriesResponse `json:"previous,omitempty"`
+	Change   *float64            `json:"change,omitempty"`
+}
+
+// UniqueVisitorsRequest counts whole UTC days; From and To are truncated to their day and both are included.
+// ShortCode is bound on the link route and Collection on the collection route.
+type UniqueVisitorsRequest struct {
+	ShortCode  string    `uri:"id" validate:"required_without=Collection"`
+	Collection string    `uri:"collection"`
+	From       time.Time `form:"from" validate:"required"`
+	To         time.Time `form:"to" validate:"required,gtefield=From"`
+}
+
+type DailyVisitorsResponse struct {
+	Date     string `json:"date"`
+	Visitors uint64 `json:"visitors"`
+}
+
+// UniqueVisitorsResponse holds the estimate for the whole range, which is not the sum of the days
+// since a visitor coming back on another day is counted once.
+type UniqueVisitorsResponse struct {
+	From     string                   `json:"from"`
+	To       string                   `json:"to"`
+	Visitors uint64                   `json:"visitors"`
+	Days     []*DailyVisitorsResponse `json:"days"`
+}
+
+// BreakdownRequest covers the per-link, per-collection and tenant-wide breakdown;
+// ShortCode is only bound on the link route and Collection on the collection route
+type BreakdownRequest struct {
+	ShortCode  string    `uri:"id"`
+	Collection string    `uri:"collection"`
+	Dimension  string    `uri:"dimension" validate:"required,oneof=referrer source medium device browser os language country city link"`
+	From       time.Time `form:"from" validate:"required"`
+	To         time.Time `form:"to" validate:"required,gtfield=From"`
+	Limit      int       `form:"limit" validate:"omitempty,min=1,max=100"`
+	ClickFilterRequest
+}
+
+type BreakdownRowResponse struct {
+	Key    string  `json:"key"`
+	Clicks int64   `json:"clicks"`
+	Share  float64 `json:"share"`
+}
+
+// BreakdownResponse lists the top values; Other is the clicks of every value past the limit.
+// City rows are keyed "country/region/city".
+type BreakdownResponse struct {
+	Dimension string                  `json:"dimension"`
+	From      time.Time               `json:"from"`
+	To        time.Time               `json:"to"`
+	Total     int64                   `json:"total"`
+	Other     int64                   `json:"other"`
+	Rows      []*BreakdownRowResponse `json:"rows"`
+}
+
+// GeoRequest asks for the most clicked cities of a link or a collection inside a bounding box;
+// the box may not cross the antimeridian
+type GeoRequest struct {
+	ShortCode    string    `uri:"id" validate:"required_without=Collection"`
+	Collection   string    `uri:"collection"`
+	MinLatitude  float64   `form:"min_lat" validate:"min=-90,max=90"`
+	MinLongitude float64   `form:"min_lon" validate:"min=-180,max=180"`
+	MaxLatitude  float64   `form:"max_lat" validate:"min=-90,max=90,gtefield=MinLatitude"`
+	MaxLongitude float64   `form:"max_lon" validate:"min=-180,max=180,gtefield=MinLongitude"`
+	From         time.Time `form:"from" validate:"required"`
+	To           time.Time `form:"to" validate:"required,gtfield=From"`
+	Limit        int       `form:"limit" validate:"omitempty,min=1,max=1000"`
+	ClickFilterRequest
+}
+
+type GeoPlaceResponse struct {
+	Country   string  `json:"country"`
+	Region    string  `json:"region"`
+	City      string  `json:"city"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Clicks    int64   `json:"clicks"`
+}
+
+// GeoResponse lists places by clicks, most first; Total is the clicks of the listed places
+type GeoResponse struct {
+	From   time.Time           `json:"from"`
+	To     time.Time           `json:"to"`
+	Total  int64               `json:"total"`
+	Places []*GeoPlaceResponse `json:"places"`
+}
+
+// LeaderboardRequest picks a rolling window; it defaults to the last hour
+type LeaderboardRequest struct {
+	Window string `form:"window" validate:"omitempty,oneof=hour day week"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank      int    `json:"rank"`
+	TenantID  int    `json:"tenant_id"`
+	ShortCode string `json:"short_code"`
+	Clicks    uint64 `json:"clicks"`
+	Exact     bool   `json:"exact"`
+}
+
+// LeaderboardResponse ranks links by clicks in [From, To); an entry is not exact when its link
+// entered the ranking after others were dropped, and then may be counted high by a few clicks
+type LeaderboardResponse struct {
+	Window  string                      `json:"window"`
+	From    time.Time                   `json:"from"`
+	To      time.Time                   `json:"to"`
+	Entries []*LeaderboardEntryResponse `json:"entries"`
+}
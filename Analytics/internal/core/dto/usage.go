@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// GetLinkStatsRequest and the other usage requests come from other services over gRPC, which name the tenant
+type GetLinkStatsRequest struct {
+	TenantID  int
+	ShortCode string
+}
+
+type BatchGetLinkStatsRequest struct {
+	TenantID   int
+	ShortCodes []string
+}
+
+// GetTenantUsageRequest covers [From, To)
+type GetTenantUsageRequest struct {
+	TenantID int
+	From     time.Time
+	To       time.Time
+}
+
+// LinkStatsResponse holds a link's lifetime clicks and its distinct visitors over the last 30 days.
+// LastClickAt is zero when the link was never clicked.
+type LinkStatsResponse struct {
+	ShortCode      string    `json:"short_code"`
+	Clicks         int64     `json:"clicks"`
+	BotClicks      int64     `json:"bot_clicks"`
+	UniqueVisitors uint64    `json:"unique_visitors"`
+	LastClickAt    time.Time `json:"last_click_at"`
+}
+
+// GetLinkStatsResponse and the other usage responses say when they were computed and how long callers may cache them
+type GetLinkStatsResponse struct {
+	Stats  *LinkStatsResponse `json:"stats"`
+	AsOf   time.Time          `json:"as_of"`
+	MaxAge time.Duration      `json:"max_age"`
+}
+
+type BatchGetLinkStatsResponse struct {
+	Stats  []*LinkStatsResponse `json:"stats"`
+	AsOf   time.Time            `json:"as_of"`
+	MaxAge time.Duration        `json:"max_age"`
+}
+
+type TenantUsageResponse struct {
+	TenantID  int           `json:"tenant_id"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Clicks    int64         `json:"clicks"`
+	BotClicks int64         `json:"bot_clicks"`
+	Complete  bool          `json:"complete"`
+	AsOf      time.Time     `json:"as_of"`
+	MaxAge    time.Duration `json:"max_age"`
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// Kinds of click anomalies
+const (
+	AnomalySpike = "spike"
+	AnomalyDrop  = "drop"
+)
+
+// Anomaly is a link whose clicks over the last interval left its usual range.
+// Baseline is the link's usual clicks per interval before the anomaly.
+type Anomaly struct {
+	TenantID  int
+	ShortCode string
+	Kind      string
+	Clicks    uint64
+	Baseline  float64
+	Window    time.Duration
+	At        time.Time
+}
+
+// AlertSettings is how a tenant wants to be alerted about its links.
+// Alerts go to UserID on each channel, to Email on the email channel,
+// and to the webhooks UserID registered on the webhook channel.
+type AlertSettings struct {
+	TenantID int
+	UserID   int
+	Name     string
+	Email    string
+	Enabled  bool
+	Channels []string
+
+	// A spike is an interval with at least SpikeMinClicks clicks and SpikeFactor times the baseline
+	SpikeFactor    float64
+	SpikeMinClicks uint64
+
+	// A drop is DropMinutes without a click on a link whose baseline is at least DropMinBaseline clicks a minute
+	DropMinBaseline float64
+	DropMinutes     int
+
+	UpdatedAt time.Time
+}
+
+// IsSpike reports whether clicks in one interval are a spike against the baseline.
+func (s *AlertSettings) IsSpike(clicks uint64, baseline float64) bool {
+	return clicks >= s.SpikeMinClicks && float64(clicks) >= s.SpikeFactor*baseline
+}
+
+// IsDrop reports whether a run of idle minutes is a drop against the baseline.
+// It holds only on the minute the run reaches DropMinutes, so one outage raises one alert.
+func (s *AlertSettings) IsDrop(idleMinutes int, baseline float64) bool {
+	return s.DropMinutes > 0 && idleMinutes == s.DropMinutes && baseline >= s.DropMinBaseline
+}
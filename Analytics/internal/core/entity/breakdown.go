@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// Dimensions clicks can be broken down by
+const (
+	DimensionReferrer = "referrer"
+	DimensionSource   = "source"
+	DimensionMedium   = "medium"
+	DimensionDevice   = "device"
+	DimensionBrowser  = "browser"
+	DimensionOS       = "os"
+	DimensionLanguage = "language"
+	DimensionCountry  = "country"
+	DimensionCity     = "city"
+	DimensionLink     = "link"
+)
+
+// BreakdownQuery asks for the top Limit values of Dimension among the clicks in [From, To).
+type BreakdownQuery struct {
+	Filter    *ClickFilter
+	Dimension string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+// BreakdownRow is the click count of one dimension value; Key is empty for clicks without one.
+type BreakdownRow struct {
+	Key    string
+	Clicks int64
+}
+
+// Breakdown holds the top values, plus the clicks outside them so shares add up to the total.
+type Breakdown struct {
+	Rows  []*BreakdownRow
+	Other int64
+	Total int64
+}
@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// Traffic classes assigned by Redirection
+const (
+	TrafficHuman   = "human"
+	TrafficBot     = "bot"
+	TrafficPreview = "preview"
+)
+
+// Device classes
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+	DeviceUnknown = "unknown"
+)
+
+// Source and medium of clicks without UTM parameters or a known referrer
+const (
+	SourceDirect   = "(direct)"
+	MediumNone     = "(none)"
+	MediumNotSet   = "(not set)"
+	MediumReferral = "referral"
+)
+
+// Click is one resolved redirect, enriched for reporting.
+type Click struct {
+	EventID        string
+	ClickID        string
+	TenantID       int
+	ShortCode      string
+	Variant        string
+	Campaign       string
+	Timestamp      time.Time
+	IPHash         string
+	Traffic        string
+	Bot            bool
+	UserAgent      string
+	Browser        string
+	BrowserVersion string
+	OS             string
+	Device         string
+	Referrer       string
+	ReferrerDomain string
+	Source         string
+	Medium         string
+	Language       string
+	Country        string
+	Region         string
+	City           string
+	CityKey        string    // Place key, set when the city is known
+	Location       *GeoPoint // City coordinates, nil when the click could not be located
+}
+
+// TrafficSource is where a click came from, in the utm_source/utm_medium sense.
+type TrafficSource struct {
+	Source string
+	Medium string
+}
+
+// UserAgent is what could be read from a User-Agent header.
+type UserAgent struct {
+	Browser        string
+	BrowserVersion string
+	OS             string
+	Device         string
+	Bot            bool
+}
@@ -0,0 +1,8 @@
+package entity
+
+// Collection is a Generation link collection, with the short codes of every link it holds
+type Collection struct {
+	ID         string
+	TenantID   int
+	ShortCodes []string
+}
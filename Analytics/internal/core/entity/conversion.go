@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+// Where a conversion was reported from
+const (
+	ConversionSourcePostback = "postback"
+	ConversionSourcePixel    = "pixel"
+)
+
+// Dimensions conversion reports can be grouped by
+const (
+	GroupByLink     = "link"
+	GroupByCampaign = "campaign"
+	GroupByVariant  = "variant"
+)
+
+// Conversion is a goal reached by a visitor, credited to the click that brought them.
+type Conversion struct {
+	ID          string    `json:"id"`
+	ClickID     string    `json:"click_id"`
+	TenantID    int       `json:"tenant_id"`
+	ShortCode   string    `json:"short_code"`
+	Variant     string    `json:"variant"`
+	Campaign    string    `json:"campaign"`
+	Event       string    `json:"event"`
+	OrderID     string    `json:"order_id"`
+	Value       float64   `json:"value"`
+	Currency    string    `json:"currency"`
+	Source      string    `json:"source"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ConvertedAt time.Time `json:"converted_at"`
+}
+
+// ConversionFilter selects the clicks, by click time, a report covers.
+type ConversionFilter struct {
+	TenantID int
+	GroupBy  string
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
+
+// ConversionStats is one row of a conversion report.
+type ConversionStats struct {
+	Key             string
+	Clicks          int64
+	Conversions     int64
+	ConvertedClicks int64
+	Value           float64
+}
+
+// Rate is the share of clicks that converted at least once
+func (s *ConversionStats) Rate() float64 {
+	if s.Clicks == 0 {
+		return 0
+	}
+	return float64(s.ConvertedClicks) / float64(s.Clicks)
+}
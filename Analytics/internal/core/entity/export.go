@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// Export file formats
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatNDJSON  = "ndjson"
+	ExportFormatParquet = "parquet"
+)
+
+// Export job states; a job moves pending -> running -> done or failed, and done -> expired once its file is removed
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+	ExportStatusExpired = "expired"
+)
+
+// ExportJob is a request for the raw clicks matching Filter in [From, To), written to one file.
+type ExportJob struct {
+	ID         string
+	TenantID   int
+	CreatedBy  int
+	Format     string
+	Filter     *ClickFilter
+	From       time.Time
+	To         time.Time
+	Status     string
+	Rows       int64
+	Size       int64
+	Error      string
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ExpiresAt  time.Time // When the file is removed; zero until the job is done
+}
+
+// FileName is the name the file is downloaded as.
+func (j *ExportJob) FileName() string {
+	return "clicks-" + j.From.UTC().Format("20060102") + "-" + j.To.UTC().Format("20060102") + "-" + j.ID + "." + j.Format
+}
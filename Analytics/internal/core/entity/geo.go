@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// earthRadiusKm is the mean radius used for great-circle distances
+const earthRadiusKm = 6371.0
+
+// GeoPoint is a location in degrees.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// DistanceKm is the great-circle distance to q.
+func (p GeoPoint) DistanceKm(q GeoPoint) float64 {
+	lat1, lat2 := p.Latitude*math.Pi/180, q.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (q.Longitude - p.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Place is a city clicks came from. Key is "country/region/city", which tells apart cities sharing a name.
+type Place struct {
+	Key      string
+	Location GeoPoint
+}
+
+// PlaceClicks is the click count of one place.
+type PlaceClicks struct {
+	Place  *Place
+	Clicks int64
+}
+
+// BoundingBox is the area between two corners; it does not wrap around the antimeridian.
+type BoundingBox struct {
+	Min GeoPoint
+	Max GeoPoint
+}
+
+func (b *BoundingBox) Contains(p GeoPoint) bool {
+	return p.Latitude >= b.Min.Latitude && p.Latitude <= b.Max.Latitude &&
+		p.Longitude >= b.Min.Longitude && p.Longitude <= b.Max.Longitude
+}
+
+func (b *BoundingBox) Center() GeoPoint {
+	return GeoPoint{
+		Latitude:  (b.Min.Latitude + b.Max.Latitude) / 2,
+		Longitude: (b.Min.Longitude + b.Max.Longitude) / 2,
+	}
+}
+
+// RadiusKm is the distance from the center to the farthest corner, so a circle of that radius covers the box.
+func (b *BoundingBox) RadiusKm() float64 {
+	center := b.Center()
+	corners := []GeoPoint{
+		b.Min,
+		b.Max,
+		{Latitude: b.Min.Latitude, Longitude: b.Max.Longitude},
+		{Latitude: b.Max.Latitude, Longitude: b.Min.Longitude},
+	}
+
+	var radius float64
+	for _, c := range corners {
+		radius = math.Max(radius, center.DistanceKm(c))
+	}
+	return radius
+}
+
+// GeoQuery asks for the Limit most clicked places inside Box among the clicks in [From, To).
+type GeoQuery struct {
+	Filter *ClickFilter
+	Box    *BoundingBox
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
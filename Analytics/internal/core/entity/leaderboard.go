@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Rolling windows a leaderboard covers
+const (
+	WindowHour = "hour"
+	WindowDay  = "day"
+	WindowWeek = "week"
+)
+
+// LeaderboardEntry is a link's clicks in a window. Clicks may overcount by at most Error.
+type LeaderboardEntry struct {
+	TenantID  int
+	ShortCode string
+	Clicks    uint64
+	Error     uint64
+}
+
+// Leaderboard lists the most clicked links in [From, To), most first.
+type Leaderboard struct {
+	Window  string
+	From    time.Time
+	To      time.Time
+	Entries []*LeaderboardEntry
+}
@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// LiveFilter selects the clicks a live stream receives. Empty fields match everything.
+type LiveFilter struct {
+	TenantID    int
+	ShortCode   string
+	Campaign    string
+	IncludeBots bool
+}
+
+func (f *LiveFilter) Match(c *Click) bool {
+	if c.TenantID != f.TenantID {
+		return false
+	}
+	if f.ShortCode != "" && c.ShortCode != f.ShortCode {
+		return false
+	}
+	if f.Campaign != "" && c.Campaign != f.Campaign {
+		return false
+	}
+	return f.IncludeBots || !c.Bot
+}
+
+// LiveTicket is what a stream ticket stands in for: the caller it was issued to.
+type LiveTicket struct {
+	TenantID int `json:"tenant_id"`
+	UserID   int `json:"user_id"`
+}
+
+// LiveCounter is the clicks a stream matched in the last tick and over its rolling window.
+type LiveCounter struct {
+	Time   time.Time
+	Clicks int64
+	Window int64
+}
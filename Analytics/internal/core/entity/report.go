@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+// How often a scheduled report is sent; each report covers the period that just ended
+const (
+	ReportFrequencyDaily   = "daily"
+	ReportFrequencyWeekly  = "weekly"
+	ReportFrequencyMonthly = "monthly"
+)
+
+// ReportSchedule sends a summary of the clicks matching Filter to Recipients after every period.
+// Periods are calendar days, ISO weeks or months in Location.
+type ReportSchedule struct {
+	ID         string
+	TenantID   int
+	CreatedBy  int
+	Name       string
+	Frequency  string
+	Location   *time.Location
+	Recipients []string
+	Filter     *ClickFilter
+	CreatedAt  time.Time
+	LastRunAt  time.Time
+	NextRunAt  time.Time
+}
+
+// PeriodStart returns the start of the period t falls in.
+func (s *ReportSchedule) PeriodStart(t time.Time) time.Time {
+	t = t.In(s.Location)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.Location)
+
+	switch s.Frequency {
+	case ReportFrequencyWeekly:
+		// Weeks start on Monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case ReportFrequencyMonthly:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// NextPeriod returns the start of the period after the one starting at start.
+func (s *ReportSchedule) NextPeriod(start time.Time) time.Time {
+	switch s.Frequency {
+	case ReportFrequencyWeekly:
+		return start.AddDate(0, 0, 7)
+	case ReportFrequencyMonthly:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// PreviousPeriod returns the start of the period before the one starting at start.
+func (s *ReportSchedule) PreviousPeriod(start time.Time) time.Time {
+	switch s.Frequency {
+	case ReportFrequencyWeekly:
+		return start.AddDate(0, 0, -7)
+	case ReportFrequencyMonthly:
+		return start.AddDate(0, -1, 0)
+	default:
+		return start.AddDate(0, 0, -1)
+	}
+}
+
+// ReportSummary is what one report run found for the period [From, To).
+type ReportSummary struct {
+	From         time.Time
+	To           time.Time
+	Total        int64
+	Previous     int64 // Clicks of the period before, for the change
+	TopLinks     []*BreakdownRow
+	TopSources   []*BreakdownRow
+	TopCountries []*BreakdownRow
+}
@@ -0,0 +1,83 @@
+package entity
+
+import "time"
+
+// Resolutions clicks are rolled up to once the raw events are compacted
+const (
+	ResolutionHour = "hour"
+	ResolutionDay  = "day"
+)
+
+// KeepForever marks a retention that never expires
+const KeepForever = -1
+
+// RetentionPolicy is how long one tenant's click data is kept at each resolution.
+// Daily rollups are kept for good.
+type RetentionPolicy struct {
+	TenantID     int
+	RawDays      int // KeepForever keeps raw clicks for good
+	HourlyMonths int // KeepForever keeps hourly rollups for good
+}
+
+// RawCutoff returns the time raw clicks older than which may be removed, and false when they are kept for good.
+func (p *RetentionPolicy) RawCutoff(now time.Time) (time.Time, bool) {
+	if p.RawDays == KeepForever {
+		return time.Time{}, false
+	}
+	return now.UTC().AddDate(0, 0, -p.RawDays), true
+}
+
+// HourlyCutoff returns the time hourly rollups older than which may be removed, and false when they are kept for good.
+func (p *RetentionPolicy) HourlyCutoff(now time.Time) (time.Time, bool) {
+	if p.HourlyMonths == KeepForever {
+		return time.Time{}, false
+	}
+	return now.UTC().AddDate(0, -p.HourlyMonths, 0), true
+}
+
+// Rollup counts one link's clicks over one bucket of a resolution.
+// Clicks leaves bots out, as the stats do by default; BotClicks holds the rest.
+// Daily rollups also keep the day's distinct visitors, so they outlive the visitor sketches.
+type Rollup struct {
+	TenantID   int
+	ShortCode  string
+	Resolution string
+	Bucket     time.Time
+	Clicks     int64
+	BotClicks  int64
+	Visitors   uint64
+}
+
+// Count is the rollup's clicks, with the bot ones when asked for
+func (r *Rollup) Count(includeBots bool) int64 {
+	if includeBots {
+		return r.Clicks + r.BotClicks
+	}
+	return r.Clicks
+}
+
+// Erasure scopes
+const (
+	ErasureLink    = "link"
+	ErasureTenant  = "tenant"
+	ErasureVisitor = "visitor"
+)
+
+// ErasureTarget names the data to erase: a link's, a whole tenant's, or one visitor's within a tenant.
+type ErasureTarget struct {
+	Scope       string
+	TenantID    int
+	ShortCode   string
+	VisitorHash string
+}
+
+// ErasureResult counts what an erasure removed: documents, the Redis keys of sketches and geo indexes,
+// and leaderboard entries.
+type ErasureResult struct {
+	Clicks             int64
+	Conversions        int64
+	Rollups            int64
+	VisitorSketches    int64
+	PlaceIndexes       int64
+	LeaderboardEntries int64
+}
@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// Bucket sizes of a time series
+const (
+	IntervalMinute = "minute"
+	IntervalHour   = "hour"
+	IntervalDay    = "day"
+)
+
+// Periods a time series can be compared against
+const (
+	ComparePrevious = "previous"
+	CompareYear     = "year"
+)
+
+// ClickFilter narrows the clicks a report counts. Empty fields match everything.
+// ShortCodes narrows them to a set of links such as a collection's; nil matches every link and an empty set none.
+type ClickFilter struct {
+	TenantID       int
+	ShortCode      string
+	ShortCodes     []string
+	Campaign       string
+	Variant        string
+	Device         string
+	Browser        string
+	OS             string
+	ReferrerDomain string
+	Source         string
+	Medium         string
+	Country        string
+	IncludeBots    bool
+}
+
+// LinksOnly reports whether the filter narrows clicks by link alone, the only way rollups can be narrowed
+func (f *ClickFilter) LinksOnly() bool {
+	return f.Campaign == "" && f.Variant == "" && f.Device == "" && f.Browser == "" && f.OS == "" &&
+		f.ReferrerDomain == "" && f.Source == "" && f.Medium == "" && f.Country == ""
+}
+
+// TimeSeriesQuery asks for clicks in [From, To) bucketed by Interval in Location.
+type TimeSeriesQuery struct {
+	Filter   *ClickFilter
+	Interval string
+	Location *time.Location
+	From     time.Time
+	To       time.Time
+}
+
+// TimeSeriesPoint is the click count of the bucket starting at Time.
+type TimeSeriesPoint struct {
+	Time   time.Time
+	Clicks int64
+}
+
+// TimeSeries is a gap-free run of buckets covering one period.
+type TimeSeries struct {
+	From   time.Time
+	To     time.Time
+	Points []*TimeSeriesPoint
+}
+
+// Total is the number of clicks across all buckets
+func (s *TimeSeries) Total() int64 {
+	var total int64
+	for _, p := range s.Points {
+		total += p.Clicks
+	}
+	return total
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// TotalsQuery selects a tenant's clicks in [From, To), grouped per link when short codes are given.
+// A zero From or To leaves that end of the range open.
+type TotalsQuery struct {
+	TenantID   int
+	ShortCodes []string
+	From       time.Time
+	To         time.Time
+}
+
+// ClickTotals is the clicks of one link or tenant, bots apart, and when the latest of them was made
+type ClickTotals struct {
+	Clicks      int64
+	BotClicks   int64
+	LastClickAt time.Time
+}
+
+// Add folds other into t, keeping the later of the two last clicks
+func (t *ClickTotals) Add(other *ClickTotals) {
+	t.Clicks += other.Clicks
+	t.BotClicks += other.BotClicks
+	if other.LastClickAt.After(t.LastClickAt) {
+		t.LastClickAt = other.LastClickAt
+	}
+}
+
+// RollupWatermark is where the rolled-up ranges end: every click before Hourly is counted in the hourly rollups,
+// and every click before Daily in the daily ones. A zero time means nothing was rolled up at that resolution.
+type RollupWatermark struct {
+	Hourly time.Time
+	Daily  time.Time
+}
+
+// LinkStats is a link's lifetime clicks with its distinct visitors over the last days
+type LinkStats struct {
+	TenantID       int
+	ShortCode      string
+	Totals         ClickTotals
+	UniqueVisitors uint64
+	ComputedAt     time.Time
+}
+
+// TenantUsage is a tenant's clicks over a period. It is complete once no compaction can change it any more.
+type TenantUsage struct {
+	TenantID   int
+	From       time.Time
+	To         time.Time
+	Totals     ClickTotals
+	Complete   bool
+	ComputedAt time.Time
+}
@@ -0,0 +1,95 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+
+	notificationv1 "github.com/huynhanx03/GoLink/events-contract/notification/v1"
+)
+
+// DefaultAlertSettings are the settings of a tenant that has not saved any; alerts stay off until it does
+func DefaultAlertSettings(tenantID int) *entity.AlertSettings {
+	return &entity.AlertSettings{
+		TenantID:        tenantID,
+		Channels:        []string{constant.NotificationChannelEmail},
+		SpikeFactor:     constant.AlertDefaultSpikeFactor,
+		SpikeMinClicks:  constant.AlertDefaultSpikeMinClicks,
+		DropMinBaseline: constant.AlertDefaultDropMinBaseline,
+		DropMinutes:     constant.AlertDefaultDropMinutes,
+	}
+}
+
+// ApplyAlertSettings copies the request onto the settings; thresholds left out keep their current value
+func ApplyAlertSettings(s *entity.AlertSettings, req *dto.UpdateAlertSettingsRequest) {
+	s.Enabled = req.Enabled
+	s.Email = req.Email
+	s.Name = req.Name
+	if len(req.Channels) > 0 {
+		s.Channels = req.Channels
+	}
+	if req.SpikeFactor != 0 {
+		s.SpikeFactor = req.SpikeFactor
+	}
+	if req.SpikeMinClicks != 0 {
+		s.SpikeMinClicks = req.SpikeMinClicks
+	}
+	if req.DropMinBaseline != 0 {
+		s.DropMinBaseline = req.DropMinBaseline
+	}
+	if req.DropMinutes != nil {
+		s.DropMinutes = *req.DropMinutes
+	}
+}
+
+func ToAlertSettingsResponse(s *entity.AlertSettings) *dto.AlertSettingsResponse {
+	return &dto.AlertSettingsResponse{
+		Enabled:         s.Enabled,
+		Channels:        s.Channels,
+		Email:           s.Email,
+		Name:            s.Name,
+		SpikeFactor:     s.SpikeFactor,
+		SpikeMinClicks:  s.SpikeMinClicks,
+		DropMinBaseline: s.DropMinBaseline,
+		DropMinutes:     s.DropMinutes,
+	}
+}
+
+// anomalyWindow writes a window the way the email reads it, e.g. "15 minutes"
+func anomalyWindow(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return strconv.Itoa(minutes) + " minutes"
+}
+
+// ToAnomalyEvent renders an anomaly into the link-anomaly template's data.
+// The collapse key holds no colons, as Notification splits its digest keys on them.
+func ToAnomalyEvent(a *entity.Anomaly, s *entity.AlertSettings) *notificationv1.NotificationSendEvent {
+	return &notificationv1.NotificationSendEvent{
+		IdempotencyKey: fmt.Sprintf("anomaly:%d:%s:%s:%d", a.TenantID, a.ShortCode, a.Kind, a.At.Unix()),
+		CollapseKey:    fmt.Sprintf("anomaly-%s-%d-%s", a.Kind, a.TenantID, a.ShortCode),
+		Type:           constant.NotificationTypeAnomaly,
+		Channels:       s.Channels,
+		Priority:       constant.NotificationPriorityHigh,
+		Recipient: notificationv1.Recipient{
+			UserID: strconv.Itoa(s.UserID),
+			Email:  s.Email,
+			Name:   s.Name,
+		},
+		TemplateData: map[string]string{
+			"Kind":       a.Kind,
+			"ShortCode":  a.ShortCode,
+			"Clicks":     strconv.FormatUint(a.Clicks, 10),
+			"Baseline":   strconv.FormatFloat(a.Baseline, 'f', 1, 64),
+			"Window":     anomalyWindow(a.Window),
+			"DetectedAt": a.At.UTC().Format("Jan 2, 2006 15:04 MST"),
+			"Name":       s.Name,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package mapper
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"go-link/common/pkg/hash"
+
+	"go-link/analytics/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+// ToClick enriches a click event with the parsed User-Agent, its source and the visitor's language.
+// A click is a bot if Redirection classified it as automated or its User-Agent says so.
+func ToClick(evt *linkv1.LinkClickedEvent, ua *entity.UserAgent, referrerDomain string, src *entity.TrafficSource) *entity.Click {
+	c := &entity.Click{
+		EventID:        evt.EventID,
+		ClickID:        evt.ClickID,
+		TenantID:       evt.TenantID,
+		ShortCode:      evt.ShortCode,
+		Variant:        evt.Variant,
+		Campaign:       evt.Campaign,
+		Timestamp:      time.UnixMilli(evt.Timestamp).UTC(),
+		IPHash:         evt.IPHash,
+		Traffic:        evt.Traffic,
+		Bot:            evt.Traffic != entity.TrafficHuman || ua.Bot,
+		UserAgent:      evt.UserAgent,
+		Browser:        ua.Browser,
+		BrowserVersion: ua.BrowserVersion,
+		OS:             ua.OS,
+		Device:         ua.Device,
+		Referrer:       evt.Referrer,
+		ReferrerDomain: referrerDomain,
+		Source:         src.Source,
+		Medium:         src.Medium,
+		Language:       PrimaryLanguage(evt.AcceptLanguage),
+		Country:        strings.ToUpper(evt.Country),
+		Region:         evt.Region,
+		City:           evt.City,
+	}
+
+	if c.City != "" {
+		c.CityKey = PlaceKey(c.Country, c.Region, c.City)
+	}
+	if evt.Latitude != 0 || evt.Longitude != 0 {
+		c.Location = &entity.GeoPoint{Latitude: evt.Latitude, Longitude: evt.Longitude}
+	}
+	return c
+}
+
+// PlaceKey joins a city with its country and region, e.g. "US/IL/Springfield"
+func PlaceKey(country, region, city string) string {
+	return country + "/" + region + "/" + city
+}
+
+// SplitPlaceKey returns the country, region and city of a place key
+func SplitPlaceKey(key string) (string, string, string) {
+	country, rest, _ := strings.Cut(key, "/")
+	region, city, _ := strings.Cut(rest, "/")
+	return country, region, city
+}
+
+// ReferrerDomain returns the lower-cased host of the referrer without a leading "www.", or "" for direct visits
+func ReferrerDomain(referrer string) string {
+	if referrer == "" {
+		return ""
+	}
+
+	u, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.ToLower(u.Hostname())
+	return strings.TrimPrefix(host, "www.")
+}
+
+// PrimaryLanguage returns the primary subtag of the first language in an Accept-Language header, e.g. "en" for "en-US,en;q=0.9"
+func PrimaryLanguage(acceptLanguage string) string {
+	first, _, _ := strings.Cut(acceptLanguage, ",")
+	tag, _, _ := strings.Cut(first, ";")
+	primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+
+	primary = strings.ToLower(primary)
+	if primary == "*" || len(primary) < 2 || len(primary) > 3 {
+		return ""
+	}
+	return primary
+}
+
+// VisitorHash identifies a visitor by IP and User-Agent, so devices sharing an address count separately
+func VisitorHash(c *entity.Click) uint64 {
+	_, h := hash.KeyToHash(c.IPHash + "\x00" + c.UserAgent)
+	return h
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+func ToConversionResponse(c *entity.Conversion, created bool) *dto.ConversionResponse {
+	return &dto.ConversionResponse{
+		ID:        c.ID,
+		Duplicate: !created,
+	}
+}
+
+func ToConversionReportResponse(filter *entity.ConversionFilter, stats []*entity.ConversionStats) *dto.ConversionReportResponse {
+	rows := make([]*dto.ConversionStatsResponse, len(stats))
+	for i, s := range stats {
+		rows[i] = &dto.ConversionStatsResponse{
+			Key:             s.Key,
+			Clicks:          s.Clicks,
+			Conversions:     s.Conversions,
+			ConvertedClicks: s.ConvertedClicks,
+			ConversionRate:  s.Rate(),
+			Value:           s.Value,
+		}
+	}
+
+	return &dto.ConversionReportResponse{
+		GroupBy: filter.GroupBy,
+		From:    filter.From,
+		To:      filter.To,
+		Rows:    rows,
+	}
+}
@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"time"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+func ToExportResponse(job *entity.ExportJob, downloadURL string) *dto.ExportResponse {
+	return &dto.ExportResponse{
+		ID:          job.ID,
+		Status:      job.Status,
+		Format:      job.Format,
+		From:        job.From,
+		To:          job.To,
+		Rows:        job.Rows,
+		Size:        job.Size,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		FinishedAt:  optionalTime(job.FinishedAt),
+		ExpiresAt:   optionalTime(job.ExpiresAt),
+		DownloadURL: downloadURL,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+func ToLiveFilter(tenantID int, req *dto.LiveStreamRequest) *entity.LiveFilter {
+	return &entity.LiveFilter{
+		TenantID:    tenantID,
+		ShortCode:   req.ShortCode,
+		Campaign:    req.Campaign,
+		IncludeBots: req.IncludeBots,
+	}
+}
+
+func ToLiveClickResponse(c *entity.Click) *dto.LiveClickResponse {
+	return &dto.LiveClickResponse{
+		ShortCode:      c.ShortCode,
+		Campaign:       c.Campaign,
+		Variant:        c.Variant,
+		Timestamp:      c.Timestamp,
+		Bot:            c.Bot,
+		Device:         c.Device,
+		Browser:        c.Browser,
+		OS:             c.OS,
+		ReferrerDomain: c.ReferrerDomain,
+		Source:         c.Source,
+		Medium:         c.Medium,
+		Country:        c.Country,
+		City:           c.City,
+	}
+}
+
+func ToLiveCounterResponse(c *entity.LiveCounter) *dto.LiveCounterResponse {
+	return &dto.LiveCounterResponse{
+		Time:       c.Time,
+		Clicks:     c.Clicks,
+		LastMinute: c.Window,
+	}
+}
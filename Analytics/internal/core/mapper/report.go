@@ -0,0 +1,79 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+
+	notificationv1 "github.com/huynhanx03/GoLink/events-contract/notification/v1"
+)
+
+// reportDateLayout is how period bounds are written in the email
+const reportDateLayout = "Jan 2, 2006"
+
+func ToReportResponse(s *entity.ReportSchedule) *dto.ReportResponse {
+	return &dto.ReportResponse{
+		ID:         s.ID,
+		Name:       s.Name,
+		Frequency:  s.Frequency,
+		TimeZone:   s.Location.String(),
+		Recipients: s.Recipients,
+		ShortCode:  s.Filter.ShortCode,
+		CreatedAt:  s.CreatedAt,
+		LastRunAt:  optionalTime(s.LastRunAt),
+		NextRunAt:  s.NextRunAt,
+	}
+}
+
+// ToReportEvent renders the summary into the analytics-report template's data.
+// The idempotency key is per schedule, period and recipient, so a run retried after a crash sends nothing twice.
+func ToReportEvent(s *entity.ReportSchedule, summary *entity.ReportSummary, recipient string) *notificationv1.NotificationSendEvent {
+	data := map[string]string{
+		"ReportName":   s.Name,
+		"PeriodStart":  summary.From.Format(reportDateLayout),
+		"PeriodEnd":    summary.To.AddDate(0, 0, -1).Format(reportDateLayout), // To is exclusive
+		"TotalClicks":  strconv.FormatInt(summary.Total, 10),
+		"TopLinks":     reportRows(summary.TopLinks),
+		"TopSources":   reportRows(summary.TopSources),
+		"TopCountries": reportRows(summary.TopCountries),
+	}
+	if summary.Previous > 0 {
+		change := float64(summary.Total-summary.Previous) / float64(summary.Previous) * 100
+		data["Change"] = fmt.Sprintf("%+.1f%%", change)
+	}
+
+	return &notificationv1.NotificationSendEvent{
+		IdempotencyKey: fmt.Sprintf("report:%s:%d:%s", s.ID, summary.From.Unix(), recipient),
+		Type:           constant.NotificationTypeReport,
+		Channel:        constant.NotificationChannelEmail,
+		Priority:       constant.NotificationPriorityLow,
+		Recipient: notificationv1.Recipient{
+			UserID: strconv.Itoa(s.CreatedBy),
+			Email:  recipient,
+		},
+		TemplateData: data,
+	}
+}
+
+// reportCellReplacer keeps values from breaking the row format
+var reportCellReplacer = strings.NewReplacer("\t", " ", "\n", " ")
+
+// reportRows writes rows the way the template's rows function reads them: one per line, cells split by tabs
+func reportRows(rows []*entity.BreakdownRow) string {
+	var b strings.Builder
+	for _, r := range rows {
+		key := r.Key
+		if key == "" {
+			key = constant.BreakdownUnknownKey
+		}
+		b.WriteString(reportCellReplacer.Replace(key))
+		b.WriteByte('\t')
+		b.WriteString(strconv.FormatInt(r.Clicks, 10))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
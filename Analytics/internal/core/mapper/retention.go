@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"strings"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+// ToErasureTarget keeps only the field the scope uses; visitor hashes are stored lower-case
+func ToErasureTarget(tenantID int, req *dto.EraseRequest) *entity.ErasureTarget {
+	target := &entity.ErasureTarget{Scope: req.Scope, TenantID: tenantID}
+	switch req.Scope {
+	case entity.ErasureLink:
+		target.ShortCode = req.ShortCode
+	case entity.ErasureVisitor:
+		target.VisitorHash = strings.ToLower(req.VisitorHash)
+	}
+	return target
+}
+
+func ToEraseResponse(r *entity.ErasureResult) *dto.EraseResponse {
+	return &dto.EraseResponse{
+		Clicks:             r.Clicks,
+		Conversions:        r.Conversions,
+		Rollups:            r.Rollups,
+		VisitorSketches:    r.VisitorSketches,
+		PlaceIndexes:       r.PlaceIndexes,
+		LeaderboardEntries: r.LeaderboardEntries,
+	}
+}
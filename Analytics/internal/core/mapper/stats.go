@@ -0,0 +1,172 @@
+package mapper
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+func ToClickFilter(tenantID int, shortCode string, req *dto.ClickFilterRequest) *entity.ClickFilter {
+	return &entity.ClickFilter{
+		TenantID:       tenantID,
+		ShortCode:      shortCode,
+		Campaign:       req.Campaign,
+		Variant:        req.Variant,
+		Device:         req.Device,
+		Browser:        req.Browser,
+		OS:             req.OS,
+		ReferrerDomain: strings.TrimPrefix(strings.ToLower(req.Referrer), "www."),
+		Source:         strings.ToLower(req.Source),
+		Medium:         strings.ToLower(req.Medium),
+		Country:        strings.ToUpper(req.Country),
+		IncludeBots:    req.IncludeBots,
+	}
+}
+
+func ToTimeSeriesResponse(s *entity.TimeSeries) *dto.TimeSeriesResponse {
+	if s == nil {
+		return nil
+	}
+
+	points := make([]*dto.TimeSeriesPointResponse, len(s.Points))
+	for i, p := range s.Points {
+		points[i] = &dto.TimeSeriesPointResponse{
+			Time:   p.Time,
+			Clicks: p.Clicks,
+		}
+	}
+
+	return &dto.TimeSeriesResponse{
+		From:   s.From,
+		To:     s.To,
+		Total:  s.Total(),
+		Points: points,
+	}
+}
+
+func ToTimeSeriesReportResponse(q *entity.TimeSeriesQuery, current, previous *entity.TimeSeries) *dto.TimeSeriesReportResponse {
+	res := &dto.TimeSeriesReportResponse{
+		Interval: q.Interval,
+		TimeZone: q.Location.String(),
+		Current:  ToTimeSeriesResponse(current),
+		Previous: ToTimeSeriesResponse(previous),
+	}
+
+	if previous != nil {
+		if before := previous.Total(); before > 0 {
+			change := float64(current.Total()-before) / float64(before)
+			res.Change = &change
+		}
+	}
+	return res
+}
+
+func ToUniqueVisitorsResponse(days []time.Time, counts []uint64, total uint64) *dto.UniqueVisitorsResponse {
+	res := &dto.UniqueVisitorsResponse{
+		Visitors: total,
+		Days:     make([]*dto.DailyVisitorsResponse, len(days)),
+	}
+	for i, d := range days {
+		res.Days[i] = &dto.DailyVisitorsResponse{
+			Date:     d.Format(constant.VisitorDayLayout),
+			Visitors: counts[i],
+		}
+	}
+	if len(days) > 0 {
+		res.From = res.Days[0].Date
+		res.To = res.Days[len(days)-1].Date
+	}
+	return res
+}
+
+func ToBreakdownResponse(q *entity.BreakdownQuery, b *entity.Breakdown) *dto.BreakdownResponse {
+	rows := make([]*dto.BreakdownRowResponse, len(b.Rows))
+	for i, r := range b.Rows {
+		row := &dto.BreakdownRowResponse{Key: r.Key, Clicks: r.Clicks}
+		if row.Key == "" {
+			row.Key = constant.BreakdownUnknownKey
+		}
+		if b.Total > 0 {
+			row.Share = float64(r.Clicks) / float64(b.Total)
+		}
+		rows[i] = row
+	}
+
+	return &dto.BreakdownResponse{
+		Dimension: q.Dimension,
+		From:      q.From,
+		To:        q.To,
+		Total:     b.Total,
+		Other:     b.Other,
+		Rows:      rows,
+	}
+}
+
+func ToGeoQuery(tenantID int, req *dto.GeoRequest) *entity.GeoQuery {
+	return &entity.GeoQuery{
+		Filter: ToClickFilter(tenantID, req.ShortCode, &req.ClickFilterRequest),
+		Box: &entity.BoundingBox{
+			Min: entity.GeoPoint{Latitude: req.MinLatitude, Longitude: req.MinLongitude},
+			Max: entity.GeoPoint{Latitude: req.MaxLatitude, Longitude: req.MaxLongitude},
+		},
+		From:  req.From,
+		To:    req.To,
+		Limit: req.Limit,
+	}
+}
+
+func ToGeoResponse(q *entity.GeoQuery, places []*entity.PlaceClicks) *dto.GeoResponse {
+	res := &dto.GeoResponse{
+		From:   q.From,
+		To:     q.To,
+		Places: make([]*dto.GeoPlaceResponse, len(places)),
+	}
+	for i, p := range places {
+		country, region, city := SplitPlaceKey(p.Place.Key)
+		res.Places[i] = &dto.GeoPlaceResponse{
+			Country:   country,
+			Region:    region,
+			City:      city,
+			Latitude:  p.Place.Location.Latitude,
+			Longitude: p.Place.Location.Longitude,
+			Clicks:    p.Clicks,
+		}
+		res.Total += p.Clicks
+	}
+	return res
+}
+
+// LeaderboardMember names a link on the global leaderboard, where codes of different tenants meet
+func LeaderboardMember(tenantID int, shortCode string) string {
+	return strconv.Itoa(tenantID) + ":" + shortCode
+}
+
+// SplitLeaderboardMember returns the tenant and short code of a global leaderboard member
+func SplitLeaderboardMember(member string) (int, string) {
+	tenant, code, _ := strings.Cut(member, ":")
+	tenantID, _ := strconv.Atoi(tenant)
+	return tenantID, code
+}
+
+func ToLeaderboardResponse(lb *entity.Leaderboard) *dto.LeaderboardResponse {
+	res := &dto.LeaderboardResponse{
+		Window:  lb.Window,
+		From:    lb.From,
+		To:      lb.To,
+		Entries: make([]*dto.LeaderboardEntryResponse, len(lb.Entries)),
+	}
+	for i, e := range lb.Entries {
+		res.Entries[i] = &dto.LeaderboardEntryResponse{
+			Rank:      i + 1,
+			TenantID:  e.TenantID,
+			ShortCode: e.ShortCode,
+			Clicks:    e.Clicks,
+			Exact:     e.Error == 0,
+		}
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"time"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+func ToLinkStatsResponse(s *entity.LinkStats) *dto.LinkStatsResponse {
+	return &dto.LinkStatsResponse{
+		ShortCode:      s.ShortCode,
+		Clicks:         s.Totals.Clicks,
+		BotClicks:      s.Totals.BotClicks,
+		UniqueVisitors: s.UniqueVisitors,
+		LastClickAt:    s.Totals.LastClickAt,
+	}
+}
+
+func ToGetLinkStatsResponse(s *entity.LinkStats) *dto.GetLinkStatsResponse {
+	return &dto.GetLinkStatsResponse{
+		Stats:  ToLinkStatsResponse(s),
+		AsOf:   s.ComputedAt,
+		MaxAge: constant.LinkStatsCacheTTL,
+	}
+}
+
+// ToBatchGetLinkStatsResponse dates the batch by its oldest stats, so a caller never keeps any of them too long
+func ToBatchGetLinkStatsResponse(stats []*entity.LinkStats) *dto.BatchGetLinkStatsResponse {
+	res := &dto.BatchGetLinkStatsResponse{
+		Stats:  make([]*dto.LinkStatsResponse, len(stats)),
+		MaxAge: constant.LinkStatsCacheTTL,
+	}
+	for i, s := range stats {
+		res.Stats[i] = ToLinkStatsResponse(s)
+		if res.AsOf.IsZero() || s.ComputedAt.Before(res.AsOf) {
+			res.AsOf = s.ComputedAt
+		}
+	}
+	if res.AsOf.IsZero() {
+		res.AsOf = time.Now().UTC()
+	}
+	return res
+}
+
+func ToTenantUsageResponse(u *entity.TenantUsage, maxAge time.Duration) *dto.TenantUsageResponse {
+	return &dto.TenantUsageResponse{
+		TenantID:  u.TenantID,
+		From:      u.From,
+		To:        u.To,
+		Clicks:    u.Totals.Clicks,
+		BotClicks: u.Totals.BotClicks,
+		Complete:  u.Complete,
+		AsOf:      u.ComputedAt,
+		MaxAge:    maxAge,
+	}
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+const anomalyServiceName = "AnomalyService"
+
+// linkRate tracks one link's clicks per interval against an EWMA of its past intervals
+type linkRate struct {
+	current   uint64  // clicks in the open interval
+	mean      float64 // EWMA of clicks per interval
+	intervals int     // closed intervals seen, for the warm-up
+	idle      int     // closed intervals in a row without a click
+	idleMean  float64 // mean when the idle run began, the baseline drops are judged against
+}
+
+// cachedSettings is a tenant's settings as last read; nil settings mean the tenant has none
+type cachedSettings struct {
+	settings *entity.AlertSettings
+	loadedAt time.Time
+}
+
+// anomalyService watches the click rate of every link this replica sees.
+// Every replica reads every click, so each keeps the same rates; the job lock lets only one of them alert.
+type anomalyService struct {
+	settingsRepo ports.AlertSettingsRepository
+	notifier     ports.NotificationPublisher
+	lock         ports.JobLock
+
+	mu    sync.Mutex
+	links map[linkKey]*linkRate
+
+	settingsMu sync.Mutex
+	settings   map[int]*cachedSettings
+}
+
+func NewAnomalyService(settingsRepo ports.AlertSettingsRepository, notifier ports.NotificationPublisher, lock ports.JobLock) ports.AnomalyService {
+	return &anomalyService{
+		settingsRepo: settingsRepo,
+		notifier:     notifier,
+		lock:         lock,
+		links:        make(map[linkKey]*linkRate),
+		settings:     make(map[int]*cachedSettings),
+	}
+}
+
+// Record counts human clicks that are still live; a backlog read at once would read as a spike
+func (s *anomalyService) Record(_ context.Context, evt *linkv1.LinkClickedEvent) {
+	if evt.Traffic != entity.TrafficHuman || evt.ShortCode == "" || evt.TenantID == 0 {
+		return
+	}
+	if time.Since(time.UnixMilli(evt.Timestamp)) > constant.LiveMaxEventAge {
+		return
+	}
+
+	key := linkKey{tenantID: evt.TenantID, shortCode: evt.ShortCode}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rate, ok := s.links[key]
+	if !ok {
+		if len(s.links) >= constant.AnomalyMaxLinks {
+			return
+		}
+		rate = &linkRate{}
+		s.links[key] = rate
+	}
+	rate.current++
+}
+
+// candidate is a closed interval of a warmed-up link that may be an anomaly
+type candidate struct {
+	key      linkKey
+	clicks   uint64
+	baseline float64
+	idle     int
+}
+
+// Evaluate closes the open interval of every link, then checks the candidates against their tenant's settings.
+// Thresholds below the lowest a tenant may set are filtered out first, so quiet links cost no settings lookup.
+func (s *anomalyService) Evaluate(ctx context.Context) error {
+	now := time.Now()
+	candidates := s.closeInterval()
+
+	for _, c := range candidates {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		settings, err := s.settingsFor(ctx, c.key.tenantID)
+		if err != nil {
+			global.LoggerZap.Warn("Failed to read alert settings", zap.Int("tenant_id", c.key.tenantID), zap.Error(err))
+			continue
+		}
+		if settings == nil || !settings.Enabled {
+			continue
+		}
+
+		anomaly := &entity.Anomaly{
+			TenantID:  c.key.tenantID,
+			ShortCode: c.key.shortCode,
+			Clicks:    c.clicks,
+			Baseline:  c.baseline,
+			At:        now,
+		}
+		switch {
+		case c.idle == 0 && settings.IsSpike(c.clicks, c.baseline):
+			anomaly.Kind = entity.AnomalySpike
+			anomaly.Window = constant.AnomalyInterval
+		case c.idle > 0 && settings.IsDrop(c.idle, c.baseline):
+			anomaly.Kind = entity.AnomalyDrop
+			anomaly.Window = time.Duration(c.idle) * constant.AnomalyInterval
+		default:
+			continue
+		}
+
+		if err := s.raise(ctx, anomaly, settings); err != nil {
+			global.LoggerZap.Error("Failed to raise anomaly alert",
+				zap.Int("tenant_id", anomaly.TenantID),
+				zap.String("short_code", anomaly.ShortCode),
+				zap.String("kind", anomaly.Kind),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// closeInterval folds the open interval into each baseline and returns the links worth checking
+func (s *anomalyService) closeInterval() []candidate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var candidates []candidate
+	for key, rate := range s.links {
+		clicks := rate.current
+		rate.current = 0
+
+		if clicks == 0 {
+			if rate.idle == 0 {
+				rate.idleMean = rate.mean
+			}
+			rate.idle++
+		} else {
+			rate.idle = 0
+		}
+
+		if rate.intervals >= constant.AnomalyWarmup {
+			switch {
+			case clicks >= constant.AlertMinSpikeClicks && float64(clicks) >= constant.AlertMinSpikeFactor*rate.mean:
+				candidates = append(candidates, candidate{key: key, clicks: clicks, baseline: rate.mean})
+			case rate.idle >= constant.AlertMinDropMinutes && rate.idleMean >= constant.AlertMinDropMinBaseline:
+				candidates = append(candidates, candidate{key: key, baseline: rate.idleMean, idle: rate.idle})
+			}
+		}
+
+		rate.mean = constant.AnomalyAlpha*float64(clicks) + (1-constant.AnomalyAlpha)*rate.mean
+		rate.intervals++
+
+		// A link that stopped long ago has nothing left to compare with
+		if clicks == 0 && rate.mean < constant.AnomalyForgetBelow {
+			delete(s.links, key)
+		}
+	}
+	return candidates
+}
+
+// raise sends one alert per link and kind per cooldown, however many replicas detect it
+func (s *anomalyService) raise(ctx context.Context, anomaly *entity.Anomaly, settings *entity.AlertSettings) error {
+	key := constant.AnomalyLockPrefix + mapper.LeaderboardMember(anomaly.TenantID, anomaly.ShortCode) + ":" + anomaly.Kind
+	acquired, err := s.lock.Acquire(ctx, key, constant.AnomalyCooldown)
+	if err != nil || !acquired {
+		return err
+	}
+
+	if err := s.notifier.Publish(ctx, mapper.ToAnomalyEvent(anomaly, settings)); err != nil {
+		// Let the next detection retry the alert
+		_ = s.lock.Release(context.WithoutCancel(ctx), key)
+		return err
+	}
+
+	global.LoggerZap.Info("Anomaly alert raised",
+		zap.Int("tenant_id", anomaly.TenantID),
+		zap.String("short_code", anomaly.ShortCode),
+		zap.String("kind", anomaly.Kind),
+		zap.Uint64("clicks", anomaly.Clicks),
+		zap.Float64("baseline", anomaly.Baseline))
+	return nil
+}
+
+// settingsFor reads a tenant's settings through a short in-memory cache, which also remembers tenants without any
+func (s *anomalyService) settingsFor(ctx context.Context, tenantID int) (*entity.AlertSettings, error) {
+	s.settingsMu.Lock()
+	cached, ok := s.settings[tenantID]
+	s.settingsMu.Unlock()
+	if ok && time.Since(cached.loadedAt) < constant.AlertSettingsCacheTTL {
+		return cached.settings, nil
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.settingsMu.Lock()
+	s.settings[tenantID] = &cachedSettings{settings: settings, loadedAt: time.Now()}
+	s.settingsMu.Unlock()
+	return settings, nil
+}
+
+func (s *anomalyService) GetSettings(ctx context.Context, _ *dto.GetAlertSettingsRequest) (*dto.AlertSettingsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(anomalyServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(anomalyServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	if settings == nil {
+		settings = mapper.DefaultAlertSettings(tenantID)
+	}
+	return mapper.ToAlertSettingsResponse(settings), nil
+}
+
+// UpdateSettings saves the tenant's settings; alerts go to the user who saved them
+func (s *anomalyService) UpdateSettings(ctx context.Context, req *dto.UpdateAlertSettingsRequest) (*dto.AlertSettingsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(anomalyServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+	userID, ok := ctx.Value(constraints.ContextKeyUserID).(int)
+	if !ok || userID == 0 {
+		return nil, apperr.NewError(anomalyServiceName, response.CodeUnauthorized, constant.MsgUserRequired, http.StatusUnauthorized, nil)
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(anomalyServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	if settings == nil {
+		settings = mapper.DefaultAlertSettings(tenantID)
+	}
+
+	mapper.ApplyAlertSettings(settings, req)
+	if settings.Enabled && settings.Email == "" && slices.Contains(settings.Channels, constant.NotificationChannelEmail) {
+		return nil, apperr.NewError(anomalyServiceName, response.CodeBadRequest, constant.MsgAlertEmailRequired, http.StatusBadRequest, nil)
+	}
+	settings.UserID = userID
+	settings.UpdatedAt = time.Now()
+
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, apperr.NewError(anomalyServiceName, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError, err)
+	}
+
+	// Other replicas pick the change up when their cached copy expires
+	s.settingsMu.Lock()
+	s.settings[tenantID] = &cachedSettings{settings: settings, loadedAt: time.Now()}
+	s.settingsMu.Unlock()
+
+	return mapper.ToAlertSettingsResponse(settings), nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/logger"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+
+	notificationv1 "github.com/huynhanx03/GoLink/events-contract/notification/v1"
+)
+
+type fakeAlertSettingsRepo struct {
+	ports.AlertSettingsRepository
+	settings map[int]*entity.AlertSettings
+}
+
+func (f *fakeAlertSettingsRepo) Save(_ context.Context, settings *entity.AlertSettings) error {
+	f.settings[settings.TenantID] = settings
+	return nil
+}
+
+func (f *fakeAlertSettingsRepo) Get(_ context.Context, tenantID int) (*entity.AlertSettings, error) {
+	return f.settings[tenantID], nil
+}
+
+type fakeNotifier struct {
+	events []*notificationv1.NotificationSendEvent
+}
+
+func (f *fakeNotifier) Publish(_ context.Context, evt *notificationv1.NotificationSendEvent) error {
+	f.events = append(f.events, evt)
+	return nil
+}
+
+type fakeJobLock struct {
+	ports.JobLock
+}
+
+func (fakeJobLock) Acquire(context.Context, string, time.Duration) (bool, error) { return true, nil }
+
+// Webhook and in-app delivery look the recipient up by user ID, so a spike alert must carry the user who saved the settings
+func TestAnomalyAlertRecipient(t *testing.T) {
+	global.LoggerZap = &logger.LoggerZap{Logger: zap.NewNop()}
+
+	notifier := &fakeNotifier{}
+	s := NewAnomalyService(&fakeAlertSettingsRepo{settings: map[int]*entity.AlertSettings{}}, notifier, fakeJobLock{}).(*anomalyService)
+
+	req := &dto.UpdateAlertSettingsRequest{Enabled: true, Channels: []string{"webhook", "in_app"}}
+
+	tenantOnly := context.WithValue(context.Background(), constraints.ContextKeyTenantID, 1)
+	if _, err := s.UpdateSettings(tenantOnly, req); err == nil {
+		t.Fatal("expected settings without a user to be rejected")
+	}
+
+	ctx := context.WithValue(tenantOnly, constraints.ContextKeyUserID, 42)
+	if _, err := s.UpdateSettings(ctx, req); err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+
+	// A warmed-up link that averaged one click a minute gets 200 in the last one
+	s.links[linkKey{tenantID: 1, shortCode: "abc"}] = &linkRate{current: 200, mean: 1, intervals: constant.AnomalyWarmup}
+	if err := s.Evaluate(context.Background()); err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	if len(notifier.events) != 1 {
+		t.Fatalf("expected one alert, got %d", len(notifier.events))
+	}
+	evt := notifier.events[0]
+	if evt.Recipient.UserID != "42" {
+		t.Errorf("expected recipient user 42, got %q", evt.Recipient.UserID)
+	}
+	if evt.TemplateData["Kind"] != entity.AnomalySpike {
+		t.Errorf("expected a spike alert, got %q", evt.TemplateData["Kind"])
+	}
+}
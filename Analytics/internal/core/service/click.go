@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+type clickService struct {
+	clickRepo   ports.ClickRepository
+	visitorRepo ports.VisitorRepository
+	placeRepo   ports.PlaceRepository
+	uaParser    ports.UserAgentParser
+	sources     ports.SourceClassifier
+}
+
+func NewClickService(
+	clickRepo ports.ClickRepository,
+	visitorRepo ports.VisitorRepository,
+	placeRepo ports.PlaceRepository,
+	uaParser ports.UserAgentParser,
+	sources ports.SourceClassifier,
+) ports.ClickService {
+	return &clickService{
+		clickRepo:   clickRepo,
+		visitorRepo: visitorRepo,
+		placeRepo:   placeRepo,
+		uaParser:    uaParser,
+		sources:     sources,
+	}
+}
+
+// Ingest enriches the events and indexes them in one bulk request.
+// Events are keyed by their event ID, so a redelivered batch never counts a click twice.
+func (s *clickService) Ingest(ctx context.Context, events []*linkv1.LinkClickedEvent) error {
+	seen := make(map[string]struct{}, len(events))
+	clicks := make([]*entity.Click, 0, len(events))
+	for _, evt := range events {
+		if evt.EventID == "" || evt.ShortCode == "" {
+			global.LoggerZap.Warn("Skipping click event without id or short code", zap.String("eventID", evt.EventID))
+			continue
+		}
+		if _, dup := seen[evt.EventID]; dup {
+			continue
+		}
+		seen[evt.EventID] = struct{}{}
+
+		clicks = append(clicks, enrichClick(evt, s.uaParser, s.sources))
+	}
+
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	created, err := s.clickRepo.SaveBulk(ctx, clicks)
+	if err != nil {
+		return err
+	}
+
+	if skipped := len(clicks) - created; skipped > 0 {
+		global.LoggerZap.Debug("Skipped already ingested clicks", zap.Int("skipped", skipped))
+	}
+
+	if err := s.addVisitors(ctx, clicks); err != nil {
+		return err
+	}
+	return s.addPlaces(ctx, clicks)
+}
+
+// enrichClick parses the event's User-Agent and attributes it to a source
+func enrichClick(evt *linkv1.LinkClickedEvent, uaParser ports.UserAgentParser, sources ports.SourceClassifier) *entity.Click {
+	domain := mapper.ReferrerDomain(evt.Referrer)
+	src := sources.Classify(evt.UTMSource, evt.UTMMedium, domain)
+	return mapper.ToClick(evt, uaParser.Parse(evt.UserAgent), domain, src)
+}
+
+// visitorDay identifies one link's sketch for one UTC day
+type visitorDay struct {
+	tenantID  int
+	shortCode string
+	day       time.Time
+}
+
+// addVisitors folds the batch's human visitors into each link's daily sketch.
+// Replayed clicks are added again on purpose: the sketch ignores visitors it has already seen.
+func (s *clickService) addVisitors(ctx context.Context, clicks []*entity.Click) error {
+	groups := make(map[visitorDay][]uint64)
+	for _, c := range clicks {
+		if c.Bot || c.IPHash == "" {
+			continue
+		}
+		key := visitorDay{
+			tenantID:  c.TenantID,
+			shortCode: c.ShortCode,
+			day:       c.Timestamp.UTC().Truncate(24 * time.Hour),
+		}
+		groups[key] = append(groups[key], mapper.VisitorHash(c))
+	}
+
+	for key, hashes := range groups {
+		if err := s.visitorRepo.Add(ctx, key.tenantID, key.shortCode, key.day, hashes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkKey identifies one link of one tenant
+type linkKey struct {
+	tenantID  int
+	shortCode string
+}
+
+// addPlaces indexes the cities of the batch's located clicks, once per link
+func (s *clickService) addPlaces(ctx context.Context, clicks []*entity.Click) error {
+	groups := make(map[linkKey]map[string]*entity.Place)
+	for _, c := range clicks {
+		if c.CityKey == "" || c.Location == nil {
+			continue
+		}
+		key := linkKey{tenantID: c.TenantID, shortCode: c.ShortCode}
+		if groups[key] == nil {
+			groups[key] = make(map[string]*entity.Place)
+		}
+		groups[key][c.CityKey] = &entity.Place{Key: c.CityKey, Location: *c.Location}
+	}
+
+	for key, byCity := range groups {
+		places := make([]*entity.Place, 0, len(byCity))
+		for _, p := range byCity {
+			places = append(places, p)
+		}
+		if err := s.placeRepo.Add(ctx, key.tenantID, key.shortCode, places); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/security"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+)
+
+const conversionServiceName = "ConversionService"
+
+type conversionService struct {
+	conversionRepo ports.ConversionRepository
+	clickRepo      ports.ClickRepository
+}
+
+func NewConversionService(conversionRepo ports.ConversionRepository, clickRepo ports.ClickRepository) ports.ConversionService {
+	return &conversionService{
+		conversionRepo: conversionRepo,
+		clickRepo:      clickRepo,
+	}
+}
+
+// Record credits a conversion to the click named by its glclid.
+// The click ID proves which link, tenant and campaign the click belongs to,
+// and the postback signature proves the tenant itself reported the conversion.
+// Retried postbacks for the same click, event and order are acknowledged but counted once.
+func (s *conversionService) Record(ctx context.Context, req *dto.ConversionPostbackRequest) (*dto.ConversionResponse, error) {
+	cfg := global.Config.Conversion
+	if cfg.ClickIDSecret == "" || cfg.PostbackSecret == "" {
+		return nil, apperr.NewError(conversionServiceName, response.CodeInternalServer, constant.MsgConversionNotEnabled, http.StatusServiceUnavailable, nil)
+	}
+
+	params := req.Params
+	click, err := security.DecodeClickID([]byte(cfg.ClickIDSecret), params.Get(security.ClickIDParam))
+	if err != nil {
+		return nil, apperr.NewError(conversionServiceName, response.CodeBadRequest, constant.MsgInvalidClickID, http.StatusBadRequest, err)
+	}
+
+	key := security.DerivePostbackKey([]byte(cfg.PostbackSecret), click.TenantID)
+	if err := security.VerifyPostback(key, params); err != nil {
+		return nil, apperr.NewError(conversionServiceName, response.CodeForbidden, constant.MsgInvalidPostback, http.StatusForbidden, err)
+	}
+
+	now := time.Now()
+	if now.Sub(click.IssuedAt) > constant.ConversionAttributionWindow {
+		return nil, apperr.NewError(conversionServiceName, response.CodeBadRequest, constant.MsgClickTooOld, http.StatusUnprocessableEntity, nil)
+	}
+
+	conversion, ok := toConversion(params, click, req.Source, now)
+	if !ok {
+		return nil, apperr.NewError(conversionServiceName, response.CodeValidationFailed, constant.MsgInvalidConversionData, http.StatusBadRequest, nil)
+	}
+
+	created, err := s.conversionRepo.Create(ctx, conversion)
+	if err != nil {
+		return nil, apperr.NewError(conversionServiceName, response.CodeDatabaseError, apperr.MsgCreateFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToConversionResponse(conversion, created), nil
+}
+
+// Report returns clicks, conversions and conversion rate per link, campaign or variant.
+// Conversions are counted against the clicks they came from, so the rate compares like with like.
+func (s *conversionService) Report(ctx context.Context, req *dto.ConversionReportRequest) (*dto.ConversionReportResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(conversionServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	filter := &entity.ConversionFilter{
+		TenantID: tenantID,
+		GroupBy:  req.GroupBy,
+		From:     req.From,
+		To:       req.To,
+		Limit:    req.Limit,
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = entity.GroupByLink
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = constant.ConversionReportDefaultLimit
+	}
+
+	stats, err := s.conversionRepo.Stats(ctx, filter)
+	if err != nil {
+		return nil, apperr.NewError(conversionServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	clicks, err := s.clickRepo.CountTracked(ctx, filter)
+	if err != nil {
+		return nil, apperr.NewError(conversionServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToConversionReportResponse(filter, mergeClicks(stats, clicks, filter.Limit)), nil
+}
+
+// PostbackKey returns the key the caller's tenant signs its postbacks with
+func (s *conversionService) PostbackKey(ctx context.Context, _ *dto.PostbackKeyRequest) (*dto.PostbackKeyResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(conversionServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	secret := global.Config.Conversion.PostbackSecret
+	if secret == "" {
+		return nil, apperr.NewError(conversionServiceName, response.CodeInternalServer, constant.MsgConversionNotEnabled, http.StatusServiceUnavailable, nil)
+	}
+
+	key := security.DerivePostbackKey([]byte(secret), tenantID)
+	return &dto.PostbackKeyResponse{Key: base64.RawURLEncoding.EncodeToString(key)}, nil
+}
+
+// toConversion reads the optional event, value, currency and order ID of a postback
+func toConversion(params url.Values, click security.ClickID, source string, now time.Time) (*entity.Conversion, bool) {
+	get := func(k string) string {
+		return strings.TrimSpace(params.Get(k))
+	}
+
+	event := get(constant.PostbackParamEvent)
+	if event == "" {
+		event = constant.ConversionDefaultEvent
+	}
+	orderID := get(constant.PostbackParamOrderID)
+	currency := strings.ToUpper(get(constant.PostbackParamCurrency))
+	if len(event) > constant.ConversionMaxFieldLength || len(orderID) > constant.ConversionMaxFieldLength ||
+		(currency != "" && len(currency) != 3) {
+		return nil, false
+	}
+
+	var value float64
+	if raw := get(constant.PostbackParamValue); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, false
+		}
+		value = v
+	}
+
+	clickID := get(security.ClickIDParam)
+	return &entity.Conversion{
+		ID:          conversionID(clickID, event, orderID),
+		ClickID:     clickID,
+		TenantID:    click.TenantID,
+		ShortCode:   click.ShortCode,
+		Variant:     click.Variant,
+		Campaign:    click.Campaign,
+		Event:       event,
+		OrderID:     orderID,
+		Value:       value,
+		Currency:    currency,
+		Source:      source,
+		ClickedAt:   click.IssuedAt,
+		ConvertedAt: now,
+	}, true
+}
+
+// conversionID derives the document ID from what makes a conversion unique, so retries collapse into one
+func conversionID(clickID, event, orderID string) string {
+	sum := sha256.Sum256([]byte(clickID + "\x00" + event + "\x00" + orderID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// mergeClicks joins click counts onto the conversion rows, keeping groups that were clicked but never converted
+func mergeClicks(stats []*entity.ConversionStats, clicks map[string]int64, limit int) []*entity.ConversionStats {
+	byKey := make(map[string]*entity.ConversionStats, len(stats)+len(clicks))
+	for _, s := range stats {
+		byKey[s.Key] = s
+	}
+	for key, n := range clicks {
+		s, ok := byKey[key]
+		if !ok {
+			s = &entity.ConversionStats{Key: key}
+			byKey[key] = s
+		}
+		s.Clicks = n
+	}
+
+	rows := make([]*entity.ConversionStats, 0, len(byKey))
+	for _, s := range byKey {
+		rows = append(rows, s)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Conversions != rows[j].Conversions {
+			return rows[i].Conversions > rows[j].Conversions
+		}
+		if rows[i].Clicks != rows[j].Clicks {
+			return rows[i].Clicks > rows[j].Clicks
+		}
+		return rows[i].Key < rows[j].Key
+	})
+
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/security"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+)
+
+const exportServiceName = "ExportService"
+
+// errExportTooLarge stops a job that reached ExportMaxRows
+var errExportTooLarge = errors.New("export exceeds the row limit")
+
+type exportService struct {
+	exportRepo     ports.ExportRepository
+	clickRepo      ports.ClickRepository
+	storage        ports.ExportStorage
+	lock           ports.JobLock
+	downloadSecret []byte
+	linkTTL        time.Duration
+}
+
+// NewExportService creates the export service; with a nil storage or an empty secret exports are turned off
+func NewExportService(exportRepo ports.ExportRepository, clickRepo ports.ClickRepository, storage ports.ExportStorage, lock ports.JobLock, downloadSecret string, linkTTL time.Duration) ports.ExportService {
+	if linkTTL <= 0 {
+		linkTTL = constant.ExportDefaultLinkTTL
+	}
+	return &exportService{
+		exportRepo:     exportRepo,
+		clickRepo:      clickRepo,
+		storage:        storage,
+		lock:           lock,
+		downloadSecret: []byte(downloadSecret),
+		linkTTL:        linkTTL,
+	}
+}
+
+func (s *exportService) enabled() bool {
+	return s.storage != nil && len(s.downloadSecret) > 0
+}
+
+// Create queues the export; a worker picks it up within ExportPollInterval
+func (s *exportService) Create(ctx context.Context, req *dto.CreateExportRequest) (*dto.ExportResponse, error) {
+	if !s.enabled() {
+		return nil, apperr.NewError(exportServiceName, response.CodeInternalServer, constant.MsgExportNotEnabled, http.StatusServiceUnavailable, nil)
+	}
+
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(exportServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+	userID, ok := ctx.Value(constraints.ContextKeyUserID).(int)
+	if !ok || userID == 0 {
+		return nil, apperr.NewError(exportServiceName, response.CodeUnauthorized, constant.MsgUserRequired, http.StatusUnauthorized, nil)
+	}
+
+	if req.To.Sub(req.From) > constant.ExportMaxRange {
+		return nil, apperr.NewError(exportServiceName, response.CodeBadRequest, constant.MsgExportRangeTooLong, http.StatusBadRequest, nil)
+	}
+
+	id, err := security.NewNonce()
+	if err != nil {
+		return nil, apperr.NewError(exportServiceName, response.CodeInternalServer, apperr.MsgGenFailed, http.StatusInternalServerError, err)
+	}
+
+	job := &entity.ExportJob{
+		ID:        id,
+		TenantID:  tenantID,
+		CreatedBy: userID,
+		Format:    req.Format,
+		Filter:    mapper.ToClickFilter(tenantID, req.ShortCode, &req.ClickFilterRequest),
+		From:      req.From.UTC(),
+		To:        req.To.UTC(),
+		Status:    entity.ExportStatusPending,
+		CreatedAt: time.Now(),
+	}
+	if err := s.exportRepo.Save(ctx, job); err != nil {
+		return nil, apperr.NewError(exportServiceName, response.CodeDatabaseError, apperr.MsgCreateFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToExportResponse(job, ""), nil
+}
+
+// Get signs a new download link on every call, valid for the link TTL but never past the file's expiry
+func (s *exportService) Get(ctx context.Context, req *dto.GetExportRequest) (*dto.ExportResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(exportServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	job, err := s.exportRepo.Get(ctx, req.ID)
+	if err != nil {
+		return nil, apperr.NewError(exportServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	if job == nil || job.TenantID != tenantID {
+		return nil, apperr.NewError(exportServiceName, response.CodeNotFound, constant.MsgExportNotFound, http.StatusNotFound, nil)
+	}
+
+	var downloadURL string
+	if job.Status == entity.ExportStatusDone && s.enabled() {
+		expiresAt := time.Now().Add(s.linkTTL).Truncate(time.Second)
+		if job.ExpiresAt.Before(expiresAt) {
+			expiresAt = job.ExpiresAt.Truncate(time.Second)
+		}
+		key := security.DeriveDownloadKey(s.downloadSecret, job.TenantID)
+		query := security.SignedLinkQuery(key, job.ID, security.SignedLink{ExpiresAt: expiresAt})
+		downloadURL = fmt.Sprintf(constant.ExportDownloadPath, job.ID) + "?" + query.Encode()
+	}
+
+	return mapper.ToExportResponse(job, downloadURL), nil
+}
+
+// Download is reached without a session, so every failure looks the same to the caller
+func (s *exportService) Download(ctx context.Context, req *dto.DownloadExportRequest) (*dto.ExportFile, error) {
+	if !s.enabled() {
+		return nil, apperr.NewError(exportServiceName, response.CodeInternalServer, constant.MsgExportNotEnabled, http.StatusServiceUnavailable, nil)
+	}
+
+	job, err := s.exportRepo.Get(ctx, req.ID)
+	if err != nil {
+		return nil, apperr.NewError(exportServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	if job == nil {
+		return nil, apperr.NewError(exportServiceName, response.CodeForbidden, constant.MsgInvalidDownload, http.StatusForbidden, nil)
+	}
+
+	key := security.DeriveDownloadKey(s.downloadSecret, job.TenantID)
+	link := security.SignedLink{ExpiresAt: time.Unix(req.Expiry, 0)}
+	if err := security.VerifyLink(key, job.ID, link, req.Signature, time.Now()); err != nil {
+		return nil, apperr.NewError(exportServiceName, response.CodeForbidden, constant.MsgInvalidDownload, http.StatusForbidden, err)
+	}
+
+	if job.Status != entity.ExportStatusDone {
+		return nil, apperr.NewError(exportServiceName, response.CodeNotFound, constant.MsgExportExpired, http.StatusGone, nil)
+	}
+
+	return &dto.ExportFile{Path: s.storage.Path(job), Name: job.FileName()}, nil
+}
+
+// RunPending runs queued jobs one after the other. A replica that died mid-job leaves it running;
+// it is picked up again once its lock has expired.
+func (s *exportService) RunPending(ctx context.Context) error {
+	if !s.enabled() {
+		return nil
+	}
+
+	now := time.Now()
+	jobs, err := s.exportRepo.ListRunnable(ctx, now.Add(-constant.ExportLockTTL), constant.ExportPollBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.claimAndRun(ctx, job.ID); err != nil {
+			global.LoggerZap.Error("Failed to run export", zap.String("export_id", job.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *exportService) claimAndRun(ctx context.Context, id string) error {
+	key := constant.ExportLockPrefix + id
+	acquired, err := s.lock.Acquire(ctx, key, constant.ExportLockTTL)
+	if err != nil || !acquired {
+		return err
+	}
+	defer func() {
+		_ = s.lock.Release(context.WithoutCancel(ctx), key)
+	}()
+
+	// Another replica may have finished the job between the listing and the lock
+	job, err := s.exportRepo.Get(ctx, id)
+	if err != nil || job == nil {
+		return err
+	}
+	stale := job.Status == entity.ExportStatusRunning && time.Since(job.StartedAt) > constant.ExportLockTTL
+	if job.Status != entity.ExportStatusPending && !stale {
+		return nil
+	}
+
+	return s.run(ctx, job)
+}
+
+func (s *exportService) run(ctx context.Context, job *entity.ExportJob) error {
+	job.Status = entity.ExportStatusRunning
+	job.StartedAt = time.Now()
+	job.Rows, job.Size, job.Error = 0, 0, ""
+	if err := s.exportRepo.Save(ctx, job); err != nil {
+		return err
+	}
+
+	size, err := s.write(ctx, job)
+	switch {
+	case err == nil:
+		now := time.Now()
+		job.Status = entity.ExportStatusDone
+		job.Size = size
+		job.FinishedAt = now
+		job.ExpiresAt = now.Add(constant.ExportFileTTL)
+	case ctx.Err() != nil:
+		// Shutting down; hand the job back to the queue
+		job.Status = entity.ExportStatusPending
+		job.StartedAt = time.Time{}
+	default:
+		job.Status = entity.ExportStatusFailed
+		job.FinishedAt = time.Now()
+		job.Error = apperr.MsgProcessFailed
+		if errors.Is(err, errExportTooLarge) {
+			job.Error = constant.MsgExportTooLarge
+		}
+	}
+
+	if saveErr := s.exportRepo.Save(context.WithoutCancel(ctx), job); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// write pages through the matching clicks into the job's file, returning the file size
+func (s *exportService) write(ctx context.Context, job *entity.ExportJob) (int64, error) {
+	w, err := s.storage.Create(job)
+	if err != nil {
+		return 0, err
+	}
+
+	var after *entity.Click
+	for {
+		clicks, err := s.clickRepo.Scan(ctx, job.Filter, job.From, job.To, after, constant.ExportPageSize)
+		if err != nil {
+			w.Abort()
+			return 0, err
+		}
+
+		for _, c := range clicks {
+			if job.Rows == constant.ExportMaxRows {
+				w.Abort()
+				return 0, errExportTooLarge
+			}
+			if err := w.Write(c); err != nil {
+				w.Abort()
+				return 0, err
+			}
+			job.Rows++
+		}
+
+		if len(clicks) < constant.ExportPageSize {
+			break
+		}
+		after = clicks[len(clicks)-1]
+	}
+
+	return w.Commit()
+}
+
+// RemoveExpired deletes expired files a batch at a time; the job stays, marked expired
+func (s *exportService) RemoveExpired(ctx context.Context) error {
+	if !s.enabled() {
+		return nil
+	}
+
+	jobs, err := s.exportRepo.ListExpired(ctx, time.Now(), constant.ExportCleanupBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := s.storage.Remove(job); err != nil {
+			global.LoggerZap.Error("Failed to remove export file", zap.String("export_id", job.ID), zap.Error(err))
+			continue
+		}
+		job.Status = entity.ExportStatusExpired
+		if err := s.exportRepo.Save(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
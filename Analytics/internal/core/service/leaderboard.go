@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/datastructs/topk"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+const leaderboardServiceName = "LeaderboardService"
+
+// windowSpec splits a rolling window into buckets of equal length
+type windowSpec struct {
+	name    string
+	bucket  time.Duration
+	buckets int
+}
+
+func (w windowSpec) length() time.Duration {
+	return w.bucket * time.Duration(w.buckets)
+}
+
+var leaderboardWindows = []windowSpec{
+	{entity.WindowHour, constant.LeaderboardHourBucket, constant.LeaderboardHourBuckets},
+	{entity.WindowDay, constant.LeaderboardDayBucket, constant.LeaderboardDayBuckets},
+	{entity.WindowWeek, constant.LeaderboardWeekBucket, constant.LeaderboardWeekBuckets},
+}
+
+// bucket counts the clicks of one slice of a window; dirty marks it for the next flush
+type bucket struct {
+	top   *topk.TopK
+	dirty bool
+}
+
+// board holds every window of one scope, a tenant or the global one.
+// Its buckets are loaded from Redis on first use, so a new replica starts from what the others saved.
+type board struct {
+	mu      sync.Mutex
+	scope   string
+	loaded  bool
+	removed bool                         // set when Flush dropped the board; holders must look it up again
+	windows map[string]map[int64]*bucket // window -> bucket start (Unix seconds) -> bucket
+}
+
+// leaderboardService ranks links over rolling windows with one Space-Saving summary per bucket.
+// Every replica reads every click, so any replica can answer; buckets go to Redis sorted sets
+// for the replicas that start later.
+type leaderboardService struct {
+	repo ports.LeaderboardRepository
+
+	mu     sync.Mutex
+	boards map[string]*board
+}
+
+func NewLeaderboardService(repo ports.LeaderboardRepository) ports.LeaderboardService {
+	return &leaderboardService{
+		repo:   repo,
+		boards: make(map[string]*board),
+	}
+}
+
+// Record counts human clicks only, and only while they are recent enough to be live:
+// clicks read late, while the consumer lags behind, may already be in the buckets other replicas saved.
+func (s *leaderboardService) Record(ctx context.Context, evt *linkv1.LinkClickedEvent) {
+	if evt.Traffic != entity.TrafficHuman || evt.ShortCode == "" || evt.TenantID == 0 {
+		return
+	}
+	at := time.UnixMilli(evt.Timestamp)
+	if time.Since(at) > constant.LiveMaxEventAge {
+		return
+	}
+
+	s.add(ctx, strconv.Itoa(evt.TenantID), evt.ShortCode, at)
+	s.add(ctx, constant.LeaderboardGlobalScope, mapper.LeaderboardMember(evt.TenantID, evt.ShortCode), at)
+}
+
+func (s *leaderboardService) add(ctx context.Context, scope, member string, at time.Time) {
+	b := s.lockedBoard(ctx, scope)
+	defer b.mu.Unlock()
+
+	for _, w := range leaderboardWindows {
+		bk := b.bucket(w, at.Truncate(w.bucket).Unix())
+		bk.top.Add(member, 1)
+		bk.dirty = true
+	}
+}
+
+// lockedBoard returns the scope's board with its lock held, loading it the first time it is used
+func (s *leaderboardService) lockedBoard(ctx context.Context, scope string) *board {
+	for {
+		s.mu.Lock()
+		b, ok := s.boards[scope]
+		if !ok {
+			b = &board{scope: scope, windows: make(map[string]map[int64]*bucket)}
+			s.boards[scope] = b
+		}
+		s.mu.Unlock()
+
+		b.mu.Lock()
+		if b.removed {
+			b.mu.Unlock()
+			continue
+		}
+
+		if !b.loaded {
+			if err := s.load(ctx, b); err != nil {
+				global.LoggerZap.Warn("Failed to load leaderboard snapshot", zap.String("scope", scope), zap.Error(err))
+			}
+			b.loaded = true
+		}
+		return b
+	}
+}
+
+// load reads the saved buckets of every window, adding to what the board already counted
+func (s *leaderboardService) load(ctx context.Context, b *board) error {
+	now := time.Now()
+	for _, w := range leaderboardWindows {
+		for _, start := range bucketStarts(w, now) {
+			counts, err := s.repo.Load(ctx, w.name, b.scope, start)
+			if err != nil {
+				return err
+			}
+			if len(counts) == 0 {
+				continue
+			}
+
+			bk := b.bucket(w, start.Unix())
+			for member, count := range counts {
+				bk.top.Add(member, count)
+			}
+		}
+	}
+	return nil
+}
+
+// bucket returns the window's bucket starting at start, creating it when needed; the caller holds b.mu
+func (b *board) bucket(w windowSpec, start int64) *bucket {
+	buckets, ok := b.windows[w.name]
+	if !ok {
+		buckets = make(map[int64]*bucket, w.buckets)
+		b.windows[w.name] = buckets
+	}
+
+	bk, ok := buckets[start]
+	if !ok {
+		top, _ := topk.New(constant.LeaderboardCapacity)
+		bk = &bucket{top: top}
+		buckets[start] = bk
+	}
+	return bk
+}
+
+// Flush saves dirty buckets, drops buckets that left their window, and forgets boards with nothing left
+func (s *leaderboardService) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	boards := make([]*board, 0, len(s.boards))
+	for _, b := range s.boards {
+		boards = append(boards, b)
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	var firstErr error
+	for _, b := range boards {
+		if err := s.flushBoard(ctx, b, now); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *leaderboardService) flushBoard(ctx context.Context, b *board, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	empty := true
+	for _, w := range leaderboardWindows {
+		oldest := now.Truncate(w.bucket).Add(-w.length() + w.bucket).Unix()
+		for start, bk := range b.windows[w.name] {
+			if start < oldest {
+				delete(b.windows[w.name], start)
+				continue
+			}
+			empty = false
+			if !bk.dirty {
+				continue
+			}
+
+			counts := make(map[string]uint64, bk.top.Len())
+			for _, it := range bk.top.Top(0) {
+				counts[it.Key] = it.Count
+			}
+			// Keep the bucket until it leaves the window, plus one bucket of slack for clock skew between replicas
+			ttl := time.Unix(start, 0).Add(w.length() + w.bucket).Sub(now)
+			if err := s.repo.Save(ctx, w.name, b.scope, time.Unix(start, 0), counts, ttl); err != nil {
+				return err
+			}
+			bk.dirty = false
+		}
+	}
+
+	if empty {
+		s.mu.Lock()
+		delete(s.boards, b.scope)
+		s.mu.Unlock()
+		b.removed = true
+	}
+	return nil
+}
+
+// TenantTop ranks the caller's links
+func (s *leaderboardService) TenantTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(leaderboardServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	lb := s.top(ctx, strconv.Itoa(tenantID), req)
+	for _, e := range lb.Entries {
+		e.TenantID = tenantID
+	}
+	return mapper.ToLeaderboardResponse(lb), nil
+}
+
+// GlobalTop ranks links across tenants; members of the global board are "tenant:code"
+func (s *leaderboardService) GlobalTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error) {
+	lb := s.top(ctx, constant.LeaderboardGlobalScope, req)
+	for _, e := range lb.Entries {
+		e.TenantID, e.ShortCode = mapper.SplitLeaderboardMember(e.ShortCode)
+	}
+	return mapper.ToLeaderboardResponse(lb), nil
+}
+
+// top merges the window's buckets into one summary and takes its head
+func (s *leaderboardService) top(ctx context.Context, scope string, req *dto.LeaderboardRequest) *entity.Leaderboard {
+	w := leaderboardWindows[0]
+	for _, spec := range leaderboardWindows {
+		if spec.name == req.Window {
+			w = spec
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = constant.LeaderboardDefaultLimit
+	}
+
+	now := time.Now()
+	starts := bucketStarts(w, now)
+	lb := &entity.Leaderboard{
+		Window: w.name,
+		From:   starts[0],
+		To:     now,
+	}
+
+	b := s.lockedBoard(ctx, scope)
+	merged, _ := topk.New(constant.LeaderboardCapacity)
+	for _, start := range starts {
+		if bk, ok := b.windows[w.name][start.Unix()]; ok {
+			merged.Merge(bk.top)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, it := range merged.Top(limit) {
+		lb.Entries = append(lb.Entries, &entity.LeaderboardEntry{ShortCode: it.Key, Clicks: it.Count, Error: it.Error})
+	}
+	return lb
+}
+
+// bucketStarts lists the starts of the window's buckets, oldest first, ending with the open one
+func bucketStarts(w windowSpec, now time.Time) []time.Time {
+	open := now.Truncate(w.bucket)
+	starts := make([]time.Time, w.buckets)
+	for i := range starts {
+		starts[i] = open.Add(-time.Duration(w.buckets-1-i) * w.bucket)
+	}
+	return starts
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/security"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+const liveServiceName = "LiveService"
+
+type liveService struct {
+	hub      *LiveHub
+	tickets  ports.LiveTicketStore
+	uaParser ports.UserAgentParser
+	sources  ports.SourceClassifier
+}
+
+func NewLiveService(hub *LiveHub, tickets ports.LiveTicketStore, uaParser ports.UserAgentParser, sources ports.SourceClassifier) ports.LiveService {
+	return &liveService{
+		hub:      hub,
+		tickets:  tickets,
+		uaParser: uaParser,
+		sources:  sources,
+	}
+}
+
+// Publish skips clicks of tenants nobody watches here and clicks too old to be live,
+// e.g. ones read while the consumer lags behind, so dashboards only ever see the last few seconds.
+func (s *liveService) Publish(ctx context.Context, evt *linkv1.LinkClickedEvent) {
+	if !s.hub.HasClients(evt.TenantID) {
+		return
+	}
+	if time.Since(time.UnixMilli(evt.Timestamp)) > constant.LiveMaxEventAge {
+		return
+	}
+
+	s.hub.Broadcast(enrichClick(evt, s.uaParser, s.sources))
+}
+
+// IssueTicket stands in for the caller's token in the stream URL, where it would end up in access logs;
+// the ticket opens one stream, within LiveTicketTTL, and nothing else.
+func (s *liveService) IssueTicket(ctx context.Context, _ *dto.CreateLiveTicketRequest) (*dto.LiveTicketResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(liveServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+	userID, ok := ctx.Value(constraints.ContextKeyUserID).(int)
+	if !ok || userID == 0 {
+		return nil, apperr.NewError(liveServiceName, response.CodeUnauthorized, constant.MsgUserRequired, http.StatusUnauthorized, nil)
+	}
+
+	id, err := security.NewNonce()
+	if err != nil {
+		return nil, apperr.NewError(liveServiceName, response.CodeInternalServer, apperr.MsgGenFailed, http.StatusInternalServerError, err)
+	}
+
+	ticket := &entity.LiveTicket{TenantID: tenantID, UserID: userID}
+	if err := s.tickets.Save(ctx, id, ticket, constant.LiveTicketTTL); err != nil {
+		return nil, apperr.NewError(liveServiceName, response.CodeInternalServer, apperr.MsgSaveFailed, http.StatusInternalServerError, err)
+	}
+
+	return &dto.LiveTicketResponse{Ticket: id, ExpiresAt: time.Now().Add(constant.LiveTicketTTL)}, nil
+}
+
+// RedeemTicket takes the ticket, so it cannot open a second stream
+func (s *liveService) RedeemTicket(ctx context.Context, id string) (*entity.LiveTicket, error) {
+	ticket, err := s.tickets.Take(ctx, id)
+	if err != nil {
+		return nil, apperr.NewError(liveServiceName, response.CodeInternalServer, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	if ticket == nil {
+		return nil, apperr.NewError(liveServiceName, response.CodeUnauthorized, constant.MsgInvalidLiveTicket, http.StatusUnauthorized, nil)
+	}
+	return ticket, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/entity"
+)
+
+// LiveClient represents a single live stream of a tenant.
+type LiveClient struct {
+	Filter *entity.LiveFilter
+	Chan   chan *entity.Click
+
+	matched atomic.Int64
+
+	// Rolling window of per-tick counts, only touched by the stream's goroutine through Tick
+	window []int64
+	pos    int
+	sum    int64
+}
+
+// Tick returns the clicks matched since the previous tick and rolls them into the window.
+func (c *LiveClient) Tick(now time.Time) *entity.LiveCounter {
+	clicks := c.matched.Swap(0)
+
+	c.sum += clicks - c.window[c.pos]
+	c.window[c.pos] = clicks
+	c.pos = (c.pos + 1) % len(c.window)
+
+	return &entity.LiveCounter{
+		Time:   now,
+		Clicks: clicks,
+		Window: c.sum,
+	}
+}
+
+// LiveHub manages all active live streams keyed by tenant ID.
+type LiveHub struct {
+	mu      sync.RWMutex
+	clients map[int][]*LiveClient
+}
+
+// NewLiveHub creates and returns a new LiveHub.
+func NewLiveHub() *LiveHub {
+	return &LiveHub{
+		clients: make(map[int][]*LiveClient),
+	}
+}
+
+// Register creates a new LiveClient for the filter and registers it in the hub.
+// The caller is responsible for calling Unregister when the connection closes.
+func (h *LiveHub) Register(filter *entity.LiveFilter) *LiveClient {
+	client := &LiveClient{
+		Filter: filter,
+		Chan:   make(chan *entity.Click, constant.LiveClientBuffer),
+		window: make([]int64, constant.LiveCounterWindow),
+	}
+
+	h.mu.Lock()
+	h.clients[filter.TenantID] = append(h.clients[filter.TenantID], client)
+	h.mu.Unlock()
+
+	return client
+}
+
+// Unregister removes the given LiveClient from the hub and closes its channel.
+func (h *LiveHub) Unregister(client *LiveClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tenantID := client.Filter.TenantID
+	conns := h.clients[tenantID]
+	filtered := conns[:0]
+	for _, c := range conns {
+		if c != client {
+			filtered = append(filtered, c)
+		}
+	}
+
+	if len(filtered) == 0 {
+		delete(h.clients, tenantID)
+	} else {
+		h.clients[tenantID] = filtered
+	}
+
+	close(client.Chan)
+}
+
+// HasClients reports whether any stream of the tenant is open, so clicks nobody watches are not enriched.
+func (h *LiveHub) HasClients(tenantID int) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[tenantID]) > 0
+}
+
+// Broadcast sends a click to every stream of its tenant whose filter matches.
+// Non-blocking: drops the click if a client's channel buffer is full, but still counts it.
+// Safe against concurrent Unregister: sends under the read lock, which Unregister waits for before closing.
+func (h *LiveHub) Broadcast(click *entity.Click) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, c := range h.clients[click.TenantID] {
+		if !c.Filter.Match(click) {
+			continue
+		}
+		c.matched.Add(1)
+
+		select {
+		case c.Chan <- click:
+		default:
+			// Client channel full — drop the click rather than block.
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/security"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+)
+
+const reportServiceName = "ReportService"
+
+type reportService struct {
+	reportRepo ports.ReportRepository
+	clickRepo  ports.ClickRepository
+	notifier   ports.NotificationPublisher
+	lock       ports.JobLock
+}
+
+func NewReportService(reportRepo ports.ReportRepository, clickRepo ports.ClickRepository, notifier ports.NotificationPublisher, lock ports.JobLock) ports.ReportService {
+	return &reportService{
+		reportRepo: reportRepo,
+		clickRepo:  clickRepo,
+		notifier:   notifier,
+		lock:       lock,
+	}
+}
+
+// Create schedules the report; the first one goes out when the current period ends
+func (s *reportService) Create(ctx context.Context, req *dto.CreateReportRequest) (*dto.ReportResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(reportServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+	userID, ok := ctx.Value(constraints.ContextKeyUserID).(int)
+	if !ok || userID == 0 {
+		return nil, apperr.NewError(reportServiceName, response.CodeUnauthorized, constant.MsgUserRequired, http.StatusUnauthorized, nil)
+	}
+
+	loc := time.UTC
+	if req.TimeZone != "" {
+		l, err := time.LoadLocation(req.TimeZone)
+		if err != nil {
+			return nil, apperr.NewError(reportServiceName, response.CodeBadRequest, constant.MsgInvalidTimeZone, http.StatusBadRequest, err)
+		}
+		loc = l
+	}
+
+	count, err := s.reportRepo.Count(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(reportServiceName, response.CodeDatabaseError, apperr.MsgCheckFailed, http.StatusInternalServerError, err)
+	}
+	if count >= constant.ReportMaxPerTenant {
+		return nil, apperr.NewError(reportServiceName, response.CodeConflict, constant.MsgTooManyReports, http.StatusConflict, nil)
+	}
+
+	id, err := security.NewNonce()
+	if err != nil {
+		return nil, apperr.NewError(reportServiceName, response.CodeInternalServer, apperr.MsgGenFailed, http.StatusInternalServerError, err)
+	}
+
+	now := time.Now()
+	schedule := &entity.ReportSchedule{
+		ID:         id,
+		TenantID:   tenantID,
+		CreatedBy:  userID,
+		Name:       req.Name,
+		Frequency:  req.Frequency,
+		Location:   loc,
+		Recipients: req.Recipients,
+		Filter:     mapper.ToClickFilter(tenantID, req.ShortCode, &req.ClickFilterRequest),
+		CreatedAt:  now,
+	}
+	schedule.NextRunAt = schedule.NextPeriod(schedule.PeriodStart(now))
+
+	if err := s.reportRepo.Save(ctx, schedule); err != nil {
+		return nil, apperr.NewError(reportServiceName, response.CodeDatabaseError, apperr.MsgCreateFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToReportResponse(schedule), nil
+}
+
+func (s *reportService) List(ctx context.Context, _ *dto.ListReportsRequest) (*dto.ListReportsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(reportServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	schedules, err := s.reportRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(reportServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	reports := make([]*dto.ReportResponse, len(schedules))
+	for i, sch := range schedules {
+		reports[i] = mapper.ToReportResponse(sch)
+	}
+	return &dto.ListReportsResponse{Reports: reports}, nil
+}
+
+func (s *reportService) Delete(ctx context.Context, req *dto.DeleteReportRequest) error {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return apperr.NewError(reportServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	schedule, err := s.reportRepo.Get(ctx, req.ID)
+	if err != nil {
+		return apperr.NewError(reportServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	if schedule == nil || schedule.TenantID != tenantID {
+		return apperr.NewError(reportServiceName, response.CodeNotFound, constant.MsgReportNotFound, http.StatusNotFound, nil)
+	}
+
+	if err := s.reportRepo.Delete(ctx, req.ID); err != nil {
+		return apperr.NewError(reportServiceName, response.CodeDatabaseError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	return nil
+}
+
+// RunDue sends the reports whose period has ended; a failed one stays due and is retried on a later poll
+func (s *reportService) RunDue(ctx context.Context) error {
+	schedules, err := s.reportRepo.ListDue(ctx, time.Now(), constant.ReportPollBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, sch := range schedules {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.run(ctx, sch); err != nil {
+			global.LoggerZap.Error("Failed to send report", zap.String("report_id", sch.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// run sends one due report. The lock is keyed by the run, so each period is sent once however many replicas poll.
+// After downtime only the latest ended period is reported; the missed ones are skipped.
+func (s *reportService) run(ctx context.Context, listed *entity.ReportSchedule) error {
+	key := constant.ReportLockPrefix + listed.ID + ":" + strconv.FormatInt(listed.NextRunAt.Unix(), 10)
+	acquired, err := s.lock.Acquire(ctx, key, constant.ReportLockTTL)
+	if err != nil || !acquired {
+		return err
+	}
+
+	sent := false
+	defer func() {
+		// Let a later poll retry a run that did not go out
+		if !sent {
+			_ = s.lock.Release(context.WithoutCancel(ctx), key)
+		}
+	}()
+
+	// The schedule may have been deleted, or run by another replica, since it was listed
+	schedule, err := s.reportRepo.Get(ctx, listed.ID)
+	if err != nil || schedule == nil || !schedule.NextRunAt.Equal(listed.NextRunAt) {
+		return err
+	}
+
+	now := time.Now()
+	to := schedule.NextRunAt.In(schedule.Location)
+	if !schedule.NextPeriod(to).After(now) {
+		to = schedule.PeriodStart(now)
+	}
+	from := schedule.PreviousPeriod(to)
+
+	summary, err := s.summarize(ctx, schedule.Filter, from, to, schedule.PreviousPeriod(from))
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range schedule.Recipients {
+		if err := s.notifier.Publish(ctx, mapper.ToReportEvent(schedule, summary, recipient)); err != nil {
+			return err
+		}
+	}
+	sent = true
+
+	schedule.LastRunAt = now
+	schedule.NextRunAt = schedule.NextPeriod(to)
+	return s.reportRepo.Save(ctx, schedule)
+}
+
+// summarize counts the period's clicks, the previous period's for comparison, and the top links, sources and countries
+func (s *reportService) summarize(ctx context.Context, filter *entity.ClickFilter, from, to, previousFrom time.Time) (*entity.ReportSummary, error) {
+	breakdown := func(dimension string, from, to time.Time, limit int) (*entity.Breakdown, error) {
+		return s.clickRepo.Breakdown(ctx, &entity.BreakdownQuery{
+			Filter:    filter,
+			Dimension: dimension,
+			From:      from,
+			To:        to,
+			Limit:     limit,
+		})
+	}
+
+	links, err := breakdown(entity.DimensionLink, from, to, constant.ReportTopLimit)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := breakdown(entity.DimensionSource, from, to, constant.ReportTopLimit)
+	if err != nil {
+		return nil, err
+	}
+	countries, err := breakdown(entity.DimensionCountry, from, to, constant.ReportTopLimit)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := breakdown(entity.DimensionLink, previousFrom, from, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ReportSummary{
+		From:         from,
+		To:           to,
+		Total:        links.Total,
+		Previous:     previous.Total,
+		TopLinks:     links.Rows,
+		TopSources:   sources.Rows,
+		TopCountries: countries.Rows,
+	}, nil
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+)
+
+const retentionServiceName = "RetentionService"
+
+type retentionService struct {
+	clickRepo      ports.ClickRepository
+	conversionRepo ports.ConversionRepository
+	rollupRepo     ports.RollupRepository
+	watermarkRepo  ports.RollupWatermarkRepository
+	visitorRepo    ports.VisitorRepository
+	placeRepo      ports.PlaceRepository
+	leaderboard    ports.LeaderboardRepository
+	billing        ports.BillingClient
+	lock           ports.JobLock
+}
+
+func NewRetentionService(
+	clickRepo ports.ClickRepository,
+	conversionRepo ports.ConversionRepository,
+	rollupRepo ports.RollupRepository,
+	watermarkRepo ports.RollupWatermarkRepository,
+	visitorRepo ports.VisitorRepository,
+	placeRepo ports.PlaceRepository,
+	leaderboard ports.LeaderboardRepository,
+	billing ports.BillingClient,
+	lock ports.JobLock,
+) ports.RetentionService {
+	return &retentionService{
+		clickRepo:      clickRepo,
+		conversionRepo: conversionRepo,
+		rollupRepo:     rollupRepo,
+		watermarkRepo:  watermarkRepo,
+		visitorRepo:    visitorRepo,
+		placeRepo:      placeRepo,
+		leaderboard:    leaderboard,
+		billing:        billing,
+		lock:           lock,
+	}
+}
+
+// Compact runs on one replica at a time. Data is only removed once it has been rolled up,
+// and never inside the window the next run rolls up again, so a rerun cannot undercount a bucket.
+// The watermark moves before anything is removed, so readers never look for clicks that are gone.
+func (s *retentionService) Compact(ctx context.Context) error {
+	key := constant.CompactionLockKey
+	acquired, err := s.lock.Acquire(ctx, key, constant.CompactionLockTTL)
+	if err != nil || !acquired {
+		return err
+	}
+	defer func() {
+		_ = s.lock.Release(context.WithoutCancel(ctx), key)
+	}()
+
+	now := time.Now().UTC()
+
+	hourlyEnd, err := s.rollUpHours(ctx, now)
+	if err != nil {
+		return err
+	}
+	dailyEnd, err := s.rollUpDays(ctx, hourlyEnd)
+	if err != nil {
+		return err
+	}
+	if err := s.watermarkRepo.Save(ctx, &entity.RollupWatermark{Hourly: hourlyEnd, Daily: dailyEnd}); err != nil {
+		return err
+	}
+
+	return s.expire(ctx, now, hourlyEnd.Add(-constant.RollupHourlyOverlap), dailyEnd.Add(-constant.RollupDailyOverlap))
+}
+
+// rollUpHours rolls raw clicks up from shortly before the last hourly bucket, or from the oldest click on the first run,
+// and returns the end of the rolled-up range
+func (s *retentionService) rollUpHours(ctx context.Context, now time.Time) (time.Time, error) {
+	_, last, err := s.rollupRepo.Bounds(ctx, entity.ResolutionHour, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	from := last.Add(-constant.RollupHourlyOverlap)
+	if last.IsZero() {
+		if from, err = s.clickRepo.Oldest(ctx, 0); err != nil {
+			return time.Time{}, err
+		}
+	}
+	to := now.Add(-constant.RollupDelay).Truncate(time.Hour)
+	if from.IsZero() {
+		return to, nil
+	}
+	from = from.Truncate(time.Hour)
+
+	var after *entity.Rollup
+	for from.Before(to) {
+		rollups, err := s.clickRepo.SumHours(ctx, from, to, after, constant.RollupPageSize)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if err := s.rollupRepo.SaveBulk(ctx, rollups); err != nil {
+			return time.Time{}, err
+		}
+		if len(rollups) < constant.RollupPageSize {
+			break
+		}
+		after = rollups[len(rollups)-1]
+	}
+	return to, nil
+}
+
+// rollUpDays rolls hourly rollups up to the last whole UTC day they cover and returns the end of that range.
+// Each day keeps its distinct visitors, read from the day's sketch.
+func (s *retentionService) rollUpDays(ctx context.Context, hourlyEnd time.Time) (time.Time, error) {
+	_, last, err := s.rollupRepo.Bounds(ctx, entity.ResolutionDay, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	from := last.Add(-constant.RollupDailyOverlap)
+	if last.IsZero() {
+		if from, _, err = s.rollupRepo.Bounds(ctx, entity.ResolutionHour, 0); err != nil {
+			return time.Time{}, err
+		}
+	}
+	to := hourlyEnd.Truncate(24 * time.Hour)
+	if from.IsZero() {
+		return to, nil
+	}
+	from = from.Truncate(24 * time.Hour)
+
+	var after *entity.Rollup
+	for from.Before(to) {
+		rollups, err := s.rollupRepo.SumDays(ctx, from, to, after, constant.RollupPageSize)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if err := s.countVisitors(ctx, rollups); err != nil {
+			return time.Time{}, err
+		}
+		if err := s.rollupRepo.SaveBulk(ctx, rollups); err != nil {
+			return time.Time{}, err
+		}
+		if len(rollups) < constant.RollupPageSize {
+			break
+		}
+		after = rollups[len(rollups)-1]
+	}
+	return to, nil
+}
+
+// countVisitors fills in each daily rollup's visitors from the link's sketch for the day
+func (s *retentionService) countVisitors(ctx context.Context, rollups []*entity.Rollup) error {
+	for _, ru := range rollups {
+		sketches, err := s.visitorRepo.Get(ctx, ru.TenantID, ru.ShortCode, []time.Time{ru.Bucket})
+		if err != nil {
+			return err
+		}
+		if sketches[0] != nil {
+			ru.Visitors = sketches[0].Count()
+		}
+	}
+	return nil
+}
+
+// expire applies each tenant's retention, never removing raw clicks past rawLimit or hourly rollups past hourlyLimit.
+// A tenant whose plan cannot be read keeps its data until a later run.
+func (s *retentionService) expire(ctx context.Context, now, rawLimit, hourlyLimit time.Time) error {
+	after := 0
+	for {
+		tenants, err := s.rollupRepo.Tenants(ctx, after, constant.RollupTenantPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, tenantID := range tenants {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			policy, err := s.policy(ctx, tenantID)
+			if err != nil {
+				global.LoggerZap.Warn("Failed to read retention, skipping tenant", zap.Int("tenant_id", tenantID), zap.Error(err))
+				continue
+			}
+			if err := s.expireTenant(ctx, policy, now, rawLimit, hourlyLimit); err != nil {
+				global.LoggerZap.Error("Failed to apply retention", zap.Int("tenant_id", tenantID), zap.Error(err))
+			}
+		}
+
+		if len(tenants) < constant.RollupTenantPageSize {
+			return nil
+		}
+		after = tenants[len(tenants)-1]
+	}
+}
+
+func (s *retentionService) expireTenant(ctx context.Context, policy *entity.RetentionPolicy, now, rawLimit, hourlyLimit time.Time) error {
+	if cutoff, ok := policy.RawCutoff(now); ok {
+		if cutoff.After(rawLimit) {
+			cutoff = rawLimit
+		}
+		deleted, err := s.clickRepo.DeleteBefore(ctx, policy.TenantID, cutoff)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			global.LoggerZap.Info("Expired raw clicks", zap.Int("tenant_id", policy.TenantID), zap.Int64("deleted", deleted))
+		}
+	}
+
+	if cutoff, ok := policy.HourlyCutoff(now); ok {
+		if cutoff.After(hourlyLimit) {
+			cutoff = hourlyLimit
+		}
+		deleted, err := s.rollupRepo.DeleteBefore(ctx, entity.ResolutionHour, policy.TenantID, cutoff)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			global.LoggerZap.Info("Expired hourly rollups", zap.Int("tenant_id", policy.TenantID), zap.Int64("deleted", deleted))
+		}
+	}
+	return nil
+}
+
+// policy reads the tenant's retention from its plan, filling limits the plan leaves out with the defaults
+func (s *retentionService) policy(ctx context.Context, tenantID int) (*entity.RetentionPolicy, error) {
+	policy, err := s.billing.GetRetention(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &entity.RetentionPolicy{TenantID: tenantID}
+	}
+	if policy.RawDays == 0 || policy.RawDays < entity.KeepForever {
+		policy.RawDays = constant.RetentionDefaultRawDays
+	}
+	if policy.HourlyMonths == 0 || policy.HourlyMonths < entity.KeepForever {
+		policy.HourlyMonths = constant.RetentionDefaultHourlyMonths
+	}
+	return policy, nil
+}
+
+// Erase removes the caller's tenant data in the request's scope
+func (s *retentionService) Erase(ctx context.Context, req *dto.EraseRequest) (*dto.EraseResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(retentionServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	return s.erase(ctx, mapper.ToErasureTarget(tenantID, req))
+}
+
+// EraseTenant removes everything kept for a deleted tenant
+func (s *retentionService) EraseTenant(ctx context.Context, req *dto.EraseTenantRequest) (*dto.EraseResponse, error) {
+	return s.erase(ctx, &entity.ErasureTarget{Scope: entity.ErasureTenant, TenantID: req.TenantID})
+}
+
+// erase removes clicks first, so a failed erasure that is retried never leaves clicks behind rollups it removed.
+// Replicas still hold the current leaderboard windows in memory, so a link can rank again until its clicks leave the week window.
+func (s *retentionService) erase(ctx context.Context, target *entity.ErasureTarget) (*dto.EraseResponse, error) {
+	result := &entity.ErasureResult{}
+	var err error
+
+	if result.Clicks, err = s.clickRepo.Erase(ctx, target); err != nil {
+		return nil, apperr.NewError(retentionServiceName, response.CodeDatabaseError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	if result.Conversions, err = s.conversionRepo.Erase(ctx, target); err != nil {
+		return nil, apperr.NewError(retentionServiceName, response.CodeDatabaseError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	if result.Rollups, err = s.rollupRepo.Erase(ctx, target); err != nil {
+		return nil, apperr.NewError(retentionServiceName, response.CodeDatabaseError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	if result.VisitorSketches, err = s.visitorRepo.Erase(ctx, target); err != nil {
+		return nil, apperr.NewError(retentionServiceName, response.CodeRedisError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	if result.PlaceIndexes, err = s.placeRepo.Erase(ctx, target); err != nil {
+		return nil, apperr.NewError(retentionServiceName, response.CodeRedisError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	if result.LeaderboardEntries, err = s.leaderboard.Erase(ctx, target); err != nil {
+		return nil, apperr.NewError(retentionServiceName, response.CodeRedisError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+
+	global.LoggerZap.Info("Analytics data erased",
+		zap.String("scope", target.Scope),
+		zap.Int("tenant_id", target.TenantID),
+		zap.String("short_code", target.ShortCode),
+		zap.Int64("clicks", result.Clicks),
+		zap.Int64("conversions", result.Conversions),
+		zap.Int64("rollups", result.Rollups),
+		zap.Int64("visitor_sketches", result.VisitorSketches),
+		zap.Int64("place_indexes", result.PlaceIndexes),
+		zap.Int64("leaderboard_entries", result.LeaderboardEntries))
+
+	return mapper.ToEraseResponse(result), nil
+}
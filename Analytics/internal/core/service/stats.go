@@ -0,0 +1,611 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/datastructs/hll"
+
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+)
+
+const statsServiceName = "StatsService"
+
+type statsService struct {
+	clickRepo   ports.ClickRepository
+	visitorRepo ports.VisitorRepository
+	placeRepo   ports.PlaceRepository
+	rollupRepo  ports.RollupRepository
+	generation  ports.GenerationClient
+}
+
+func NewStatsService(clickRepo ports.ClickRepository, visitorRepo ports.VisitorRepository, placeRepo ports.PlaceRepository, rollupRepo ports.RollupRepository, generation ports.GenerationClient) ports.StatsService {
+	return &statsService{
+		clickRepo:   clickRepo,
+		visitorRepo: visitorRepo,
+		placeRepo:   placeRepo,
+		rollupRepo:  rollupRepo,
+		generation:  generation,
+	}
+}
+
+// TimeSeries buckets clicks by minute, hour or calendar day in the caller's time zone.
+// The range is widened to whole buckets so the first and last points are not partial,
+// and the comparison period is shifted by the same calendar amount so points line up one for one.
+func (s *statsService) TimeSeries(ctx context.Context, req *dto.TimeSeriesRequest) (*dto.TimeSeriesReportResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(statsServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	loc := time.UTC
+	if req.TimeZone != "" {
+		l, err := time.LoadLocation(req.TimeZone)
+		if err != nil {
+			return nil, apperr.NewError(statsServiceName, response.CodeBadRequest, constant.MsgInvalidTimeZone, http.StatusBadRequest, err)
+		}
+		loc = l
+	}
+
+	interval := req.Interval
+	if interval == "" {
+		interval = defaultInterval(req.To.Sub(req.From))
+	}
+
+	from := floorBucket(req.From.In(loc), interval)
+	to := ceilBucket(req.To.In(loc), interval)
+	if bucketCount(from, to, interval) > constant.TimeSeriesMaxPoints {
+		return nil, apperr.NewError(statsServiceName, response.CodeBadRequest, constant.MsgTooManyBuckets, http.StatusBadRequest, nil)
+	}
+
+	query := &entity.TimeSeriesQuery{
+		Filter:   mapper.ToClickFilter(tenantID, req.ShortCode, &req.ClickFilterRequest),
+		Interval: interval,
+		Location: loc,
+		From:     from,
+		To:       to,
+	}
+	if err := s.narrowToCollection(ctx, query.Filter, req.Collection); err != nil {
+		return nil, err
+	}
+
+	current, err := s.series(ctx, query)
+	if err != nil {
+		return nil, apperr.NewError(statsServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	var previous *entity.TimeSeries
+	if req.Compare != "" {
+		prev := *query
+		prev.From, prev.To = comparePeriod(from, to, interval, req.Compare)
+		if previous, err = s.series(ctx, &prev); err != nil {
+			return nil, apperr.NewError(statsServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+	}
+
+	return mapper.ToTimeSeriesReportResponse(query, current, previous), nil
+}
+
+// UniqueVisitors merges the daily sketches of the link or of every link in the collection,
+// so a visitor seen on several days or links counts once for the range.
+// Days whose sketch has expired fall back to the distinct visitors kept in the daily rollup;
+// those can't be told apart from other days' visitors, so they are added to the total as they are.
+func (s *statsService) UniqueVisitors(ctx context.Context, req *dto.UniqueVisitorsRequest) (*dto.UniqueVisitorsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(statsServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	from := req.From.UTC().Truncate(24 * time.Hour)
+	to := req.To.UTC().Truncate(24 * time.Hour)
+
+	var days []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if len(days) == constant.UniqueVisitorsMaxDays {
+			return nil, apperr.NewError(statsServiceName, response.CodeBadRequest, constant.MsgRangeTooLong, http.StatusBadRequest, nil)
+		}
+		days = append(days, d)
+	}
+
+	links := []string{req.ShortCode}
+	if req.Collection != "" {
+		filter := &entity.ClickFilter{TenantID: tenantID}
+		if err := s.narrowToCollection(ctx, filter, req.Collection); err != nil {
+			return nil, err
+		}
+		links = filter.ShortCodes
+	}
+
+	sketches := make([]*hll.Sketch, len(days))
+	for _, code := range links {
+		linkSketches, err := s.visitorRepo.Get(ctx, tenantID, code, days)
+		if err != nil {
+			return nil, apperr.NewError(statsServiceName, response.CodeRedisError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+		for i, sketch := range linkSketches {
+			if sketch == nil {
+				continue
+			}
+			if sketches[i] == nil {
+				sketches[i] = sketch
+				continue
+			}
+			if err := sketches[i].Merge(sketch); err != nil {
+				return nil, apperr.NewError(statsServiceName, response.CodeInternalServer, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+			}
+		}
+	}
+
+	total, err := hll.New(hll.DefaultPrecision)
+	if err != nil {
+		return nil, apperr.NewError(statsServiceName, response.CodeInternalServer, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	counts := make([]uint64, len(days))
+	expired := false
+	for i, sketch := range sketches {
+		if sketch == nil {
+			expired = expired || days[i].Before(time.Now().Add(-constant.VisitorSketchTTL))
+			continue
+		}
+		counts[i] = sketch.Count()
+		if err := total.Merge(sketch); err != nil {
+			return nil, apperr.NewError(statsServiceName, response.CodeInternalServer, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+	}
+
+	var rolledUp uint64
+	if expired && len(links) > 0 {
+		rollups, err := s.rollupRepo.Series(ctx, entity.ResolutionDay, &entity.TotalsQuery{
+			TenantID:   tenantID,
+			ShortCodes: links,
+			From:       from,
+			To:         to.AddDate(0, 0, 1),
+		})
+		if err != nil {
+			return nil, apperr.NewError(statsServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+
+		byDay := make(map[int64]uint64, len(rollups))
+		for _, r := range rollups {
+			byDay[r.Bucket.UTC().Unix()] = r.Visitors
+		}
+		for i, sketch := range sketches {
+			if sketch == nil {
+				counts[i] = byDay[days[i].Unix()]
+				rolledUp += counts[i]
+			}
+		}
+	}
+
+	return mapper.ToUniqueVisitorsResponse(days, counts, total.Count()+rolledUp), nil
+}
+
+// Breakdown returns the most clicked values of one dimension with their share of all matching clicks
+func (s *statsService) Breakdown(ctx context.Context, req *dto.BreakdownRequest) (*dto.BreakdownResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(statsServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	query := &entity.BreakdownQuery{
+		Filter:    mapper.ToClickFilter(tenantID, req.ShortCode, &req.ClickFilterRequest),
+		Dimension: req.Dimension,
+		From:      req.From,
+		To:        req.To,
+		Limit:     req.Limit,
+	}
+	if query.Limit <= 0 {
+		query.Limit = constant.BreakdownDefaultLimit
+	}
+	if err := s.narrowToCollection(ctx, query.Filter, req.Collection); err != nil {
+		return nil, err
+	}
+
+	breakdown, err := s.breakdown(ctx, query)
+	if err != nil {
+		return nil, apperr.NewError(statsServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToBreakdownResponse(query, breakdown), nil
+}
+
+// Geo finds the cities inside the box in the Redis geo index of the link or of every link in the collection,
+// then counts their clicks for the range. Cities without clicks in the range are left out.
+func (s *statsService) Geo(ctx context.Context, req *dto.GeoRequest) (*dto.GeoResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(statsServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	query := mapper.ToGeoQuery(tenantID, req)
+	if query.Limit <= 0 {
+		query.Limit = constant.GeoDefaultLimit
+	}
+
+	if err := s.narrowToCollection(ctx, query.Filter, req.Collection); err != nil {
+		return nil, err
+	}
+	links := query.Filter.ShortCodes
+	if req.Collection == "" {
+		links = []string{req.ShortCode}
+	}
+
+	var keys []string
+	byKey := make(map[string]*entity.Place)
+	for _, code := range links {
+		places, err := s.placeRepo.Within(ctx, tenantID, code, query.Box)
+		if err != nil {
+			return nil, apperr.NewError(statsServiceName, response.CodeRedisError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+		for _, p := range places {
+			if _, ok := byKey[p.Key]; !ok {
+				keys = append(keys, p.Key)
+				byKey[p.Key] = p
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return mapper.ToGeoResponse(query, nil), nil
+	}
+
+	counts, err := s.clickRepo.PlaceClicks(ctx, query, keys)
+	if err != nil {
+		return nil, apperr.NewError(statsServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	result := make([]*entity.PlaceClicks, 0, len(counts))
+	for key, clicks := range counts {
+		if p, ok := byKey[key]; ok {
+			result = append(result, &entity.PlaceClicks{Place: p, Clicks: clicks})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Clicks != result[j].Clicks {
+			return result[i].Clicks > result[j].Clicks
+		}
+		return result[i].Place.Key < result[j].Place.Key
+	})
+
+	return mapper.ToGeoResponse(query, result), nil
+}
+
+// narrowToCollection limits the filter to the links of the tenant's collection, when one is asked for.
+// Links that have expired still count, so a finished campaign keeps its numbers.
+func (s *statsService) narrowToCollection(ctx context.Context, filter *entity.ClickFilter, collectionID string) error {
+	if collectionID == "" {
+		return nil
+	}
+
+	collection, err := s.generation.GetCollection(ctx, filter.TenantID, collectionID)
+	if err != nil {
+		return apperr.NewError(statsServiceName, response.CodeInternalError, constant.MsgGetCollectionFailed, http.StatusInternalServerError, err)
+	}
+	if collection == nil {
+		return apperr.NewError(statsServiceName, response.CodeNotFound, constant.MsgCollectionNotFound, http.StatusNotFound, nil)
+	}
+
+	filter.ShortCodes = collection.ShortCodes
+	return nil
+}
+
+// series counts the query's clicks from raw clicks where they are still kept and from the rollups before that.
+// Minute buckets are only read from raw clicks, and daily rollups only fill day buckets:
+// a UTC day is counted on the same date in the caller's zone, since its hours are no longer known.
+func (s *statsService) series(ctx context.Context, query *entity.TimeSeriesQuery) (*entity.TimeSeries, error) {
+	if query.Interval == entity.IntervalMinute {
+		return s.clickRepo.TimeSeries(ctx, query)
+	}
+
+	tiers, codes, err := s.tiers(ctx, query.Filter, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	if tiers == nil {
+		return s.clickRepo.TimeSeries(ctx, query)
+	}
+
+	series := &entity.TimeSeries{From: query.From, To: query.To}
+	points := make(map[int64]*entity.TimeSeriesPoint)
+	for t := query.From; t.Before(query.To); t = nextBucket(t, query.Interval) {
+		p := &entity.TimeSeriesPoint{Time: t}
+		series.Points = append(series.Points, p)
+		points[t.Unix()] = p
+	}
+	add := func(t time.Time, clicks int64) {
+		if p, ok := points[floorBucket(t.In(query.Location), query.Interval).Unix()]; ok {
+			p.Clicks += clicks
+		}
+	}
+
+	for _, t := range tiers {
+		switch t.resolution {
+		case "":
+			part := *query
+			part.From, part.To = t.from, t.to
+			raw, err := s.clickRepo.TimeSeries(ctx, &part)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range raw.Points {
+				add(p.Time, p.Clicks)
+			}
+		case entity.ResolutionHour:
+			rollups, err := s.rollupRepo.Series(ctx, t.resolution, &entity.TotalsQuery{TenantID: query.Filter.TenantID, ShortCodes: codes, From: t.from, To: t.to})
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range rollups {
+				add(r.Bucket, r.Count(query.Filter.IncludeBots))
+			}
+		default:
+			if query.Interval != entity.IntervalDay {
+				continue
+			}
+			from := t.from.In(query.Location)
+			rollups, err := s.rollupRepo.Series(ctx, t.resolution, &entity.TotalsQuery{
+				TenantID:   query.Filter.TenantID,
+				ShortCodes: codes,
+				From:       time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC),
+				To:         t.to,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range rollups {
+				b := r.Bucket.UTC()
+				add(time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, query.Location), r.Count(query.Filter.IncludeBots))
+			}
+		}
+	}
+
+	return series, nil
+}
+
+// breakdown counts the query's clicks from raw clicks where they are still kept and from the rollups before that.
+// Rollups only know the link, so by any other dimension their clicks are counted under the unknown value.
+func (s *statsService) breakdown(ctx context.Context, query *entity.BreakdownQuery) (*entity.Breakdown, error) {
+	tiers, codes, err := s.tiers(ctx, query.Filter, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	if tiers == nil {
+		return s.clickRepo.Breakdown(ctx, query)
+	}
+
+	counts := make(map[string]int64)
+	var total int64
+	for _, t := range tiers {
+		if t.resolution == "" {
+			part := *query
+			part.From, part.To = t.from, t.to
+			raw, err := s.clickRepo.Breakdown(ctx, &part)
+			if err != nil {
+				return nil, err
+			}
+			for _, row := range raw.Rows {
+				counts[row.Key] += row.Clicks
+			}
+			total += raw.Total
+			continue
+		}
+
+		totalsQuery := &entity.TotalsQuery{TenantID: query.Filter.TenantID, ShortCodes: codes, From: t.from, To: t.to}
+		totals, err := s.rollupRepo.Totals(ctx, t.resolution, totalsQuery)
+		if err != nil {
+			return nil, err
+		}
+		var clicks int64
+		for _, c := range totals {
+			clicks += c.Clicks
+			if query.Filter.IncludeBots {
+				clicks += c.BotClicks
+			}
+		}
+		total += clicks
+
+		if query.Dimension != entity.DimensionLink {
+			counts[""] += clicks
+			continue
+		}
+		top, err := s.rollupRepo.TopLinks(ctx, t.resolution, totalsQuery, query.Filter.IncludeBots, query.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range top {
+			counts[r.ShortCode] += r.Count(query.Filter.IncludeBots)
+		}
+	}
+
+	breakdown := &entity.Breakdown{Total: total, Other: total}
+	for key, clicks := range counts {
+		if clicks > 0 {
+			breakdown.Rows = append(breakdown.Rows, &entity.BreakdownRow{Key: key, Clicks: clicks})
+		}
+	}
+	sort.Slice(breakdown.Rows, func(i, j int) bool {
+		if breakdown.Rows[i].Clicks != breakdown.Rows[j].Clicks {
+			return breakdown.Rows[i].Clicks > breakdown.Rows[j].Clicks
+		}
+		return breakdown.Rows[i].Key < breakdown.Rows[j].Key
+	})
+	if len(breakdown.Rows) > query.Limit {
+		breakdown.Rows = breakdown.Rows[:query.Limit]
+	}
+	for _, row := range breakdown.Rows {
+		breakdown.Other -= row.Clicks
+	}
+	return breakdown, nil
+}
+
+// tiers splits [from, to) by where the filter's clicks are kept: daily rollups before the hourly ones begin,
+// hourly rollups before the oldest raw click, and raw clicks from then on. The splits fall on whole hours and days
+// that were rolled up whole, so no click is counted twice. It returns nil tiers when raw clicks still cover the range,
+// or when the filter narrows by more than links, which rollups can't answer.
+func (s *statsService) tiers(ctx context.Context, filter *entity.ClickFilter, from, to time.Time) ([]tier, []string, error) {
+	codes, ok := rollupLinks(filter)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	oldest, err := s.clickRepo.Oldest(ctx, filter.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Compaction only deletes clicks older than the oldest one left
+	if !oldest.IsZero() && !from.Before(oldest) {
+		return nil, nil, nil
+	}
+
+	firstHour, lastHour, err := s.rollupRepo.Bounds(ctx, entity.ResolutionHour, filter.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, lastDay, err := s.rollupRepo.Bounds(ctx, entity.ResolutionDay, filter.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The hour of the oldest raw click may have lost earlier clicks, so it is read from its rollup when it has one
+	rawFrom := to
+	if !oldest.IsZero() {
+		rawFrom = oldest.Truncate(time.Hour)
+		if !lastHour.IsZero() && !rawFrom.After(lastHour) {
+			rawFrom = rawFrom.Add(time.Hour)
+		}
+	}
+
+	// Likewise the first day of hourly rollups may have lost earlier hours, so it is read from its daily rollup
+	hourFrom := rawFrom.Truncate(24 * time.Hour)
+	if !firstHour.IsZero() && firstHour.Before(rawFrom) {
+		hourFrom = firstHour.Truncate(24 * time.Hour)
+		if next := hourFrom.AddDate(0, 0, 1); !lastDay.IsZero() && !hourFrom.After(lastDay) && !next.After(rawFrom) {
+			hourFrom = next
+		}
+	}
+
+	clip := func(t time.Time) time.Time {
+		if t.Before(from) {
+			return from
+		}
+		if t.After(to) {
+			return to
+		}
+		return t
+	}
+	var tiers []tier
+	for _, t := range []tier{
+		{resolution: entity.ResolutionDay, from: from, to: hourFrom},
+		{resolution: entity.ResolutionHour, from: hourFrom, to: rawFrom},
+		{from: rawFrom, to: to},
+	} {
+		if t.from, t.to = clip(t.from), clip(t.to); t.from.Before(t.to) {
+			tiers = append(tiers, t)
+		}
+	}
+	return tiers, codes, nil
+}
+
+// rollupLinks returns the links the filter narrows rollups to, nil for every link.
+// It reports false when rollups can't be narrowed the same way, or when the filter matches no link at all.
+func rollupLinks(filter *entity.ClickFilter) ([]string, bool) {
+	if !filter.LinksOnly() {
+		return nil, false
+	}
+
+	codes := filter.ShortCodes
+	if filter.ShortCode != "" {
+		if codes != nil && !slices.Contains(codes, filter.ShortCode) {
+			return nil, false
+		}
+		codes = []string{filter.ShortCode}
+	}
+	if codes != nil && len(codes) == 0 {
+		return nil, false
+	}
+	return codes, true
+}
+
+// defaultInterval picks the finest bucket that keeps a span readable
+func defaultInterval(span time.Duration) string {
+	switch {
+	case span <= constant.TimeSeriesMinuteSpan:
+		return entity.IntervalMinute
+	case span <= constant.TimeSeriesHourSpan:
+		return entity.IntervalHour
+	default:
+		return entity.IntervalDay
+	}
+}
+
+// floorBucket returns the start of the bucket holding t, in t's location.
+// Hours and days are built from the wall clock since zones like +05:30 don't sit on whole UTC hours.
+func floorBucket(t time.Time, interval string) time.Time {
+	switch interval {
+	case entity.IntervalMinute:
+		return t.Truncate(time.Minute)
+	case entity.IntervalHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// ceilBucket returns the end of the bucket holding t, or t itself when it already starts a bucket
+func ceilBucket(t time.Time, interval string) time.Time {
+	start := floorBucket(t, interval)
+	if start.Equal(t) {
+		return t
+	}
+	return nextBucket(start, interval)
+}
+
+func nextBucket(t time.Time, interval string) time.Time {
+	switch interval {
+	case entity.IntervalMinute:
+		return t.Add(time.Minute)
+	case entity.IntervalHour:
+		return t.Add(time.Hour)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// bucketCount is the number of buckets in [from, to); days are counted on the calendar so DST days count once
+func bucketCount(from, to time.Time, interval string) int {
+	switch interval {
+	case entity.IntervalMinute:
+		return int(to.Sub(from) / time.Minute)
+	case entity.IntervalHour:
+		return int(to.Sub(from) / time.Hour)
+	default:
+		days := 0
+		for t := from; t.Before(to) && days <= constant.TimeSeriesMaxPoints; t = t.AddDate(0, 0, 1) {
+			days++
+		}
+		return days
+	}
+}
+
+// comparePeriod returns the period a series is compared with: the one right before it, or the same dates a year earlier
+func comparePeriod(from, to time.Time, interval, compare string) (time.Time, time.Time) {
+	if compare == entity.CompareYear {
+		return from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+	}
+
+	if interval == entity.IntervalDay {
+		days := bucketCount(from, to, interval)
+		return from.AddDate(0, 0, -days), from
+	}
+	return from.Add(-to.Sub(from)), from
+}
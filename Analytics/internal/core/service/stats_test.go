@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-link/common/pkg/constraints"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+type fakeGeneration struct {
+	collections map[string]*entity.Collection
+}
+
+func (g *fakeGeneration) GetCollection(_ context.Context, _ int, collectionID string) (*entity.Collection, error) {
+	return g.collections[collectionID], nil
+}
+
+// newEmptyCollectionStats has no repositories, so any read of clicks, sketches or places panics
+func newEmptyCollectionStats() *statsService {
+	return &statsService{generation: &fakeGeneration{collections: map[string]*entity.Collection{
+		"empty": {ID: "empty", TenantID: 1, ShortCodes: []string{}},
+	}}}
+}
+
+func TestNarrowToEmptyCollection(t *testing.T) {
+	s := newEmptyCollectionStats()
+
+	filter := &entity.ClickFilter{TenantID: 1}
+	if err := s.narrowToCollection(context.Background(), filter, "empty"); err != nil {
+		t.Fatalf("narrowToCollection: %v", err)
+	}
+	if filter.ShortCodes == nil || len(filter.ShortCodes) != 0 {
+		t.Fatalf("expected an empty set of links, got %#v", filter.ShortCodes)
+	}
+	if _, ok := rollupLinks(filter); ok {
+		t.Error("rollups must not be read for a collection without links")
+	}
+}
+
+func TestEmptyCollectionStats(t *testing.T) {
+	s := newEmptyCollectionStats()
+	ctx := context.WithValue(context.Background(), constraints.ContextKeyTenantID, 1)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 6)
+
+	visitors, err := s.UniqueVisitors(ctx, &dto.UniqueVisitorsRequest{Collection: "empty", From: from, To: to})
+	if err != nil {
+		t.Fatalf("UniqueVisitors: %v", err)
+	}
+	if visitors.Visitors != 0 || len(visitors.Days) != 7 {
+		t.Errorf("expected 7 days without visitors, got %d visitors over %d days", visitors.Visitors, len(visitors.Days))
+	}
+
+	geo, err := s.Geo(ctx, &dto.GeoRequest{Collection: "empty", MinLatitude: -90, MinLongitude: -180, MaxLatitude: 90, MaxLongitude: 180, From: from, To: to})
+	if err != nil {
+		t.Fatalf("Geo: %v", err)
+	}
+	if len(geo.Places) != 0 {
+		t.Errorf("expected no places, got %d", len(geo.Places))
+	}
+}
+
+func TestMissingCollection(t *testing.T) {
+	s := newEmptyCollectionStats()
+
+	err := s.narrowToCollection(context.Background(), &entity.ClickFilter{TenantID: 1}, "other")
+	if err == nil {
+		t.Fatal("expected an error for a collection the tenant doesn't have")
+	}
+}
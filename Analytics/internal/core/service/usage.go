@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/datastructs/hll"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/constant"
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/core/mapper"
+	"go-link/analytics/internal/ports"
+)
+
+const usageServiceName = "UsageService"
+
+// tier is the part of a range read from one store: rollups of a resolution, or raw clicks when it has none
+type tier struct {
+	resolution string
+	from       time.Time
+	to         time.Time
+}
+
+type usageService struct {
+	clickRepo     ports.ClickRepository
+	rollupRepo    ports.RollupRepository
+	watermarkRepo ports.RollupWatermarkRepository
+	visitorRepo   ports.VisitorRepository
+	cache         ports.UsageCache
+}
+
+func NewUsageService(
+	clickRepo ports.ClickRepository,
+	rollupRepo ports.RollupRepository,
+	watermarkRepo ports.RollupWatermarkRepository,
+	visitorRepo ports.VisitorRepository,
+	cache ports.UsageCache,
+) ports.UsageService {
+	return &usageService{
+		clickRepo:     clickRepo,
+		rollupRepo:    rollupRepo,
+		watermarkRepo: watermarkRepo,
+		visitorRepo:   visitorRepo,
+		cache:         cache,
+	}
+}
+
+// AuthorizeTenant lets a caller read a tenant's data when it acts for that tenant or as an admin.
+// Services calling on their own behalf carry no tenant and may read any.
+func AuthorizeTenant(ctx context.Context, tenantID int) error {
+	if tenantID <= 0 {
+		return apperr.NewError(usageServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+	if isAdmin, _ := ctx.Value(constraints.ContextKeyIsAdmin).(bool); isAdmin {
+		return nil
+	}
+	if caller, ok := ctx.Value(constraints.ContextKeyTenantID).(int); ok && caller != 0 && caller != tenantID {
+		return apperr.NewError(usageServiceName, response.CodeForbidden, constant.MsgTenantMismatch, http.StatusForbidden, nil)
+	}
+	return nil
+}
+
+func (s *usageService) GetLinkStats(ctx context.Context, req *dto.GetLinkStatsRequest) (*dto.GetLinkStatsResponse, error) {
+	if err := AuthorizeTenant(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+	if req.ShortCode == "" {
+		return nil, apperr.NewError(usageServiceName, response.CodeBadRequest, constant.MsgShortCodeRequired, http.StatusBadRequest, nil)
+	}
+
+	stats, err := s.linkStats(ctx, req.TenantID, []string{req.ShortCode})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToGetLinkStatsResponse(stats[0]), nil
+}
+
+func (s *usageService) BatchGetLinkStats(ctx context.Context, req *dto.BatchGetLinkStatsRequest) (*dto.BatchGetLinkStatsResponse, error) {
+	if err := AuthorizeTenant(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+	if len(req.ShortCodes) > constant.LinkStatsMaxBatch {
+		return nil, apperr.NewError(usageServiceName, response.CodeBadRequest, constant.MsgTooManyShortCodes, http.StatusBadRequest, nil)
+	}
+	for _, code := range req.ShortCodes {
+		if code == "" {
+			return nil, apperr.NewError(usageServiceName, response.CodeBadRequest, constant.MsgShortCodeRequired, http.StatusBadRequest, nil)
+		}
+	}
+
+	stats, err := s.linkStats(ctx, req.TenantID, req.ShortCodes)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToBatchGetLinkStatsResponse(stats), nil
+}
+
+// GetTenantUsage counts the period from the hourly rollups and the raw clicks after them.
+// A period older than the tenant's hourly retention only counts what is still kept.
+func (s *usageService) GetTenantUsage(ctx context.Context, req *dto.GetTenantUsageRequest) (*dto.TenantUsageResponse, error) {
+	if err := AuthorizeTenant(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+	from, to := req.From.UTC(), req.To.UTC()
+	if !to.After(from) || to.Sub(from) > constant.UsageMaxRange {
+		return nil, apperr.NewError(usageServiceName, response.CodeBadRequest, constant.MsgInvalidUsagePeriod, http.StatusBadRequest, nil)
+	}
+
+	usage, err := s.cache.GetUsage(ctx, req.TenantID, from, to)
+	if err != nil {
+		global.LoggerZap.Warn("Failed to read cached usage", zap.Int("tenant_id", req.TenantID), zap.Error(err))
+	}
+	if usage != nil {
+		return mapper.ToTenantUsageResponse(usage, usageTTL(usage)), nil
+	}
+
+	now := time.Now().UTC()
+	marks, err := s.watermark(ctx)
+	if err != nil {
+		return nil, apperr.NewError(usageServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	totals, err := s.totals(ctx, &entity.TotalsQuery{TenantID: req.TenantID}, periodTiers(from, to, marks))
+	if err != nil {
+		return nil, apperr.NewError(usageServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	usage = &entity.TenantUsage{
+		TenantID: req.TenantID,
+		From:     from,
+		To:       to,
+		// Compaction rolls up again only the last few hours before the watermark, so earlier counts are settled
+		Complete:   !marks.Hourly.IsZero() && !to.After(marks.Hourly.Add(-constant.RollupHourlyOverlap)),
+		ComputedAt: now,
+	}
+	if t, ok := totals[""]; ok {
+		usage.Totals = *t
+	}
+
+	if err := s.cache.SaveUsage(ctx, usage, usageTTL(usage)); err != nil {
+		global.LoggerZap.Warn("Failed to cache usage", zap.Int("tenant_id", req.TenantID), zap.Error(err))
+	}
+	return mapper.ToTenantUsageResponse(usage, usageTTL(usage)), nil
+}
+
+// linkStats serves what the cache holds and computes the rest in one pass, returning stats in the order asked for
+func (s *usageService) linkStats(ctx context.Context, tenantID int, shortCodes []string) ([]*entity.LinkStats, error) {
+	found, err := s.cache.GetLinks(ctx, tenantID, shortCodes)
+	if err != nil {
+		global.LoggerZap.Warn("Failed to read cached link stats", zap.Int("tenant_id", tenantID), zap.Error(err))
+		found = make(map[string]*entity.LinkStats, len(shortCodes))
+	}
+
+	var missing []string
+	seen := make(map[string]bool, len(shortCodes))
+	for _, code := range shortCodes {
+		if _, ok := found[code]; !ok && !seen[code] {
+			missing = append(missing, code)
+			seen[code] = true
+		}
+	}
+
+	if len(missing) > 0 {
+		computed, err := s.computeLinkStats(ctx, tenantID, missing)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.cache.SaveLinks(ctx, computed, constant.LinkStatsCacheTTL); err != nil {
+			global.LoggerZap.Warn("Failed to cache link stats", zap.Int("tenant_id", tenantID), zap.Error(err))
+		}
+		for _, st := range computed {
+			found[st.ShortCode] = st
+		}
+	}
+
+	stats := make([]*entity.LinkStats, len(shortCodes))
+	for i, code := range shortCodes {
+		stats[i] = found[code]
+	}
+	return stats, nil
+}
+
+func (s *usageService) computeLinkStats(ctx context.Context, tenantID int, shortCodes []string) ([]*entity.LinkStats, error) {
+	now := time.Now().UTC()
+	marks, err := s.watermark(ctx)
+	if err != nil {
+		return nil, apperr.NewError(usageServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	totals, err := s.totals(ctx, &entity.TotalsQuery{TenantID: tenantID, ShortCodes: shortCodes}, lifetimeTiers(marks))
+	if err != nil {
+		return nil, apperr.NewError(usageServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	visitors, err := s.visitors(ctx, tenantID, shortCodes, now)
+	if err != nil {
+		return nil, apperr.NewError(usageServiceName, response.CodeRedisError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	stats := make([]*entity.LinkStats, len(shortCodes))
+	for i, code := range shortCodes {
+		stats[i] = &entity.LinkStats{
+			TenantID:       tenantID,
+			ShortCode:      code,
+			UniqueVisitors: visitors[i],
+			ComputedAt:     now,
+		}
+		if t, ok := totals[code]; ok {
+			stats[i].Totals = *t
+		}
+	}
+	return stats, nil
+}
+
+// totals adds up the query's clicks over each tier, read from the store that holds it
+func (s *usageService) totals(ctx context.Context, query *entity.TotalsQuery, tiers []tier) (map[string]*entity.ClickTotals, error) {
+	sums := make(map[string]*entity.ClickTotals)
+	for _, t := range tiers {
+		q := *query
+		q.From, q.To = t.from, t.to
+
+		var part map[string]*entity.ClickTotals
+		var err error
+		if t.resolution == "" {
+			part, err = s.clickRepo.Totals(ctx, &q)
+		} else {
+			part, err = s.rollupRepo.Totals(ctx, t.resolution, &q)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for key, p := range part {
+			if sums[key] == nil {
+				sums[key] = &entity.ClickTotals{}
+			}
+			sums[key].Add(p)
+		}
+	}
+	return sums, nil
+}
+
+// watermark reads where the rollups end. When none is saved, e.g. before the first compaction,
+// it is taken from the last bucket of each resolution.
+func (s *usageService) watermark(ctx context.Context) (*entity.RollupWatermark, error) {
+	marks, err := s.watermarkRepo.Get(ctx)
+	if err != nil || marks != nil {
+		return marks, err
+	}
+
+	_, lastHour, err := s.rollupRepo.Bounds(ctx, entity.ResolutionHour, 0)
+	if err != nil {
+		return nil, err
+	}
+	_, lastDay, err := s.rollupRepo.Bounds(ctx, entity.ResolutionDay, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	marks = &entity.RollupWatermark{}
+	if !lastHour.IsZero() {
+		marks.Hourly = lastHour.Add(time.Hour)
+	}
+	if !lastDay.IsZero() {
+		marks.Daily = lastDay.AddDate(0, 0, 1)
+	}
+	return marks, nil
+}
+
+// visitors estimates each link's distinct visitors over the last days, merging a few links' daily sketches at a time
+func (s *usageService) visitors(ctx context.Context, tenantID int, shortCodes []string, now time.Time) ([]uint64, error) {
+	today := now.Truncate(24 * time.Hour)
+	days := make([]time.Time, constant.LinkStatsVisitorDays)
+	for i := range days {
+		days[i] = today.AddDate(0, 0, -i)
+	}
+
+	counts := make([]uint64, len(shortCodes))
+	errs := make([]error, len(shortCodes))
+	sem := make(chan struct{}, constant.LinkStatsConcurrency)
+	var wg sync.WaitGroup
+	for i, code := range shortCodes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			counts[i], errs[i] = s.uniqueVisitors(ctx, tenantID, code, days)
+		}()
+	}
+	wg.Wait()
+
+	return counts, errors.Join(errs...)
+}
+
+func (s *usageService) uniqueVisitors(ctx context.Context, tenantID int, shortCode string, days []time.Time) (uint64, error) {
+	sketches, err := s.visitorRepo.Get(ctx, tenantID, shortCode, days)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := hll.New(hll.DefaultPrecision)
+	if err != nil {
+		return 0, err
+	}
+	for _, sketch := range sketches {
+		if sketch == nil {
+			continue
+		}
+		if err := total.Merge(sketch); err != nil {
+			return 0, err
+		}
+	}
+	return total.Count(), nil
+}
+
+// lifetimeTiers reads the days before the daily watermark from the daily rollups,
+// the hours after them up to the hourly watermark from the hourly rollups, and every later click raw
+func lifetimeTiers(marks *entity.RollupWatermark) []tier {
+	var tiers []tier
+	if !marks.Daily.IsZero() {
+		tiers = append(tiers, tier{resolution: entity.ResolutionDay, to: marks.Daily})
+	}
+	if marks.Hourly.After(marks.Daily) {
+		tiers = append(tiers, tier{resolution: entity.ResolutionHour, from: marks.Daily, to: marks.Hourly})
+	}
+	return append(tiers, tier{from: marks.Hourly})
+}
+
+// periodTiers reads [from, to) from the hourly rollups up to the hourly watermark and raw after it.
+// Daily rollups are left out since a period need not start or end on a UTC day.
+func periodTiers(from, to time.Time, marks *entity.RollupWatermark) []tier {
+	split := marks.Hourly
+	if split.Before(from) {
+		split = from
+	}
+	if split.After(to) {
+		split = to
+	}
+
+	var tiers []tier
+	if split.After(from) {
+		tiers = append(tiers, tier{resolution: entity.ResolutionHour, from: from, to: split})
+	}
+	if to.After(split) {
+		tiers = append(tiers, tier{from: split, to: to})
+	}
+	return tiers
+}
+
+// usageTTL keeps settled usage for long, and usage that can still change only briefly
+func usageTTL(usage *entity.TenantUsage) time.Duration {
+	if usage.Complete {
+		return constant.CompleteUsageCacheTTL
+	}
+	return constant.UsageCacheTTL
+}
@@ -0,0 +1,37 @@
+package di
+
+import (
+	search "go-link/analytics/internal/adapters/driven/search"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/adapters/driver/worker"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type AnomalyContainer struct {
+	Repository ports.AlertSettingsRepository
+	Service    ports.AnomalyService
+	Worker     ports.AnomalyWorker
+	Handler    driverHttp.AlertHandler
+}
+
+func InitAnomalyDependencies(notification *NotificationContainer, lock ports.JobLock) *AnomalyContainer {
+	// Repository
+	repository := search.NewAlertSettingsRepository()
+
+	// Service
+	service := service.NewAnomalyService(repository, notification.Publisher, lock)
+
+	// Worker
+	worker := worker.NewAnomalyWorker(service)
+
+	// Handler
+	handler := driverHttp.NewAlertHandler(service)
+
+	return &AnomalyContainer{
+		Repository: repository,
+		Service:    service,
+		Worker:     worker,
+		Handler:    handler,
+	}
+}
@@ -0,0 +1,72 @@
+package di
+
+import (
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/mq/kafka"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/cache"
+	search "go-link/analytics/internal/adapters/driven/search"
+	clickconsumer "go-link/analytics/internal/adapters/driver/consumer/click"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/infrastructure/referrer"
+	"go-link/analytics/internal/infrastructure/useragent"
+	"go-link/analytics/internal/ports"
+)
+
+type ClickContainer struct {
+	Repository        ports.ClickRepository
+	VisitorRepository ports.VisitorRepository
+	PlaceRepository   ports.PlaceRepository
+	UserAgentParser   ports.UserAgentParser
+	SourceClassifier  ports.SourceClassifier
+	Service           ports.ClickService
+	Consumer          ports.ClickConsumer
+}
+
+func InitClickDependencies() *ClickContainer {
+	// Repository
+	repository := search.NewClickRepository()
+	visitorRepo := cache.NewVisitorRepository(global.Redis)
+	placeRepo := cache.NewPlaceRepository(global.Redis)
+
+	// Enrichment
+	uaParser, err := useragent.NewParser(global.Config.Enrichment.UserAgentRulesPath)
+	if err != nil {
+		global.LoggerZap.Fatal("failed to load user agent rules", zap.Error(err))
+	}
+
+	sources, err := referrer.NewClassifier(global.Config.Enrichment.ReferrerRulesPath)
+	if err != nil {
+		global.LoggerZap.Fatal("failed to load referrer rules", zap.Error(err))
+	}
+
+	// Service
+	service := service.NewClickService(repository, visitorRepo, placeRepo, uaParser, sources)
+
+	// Consumer
+	kafkaCfg := &kafka.Config{
+		Brokers:  global.Config.Kafka.Brokers,
+		ClientID: "analytics-clicks",
+		ConsumerInfo: kafka.ConsumerConfig{
+			SessionTimeout:    global.Config.Kafka.Timeout * 1000,
+			MaxProcessingTime: global.Config.Kafka.MaxProcessingTime,
+		},
+	}
+
+	consumer, err := clickconsumer.NewClickConsumer(kafkaCfg, service)
+	if err != nil {
+		global.LoggerZap.Fatal("failed to create click consumer", zap.Error(err))
+	}
+
+	return &ClickContainer{
+		Repository:        repository,
+		VisitorRepository: visitorRepo,
+		PlaceRepository:   placeRepo,
+		UserAgentParser:   uaParser,
+		SourceClassifier:  sources,
+		Service:           service,
+		Consumer:          consumer,
+	}
+}
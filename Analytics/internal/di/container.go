@@ -0,0 +1,17 @@
+package di
+
+type Container struct {
+	ClickContainer        *ClickContainer
+	ConversionContainer   *ConversionContainer
+	StatsContainer        *StatsContainer
+	LeaderboardContainer  *LeaderboardContainer
+	LiveContainer         *LiveContainer
+	ExportContainer       *ExportContainer
+	ReportContainer       *ReportContainer
+	NotificationContainer *NotificationContainer
+	AnomalyContainer      *AnomalyContainer
+	RetentionContainer    *RetentionContainer
+	UsageContainer        *UsageContainer
+}
+
+var GlobalContainer *Container
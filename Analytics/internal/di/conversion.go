@@ -0,0 +1,31 @@
+package di
+
+import (
+	search "go-link/analytics/internal/adapters/driven/search"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type ConversionContainer struct {
+	Repository ports.ConversionRepository
+	Service    ports.ConversionService
+	Handler    driverHttp.ConversionHandler
+}
+
+func InitConversionDependencies(clickRepo ports.ClickRepository) *ConversionContainer {
+	// Repository
+	conversionRepo := search.NewConversionRepository()
+
+	// Service
+	service := service.NewConversionService(conversionRepo, clickRepo)
+
+	// Handler
+	handler := driverHttp.NewConversionHandler(service)
+
+	return &ConversionContainer{
+		Repository: conversionRepo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
@@ -0,0 +1,49 @@
+package di
+
+import (
+	"time"
+
+	"go-link/analytics/global"
+	search "go-link/analytics/internal/adapters/driven/search"
+	"go-link/analytics/internal/adapters/driven/storage"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/adapters/driver/worker"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type ExportContainer struct {
+	Repository ports.ExportRepository
+	Service    ports.ExportService
+	Worker     ports.ExportWorker
+	Handler    driverHttp.ExportHandler
+}
+
+func InitExportDependencies(click *ClickContainer, lock ports.JobLock) *ExportContainer {
+	cfg := global.Config.Export
+
+	// Repository
+	repository := search.NewExportRepository()
+
+	// Storage; exports stay off until a directory is configured
+	var files ports.ExportStorage
+	if cfg.Directory != "" {
+		files = storage.NewExportStorage(cfg.Directory)
+	}
+
+	// Service
+	service := service.NewExportService(repository, click.Repository, files, lock, cfg.DownloadSecret, time.Duration(cfg.LinkTTL)*time.Second)
+
+	// Worker
+	worker := worker.NewExportWorker(service)
+
+	// Handler
+	handler := driverHttp.NewExportHandler(service)
+
+	return &ExportContainer{
+		Repository: repository,
+		Service:    service,
+		Worker:     worker,
+		Handler:    handler,
+	}
+}
@@ -0,0 +1,38 @@
+package di
+
+import (
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/cache"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/adapters/driver/worker"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type LeaderboardContainer struct {
+	Repository ports.LeaderboardRepository
+	Service    ports.LeaderboardService
+	Worker     ports.LeaderboardWorker
+	Handler    driverHttp.LeaderboardHandler
+}
+
+func InitLeaderboardDependencies() *LeaderboardContainer {
+	// Repository
+	repository := cache.NewLeaderboardRepository(global.Redis)
+
+	// Service
+	service := service.NewLeaderboardService(repository)
+
+	// Worker
+	worker := worker.NewLeaderboardWorker(service)
+
+	// Handler
+	handler := driverHttp.NewLeaderboardHandler(service)
+
+	return &LeaderboardContainer{
+		Repository: repository,
+		Service:    service,
+		Worker:     worker,
+		Handler:    handler,
+	}
+}
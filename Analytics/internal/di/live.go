@@ -0,0 +1,58 @@
+package di
+
+import (
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/mq/kafka"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/cache"
+	liveconsumer "go-link/analytics/internal/adapters/driver/consumer/live"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+// LiveContainer holds all live-stream dependencies.
+type LiveContainer struct {
+	Hub      *service.LiveHub
+	Service  ports.LiveService
+	Consumer ports.LiveConsumer
+	Handler  *driverHttp.LiveHandler
+}
+
+// InitLiveDependencies wires the live streams to the enrichment the click container loaded.
+// The live consumer sees every click on every replica, so it also feeds the leaderboards and the anomaly detector.
+func InitLiveDependencies(click *ClickContainer, leaderboard *LeaderboardContainer, anomaly *AnomalyContainer) *LiveContainer {
+	hub := service.NewLiveHub()
+
+	// Cache
+	tickets := cache.NewLiveTicketStore(global.Redis)
+
+	// Service
+	service := service.NewLiveService(hub, tickets, click.UserAgentParser, click.SourceClassifier)
+
+	// Consumer
+	kafkaCfg := &kafka.Config{
+		Brokers:  global.Config.Kafka.Brokers,
+		ClientID: "analytics-live",
+		ConsumerInfo: kafka.ConsumerConfig{
+			InitialOffset: kafka.OffsetNewest,
+		},
+	}
+
+	consumer, err := liveconsumer.NewLiveConsumer(kafkaCfg, service, leaderboard.Service, anomaly.Service)
+	if err != nil {
+		global.LoggerZap.Fatal("failed to create live consumer", zap.Error(err))
+	}
+
+	// Handler
+	handler := driverHttp.NewLiveHandler(hub, service)
+
+	return &LiveContainer{
+		Hub:      hub,
+		Service:  service,
+		Consumer: consumer,
+		Handler:  handler,
+	}
+}
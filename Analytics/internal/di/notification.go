@@ -0,0 +1,37 @@
+package di
+
+import (
+	"go.uber.org/zap"
+
+	"go-link/common/pkg/mq/kafka"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/producer"
+	"go-link/analytics/internal/ports"
+)
+
+// NotificationContainer holds the producer reports and alerts are sent to Notification with.
+type NotificationContainer struct {
+	Producer  kafka.SyncProducer
+	Publisher ports.NotificationPublisher
+}
+
+func InitNotificationDependencies() *NotificationContainer {
+	// Producer
+	notificationProducer, err := kafka.NewSyncProducer(&kafka.Config{
+		Brokers:  global.Config.Kafka.Brokers,
+		ClientID: "analytics-notifications",
+		ProducerInfo: kafka.ProducerConfig{
+			MaxRetries:   global.Config.Kafka.MaxRetries,
+			RetryBackoff: global.Config.Kafka.RetryBackoff,
+		},
+	})
+	if err != nil {
+		global.LoggerZap.Fatal("failed to create notification producer", zap.Error(err))
+	}
+
+	return &NotificationContainer{
+		Producer:  notificationProducer,
+		Publisher: producer.NewNotificationPublisher(notificationProducer),
+	}
+}
@@ -0,0 +1,37 @@
+package di
+
+import (
+	search "go-link/analytics/internal/adapters/driven/search"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/adapters/driver/worker"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type ReportContainer struct {
+	Repository ports.ReportRepository
+	Service    ports.ReportService
+	Worker     ports.ReportWorker
+	Handler    driverHttp.ReportHandler
+}
+
+func InitReportDependencies(click *ClickContainer, notification *NotificationContainer, lock ports.JobLock) *ReportContainer {
+	// Repository
+	repository := search.NewReportRepository()
+
+	// Service
+	service := service.NewReportService(repository, click.Repository, notification.Publisher, lock)
+
+	// Worker
+	worker := worker.NewReportWorker(service)
+
+	// Handler
+	handler := driverHttp.NewReportHandler(service)
+
+	return &ReportContainer{
+		Repository: repository,
+		Service:    service,
+		Worker:     worker,
+		Handler:    handler,
+	}
+}
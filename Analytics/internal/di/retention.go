@@ -0,0 +1,51 @@
+package di
+
+import (
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/cache"
+	driven "go-link/analytics/internal/adapters/driven/grpc"
+	search "go-link/analytics/internal/adapters/driven/search"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/adapters/driver/worker"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type RetentionContainer struct {
+	Repository          ports.RollupRepository
+	WatermarkRepository ports.RollupWatermarkRepository
+	Service             ports.RetentionService
+	Worker              ports.CompactionWorker
+	Handler             driverHttp.RetentionHandler
+}
+
+func InitRetentionDependencies(click *ClickContainer, conversion *ConversionContainer, leaderboard *LeaderboardContainer, lock ports.JobLock) *RetentionContainer {
+	// Repository
+	repository := search.NewRollupRepository()
+	watermarkRepo := cache.NewRollupWatermarkRepository(global.Redis)
+
+	// Billing Client
+	billingClient, err := driven.NewBillingClient(global.Config.Services.BillingService)
+	if err != nil {
+		global.LoggerZap.Fatal("Failed to connect to Billing Service", zap.Error(err))
+	}
+
+	// Service
+	service := service.NewRetentionService(click.Repository, conversion.Repository, repository, watermarkRepo, click.VisitorRepository, click.PlaceRepository, leaderboard.Repository, billingClient, lock)
+
+	// Worker
+	worker := worker.NewCompactionWorker(service)
+
+	// Handler
+	handler := driverHttp.NewRetentionHandler(service)
+
+	return &RetentionContainer{
+		Repository:          repository,
+		WatermarkRepository: watermarkRepo,
+		Service:             service,
+		Worker:              worker,
+		Handler:             handler,
+	}
+}
@@ -0,0 +1,35 @@
+package di
+
+import (
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	driven "go-link/analytics/internal/adapters/driven/grpc"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type StatsContainer struct {
+	Service ports.StatsService
+	Handler driverHttp.StatsHandler
+}
+
+func InitStatsDependencies(click *ClickContainer, retention *RetentionContainer) *StatsContainer {
+	// Generation Client
+	generationClient, err := driven.NewGenerationClient(global.Config.Services.GenerationService)
+	if err != nil {
+		global.LoggerZap.Fatal("Failed to connect to Generation Service", zap.Error(err))
+	}
+
+	// Service
+	service := service.NewStatsService(click.Repository, click.VisitorRepository, click.PlaceRepository, retention.Repository, generationClient)
+
+	// Handler
+	handler := driverHttp.NewStatsHandler(service)
+
+	return &StatsContainer{
+		Service: service,
+		Handler: handler,
+	}
+}
@@ -0,0 +1,27 @@
+package di
+
+import (
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/cache"
+	"go-link/analytics/internal/core/service"
+	"go-link/analytics/internal/ports"
+)
+
+type UsageContainer struct {
+	Cache   ports.UsageCache
+	Service ports.UsageService
+}
+
+// InitUsageDependencies wires the click counts served to other services over gRPC
+func InitUsageDependencies(click *ClickContainer, retention *RetentionContainer) *UsageContainer {
+	// Cache
+	usageCache := cache.NewUsageCache(global.Redis)
+
+	// Service
+	service := service.NewUsageService(click.Repository, retention.Repository, retention.WatermarkRepository, click.VisitorRepository, usageCache)
+
+	return &UsageContainer{
+		Cache:   usageCache,
+		Service: service,
+	}
+}
@@ -0,0 +1,31 @@
+package di
+
+import (
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/cache"
+)
+
+func SetupDependencies() *Container {
+	click := InitClickDependencies()
+	leaderboard := InitLeaderboardDependencies()
+	jobLock := cache.NewJobLock(global.Redis)
+	notification := InitNotificationDependencies()
+	anomaly := InitAnomalyDependencies(notification, jobLock)
+	conversion := InitConversionDependencies(click.Repository)
+	retention := InitRetentionDependencies(click, conversion, leaderboard, jobLock)
+	container := &Container{
+		ClickContainer:        click,
+		ConversionContainer:   conversion,
+		StatsContainer:        InitStatsDependencies(click, retention),
+		LeaderboardContainer:  leaderboard,
+		LiveContainer:         InitLiveDependencies(click, leaderboard, anomaly),
+		ExportContainer:       InitExportDependencies(click, jobLock),
+		ReportContainer:       InitReportDependencies(click, notification, jobLock),
+		NotificationContainer: notification,
+		AnomalyContainer:      anomaly,
+		RetentionContainer:    retention,
+		UsageContainer:        InitUsageDependencies(click, retention),
+	}
+	GlobalContainer = container
+	return container
+}
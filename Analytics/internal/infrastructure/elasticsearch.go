@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"context"
+	"strings"
+
+	"go-link/common/pkg/database/elasticsearch"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/adapters/driven/search/models"
+)
+
+func SetupElasticsearch() {
+	client, err := elasticsearch.New(global.Config.Elasticsearch)
+	if err != nil {
+		global.LoggerZap.Sugar().Fatalf("Failed to connect to Elasticsearch: %v", err)
+	}
+
+	global.ElasticClient = client
+	global.LoggerZap.Sugar().Info("Connected to Elasticsearch successfully")
+}
+
+// SetupIndices creates the analytics indices with their mappings on first start
+func SetupIndices() {
+	indices := map[string]string{
+		models.ConversionIndexName:     models.ConversionMapping,
+		models.ClickIndexName:          models.ClickMapping,
+		models.ExportIndexName:         models.ExportMapping,
+		models.ReportScheduleIndexName: models.ReportScheduleMapping,
+		models.AlertSettingsIndexName:  models.AlertSettingsMapping,
+		models.RollupHourlyIndexName:   models.RollupMapping,
+		models.RollupDailyIndexName:    models.RollupMapping,
+	}
+
+	ctx := context.Background()
+	for name, mapping := range indices {
+		if err := elasticsearch.EnsureIndex(ctx, global.ElasticClient, name, strings.NewReader(mapping)); err != nil {
+			global.LoggerZap.Sugar().Fatalf("Failed to create index %s: %v", name, err)
+		}
+	}
+}
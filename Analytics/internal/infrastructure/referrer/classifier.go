@@ -0,0 +1,97 @@
+package referrer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+// defaultRules is the built-in rules file; a newer one can be supplied without a rebuild
+//
+//go:embed rules.json
+var defaultRules []byte
+
+// rule names the source and medium of a group of referrer domains. Rules are tried in order,
+// so narrower ones such as mail.google.com must come before google.
+// A domain ending in "." matches any suffix after it, e.g. "google." matches google.co.uk.
+type rule struct {
+	Source  string   `json:"source"`
+	Medium  string   `json:"medium"`
+	Domains []string `json:"domains"`
+}
+
+// Classifier attributes clicks to a source and medium.
+type Classifier struct {
+	rules    []rule
+	bySource map[string]*rule
+}
+
+// NewClassifier loads the rules file at path, or the built-in rules when path is empty
+func NewClassifier(path string) (ports.SourceClassifier, error) {
+	data := defaultRules
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+
+	var rules []rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("referrer rules: %w", err)
+	}
+
+	c := &Classifier{rules: rules, bySource: make(map[string]*rule, len(rules))}
+	for i := range rules {
+		c.bySource[rules[i].Source] = &rules[i]
+	}
+	return c, nil
+}
+
+// Classify follows the usual attribution order: UTM parameters win, then known referrers,
+// then any other referrer as a plain referral; a click with neither is direct.
+func (c *Classifier) Classify(utmSource, utmMedium, referrerDomain string) *entity.TrafficSource {
+	utmSource = strings.ToLower(strings.TrimSpace(utmSource))
+	utmMedium = strings.ToLower(strings.TrimSpace(utmMedium))
+
+	src := c.fromReferrer(referrerDomain)
+	if utmSource != "" {
+		src.Source = utmSource
+		src.Medium = entity.MediumNotSet
+		if r, ok := c.bySource[utmSource]; ok {
+			src.Medium = r.Medium
+		}
+	}
+	if utmMedium != "" {
+		src.Medium = utmMedium
+	}
+	return src
+}
+
+func (c *Classifier) fromReferrer(domain string) *entity.TrafficSource {
+	if domain == "" {
+		return &entity.TrafficSource{Source: entity.SourceDirect, Medium: entity.MediumNone}
+	}
+
+	for _, r := range c.rules {
+		for _, d := range r.Domains {
+			if matchDomain(domain, d) {
+				return &entity.TrafficSource{Source: r.Source, Medium: r.Medium}
+			}
+		}
+	}
+	return &entity.TrafficSource{Source: domain, Medium: entity.MediumReferral}
+}
+
+func matchDomain(host, pattern string) bool {
+	if strings.HasSuffix(pattern, ".") {
+		return strings.HasPrefix(host, pattern) || strings.Contains(host, "."+pattern)
+	}
+	return host == pattern || strings.HasSuffix(host, "."+pattern)
+}
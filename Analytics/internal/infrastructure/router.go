@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"net/http"
+
+	"go-link/common/pkg/common/http/handler"
+	"go-link/common/pkg/common/http/middlewares"
+	"go-link/common/pkg/permissions"
+
+	"github.com/gin-gonic/gin"
+
+	"go-link/analytics/global"
+	driverHttp "go-link/analytics/internal/adapters/driver/http"
+)
+
+// RouterGroup contains all routes
+type RouterGroup struct {
+	ConversionHandler  driverHttp.ConversionHandler
+	StatsHandler       driverHttp.StatsHandler
+	LiveHandler        *driverHttp.LiveHandler
+	LeaderboardHandler driverHttp.LeaderboardHandler
+	ExportHandler      driverHttp.ExportHandler
+	ReportHandler      driverHttp.ReportHandler
+	AlertHandler       driverHttp.AlertHandler
+	RetentionHandler   driverHttp.RetentionHandler
+}
+
+// NewRouterGroup creates a new RouterGroup
+func NewRouterGroup(conversionHandler driverHttp.ConversionHandler, statsHandler driverHttp.StatsHandler, liveHandler *driverHttp.LiveHandler, leaderboardHandler driverHttp.LeaderboardHandler, exportHandler driverHttp.ExportHandler, reportHandler driverHttp.ReportHandler, alertHandler driverHttp.AlertHandler, retentionHandler driverHttp.RetentionHandler) *RouterGroup {
+	return &RouterGroup{
+		ConversionHandler:  conversionHandler,
+		StatsHandler:       statsHandler,
+		LiveHandler:        liveHandler,
+		LeaderboardHandler: leaderboardHandler,
+		ExportHandler:      exportHandler,
+		ReportHandler:      reportHandler,
+		AlertHandler:       alertHandler,
+		RetentionHandler:   retentionHandler,
+	}
+}
+
+// registerRoutes registers all routes
+func (rg *RouterGroup) registerRoutes(r *gin.Engine) {
+	// Conversion postbacks are authenticated by their signature, not a session
+	conversions := r.Group("/conversions")
+	{
+		conversions.GET("/postback", rg.ConversionHandler.Postback)
+		conversions.POST("/postback", rg.ConversionHandler.Postback)
+		conversions.GET("/pixel.gif", rg.ConversionHandler.Pixel)
+	}
+
+	analytics := r.Group("/analytics")
+	analytics.Use(middlewares.Authentication(global.Config.JWT.PublicKey))
+	{
+		analytics.GET("/timeseries", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.TimeSeries))
+		analytics.GET("/links/:id/timeseries", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.TimeSeries))
+		analytics.GET("/links/:id/visitors", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.UniqueVisitors))
+		analytics.GET("/breakdown/:dimension", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.Breakdown))
+		analytics.GET("/links/:id/breakdown/:dimension", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.Breakdown))
+		analytics.GET("/collections/:collection/timeseries", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.TimeSeries))
+		analytics.GET("/collections/:collection/breakdown/:dimension", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.Breakdown))
+		analytics.GET("/collections/:collection/visitors", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.UniqueVisitors))
+		analytics.GET("/collections/:collection/geo", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.Geo))
+		analytics.GET("/links/:id/geo", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.StatsHandler.Geo))
+		analytics.GET("/leaderboard", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.LeaderboardHandler.TenantTop))
+		analytics.GET("/admin/leaderboard", middlewares.RequireAdmin(), driverHttp.WrapQuery(rg.LeaderboardHandler.GlobalTop))
+		analytics.POST("/exports", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), handler.Wrap(rg.ExportHandler.Create))
+		analytics.GET("/exports/:id", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), handler.Wrap(rg.ExportHandler.Get))
+		analytics.POST("/reports", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeCreate), handler.Wrap(rg.ReportHandler.Create))
+		analytics.GET("/reports", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), handler.Wrap(rg.ReportHandler.List))
+		analytics.DELETE("/reports/:id", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeDelete), handler.Wrap(rg.ReportHandler.Delete))
+		analytics.GET("/alerts/settings", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), handler.Wrap(rg.AlertHandler.GetSettings))
+		analytics.PUT("/alerts/settings", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeUpdate), handler.Wrap(rg.AlertHandler.UpdateSettings))
+		analytics.POST("/erasure", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeDelete), handler.Wrap(rg.RetentionHandler.Erase))
+		analytics.DELETE("/admin/tenants/:id/data", middlewares.RequireAdmin(), handler.Wrap(rg.RetentionHandler.EraseTenant))
+		analytics.GET("/conversions", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.ConversionHandler.Report))
+		analytics.POST("/live/tickets", middlewares.RequirePermission(permissions.ResourceKeyAnalytics, permissions.PermissionScopeRead), handler.Wrap(rg.LiveHandler.Ticket))
+		analytics.GET("/conversions/postback-key", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeUpdate), handler.Wrap(rg.ConversionHandler.PostbackKey))
+	}
+
+	// Export downloads are authorized by their signed, expiring link so they can be fetched by any HTTP client.
+	// Download is gin-coupled directly.
+	r.GET("/analytics/exports/:id/download", rg.ExportHandler.Download)
+
+	// Live streams are opened by EventSource, which cannot send headers; they are authorized by a single-use ticket
+	// from /analytics/live/tickets, so the caller's token never appears in a URL.
+	// Stream is gin-coupled directly.
+	r.GET("/analytics/live", rg.LiveHandler.Stream)
+}
+
+// Ping
+func Ping(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "OK",
+		"message": "I'm running!",
+	})
+}
+
+// NewEngine creates and configures the Gin engine
+func NewEngine(routerGroup *RouterGroup) *gin.Engine {
+	if global.Config.Server.Mode != "release" {
+		gin.SetMode(gin.DebugMode)
+	}
+
+	r := gin.New()
+
+	// middlewares
+	r.Use(middlewares.RecoveryMiddleware)
+	r.Use(middlewares.CORSMiddleware)
+
+	r.GET("/ping", Ping)
+
+	// Register routes
+	routerGroup.registerRoutes(r)
+
+	return r
+}
@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go-link/analytics/global"
+	"go-link/analytics/internal/di"
+)
+
+func Run() error {
+	LoadConfig()
+	SetupLogger()
+	SetupRedis()
+	SetupKeys()
+	SetupElasticsearch()
+	SetupIndices()
+	di.SetupDependencies()
+	http := NewHTTPServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clickConsumer := di.GlobalContainer.ClickContainer.Consumer
+	if err := clickConsumer.Start(ctx); err != nil {
+		global.LoggerZap.Error("Click Consumer failed", zap.Error(err))
+	}
+	defer clickConsumer.Stop()
+
+	leaderboardWorker := di.GlobalContainer.LeaderboardContainer.Worker
+	go func() {
+		if err := leaderboardWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Leaderboard worker stopped", zap.Error(err))
+		}
+	}()
+	defer leaderboardWorker.Stop()
+
+	liveConsumer := di.GlobalContainer.LiveContainer.Consumer
+	if err := liveConsumer.Start(ctx); err != nil {
+		global.LoggerZap.Error("Live Consumer failed", zap.Error(err))
+	}
+	defer liveConsumer.Stop()
+
+	exportWorker := di.GlobalContainer.ExportContainer.Worker
+	go func() {
+		if err := exportWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Export worker stopped", zap.Error(err))
+		}
+	}()
+	defer exportWorker.Stop()
+
+	notificationProducer := di.GlobalContainer.NotificationContainer.Producer
+	defer func() {
+		if err := notificationProducer.Close(); err != nil {
+			global.LoggerZap.Error("Failed to close notification producer", zap.Error(err))
+		}
+	}()
+
+	reportWorker := di.GlobalContainer.ReportContainer.Worker
+	go func() {
+		if err := reportWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Report worker stopped", zap.Error(err))
+		}
+	}()
+	defer reportWorker.Stop()
+
+	anomalyWorker := di.GlobalContainer.AnomalyContainer.Worker
+	go func() {
+		if err := anomalyWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Anomaly worker stopped", zap.Error(err))
+		}
+	}()
+	defer anomalyWorker.Stop()
+
+	compactionWorker := di.GlobalContainer.RetentionContainer.Worker
+	go func() {
+		if err := compactionWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Compaction worker stopped", zap.Error(err))
+		}
+	}()
+	defer compactionWorker.Stop()
+
+	grpcServer := NewGRPCServer()
+	go func() {
+		if err := grpcServer.Run(); err != nil {
+			panic(fmt.Sprintf("Failed to run gRPC server: %v", err))
+		}
+	}()
+
+	return http.Run()
+}
@@ -0,0 +1,144 @@
+package useragent
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"go-link/analytics/internal/core/entity"
+	"go-link/analytics/internal/ports"
+)
+
+// defaultRules is the built-in rules file; a newer one can be supplied without a rebuild
+//
+//go:embed rules.json
+var defaultRules []byte
+
+// rulesFile is the layout of rules.json. Browser and OS rules are tried in order and the first
+// match wins, so browsers that embed another's token (Edge and Opera say "Chrome") come first.
+type rulesFile struct {
+	Bot      string     `json:"bot"`
+	Tablet   string     `json:"tablet"`
+	Mobile   string     `json:"mobile"`
+	Browsers []ruleSpec `json:"browsers"`
+	OS       []ruleSpec `json:"os"`
+}
+
+type ruleSpec struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// rule maps a User-Agent pattern to a name; the first capture group, if any, is the version
+type rule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// Parser classifies User-Agent strings with an ordered rule table.
+type Parser struct {
+	bot      *regexp.Regexp
+	tablet   *regexp.Regexp
+	mobile   *regexp.Regexp
+	browsers []rule
+	os       []rule
+}
+
+// NewParser loads the rules file at path, or the built-in rules when path is empty
+func NewParser(path string) (ports.UserAgentParser, error) {
+	data := defaultRules
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+
+	var f rulesFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("user agent rules: %w", err)
+	}
+
+	p := &Parser{}
+	var err error
+	if p.bot, err = regexp.Compile(f.Bot); err != nil {
+		return nil, fmt.Errorf("user agent rules: bot: %w", err)
+	}
+	if p.tablet, err = regexp.Compile(f.Tablet); err != nil {
+		return nil, fmt.Errorf("user agent rules: tablet: %w", err)
+	}
+	if p.mobile, err = regexp.Compile(f.Mobile); err != nil {
+		return nil, fmt.Errorf("user agent rules: mobile: %w", err)
+	}
+	if p.browsers, err = compileRules(f.Browsers); err != nil {
+		return nil, err
+	}
+	if p.os, err = compileRules(f.OS); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func compileRules(specs []ruleSpec) ([]rule, error) {
+	rules := make([]rule, len(specs))
+	for i, s := range specs {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("user agent rules: %s: %w", s.Name, err)
+		}
+		rules[i] = rule{name: s.Name, pattern: re}
+	}
+	return rules, nil
+}
+
+// Parse reads browser, OS and device class; anything unrecognised is left empty
+func (p *Parser) Parse(userAgent string) *entity.UserAgent {
+	ua := &entity.UserAgent{}
+	if strings.TrimSpace(userAgent) == "" {
+		ua.Device = entity.DeviceUnknown
+		return ua
+	}
+
+	ua.Browser, ua.BrowserVersion = match(p.browsers, userAgent)
+	ua.OS, _ = match(p.os, userAgent)
+	ua.Bot = p.bot.MatchString(userAgent)
+	ua.Device = p.device(ua, userAgent)
+
+	return ua
+}
+
+func match(rules []rule, userAgent string) (string, string) {
+	for _, r := range rules {
+		m := r.pattern.FindStringSubmatch(userAgent)
+		if m == nil {
+			continue
+		}
+		if len(m) > 1 {
+			return r.name, m[1]
+		}
+		return r.name, ""
+	}
+	return "", ""
+}
+
+// device tells phones from tablets; Android tablets are the Android devices that don't say "Mobile"
+func (p *Parser) device(ua *entity.UserAgent, userAgent string) string {
+	switch {
+	case ua.Bot:
+		return entity.DeviceBot
+	case p.tablet.MatchString(userAgent):
+		return entity.DeviceTablet
+	case p.mobile.MatchString(userAgent):
+		return entity.DeviceMobile
+	case ua.OS == "Android":
+		return entity.DeviceTablet
+	case ua.OS == "":
+		return entity.DeviceUnknown
+	default:
+		return entity.DeviceDesktop
+	}
+}
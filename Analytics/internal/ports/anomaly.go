@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+type AlertSettingsRepository interface {
+	Save(ctx context.Context, settings *entity.AlertSettings) error
+	// Get returns nil, without error, when the tenant has not saved settings.
+	Get(ctx context.Context, tenantID int) (*entity.AlertSettings, error)
+}
+
+type AnomalyService interface {
+	// Record counts a click towards its link's rate for the open interval.
+	Record(ctx context.Context, evt *linkv1.LinkClickedEvent)
+	// Evaluate closes the interval, compares every link with its baseline and raises alerts.
+	Evaluate(ctx context.Context) error
+	GetSettings(ctx context.Context, req *dto.GetAlertSettingsRequest) (*dto.AlertSettingsResponse, error)
+	UpdateSettings(ctx context.Context, req *dto.UpdateAlertSettingsRequest) (*dto.AlertSettingsResponse, error)
+}
+
+type AnomalyWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
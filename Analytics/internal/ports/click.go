@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/analytics/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+type ClickRepository interface {
+	// SaveBulk stores clicks keyed by event ID, skipping ones already stored, and returns how many were new.
+	SaveBulk(ctx context.Context, clicks []*entity.Click) (int, error)
+	// CountTracked counts the clicks that were issued a click ID, grouped as the filter asks.
+	CountTracked(ctx context.Context, filter *entity.ConversionFilter) (map[string]int64, error)
+	// TimeSeries counts clicks per bucket, returning every bucket of the range even when it is empty.
+	TimeSeries(ctx context.Context, query *entity.TimeSeriesQuery) (*entity.TimeSeries, error)
+	// Breakdown counts clicks per value of a dimension, keeping the most clicked ones.
+	Breakdown(ctx context.Context, query *entity.BreakdownQuery) (*entity.Breakdown, error)
+	// PlaceClicks counts clicks per place key, among the given keys only.
+	PlaceClicks(ctx context.Context, query *entity.GeoQuery, keys []string) (map[string]int64, error)
+	// Scan pages through the clicks in [from, to) oldest first, starting after the given click, or at the start when it is nil.
+	Scan(ctx context.Context, filter *entity.ClickFilter, from, to time.Time, after *entity.Click, size int) ([]*entity.Click, error)
+	// Totals counts the query's clicks per link, or under the empty key for the whole tenant when it names no links.
+	Totals(ctx context.Context, query *entity.TotalsQuery) (map[string]*entity.ClickTotals, error)
+	// SumHours rolls the clicks in [from, to) up per link per UTC hour, a page at a time after the given rollup.
+	SumHours(ctx context.Context, from, to time.Time, after *entity.Rollup, size int) ([]*entity.Rollup, error)
+	// Oldest returns when the tenant's oldest stored click was made, or every tenant's when tenantID is 0; zero when there are none.
+	Oldest(ctx context.Context, tenantID int) (time.Time, error)
+	// DeleteBefore removes a tenant's clicks made before the cutoff and returns how many it removed.
+	DeleteBefore(ctx context.Context, tenantID int, before time.Time) (int64, error)
+	// Erase removes every click of the target and returns how many it removed.
+	Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error)
+}
+
+// UserAgentParser reads browser, OS and device class from a User-Agent header.
+type UserAgentParser interface {
+	Parse(userAgent string) *entity.UserAgent
+}
+
+// SourceClassifier attributes a click to a source and medium from its UTM parameters and referrer.
+type SourceClassifier interface {
+	Classify(utmSource, utmMedium, referrerDomain string) *entity.TrafficSource
+}
+
+type ClickService interface {
+	// Ingest enriches and stores a batch of click events; an error means the batch should be redelivered.
+	Ingest(ctx context.Context, events []*linkv1.LinkClickedEvent) error
+}
+
+type ClickConsumer interface {
+	Start(ctx context.Context) error
+	Stop() error
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+type ConversionRepository interface {
+	// Create stores the conversion unless one with the same ID exists, and reports whether it did.
+	Create(ctx context.Context, conversion *entity.Conversion) (bool, error)
+	Stats(ctx context.Context, filter *entity.ConversionFilter) ([]*entity.ConversionStats, error)
+	// Erase removes every conversion of the target and returns how many it removed.
+	Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error)
+}
+
+type ConversionService interface {
+	Record(ctx context.Context, req *dto.ConversionPostbackRequest) (*dto.ConversionResponse, error)
+	Report(ctx context.Context, req *dto.ConversionReportRequest) (*dto.ConversionReportResponse, error)
+	PostbackKey(ctx context.Context, req *dto.PostbackKeyRequest) (*dto.PostbackKeyResponse, error)
+}
@@ -0,0 +1,56 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+type ExportRepository interface {
+	Save(ctx context.Context, job *entity.ExportJob) error
+	// Get returns nil, without error, when the job does not exist.
+	Get(ctx context.Context, id string) (*entity.ExportJob, error)
+	// ListRunnable returns pending jobs, and running ones started before staleBefore whose worker is presumed gone, oldest first.
+	ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]*entity.ExportJob, error)
+	// ListExpired returns finished jobs whose file should have been removed by now.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.ExportJob, error)
+}
+
+// ExportStorage keeps export files until they expire.
+type ExportStorage interface {
+	// Create opens a writer for the job's file in the job's format.
+	Create(job *entity.ExportJob) (ExportWriter, error)
+	// Path returns where a finished job's file is.
+	Path(job *entity.ExportJob) string
+	// Remove deletes the job's file; a file already gone is not an error.
+	Remove(job *entity.ExportJob) error
+}
+
+// ExportWriter writes clicks to a file that only becomes visible once committed.
+type ExportWriter interface {
+	Write(click *entity.Click) error
+	// Commit finishes the file and returns its size.
+	Commit() (int64, error)
+	// Abort drops a partly written file.
+	Abort()
+}
+
+type ExportService interface {
+	// Create queues an export of the caller's clicks.
+	Create(ctx context.Context, req *dto.CreateExportRequest) (*dto.ExportResponse, error)
+	// Get returns a job's progress and, once it is done, a fresh download link.
+	Get(ctx context.Context, req *dto.GetExportRequest) (*dto.ExportResponse, error)
+	// Download checks a download link and returns the file to send.
+	Download(ctx context.Context, req *dto.DownloadExportRequest) (*dto.ExportFile, error)
+	// RunPending claims and runs the jobs waiting for a worker.
+	RunPending(ctx context.Context) error
+	// RemoveExpired deletes the files of jobs past their expiry.
+	RemoveExpired(ctx context.Context) error
+}
+
+type ExportWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+// LeaderboardRepository persists leaderboard buckets so a restarted replica picks up where the others are.
+type LeaderboardRepository interface {
+	// Save replaces a bucket's snapshot with the given counts.
+	Save(ctx context.Context, window, scope string, bucket time.Time, counts map[string]uint64, ttl time.Duration) error
+	// Load returns a bucket's snapshot, empty when none was saved.
+	Load(ctx context.Context, window, scope string, bucket time.Time) (map[string]uint64, error)
+	// Erase removes the target's entries from every saved bucket and returns how many it removed.
+	Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error)
+}
+
+type LeaderboardService interface {
+	// Record counts a click towards its tenant's and the global leaderboards.
+	Record(ctx context.Context, evt *linkv1.LinkClickedEvent)
+	// Flush writes the buckets changed since the last flush and forgets expired ones.
+	Flush(ctx context.Context) error
+	// TenantTop returns the caller's most clicked links in a window.
+	TenantTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error)
+	// GlobalTop returns the most clicked links across tenants in a window.
+	GlobalTop(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error)
+}
+
+type LeaderboardWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
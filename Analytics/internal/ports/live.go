@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+type LiveService interface {
+	// Publish enriches a click event and pushes it to the live streams of its tenant on this replica.
+	Publish(ctx context.Context, evt *linkv1.LinkClickedEvent)
+	// IssueTicket hands the caller a single-use, short-lived ticket to open a live stream with.
+	IssueTicket(ctx context.Context, req *dto.CreateLiveTicketRequest) (*dto.LiveTicketResponse, error)
+	// RedeemTicket uses up a ticket and returns the caller it was issued to.
+	RedeemTicket(ctx context.Context, ticket string) (*entity.LiveTicket, error)
+}
+
+type LiveTicketStore interface {
+	Save(ctx context.Context, id string, ticket *entity.LiveTicket, ttl time.Duration) error
+	// Take returns the ticket and deletes it in one step; it returns nil, without error, when the ticket
+	// does not exist, has expired or was already taken.
+	Take(ctx context.Context, id string) (*entity.LiveTicket, error)
+}
+
+type LiveConsumer interface {
+	Start(ctx context.Context) error
+	Stop() error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// JobLock makes sure background jobs shared by the replicas run on one of them at a time.
+type JobLock interface {
+	// Acquire takes the lock for ttl, reporting false when another replica holds it.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	notificationv1 "github.com/huynhanx03/GoLink/events-contract/notification/v1"
+)
+
+// NotificationPublisher hands messages to the Notification service.
+type NotificationPublisher interface {
+	// Publish returns once the message is accepted by the broker.
+	Publish(ctx context.Context, evt *notificationv1.NotificationSendEvent) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+// PlaceRepository indexes the cities each link was clicked from by their coordinates.
+type PlaceRepository interface {
+	// Add records places for the link; adding a place again only moves it to the latest coordinates.
+	Add(ctx context.Context, tenantID int, shortCode string, places []*entity.Place) error
+	// Within returns the link's places inside the box.
+	Within(ctx context.Context, tenantID int, shortCode string, box *entity.BoundingBox) ([]*entity.Place, error)
+	// Erase deletes the geo indexes of the target and returns how many it deleted.
+	Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error)
+}
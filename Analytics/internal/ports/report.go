@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+type ReportRepository interface {
+	Save(ctx context.Context, schedule *entity.ReportSchedule) error
+	// Get returns nil, without error, when the schedule does not exist.
+	Get(ctx context.Context, id string) (*entity.ReportSchedule, error)
+	Delete(ctx context.Context, id string) error
+	ListByTenant(ctx context.Context, tenantID int) ([]*entity.ReportSchedule, error)
+	// ListDue returns the schedules whose next run is at or before now, most overdue first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ReportSchedule, error)
+	// Count returns how many schedules a tenant has.
+	Count(ctx context.Context, tenantID int) (int64, error)
+}
+
+type ReportService interface {
+	Create(ctx context.Context, req *dto.CreateReportRequest) (*dto.ReportResponse, error)
+	List(ctx context.Context, req *dto.ListReportsRequest) (*dto.ListReportsResponse, error)
+	Delete(ctx context.Context, req *dto.DeleteReportRequest) error
+	// RunDue sends every report whose period has ended.
+	RunDue(ctx context.Context) error
+}
+
+type ReportWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
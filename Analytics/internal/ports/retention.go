@@ -0,0 +1,58 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+// RollupRepository keeps click counts per link per hour and per day once raw clicks are compacted.
+type RollupRepository interface {
+	// SaveBulk stores rollups, replacing ones already stored for the same link and bucket.
+	SaveBulk(ctx context.Context, rollups []*entity.Rollup) error
+	// Bounds returns the first and last bucket stored at a resolution for the tenant, or for every tenant when tenantID is 0.
+	// They are zero times when there are none.
+	Bounds(ctx context.Context, resolution string, tenantID int) (time.Time, time.Time, error)
+	// SumDays rolls the hourly rollups in [from, to) up per link per UTC day, a page at a time after the given rollup.
+	SumDays(ctx context.Context, from, to time.Time, after *entity.Rollup, size int) ([]*entity.Rollup, error)
+	// Totals adds up the query's rollups of a resolution per link, or under the empty key for the whole tenant when it names no links.
+	// The last click is the start of the latest bucket.
+	Totals(ctx context.Context, resolution string, query *entity.TotalsQuery) (map[string]*entity.ClickTotals, error)
+	// Series sums the query's rollups of a resolution per bucket, oldest first, leaving buckets without rollups out.
+	Series(ctx context.Context, resolution string, query *entity.TotalsQuery) ([]*entity.Rollup, error)
+	// TopLinks sums the query's rollups of a resolution per link and returns the size most clicked, bots counted when asked.
+	TopLinks(ctx context.Context, resolution string, query *entity.TotalsQuery, includeBots bool, size int) ([]*entity.Rollup, error)
+	// Tenants returns the tenants with hourly rollups in ascending order, a page at a time after the given tenant.
+	Tenants(ctx context.Context, after int, size int) ([]int, error)
+	// DeleteBefore removes a tenant's rollups of a resolution whose bucket starts before the cutoff.
+	DeleteBefore(ctx context.Context, resolution string, tenantID int, before time.Time) (int64, error)
+	// Erase removes every rollup of the target and returns how many it removed.
+	Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error)
+}
+
+// RollupWatermarkRepository records where the rolled-up ranges end, so readers know which clicks to read from rollups.
+type RollupWatermarkRepository interface {
+	// Get returns the last watermark saved, or nil when none was.
+	Get(ctx context.Context) (*entity.RollupWatermark, error)
+	Save(ctx context.Context, watermark *entity.RollupWatermark) error
+}
+
+type BillingClient interface {
+	// GetRetention returns the retention of the tenant's plan, or nil when the tenant has no plan.
+	// Limits the plan leaves out are zero.
+	GetRetention(ctx context.Context, tenantID int) (*entity.RetentionPolicy, error)
+}
+
+type RetentionService interface {
+	// Compact rolls raw clicks up to hours and hours up to days, then removes what each tenant's plan no longer keeps.
+	Compact(ctx context.Context) error
+	Erase(ctx context.Context, req *dto.EraseRequest) (*dto.EraseResponse, error)
+	EraseTenant(ctx context.Context, req *dto.EraseTenantRequest) (*dto.EraseResponse, error)
+}
+
+type CompactionWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+type GenerationClient interface {
+	// GetCollection returns the tenant's collection with its links, or nil when the tenant has no such collection.
+	GetCollection(ctx context.Context, tenantID int, collectionID string) (*entity.Collection, error)
+}
+
+type StatsService interface {
+	// TimeSeries returns clicks over time for one link, one collection, or the whole tenant when neither is given.
+	TimeSeries(ctx context.Context, req *dto.TimeSeriesRequest) (*dto.TimeSeriesReportResponse, error)
+	// UniqueVisitors estimates distinct visitors of a link or a collection over whole UTC days.
+	UniqueVisitors(ctx context.Context, req *dto.UniqueVisitorsRequest) (*dto.UniqueVisitorsResponse, error)
+	// Breakdown returns the top referrers, sources, devices, browsers, OSes, languages, countries or cities of a link, a collection or the tenant.
+	Breakdown(ctx context.Context, req *dto.BreakdownRequest) (*dto.BreakdownResponse, error)
+	// Geo returns the most clicked cities of a link or a collection inside a bounding box.
+	Geo(ctx context.Context, req *dto.GeoRequest) (*dto.GeoResponse, error)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/analytics/internal/core/dto"
+	"go-link/analytics/internal/core/entity"
+)
+
+// UsageCache keeps computed link stats and tenant usage for a short while.
+type UsageCache interface {
+	// GetLinks returns the cached stats of the links that have them.
+	GetLinks(ctx context.Context, tenantID int, shortCodes []string) (map[string]*entity.LinkStats, error)
+	SaveLinks(ctx context.Context, stats []*entity.LinkStats, ttl time.Duration) error
+	// GetUsage returns the cached usage of the period, or nil when it is not cached.
+	GetUsage(ctx context.Context, tenantID int, from, to time.Time) (*entity.TenantUsage, error)
+	SaveUsage(ctx context.Context, usage *entity.TenantUsage, ttl time.Duration) error
+}
+
+// UsageService serves click counts to other services.
+type UsageService interface {
+	// GetLinkStats returns a link's lifetime clicks, recent distinct visitors and latest click.
+	GetLinkStats(ctx context.Context, req *dto.GetLinkStatsRequest) (*dto.GetLinkStatsResponse, error)
+	// BatchGetLinkStats returns the stats of several links of one tenant, in the order they were asked for.
+	BatchGetLinkStats(ctx context.Context, req *dto.BatchGetLinkStatsRequest) (*dto.BatchGetLinkStatsResponse, error)
+	// GetTenantUsage counts a tenant's clicks over a period, e.g. for metered billing.
+	GetTenantUsage(ctx context.Context, req *dto.GetTenantUsageRequest) (*dto.TenantUsageResponse, error)
+}
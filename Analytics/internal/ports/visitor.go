@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go-link/common/pkg/datastructs/hll"
+
+	"go-link/analytics/internal/core/entity"
+)
+
+// VisitorRepository keeps one HyperLogLog sketch of visitors per link per UTC day.
+type VisitorRepository interface {
+	// Add folds visitor hashes into the link's sketch for the day; adding a visitor twice changes nothing.
+	Add(ctx context.Context, tenantID int, shortCode string, day time.Time, hashes []uint64) error
+	// Get returns the link's sketch for each day, or nil for days without visitors.
+	Get(ctx context.Context, tenantID int, shortCode string, days []time.Time) ([]*hll.Sketch, error)
+	// Erase deletes the sketches of the target and returns how many it deleted.
+	Erase(ctx context.Context, target *entity.ErasureTarget) (int64, error)
+}
@@ -81,3 +81,30 @@ func (s *BillingServer) CancelSubscription(ctx context.Context, req *billingv1.C
 		Success: true,
 	}, nil
 }
+
+// GetTenantRetention reads the analytics retention of the tenant's plan; limits the plan leaves out are returned as 0.
+func (s *BillingServer) GetTenantRetention(ctx context.Context, req *billingv1.GetTenantRetentionRequest) (*billingv1.GetTenantRetentionResponse, error) {
+	ctx = metadata.ExtractIncomingContext(ctx)
+
+	sub, err := s.subscriptionService.GetByTenant(ctx, int(req.TenantId))
+	if err != nil {
+		if appErr, ok := err.(*apperr.AppError); ok && appErr.Code == response.CodeNotFound {
+			return nil, status.Error(codes.NotFound, "subscription not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	plan, err := s.planService.Get(ctx, sub.PlanID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &billingv1.GetTenantRetentionResponse{TenantId: req.TenantId}
+	if limit, ok := plan.Features[constant.LimitKeyRawRetentionDays].(float64); ok {
+		res.RawRetentionDays = int64(limit)
+	}
+	if limit, ok := plan.Features[constant.LimitKeyHourlyRetentionMonths].(float64); ok {
+		res.HourlyRetentionMonths = int64(limit)
+	}
+	return res, nil
+}
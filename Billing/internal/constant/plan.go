@@ -5,6 +5,10 @@ const (
 	LimitKeyTTL            = "ttl"
 	LimitKeyCustomerDomain = "customer_domain"
 
+	// Analytics retention; -1 keeps data forever
+	LimitKeyRawRetentionDays      = "raw_retention_days"
+	LimitKeyHourlyRetentionMonths = "hourly_retention_months"
+
 	PlanPeriodMonth   = "month"
 	PlanPeriodYear    = "year"
 	PlanPeriodForever = "forever"
@@ -81,6 +81,15 @@ func (s *subscriptionService) Create(ctx context.Context, req *dto.CreateSubscri
 	return mapper.ToSubscriptionResponse(sub), nil
 }
 
+// GetByTenant retrieves the subscription of a tenant.
+func (s *subscriptionService) GetByTenant(ctx context.Context, tenantID int) (*dto.SubscriptionResponse, error) {
+	sub, err := s.subscriptionRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToSubscriptionResponse(sub), nil
+}
+
 // UpdateByTenant updates a subscription by tenant ID.
 func (s *subscriptionService) UpdateByTenant(ctx context.Context, tenantID int, req *dto.UpdateSubscriptionRequest) (*dto.SubscriptionResponse, error) {
 	sub, err := s.subscriptionRepo.GetByTenantID(ctx, tenantID)
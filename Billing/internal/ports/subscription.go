@@ -22,5 +22,6 @@ type SubscriptionService interface {
 	Create(ctx context.Context, req *dto.CreateSubscriptionRequest) (*dto.SubscriptionResponse, error)
 	Update(ctx context.Context, id int, req *dto.UpdateSubscriptionRequest) (*dto.SubscriptionResponse, error)
 	UpdateByTenant(ctx context.Context, tenantID int, req *dto.UpdateSubscriptionRequest) (*dto.SubscriptionResponse, error)
+	GetByTenant(ctx context.Context, tenantID int) (*dto.SubscriptionResponse, error)
 	Delete(ctx context.Context, id int) error
 }
@@ -48,9 +48,9 @@ CREATE TABLE IF NOT EXISTS invoices (
 -- insert data
 
 INSERT INTO plans (id, name, description, base_price, period, features, is_active, created_at, updated_at, deleted_at, deleted_by) VALUES
-(1, 'Free', 'Perfect for getting started', 0.00, 'forever', '{"max_links": 10, "ttl": 604800}', true, NOW(), NOW(), NULL, NULL),
-(2, 'Pro', 'For power users and creators', 9.99, 'month', '{"max_links": 100, "ttl": 2592000}', true, NOW(), NOW(), NULL, NULL),
-(3, 'Enterprise', 'For large teams and businesses', 99.99, 'month', '{"max_links": 1000, "ttl": 31536000, "customer_domain": true}', true, NOW(), NOW(), NULL, NULL)
+(1, 'Free', 'Perfect for getting started', 0.00, 'forever', '{"max_links": 10, "ttl": 604800, "raw_retention_days": 30, "hourly_retention_months": 3}', true, NOW(), NOW(), NULL, NULL),
+(2, 'Pro', 'For power users and creators', 9.99, 'month', '{"max_links": 100, "ttl": 2592000, "raw_retention_days": 90, "hourly_retention_months": 13}', true, NOW(), NOW(), NULL, NULL),
+(3, 'Enterprise', 'For large teams and businesses', 99.99, 'month', '{"max_links": 1000, "ttl": 31536000, "customer_domain": true, "raw_retention_days": 365, "hourly_retention_months": -1}', true, NOW(), NOW(), NULL, NULL)
 ON CONFLICT (id) DO NOTHING;
 
 -- reset sequences
@@ -0,0 +1,745 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: analytics/v1/service.proto
+
+package analyticsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Lifetime clicks of a link, human and bot apart, with its distinct visitors over the last 30 days
+type LinkStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShortCode      string                 `protobuf:"bytes,1,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Clicks         int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	BotClicks      int64                  `protobuf:"varint,3,opt,name=bot_clicks,json=botClicks,proto3" json:"bot_clicks,omitempty"`
+	UniqueVisitors uint64                 `protobuf:"varint,4,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	// Unix milliseconds, 0 when the link was never clicked; only hour- or day-precise once its raw clicks have expired
+	LastClickedAt int64 `protobuf:"varint,5,opt,name=last_clicked_at,json=lastClickedAt,proto3" json:"last_clicked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkStats) Reset() {
+	*x = LinkStats{}
+	mi := &file_analytics_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStats) ProtoMessage() {}
+
+func (x *LinkStats) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStats.ProtoReflect.Descriptor instead.
+func (*LinkStats) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LinkStats) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *LinkStats) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *LinkStats) GetBotClicks() int64 {
+	if x != nil {
+		return x.BotClicks
+	}
+	return 0
+}
+
+func (x *LinkStats) GetUniqueVisitors() uint64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *LinkStats) GetLastClickedAt() int64 {
+	if x != nil {
+		return x.LastClickedAt
+	}
+	return 0
+}
+
+type GetLinkStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ShortCode     string                 `protobuf:"bytes,2,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkStatsRequest) Reset() {
+	*x = GetLinkStatsRequest{}
+	mi := &file_analytics_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkStatsRequest) ProtoMessage() {}
+
+func (x *GetLinkStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkStatsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLinkStatsRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *GetLinkStatsRequest) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+// as_of is when the stats were computed, in Unix milliseconds; callers may cache them until max_age_seconds after it
+type GetLinkStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *LinkStats             `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	AsOf          int64                  `protobuf:"varint,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	MaxAgeSeconds int64                  `protobuf:"varint,3,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkStatsResponse) Reset() {
+	*x = GetLinkStatsResponse{}
+	mi := &file_analytics_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkStatsResponse) ProtoMessage() {}
+
+func (x *GetLinkStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkStatsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLinkStatsResponse) GetStats() *LinkStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *GetLinkStatsResponse) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+type BatchGetLinkStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ShortCodes    []string               `protobuf:"bytes,2,rep,name=short_codes,json=shortCodes,proto3" json:"short_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetLinkStatsRequest) Reset() {
+	*x = BatchGetLinkStatsRequest{}
+	mi := &file_analytics_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetLinkStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetLinkStatsRequest) ProtoMessage() {}
+
+func (x *BatchGetLinkStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetLinkStatsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetLinkStatsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetLinkStatsRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *BatchGetLinkStatsRequest) GetShortCodes() []string {
+	if x != nil {
+		return x.ShortCodes
+	}
+	return nil
+}
+
+// Stats are in the order the short codes were asked for; links never clicked have zero counts.
+// as_of is when the oldest of them was computed.
+type BatchGetLinkStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*LinkStats           `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	AsOf          int64                  `protobuf:"varint,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	MaxAgeSeconds int64                  `protobuf:"varint,3,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetLinkStatsResponse) Reset() {
+	*x = BatchGetLinkStatsResponse{}
+	mi := &file_analytics_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetLinkStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetLinkStatsResponse) ProtoMessage() {}
+
+func (x *BatchGetLinkStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetLinkStatsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetLinkStatsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetLinkStatsResponse) GetStats() []*LinkStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *BatchGetLinkStatsResponse) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *BatchGetLinkStatsResponse) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+// Usage over [from, to), both Unix milliseconds, e.g. a billing period
+type GetTenantUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	From          int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantUsageRequest) Reset() {
+	*x = GetTenantUsageRequest{}
+	mi := &file_analytics_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantUsageRequest) ProtoMessage() {}
+
+func (x *GetTenantUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantUsageRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTenantUsageRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *GetTenantUsageRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetTenantUsageRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// complete is set once the period's counts can no longer change, so they are safe to invoice
+type GetTenantUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	From          int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Clicks        int64                  `protobuf:"varint,4,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	BotClicks     int64                  `protobuf:"varint,5,opt,name=bot_clicks,json=botClicks,proto3" json:"bot_clicks,omitempty"`
+	Complete      bool                   `protobuf:"varint,6,opt,name=complete,proto3" json:"complete,omitempty"`
+	AsOf          int64                  `protobuf:"varint,7,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	MaxAgeSeconds int64                  `protobuf:"varint,8,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantUsageResponse) Reset() {
+	*x = GetTenantUsageResponse{}
+	mi := &file_analytics_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantUsageResponse) ProtoMessage() {}
+
+func (x *GetTenantUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetTenantUsageResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTenantUsageResponse) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetBotClicks() int64 {
+	if x != nil {
+		return x.BotClicks
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *GetTenantUsageResponse) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+// Narrows the counters to one link or campaign; bot clicks are left out unless asked for
+type WatchCountersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ShortCode     string                 `protobuf:"bytes,2,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Campaign      string                 `protobuf:"bytes,3,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	IncludeBots   bool                   `protobuf:"varint,4,opt,name=include_bots,json=includeBots,proto3" json:"include_bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCountersRequest) Reset() {
+	*x = WatchCountersRequest{}
+	mi := &file_analytics_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCountersRequest) ProtoMessage() {}
+
+func (x *WatchCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCountersRequest.ProtoReflect.Descriptor instead.
+func (*WatchCountersRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchCountersRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *WatchCountersRequest) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *WatchCountersRequest) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *WatchCountersRequest) GetIncludeBots() bool {
+	if x != nil {
+		return x.IncludeBots
+	}
+	return false
+}
+
+// Clicks in the last second and over the last minute; time is Unix milliseconds
+type Counter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	LastMinute    int64                  `protobuf:"varint,3,opt,name=last_minute,json=lastMinute,proto3" json:"last_minute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Counter) Reset() {
+	*x = Counter{}
+	mi := &file_analytics_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Counter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Counter) ProtoMessage() {}
+
+func (x *Counter) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Counter.ProtoReflect.Descriptor instead.
+func (*Counter) Descriptor() ([]byte, []int) {
+	return file_analytics_v1_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Counter) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Counter) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *Counter) GetLastMinute() int64 {
+	if x != nil {
+		return x.LastMinute
+	}
+	return 0
+}
+
+var File_analytics_v1_service_proto protoreflect.FileDescriptor
+
+const file_analytics_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1aanalytics/v1/service.proto\x12\fanalytics.v1\"\xb2\x01\n" +
+	"\tLinkStats\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x01 \x01(\tR\tshortCode\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12\x1d\n" +
+	"\n" +
+	"bot_clicks\x18\x03 \x01(\x03R\tbotClicks\x12'\n" +
+	"\x0funique_visitors\x18\x04 \x01(\x04R\x0euniqueVisitors\x12&\n" +
+	"\x0flast_clicked_at\x18\x05 \x01(\x03R\rlastClickedAt\"Q\n" +
+	"\x13GetLinkStatsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x02 \x01(\tR\tshortCode\"\x82\x01\n" +
+	"\x14GetLinkStatsResponse\x12-\n" +
+	"\x05stats\x18\x01 \x01(\v2\x17.analytics.v1.LinkStatsR\x05stats\x12\x13\n" +
+	"\x05as_of\x18\x02 \x01(\x03R\x04asOf\x12&\n" +
+	"\x0fmax_age_seconds\x18\x03 \x01(\x03R\rmaxAgeSeconds\"X\n" +
+	"\x18BatchGetLinkStatsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x1f\n" +
+	"\vshort_codes\x18\x02 \x03(\tR\n" +
+	"shortCodes\"\x87\x01\n" +
+	"\x19BatchGetLinkStatsResponse\x12-\n" +
+	"\x05stats\x18\x01 \x03(\v2\x17.analytics.v1.LinkStatsR\x05stats\x12\x13\n" +
+	"\x05as_of\x18\x02 \x01(\x03R\x04asOf\x12&\n" +
+	"\x0fmax_age_seconds\x18\x03 \x01(\x03R\rmaxAgeSeconds\"X\n" +
+	"\x15GetTenantUsageRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\"\xe9\x01\n" +
+	"\x16GetTenantUsageResponse\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12\x16\n" +
+	"\x06clicks\x18\x04 \x01(\x03R\x06clicks\x12\x1d\n" +
+	"\n" +
+	"bot_clicks\x18\x05 \x01(\x03R\tbotClicks\x12\x1a\n" +
+	"\bcomplete\x18\x06 \x01(\bR\bcomplete\x12\x13\n" +
+	"\x05as_of\x18\a \x01(\x03R\x04asOf\x12&\n" +
+	"\x0fmax_age_seconds\x18\b \x01(\x03R\rmaxAgeSeconds\"\x91\x01\n" +
+	"\x14WatchCountersRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x02 \x01(\tR\tshortCode\x12\x1a\n" +
+	"\bcampaign\x18\x03 \x01(\tR\bcampaign\x12!\n" +
+	"\finclude_bots\x18\x04 \x01(\bR\vincludeBots\"V\n" +
+	"\aCounter\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12\x1f\n" +
+	"\vlast_minute\x18\x03 \x01(\x03R\n" +
+	"lastMinute2\xfa\x02\n" +
+	"\x10AnalyticsService\x12U\n" +
+	"\fGetLinkStats\x12!.analytics.v1.GetLinkStatsRequest\x1a\".analytics.v1.GetLinkStatsResponse\x12d\n" +
+	"\x11BatchGetLinkStats\x12&.analytics.v1.BatchGetLinkStatsRequest\x1a'.analytics.v1.BatchGetLinkStatsResponse\x12[\n" +
+	"\x0eGetTenantUsage\x12#.analytics.v1.GetTenantUsageRequest\x1a$.analytics.v1.GetTenantUsageResponse\x12L\n" +
+	"\rWatchCounters\x12\".analytics.v1.WatchCountersRequest\x1a\x15.analytics.v1.Counter0\x01B0Z.go-link/common/gen/go/analytics/v1;analyticsv1b\x06proto3"
+
+var (
+	file_analytics_v1_service_proto_rawDescOnce sync.Once
+	file_analytics_v1_service_proto_rawDescData []byte
+)
+
+func file_analytics_v1_service_proto_rawDescGZIP() []byte {
+	file_analytics_v1_service_proto_rawDescOnce.Do(func() {
+		file_analytics_v1_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analytics_v1_service_proto_rawDesc), len(file_analytics_v1_service_proto_rawDesc)))
+	})
+	return file_analytics_v1_service_proto_rawDescData
+}
+
+var file_analytics_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_analytics_v1_service_proto_goTypes = []any{
+	(*LinkStats)(nil),                 // 0: analytics.v1.LinkStats
+	(*GetLinkStatsRequest)(nil),       // 1: analytics.v1.GetLinkStatsRequest
+	(*GetLinkStatsResponse)(nil),      // 2: analytics.v1.GetLinkStatsResponse
+	(*BatchGetLinkStatsRequest)(nil),  // 3: analytics.v1.BatchGetLinkStatsRequest
+	(*BatchGetLinkStatsResponse)(nil), // 4: analytics.v1.BatchGetLinkStatsResponse
+	(*GetTenantUsageRequest)(nil),     // 5: analytics.v1.GetTenantUsageRequest
+	(*GetTenantUsageResponse)(nil),    // 6: analytics.v1.GetTenantUsageResponse
+	(*WatchCountersRequest)(nil),      // 7: analytics.v1.WatchCountersRequest
+	(*Counter)(nil),                   // 8: analytics.v1.Counter
+}
+var file_analytics_v1_service_proto_depIdxs = []int32{
+	0, // 0: analytics.v1.GetLinkStatsResponse.stats:type_name -> analytics.v1.LinkStats
+	0, // 1: analytics.v1.BatchGetLinkStatsResponse.stats:type_name -> analytics.v1.LinkStats
+	1, // 2: analytics.v1.AnalyticsService.GetLinkStats:input_type -> analytics.v1.GetLinkStatsRequest
+	3, // 3: analytics.v1.AnalyticsService.BatchGetLinkStats:input_type -> analytics.v1.BatchGetLinkStatsRequest
+	5, // 4: analytics.v1.AnalyticsService.GetTenantUsage:input_type -> analytics.v1.GetTenantUsageRequest
+	7, // 5: analytics.v1.AnalyticsService.WatchCounters:input_type -> analytics.v1.WatchCountersRequest
+	2, // 6: analytics.v1.AnalyticsService.GetLinkStats:output_type -> analytics.v1.GetLinkStatsResponse
+	4, // 7: analytics.v1.AnalyticsService.BatchGetLinkStats:output_type -> analytics.v1.BatchGetLinkStatsResponse
+	6, // 8: analytics.v1.AnalyticsService.GetTenantUsage:output_type -> analytics.v1.GetTenantUsageResponse
+	8, // 9: analytics.v1.AnalyticsService.WatchCounters:output_type -> analytics.v1.Counter
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_analytics_v1_service_proto_init() }
+func file_analytics_v1_service_proto_init() {
+	if File_analytics_v1_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analytics_v1_service_proto_rawDesc), len(file_analytics_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analytics_v1_service_proto_goTypes,
+		DependencyIndexes: file_analytics_v1_service_proto_depIdxs,
+		MessageInfos:      file_analytics_v1_service_proto_msgTypes,
+	}.Build()
+	File_analytics_v1_service_proto = out.File
+	file_analytics_v1_service_proto_goTypes = nil
+	file_analytics_v1_service_proto_depIdxs = nil
+}
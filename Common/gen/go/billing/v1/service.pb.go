@@ -301,6 +301,111 @@ func (x *CancelSubscriptionResponse) GetSuccess() bool {
 	return false
 }
 
+type GetTenantRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantRetentionRequest) Reset() {
+	*x = GetTenantRetentionRequest{}
+	mi := &file_billing_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantRetentionRequest) ProtoMessage() {}
+
+func (x *GetTenantRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantRetentionRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_billing_v1_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTenantRetentionRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+// Retention of a tenant's analytics under its plan: 0 leaves the service default, -1 keeps data forever.
+type GetTenantRetentionResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TenantId              int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	RawRetentionDays      int64                  `protobuf:"varint,2,opt,name=raw_retention_days,json=rawRetentionDays,proto3" json:"raw_retention_days,omitempty"`
+	HourlyRetentionMonths int64                  `protobuf:"varint,3,opt,name=hourly_retention_months,json=hourlyRetentionMonths,proto3" json:"hourly_retention_months,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetTenantRetentionResponse) Reset() {
+	*x = GetTenantRetentionResponse{}
+	mi := &file_billing_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantRetentionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantRetentionResponse) ProtoMessage() {}
+
+func (x *GetTenantRetentionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantRetentionResponse.ProtoReflect.Descriptor instead.
+func (*GetTenantRetentionResponse) Descriptor() ([]byte, []int) {
+	return file_billing_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTenantRetentionResponse) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *GetTenantRetentionResponse) GetRawRetentionDays() int64 {
+	if x != nil {
+		return x.RawRetentionDays
+	}
+	return 0
+}
+
+func (x *GetTenantRetentionResponse) GetHourlyRetentionMonths() int64 {
+	if x != nil {
+		return x.HourlyRetentionMonths
+	}
+	return 0
+}
+
 var File_billing_v1_service_proto protoreflect.FileDescriptor
 
 const file_billing_v1_service_proto_rawDesc = "" +
@@ -320,11 +425,18 @@ const file_billing_v1_service_proto_rawDesc = "" +
 	"\x19CancelSubscriptionRequest\x12'\n" +
 	"\x0fsubscription_id\x18\x01 \x01(\x03R\x0esubscriptionId\"6\n" +
 	"\x1aCancelSubscriptionResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xb0\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x19GetTenantRetentionRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\"\x9f\x01\n" +
+	"\x1aGetTenantRetentionResponse\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12,\n" +
+	"\x12raw_retention_days\x18\x02 \x01(\x03R\x10rawRetentionDays\x126\n" +
+	"\x17hourly_retention_months\x18\x03 \x01(\x03R\x15hourlyRetentionMonths2\x95\x03\n" +
 	"\x0eBillingService\x12T\n" +
 	"\rGetTierConfig\x12 .billing.v1.GetTierConfigRequest\x1a!.billing.v1.GetTierConfigResponse\x12c\n" +
 	"\x12CreateSubscription\x12%.billing.v1.CreateSubscriptionRequest\x1a&.billing.v1.CreateSubscriptionResponse\x12c\n" +
-	"\x12CancelSubscription\x12%.billing.v1.CancelSubscriptionRequest\x1a&.billing.v1.CancelSubscriptionResponseB,Z*go-link/common/gen/go/billing/v1;billingv1b\x06proto3"
+	"\x12CancelSubscription\x12%.billing.v1.CancelSubscriptionRequest\x1a&.billing.v1.CancelSubscriptionResponse\x12c\n" +
+	"\x12GetTenantRetention\x12%.billing.v1.GetTenantRetentionRequest\x1a&.billing.v1.GetTenantRetentionResponseB,Z*go-link/common/gen/go/billing/v1;billingv1b\x06proto3"
 
 var (
 	file_billing_v1_service_proto_rawDescOnce sync.Once
@@ -338,7 +450,7 @@ func file_billing_v1_service_proto_rawDescGZIP() []byte {
 	return file_billing_v1_service_proto_rawDescData
 }
 
-var file_billing_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_billing_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_billing_v1_service_proto_goTypes = []any{
 	(*GetTierConfigRequest)(nil),       // 0: billing.v1.GetTierConfigRequest
 	(*GetTierConfigResponse)(nil),      // 1: billing.v1.GetTierConfigResponse
@@ -346,16 +458,20 @@ var file_billing_v1_service_proto_goTypes = []any{
 	(*CreateSubscriptionResponse)(nil), // 3: billing.v1.CreateSubscriptionResponse
 	(*CancelSubscriptionRequest)(nil),  // 4: billing.v1.CancelSubscriptionRequest
 	(*CancelSubscriptionResponse)(nil), // 5: billing.v1.CancelSubscriptionResponse
+	(*GetTenantRetentionRequest)(nil),  // 6: billing.v1.GetTenantRetentionRequest
+	(*GetTenantRetentionResponse)(nil), // 7: billing.v1.GetTenantRetentionResponse
 }
 var file_billing_v1_service_proto_depIdxs = []int32{
 	0, // 0: billing.v1.BillingService.GetTierConfig:input_type -> billing.v1.GetTierConfigRequest
 	2, // 1: billing.v1.BillingService.CreateSubscription:input_type -> billing.v1.CreateSubscriptionRequest
 	4, // 2: billing.v1.BillingService.CancelSubscription:input_type -> billing.v1.CancelSubscriptionRequest
-	1, // 3: billing.v1.BillingService.GetTierConfig:output_type -> billing.v1.GetTierConfigResponse
-	3, // 4: billing.v1.BillingService.CreateSubscription:output_type -> billing.v1.CreateSubscriptionResponse
-	5, // 5: billing.v1.BillingService.CancelSubscription:output_type -> billing.v1.CancelSubscriptionResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
+	6, // 3: billing.v1.BillingService.GetTenantRetention:input_type -> billing.v1.GetTenantRetentionRequest
+	1, // 4: billing.v1.BillingService.GetTierConfig:output_type -> billing.v1.GetTierConfigResponse
+	3, // 5: billing.v1.BillingService.CreateSubscription:output_type -> billing.v1.CreateSubscriptionResponse
+	5, // 6: billing.v1.BillingService.CancelSubscription:output_type -> billing.v1.CancelSubscriptionResponse
+	7, // 7: billing.v1.BillingService.GetTenantRetention:output_type -> billing.v1.GetTenantRetentionResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -372,7 +488,7 @@ func file_billing_v1_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_billing_v1_service_proto_rawDesc), len(file_billing_v1_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
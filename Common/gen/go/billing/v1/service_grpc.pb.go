@@ -22,6 +22,7 @@ const (
 	BillingService_GetTierConfig_FullMethodName      = "/billing.v1.BillingService/GetTierConfig"
 	BillingService_CreateSubscription_FullMethodName = "/billing.v1.BillingService/CreateSubscription"
 	BillingService_CancelSubscription_FullMethodName = "/billing.v1.BillingService/CancelSubscription"
+	BillingService_GetTenantRetention_FullMethodName = "/billing.v1.BillingService/GetTenantRetention"
 )
 
 // BillingServiceClient is the client API for BillingService service.
@@ -31,6 +32,7 @@ type BillingServiceClient interface {
 	GetTierConfig(ctx context.Context, in *GetTierConfigRequest, opts ...grpc.CallOption) (*GetTierConfigResponse, error)
 	CreateSubscription(ctx context.Context, in *CreateSubscriptionRequest, opts ...grpc.CallOption) (*CreateSubscriptionResponse, error)
 	CancelSubscription(ctx context.Context, in *CancelSubscriptionRequest, opts ...grpc.CallOption) (*CancelSubscriptionResponse, error)
+	GetTenantRetention(ctx context.Context, in *GetTenantRetentionRequest, opts ...grpc.CallOption) (*GetTenantRetentionResponse, error)
 }
 
 type billingServiceClient struct {
@@ -71,6 +73,16 @@ func (c *billingServiceClient) CancelSubscription(ctx context.Context, in *Cance
 	return out, nil
 }
 
+func (c *billingServiceClient) GetTenantRetention(ctx context.Context, in *GetTenantRetentionRequest, opts ...grpc.CallOption) (*GetTenantRetentionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTenantRetentionResponse)
+	err := c.cc.Invoke(ctx, BillingService_GetTenantRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BillingServiceServer is the server API for BillingService service.
 // All implementations must embed UnimplementedBillingServiceServer
 // for forward compatibility.
@@ -78,6 +90,7 @@ type BillingServiceServer interface {
 	GetTierConfig(context.Context, *GetTierConfigRequest) (*GetTierConfigResponse, error)
 	CreateSubscription(context.Context, *CreateSubscriptionRequest) (*CreateSubscriptionResponse, error)
 	CancelSubscription(context.Context, *CancelSubscriptionRequest) (*CancelSubscriptionResponse, error)
+	GetTenantRetention(context.Context, *GetTenantRetentionRequest) (*GetTenantRetentionResponse, error)
 	mustEmbedUnimplementedBillingServiceServer()
 }
 
@@ -97,6 +110,9 @@ func (UnimplementedBillingServiceServer) CreateSubscription(context.Context, *Cr
 func (UnimplementedBillingServiceServer) CancelSubscription(context.Context, *CancelSubscriptionRequest) (*CancelSubscriptionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CancelSubscription not implemented")
 }
+func (UnimplementedBillingServiceServer) GetTenantRetention(context.Context, *GetTenantRetentionRequest) (*GetTenantRetentionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTenantRetention not implemented")
+}
 func (UnimplementedBillingServiceServer) mustEmbedUnimplementedBillingServiceServer() {}
 func (UnimplementedBillingServiceServer) testEmbeddedByValue()                        {}
 
@@ -172,6 +188,24 @@ func _BillingService_CancelSubscription_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BillingService_GetTenantRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).GetTenantRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_GetTenantRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).GetTenantRetention(ctx, req.(*GetTenantRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BillingService_ServiceDesc is the grpc.ServiceDesc for BillingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,6 +225,10 @@ var BillingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelSubscription",
 			Handler:    _BillingService_CancelSubscription_Handler,
 		},
+		{
+			MethodName: "GetTenantRetention",
+			Handler:    _BillingService_GetTenantRetention_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "billing/v1/service.proto",
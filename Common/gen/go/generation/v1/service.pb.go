@@ -0,0 +1,436 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: generation/v1/service.proto
+
+package generationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Link struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	UserId      int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TenantId    int64                  `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Unix milliseconds, 0 when the link never expires
+	ExpiresAt        int64  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Disabled         bool   `protobuf:"varint,6,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	PasswordHash     string `protobuf:"bytes,7,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	RequireSignature bool   `protobuf:"varint,8,opt,name=require_signature,json=requireSignature,proto3" json:"require_signature,omitempty"`
+	Visibility       string `protobuf:"bytes,9,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	// Unix milliseconds
+	CreatedAt     int64 `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64 `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AppendClickId bool  `protobuf:"varint,12,opt,name=append_click_id,json=appendClickId,proto3" json:"append_click_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_generation_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_generation_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_generation_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Link) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Link) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *Link) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Link) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *Link) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Link) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Link) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *Link) GetRequireSignature() bool {
+	if x != nil {
+		return x.RequireSignature
+	}
+	return false
+}
+
+func (x *Link) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *Link) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Link) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Link) GetAppendClickId() bool {
+	if x != nil {
+		return x.AppendClickId
+	}
+	return false
+}
+
+type GetLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkRequest) Reset() {
+	*x = GetLinkRequest{}
+	mi := &file_generation_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkRequest) ProtoMessage() {}
+
+func (x *GetLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_generation_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkRequest) Descriptor() ([]byte, []int) {
+	return file_generation_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLinkRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *Link                  `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkResponse) Reset() {
+	*x = GetLinkResponse{}
+	mi := &file_generation_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkResponse) ProtoMessage() {}
+
+func (x *GetLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_generation_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkResponse) Descriptor() ([]byte, []int) {
+	return file_generation_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLinkResponse) GetLink() *Link {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+// Lists the links of a tenant's collection; a collection of another tenant is reported as not found
+type ListCollectionLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionLinksRequest) Reset() {
+	*x = ListCollectionLinksRequest{}
+	mi := &file_generation_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionLinksRequest) ProtoMessage() {}
+
+func (x *ListCollectionLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_generation_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionLinksRequest) Descriptor() ([]byte, []int) {
+	return file_generation_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCollectionLinksRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ListCollectionLinksRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+// link_ids may include links that have since expired
+type ListCollectionLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinkIds       []string               `protobuf:"bytes,1,rep,name=link_ids,json=linkIds,proto3" json:"link_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionLinksResponse) Reset() {
+	*x = ListCollectionLinksResponse{}
+	mi := &file_generation_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionLinksResponse) ProtoMessage() {}
+
+func (x *ListCollectionLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_generation_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionLinksResponse) Descriptor() ([]byte, []int) {
+	return file_generation_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCollectionLinksResponse) GetLinkIds() []string {
+	if x != nil {
+		return x.LinkIds
+	}
+	return nil
+}
+
+var File_generation_v1_service_proto protoreflect.FileDescriptor
+
+const file_generation_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1bgeneration/v1/service.proto\x12\rgeneration.v1\"\x82\x03\n" +
+	"\x04Link\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\x03R\btenantId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12\x1a\n" +
+	"\bdisabled\x18\x06 \x01(\bR\bdisabled\x12#\n" +
+	"\rpassword_hash\x18\a \x01(\tR\fpasswordHash\x12+\n" +
+	"\x11require_signature\x18\b \x01(\bR\x10requireSignature\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\t \x01(\tR\n" +
+	"visibility\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\x03R\tupdatedAt\x12&\n" +
+	"\x0fappend_click_id\x18\f \x01(\bR\rappendClickId\" \n" +
+	"\x0eGetLinkRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
+	"\x0fGetLinkResponse\x12'\n" +
+	"\x04link\x18\x01 \x01(\v2\x13.generation.v1.LinkR\x04link\"^\n" +
+	"\x1aListCollectionLinksRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\"8\n" +
+	"\x1bListCollectionLinksResponse\x12\x19\n" +
+	"\blink_ids\x18\x01 \x03(\tR\alinkIds2\xcb\x01\n" +
+	"\x11GenerationService\x12H\n" +
+	"\aGetLink\x12\x1d.generation.v1.GetLinkRequest\x1a\x1e.generation.v1.GetLinkResponse\x12l\n" +
+	"\x13ListCollectionLinks\x12).generation.v1.ListCollectionLinksRequest\x1a*.generation.v1.ListCollectionLinksResponseB2Z0go-link/common/gen/go/generation/v1;generationv1b\x06proto3"
+
+var (
+	file_generation_v1_service_proto_rawDescOnce sync.Once
+	file_generation_v1_service_proto_rawDescData []byte
+)
+
+func file_generation_v1_service_proto_rawDescGZIP() []byte {
+	file_generation_v1_service_proto_rawDescOnce.Do(func() {
+		file_generation_v1_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_generation_v1_service_proto_rawDesc), len(file_generation_v1_service_proto_rawDesc)))
+	})
+	return file_generation_v1_service_proto_rawDescData
+}
+
+var file_generation_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_generation_v1_service_proto_goTypes = []any{
+	(*Link)(nil),                        // 0: generation.v1.Link
+	(*GetLinkRequest)(nil),              // 1: generation.v1.GetLinkRequest
+	(*GetLinkResponse)(nil),             // 2: generation.v1.GetLinkResponse
+	(*ListCollectionLinksRequest)(nil),  // 3: generation.v1.ListCollectionLinksRequest
+	(*ListCollectionLinksResponse)(nil), // 4: generation.v1.ListCollectionLinksResponse
+}
+var file_generation_v1_service_proto_depIdxs = []int32{
+	0, // 0: generation.v1.GetLinkResponse.link:type_name -> generation.v1.Link
+	1, // 1: generation.v1.GenerationService.GetLink:input_type -> generation.v1.GetLinkRequest
+	3, // 2: generation.v1.GenerationService.ListCollectionLinks:input_type -> generation.v1.ListCollectionLinksRequest
+	2, // 3: generation.v1.GenerationService.GetLink:output_type -> generation.v1.GetLinkResponse
+	4, // 4: generation.v1.GenerationService.ListCollectionLinks:output_type -> generation.v1.ListCollectionLinksResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_generation_v1_service_proto_init() }
+func file_generation_v1_service_proto_init() {
+	if File_generation_v1_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_generation_v1_service_proto_rawDesc), len(file_generation_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_generation_v1_service_proto_goTypes,
+		DependencyIndexes: file_generation_v1_service_proto_depIdxs,
+		MessageInfos:      file_generation_v1_service_proto_msgTypes,
+	}.Build()
+	File_generation_v1_service_proto = out.File
+	file_generation_v1_service_proto_goTypes = nil
+	file_generation_v1_service_proto_depIdxs = nil
+}
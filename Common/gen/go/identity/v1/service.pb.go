@@ -489,6 +489,102 @@ func (x *UpdateTenantPlanResponse) GetSuccess() bool {
 	return false
 }
 
+type ResolveDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDomainRequest) Reset() {
+	*x = ResolveDomainRequest{}
+	mi := &file_identity_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDomainRequest) ProtoMessage() {}
+
+func (x *ResolveDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDomainRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDomainRequest) Descriptor() ([]byte, []int) {
+	return file_identity_v1_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResolveDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type ResolveDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	IsVerified    bool                   `protobuf:"varint,2,opt,name=is_verified,json=isVerified,proto3" json:"is_verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDomainResponse) Reset() {
+	*x = ResolveDomainResponse{}
+	mi := &file_identity_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDomainResponse) ProtoMessage() {}
+
+func (x *ResolveDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDomainResponse.ProtoReflect.Descriptor instead.
+func (*ResolveDomainResponse) Descriptor() ([]byte, []int) {
+	return file_identity_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResolveDomainResponse) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ResolveDomainResponse) GetIsVerified() bool {
+	if x != nil {
+		return x.IsVerified
+	}
+	return false
+}
+
 var File_identity_v1_service_proto protoreflect.FileDescriptor
 
 const file_identity_v1_service_proto_rawDesc = "" +
@@ -521,14 +617,21 @@ const file_identity_v1_service_proto_rawDesc = "" +
 	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x17\n" +
 	"\aplan_id\x18\x02 \x01(\x03R\x06planId\"4\n" +
 	"\x18UpdateTenantPlanResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xe2\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\".\n" +
+	"\x14ResolveDomainRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\"U\n" +
+	"\x15ResolveDomainResponse\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x1f\n" +
+	"\vis_verified\x18\x02 \x01(\bR\n" +
+	"isVerified2\xba\x03\n" +
 	"\x0fIdentityService\x12P\n" +
 	"\vGetUserRole\x12\x1f.identity.v1.GetUserRoleRequest\x1a .identity.v1.GetUserRoleResponse\x12M\n" +
 	"\n" +
 	"CreateUser\x12\x1e.identity.v1.CreateUserRequest\x1a\x1f.identity.v1.CreateUserResponse\x12M\n" +
 	"\n" +
 	"DeleteUser\x12\x1e.identity.v1.DeleteUserRequest\x1a\x1f.identity.v1.DeleteUserResponse\x12_\n" +
-	"\x10UpdateTenantPlan\x12$.identity.v1.UpdateTenantPlanRequest\x1a%.identity.v1.UpdateTenantPlanResponseB+Z)go-link/common/gen/identity/v1;identityv1b\x06proto3"
+	"\x10UpdateTenantPlan\x12$.identity.v1.UpdateTenantPlanRequest\x1a%.identity.v1.UpdateTenantPlanResponse\x12V\n" +
+	"\rResolveDomain\x12!.identity.v1.ResolveDomainRequest\x1a\".identity.v1.ResolveDomainResponseB+Z)go-link/common/gen/identity/v1;identityv1b\x06proto3"
 
 var (
 	file_identity_v1_service_proto_rawDescOnce sync.Once
@@ -542,7 +645,7 @@ func file_identity_v1_service_proto_rawDescGZIP() []byte {
 	return file_identity_v1_service_proto_rawDescData
 }
 
-var file_identity_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_identity_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_identity_v1_service_proto_goTypes = []any{
 	(*GetUserRoleRequest)(nil),       // 0: identity.v1.GetUserRoleRequest
 	(*GetUserRoleResponse)(nil),      // 1: identity.v1.GetUserRoleResponse
@@ -553,22 +656,26 @@ var file_identity_v1_service_proto_goTypes = []any{
 	(*DeleteUserResponse)(nil),       // 6: identity.v1.DeleteUserResponse
 	(*UpdateTenantPlanRequest)(nil),  // 7: identity.v1.UpdateTenantPlanRequest
 	(*UpdateTenantPlanResponse)(nil), // 8: identity.v1.UpdateTenantPlanResponse
+	(*ResolveDomainRequest)(nil),     // 9: identity.v1.ResolveDomainRequest
+	(*ResolveDomainResponse)(nil),    // 10: identity.v1.ResolveDomainResponse
 }
 var file_identity_v1_service_proto_depIdxs = []int32{
-	2, // 0: identity.v1.GetUserRoleResponse.role:type_name -> identity.v1.Role
-	0, // 1: identity.v1.IdentityService.GetUserRole:input_type -> identity.v1.GetUserRoleRequest
-	3, // 2: identity.v1.IdentityService.CreateUser:input_type -> identity.v1.CreateUserRequest
-	5, // 3: identity.v1.IdentityService.DeleteUser:input_type -> identity.v1.DeleteUserRequest
-	7, // 4: identity.v1.IdentityService.UpdateTenantPlan:input_type -> identity.v1.UpdateTenantPlanRequest
-	1, // 5: identity.v1.IdentityService.GetUserRole:output_type -> identity.v1.GetUserRoleResponse
-	4, // 6: identity.v1.IdentityService.CreateUser:output_type -> identity.v1.CreateUserResponse
-	6, // 7: identity.v1.IdentityService.DeleteUser:output_type -> identity.v1.DeleteUserResponse
-	8, // 8: identity.v1.IdentityService.UpdateTenantPlan:output_type -> identity.v1.UpdateTenantPlanResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2,  // 0: identity.v1.GetUserRoleResponse.role:type_name -> identity.v1.Role
+	0,  // 1: identity.v1.IdentityService.GetUserRole:input_type -> identity.v1.GetUserRoleRequest
+	3,  // 2: identity.v1.IdentityService.CreateUser:input_type -> identity.v1.CreateUserRequest
+	5,  // 3: identity.v1.IdentityService.DeleteUser:input_type -> identity.v1.DeleteUserRequest
+	7,  // 4: identity.v1.IdentityService.UpdateTenantPlan:input_type -> identity.v1.UpdateTenantPlanRequest
+	9,  // 5: identity.v1.IdentityService.ResolveDomain:input_type -> identity.v1.ResolveDomainRequest
+	1,  // 6: identity.v1.IdentityService.GetUserRole:output_type -> identity.v1.GetUserRoleResponse
+	4,  // 7: identity.v1.IdentityService.CreateUser:output_type -> identity.v1.CreateUserResponse
+	6,  // 8: identity.v1.IdentityService.DeleteUser:output_type -> identity.v1.DeleteUserResponse
+	8,  // 9: identity.v1.IdentityService.UpdateTenantPlan:output_type -> identity.v1.UpdateTenantPlanResponse
+	10, // 10: identity.v1.IdentityService.ResolveDomain:output_type -> identity.v1.ResolveDomainResponse
+	6,  // [6:11] is the sub-list for method output_type
+	1,  // [1:6] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_identity_v1_service_proto_init() }
@@ -582,7 +689,7 @@ func file_identity_v1_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_identity_v1_service_proto_rawDesc), len(file_identity_v1_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const (
 	IdentityService_CreateUser_FullMethodName       = "/identity.v1.IdentityService/CreateUser"
 	IdentityService_DeleteUser_FullMethodName       = "/identity.v1.IdentityService/DeleteUser"
 	IdentityService_UpdateTenantPlan_FullMethodName = "/identity.v1.IdentityService/UpdateTenantPlan"
+	IdentityService_ResolveDomain_FullMethodName    = "/identity.v1.IdentityService/ResolveDomain"
 )
 
 // IdentityServiceClient is the client API for IdentityService service.
@@ -33,6 +34,7 @@ type IdentityServiceClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	UpdateTenantPlan(ctx context.Context, in *UpdateTenantPlanRequest, opts ...grpc.CallOption) (*UpdateTenantPlanResponse, error)
+	ResolveDomain(ctx context.Context, in *ResolveDomainRequest, opts ...grpc.CallOption) (*ResolveDomainResponse, error)
 }
 
 type identityServiceClient struct {
@@ -83,6 +85,16 @@ func (c *identityServiceClient) UpdateTenantPlan(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *identityServiceClient) ResolveDomain(ctx context.Context, in *ResolveDomainRequest, opts ...grpc.CallOption) (*ResolveDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveDomainResponse)
+	err := c.cc.Invoke(ctx, IdentityService_ResolveDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IdentityServiceServer is the server API for IdentityService service.
 // All implementations must embed UnimplementedIdentityServiceServer
 // for forward compatibility.
@@ -91,6 +103,7 @@ type IdentityServiceServer interface {
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	UpdateTenantPlan(context.Context, *UpdateTenantPlanRequest) (*UpdateTenantPlanResponse, error)
+	ResolveDomain(context.Context, *ResolveDomainRequest) (*ResolveDomainResponse, error)
 	mustEmbedUnimplementedIdentityServiceServer()
 }
 
@@ -113,6 +126,9 @@ func (UnimplementedIdentityServiceServer) DeleteUser(context.Context, *DeleteUse
 func (UnimplementedIdentityServiceServer) UpdateTenantPlan(context.Context, *UpdateTenantPlanRequest) (*UpdateTenantPlanResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateTenantPlan not implemented")
 }
+func (UnimplementedIdentityServiceServer) ResolveDomain(context.Context, *ResolveDomainRequest) (*ResolveDomainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveDomain not implemented")
+}
 func (UnimplementedIdentityServiceServer) mustEmbedUnimplementedIdentityServiceServer() {}
 func (UnimplementedIdentityServiceServer) testEmbeddedByValue()                         {}
 
@@ -206,6 +222,24 @@ func _IdentityService_UpdateTenantPlan_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IdentityService_ResolveDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityServiceServer).ResolveDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdentityService_ResolveDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityServiceServer).ResolveDomain(ctx, req.(*ResolveDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IdentityService_ServiceDesc is the grpc.ServiceDesc for IdentityService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +263,10 @@ var IdentityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateTenantPlan",
 			Handler:    _IdentityService_UpdateTenantPlan_Handler,
 		},
+		{
+			MethodName: "ResolveDomain",
+			Handler:    _IdentityService_ResolveDomain_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "identity/v1/service.proto",
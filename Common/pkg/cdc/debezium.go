@@ -24,6 +24,17 @@ type DebeziumPayload[T any] struct {
 	TsMs   int64     `json:"ts_ms"`
 }
 
+// SourceTsMs returns when the change was committed at the source database,
+// falling back to the time Debezium processed it when the source block carries no timestamp.
+func (p *DebeziumPayload[T]) SourceTsMs() int64 {
+	if source, ok := p.Source.(map[string]any); ok {
+		if ts, ok := source["ts_ms"].(float64); ok && ts > 0 {
+			return int64(ts)
+		}
+	}
+	return p.TsMs
+}
+
 // DebeziumEnvelope represents the outer wrapper if Debezium is configured with envelopes
 type DebeziumEnvelope[T any] struct {
 	Payload DebeziumPayload[T] `json:"payload"`
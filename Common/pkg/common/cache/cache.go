@@ -23,16 +23,22 @@ type CacheEngine interface {
 	BatchSet(ctx context.Context, values map[string]any, ttl time.Duration) error
 	DeleteBulk(ctx context.Context, keys []string) error
 	Incr(ctx context.Context, key string) (int64, error)
+	IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error)
 	Decr(ctx context.Context, key string) (int64, error)
 	SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error)
+	CompareAndDelete(ctx context.Context, key string, value any) (bool, error)
 	Expire(ctx context.Context, key string, ttl time.Duration) error
 	GeoAdd(ctx context.Context, key string, locations ...*GeoLocation) error
 	GeoRemove(ctx context.Context, key string, members ...string) error
 	GeoRadius(ctx context.Context, key string, longitude, latitude, radius float64, unit string) ([]*GeoLocation, error)
 	ZAdd(ctx context.Context, key string, members ...*ZMember) error
+	ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error)
+	ZRem(ctx context.Context, key string, members ...string) (int64, error)
 	ZRemRangeByScore(ctx context.Context, key string, min, max string) error
+	ZRemRangeByRank(ctx context.Context, key string, start, stop int64) error
 	ZCount(ctx context.Context, key string, min, max string) (int64, error)
 	ZRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+	ZRangeWithScores(ctx context.Context, key string, start, stop int64) ([]*ZMember, error)
 	Keys(ctx context.Context, pattern string) ([]string, error)
 	Close()
 }
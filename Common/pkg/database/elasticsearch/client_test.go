@@ -2,8 +2,11 @@ package elasticsearch
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +31,13 @@ type TestDocument struct {
 	Value              int    `json:"value"`
 }
 
+// LooseDocument lets a test send a value the index mapping rejects
+type LooseDocument struct {
+	*BaseModel[string] `bson:",inline"`
+	Title              string `json:"title"`
+	Value              any    `json:"value"`
+}
+
 func TestClient_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -83,6 +93,26 @@ func TestClient_Integration(t *testing.T) {
 	t.Run("Find", func(t *testing.T) {
 		testFind(t, ctx, repo)
 	})
+
+	t.Run("DeleteByQuery", func(t *testing.T) {
+		testDeleteByQuery(t, ctx, repo)
+	})
+
+	t.Run("CreateIfAbsent", func(t *testing.T) {
+		testCreateIfAbsent(t, ctx, repo)
+	})
+
+	t.Run("CreateBulkIfAbsent", func(t *testing.T) {
+		testCreateBulkIfAbsent(t, ctx, repo)
+	})
+
+	t.Run("CreateBulkIfAbsentPartialFailure", func(t *testing.T) {
+		testCreateBulkIfAbsentPartialFailure(t, ctx, NewBaseRepository[LooseDocument, string](client, "test-index"))
+	})
+
+	t.Run("Aggregate", func(t *testing.T) {
+		testAggregate(t, ctx, repo)
+	})
 }
 
 // Ensure T is TestDocument
@@ -240,6 +270,158 @@ func testFind(t *testing.T, ctx context.Context, repo *BaseRepository[TestDocume
 	}
 }
 
+func testDeleteByQuery(t *testing.T, ctx context.Context, repo *BaseRepository[TestDocument, string]) {
+	bm1 := NewBaseModel[string]("13")
+	doc1 := &TestDocument{BaseModel: &bm1, Title: "purge", Value: 1300}
+	bm2 := NewBaseModel[string]("14")
+	doc2 := &TestDocument{BaseModel: &bm2, Title: "purge", Value: 1400}
+	bm3 := NewBaseModel[string]("15")
+	doc3 := &TestDocument{BaseModel: &bm3, Title: "keep", Value: 1500}
+	repo.Create(ctx, doc1)
+	repo.Create(ctx, doc2)
+	repo.Create(ctx, doc3)
+
+	query := `{"query": {"range": {"value": {"gte": 1300, "lt": 1500}}}}`
+	deleted, err := repo.DeleteByQuery(ctx, strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Failed to delete by query: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("Expected 2 deleted, got %d", deleted)
+	}
+
+	exists, _ := repo.Exists(ctx, "15")
+	if !exists {
+		t.Error("Document outside the query should still exist")
+	}
+}
+
+func testCreateIfAbsent(t *testing.T, ctx context.Context, repo *BaseRepository[TestDocument, string]) {
+	bm := NewBaseModel[string]("16")
+	doc := &TestDocument{BaseModel: &bm, Title: "first", Value: 1600}
+
+	created, err := repo.CreateIfAbsent(ctx, doc)
+	if err != nil {
+		t.Fatalf("Failed to create doc: %v", err)
+	}
+	if !created {
+		t.Error("A new document should be created")
+	}
+
+	// A second delivery of the same ID is a conflict: skipped, not an error
+	again := &TestDocument{BaseModel: &bm, Title: "second", Value: 1601}
+	created, err = repo.CreateIfAbsent(ctx, again)
+	if err != nil {
+		t.Fatalf("A conflict should not fail: %v", err)
+	}
+	if created {
+		t.Error("An existing document should be skipped")
+	}
+
+	fetched, err := repo.Get(ctx, "16")
+	if err != nil {
+		t.Fatalf("Failed to get doc: %v", err)
+	}
+	if (*fetched).Title != "first" {
+		t.Errorf("Existing document should be kept, got title '%s'", (*fetched).Title)
+	}
+}
+
+func testCreateBulkIfAbsent(t *testing.T, ctx context.Context, repo *BaseRepository[TestDocument, string]) {
+	bm1 := NewBaseModel[string]("17")
+	doc1 := &TestDocument{BaseModel: &bm1, Title: "bulk-absent-1", Value: 1700}
+	repo.Create(ctx, doc1)
+
+	bm2 := NewBaseModel[string]("18")
+	doc2 := &TestDocument{BaseModel: &bm2, Title: "bulk-absent-2", Value: 1800}
+	dup := &TestDocument{BaseModel: &bm1, Title: "bulk-absent-dup", Value: 1701}
+
+	created, err := repo.CreateBulkIfAbsent(ctx, []*TestDocument{dup, doc2})
+	if err != nil {
+		t.Fatalf("Conflicts should not fail the batch: %v", err)
+	}
+	if created != 1 {
+		t.Errorf("Expected 1 created, got %d", created)
+	}
+
+	fetched, _ := repo.Get(ctx, "17")
+	if fetched == nil || (*fetched).Title != "bulk-absent-1" {
+		t.Error("Existing document should be kept")
+	}
+}
+
+func testCreateBulkIfAbsentPartialFailure(t *testing.T, ctx context.Context, repo *BaseRepository[LooseDocument, string]) {
+	bm1 := NewBaseModel[string]("19")
+	ok := &LooseDocument{BaseModel: &bm1, Title: "partial-ok", Value: 1900}
+	bm2 := NewBaseModel[string]("20")
+	bad := &LooseDocument{BaseModel: &bm2, Title: "partial-bad", Value: "not-a-number"}
+	bm3 := NewBaseModel[string]("16")
+	dup := &LooseDocument{BaseModel: &bm3, Title: "partial-dup", Value: 1602}
+
+	created, err := repo.CreateBulkIfAbsent(ctx, []*LooseDocument{ok, bad, dup})
+	if !errors.Is(err, ErrBulkItemsFailed) {
+		t.Fatalf("Expected ErrBulkItemsFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "1 of 3") {
+		t.Errorf("Only the rejected document should count as failed, got %v", err)
+	}
+	if created != 1 {
+		t.Errorf("Expected 1 created, got %d", created)
+	}
+
+	exists, _ := repo.Exists(ctx, "19")
+	if !exists {
+		t.Error("Accepted document should exist despite the failed one")
+	}
+}
+
+func testAggregate(t *testing.T, ctx context.Context, repo *BaseRepository[TestDocument, string]) {
+	docs := make([]*TestDocument, 0, 3)
+	for i, title := range []string{"agg-a", "agg-a", "agg-b"} {
+		bm := NewBaseModel[string](fmt.Sprintf("%d", 21+i))
+		docs = append(docs, &TestDocument{BaseModel: &bm, Title: title, Value: 2100 + i})
+	}
+	if err := repo.CreateBulk(ctx, docs); err != nil {
+		t.Fatalf("Failed to batch create: %v", err)
+	}
+	time.Sleep(1 * time.Second) // Ensure index refresh
+
+	query := `{
+		"query": {"range": {"value": {"gte": 2100, "lt": 2200}}},
+		"aggs": {
+			"total": {"sum": {"field": "value"}},
+			"titles": {"terms": {"field": "title.keyword"}}
+		}
+	}`
+	aggs, err := repo.Aggregate(ctx, strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Failed to aggregate: %v", err)
+	}
+
+	var total struct {
+		Value float64 `json:"value"`
+	}
+	if err := json.Unmarshal(aggs["total"], &total); err != nil {
+		t.Fatalf("Failed to decode sum: %v", err)
+	}
+	if total.Value != 2100+2101+2102 {
+		t.Errorf("Expected sum %d, got %v", 2100+2101+2102, total.Value)
+	}
+
+	var titles struct {
+		Buckets []struct {
+			Key      string `json:"key"`
+			DocCount int    `json:"doc_count"`
+		} `json:"buckets"`
+	}
+	if err := json.Unmarshal(aggs["titles"], &titles); err != nil {
+		t.Fatalf("Failed to decode terms: %v", err)
+	}
+	if len(titles.Buckets) != 2 || titles.Buckets[0].Key != "agg-a" || titles.Buckets[0].DocCount != 2 {
+		t.Errorf("Unexpected buckets: %+v", titles.Buckets)
+	}
+}
+
 func setupElasticsearchContainer(ctx context.Context, t *testing.T) (string, func()) {
 	req := testcontainers.ContainerRequest{
 		Image: elasticsearchImage,
@@ -15,4 +15,6 @@ var (
 	ErrDeleteRequestFailed = errors.New("failed to execute delete request")
 	ErrSearchRequestFailed = errors.New("failed to execute search request")
 	ErrDecodeFailed        = errors.New("failed to decode response")
+	ErrCreateIndexFailed   = errors.New("failed to create index")
+	ErrBulkItemsFailed     = errors.New("bulk request failed for some documents")
 )
@@ -0,0 +1,42 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+const indexExistsError = "resource_already_exists_exception"
+
+// EnsureIndex creates the index with the given settings and mappings unless it already exists.
+// Losing a creation race to another instance is not an error.
+func EnsureIndex(ctx context.Context, client ElasticClient, index string, body io.Reader) error {
+	exists, err := esapi.IndicesExistsRequest{Index: []string{index}}.Do(ctx, client)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCreateIndexFailed, err)
+	}
+	exists.Body.Close()
+
+	if exists.StatusCode == 200 {
+		return nil
+	}
+
+	res, err := esapi.IndicesCreateRequest{Index: index, Body: body}.Do(ctx, client)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCreateIndexFailed, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		reason, _ := io.ReadAll(res.Body)
+		if bytes.Contains(reason, []byte(indexExistsError)) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrCreateIndexFailed, res.Status())
+	}
+
+	return nil
+}
@@ -56,6 +56,37 @@ func (r *BaseRepository[T, ID]) Index(ctx context.Context, doc *T) error {
 	return nil
 }
 
+// CreateIfAbsent inserts the document only if its ID is not taken yet.
+// It reports false, without error, when the document already exists, which makes retried writes idempotent.
+func (r *BaseRepository[T, ID]) CreateIfAbsent(ctx context.Context, doc *T) (bool, error) {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrMarshalFailed, err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      r.index,
+		DocumentID: fmt.Sprintf("%v", (*doc).GetID()),
+		Body:       bytes.NewReader(body),
+		OpType:     "create",
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrIndexRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 409 {
+		return false, nil
+	}
+	if res.IsError() {
+		return false, fmt.Errorf("%w: %s", ErrIndexRequestFailed, res.Status())
+	}
+
+	return true, nil
+}
+
 // Create inserts a new document
 func (r *BaseRepository[T, ID]) Create(ctx context.Context, doc *T) error {
 	return r.Index(ctx, doc)
@@ -117,6 +148,37 @@ func (r *BaseRepository[T, ID]) Delete(ctx context.Context, docID ID) error {
 	return nil
 }
 
+// DeleteByQuery removes every document matching the query and returns how many it removed.
+// Documents changed while the deletion runs are skipped rather than failing it.
+func (r *BaseRepository[T, ID]) DeleteByQuery(ctx context.Context, query io.Reader) (int64, error) {
+	refresh := true
+	req := esapi.DeleteByQueryRequest{
+		Index:     []string{r.index},
+		Body:      query,
+		Conflicts: "proceed",
+		Refresh:   &refresh,
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDeleteRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("%w: %s", ErrDeleteRequestFailed, res.Status())
+	}
+
+	var response struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDecodeFailed, err)
+	}
+
+	return response.Deleted, nil
+}
+
 // Search executes a raw query
 func (r *BaseRepository[T, ID]) Search(ctx context.Context, query io.Reader) ([]*T, error) {
 	req := esapi.SearchRequest{
@@ -157,6 +219,36 @@ func (r *BaseRepository[T, ID]) Search(ctx context.Context, query io.Reader) ([]
 	return results, nil
 }
 
+// Aggregate runs a query for its aggregations only and returns them keyed by name, undecoded
+func (r *BaseRepository[T, ID]) Aggregate(ctx context.Context, query io.Reader) (map[string]json.RawMessage, error) {
+	size := 0
+	req := esapi.SearchRequest{
+		Index: []string{r.index},
+		Body:  query,
+		Size:  &size,
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSearchRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("%w: %s", ErrSearchRequestFailed, res.Status())
+	}
+
+	var response struct {
+		Aggregations map[string]json.RawMessage `json:"aggregations"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecodeFailed, err)
+	}
+
+	return response.Aggregations, nil
+}
+
 // Find retrieves documents with pagination, search/filter, and sorting
 func (r *BaseRepository[T, ID]) Find(ctx context.Context, opts *dto.QueryOptions) (*dto.Paginated[*T], error) {
 	if opts == nil {
@@ -225,6 +317,67 @@ func (r *BaseRepository[T, ID]) CreateBulk(ctx context.Context, docs []*T) error
 	return nil
 }
 
+// CreateBulkIfAbsent inserts documents with the Bulk API "create" action, so IDs that already exist are skipped.
+// It returns how many documents were created; any item failure other than a duplicate ID fails the call,
+// which lets callers retry the whole batch safely.
+func (r *BaseRepository[T, ID]) CreateBulkIfAbsent(ctx context.Context, docs []*T) (int, error) {
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		meta := []byte(fmt.Sprintf(`{ "create" : { "_index" : "%s", "_id" : "%v" } }%s`, r.index, (*doc).GetID(), "\n"))
+		buf.Write(meta)
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrMarshalFailed, err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	req := esapi.BulkRequest{Body: bytes.NewReader(buf.Bytes())}
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrIndexRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("%w: %s", ErrIndexRequestFailed, res.Status())
+	}
+
+	var response struct {
+		Items []map[string]struct {
+			Status int `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDecodeFailed, err)
+	}
+
+	created, failed := 0, 0
+	for _, item := range response.Items {
+		for _, result := range item {
+			switch {
+			case result.Status == 409:
+				// Already indexed by an earlier delivery
+			case result.Status >= 300:
+				failed++
+			default:
+				created++
+			}
+		}
+	}
+	if failed > 0 {
+		return created, fmt.Errorf("%w: %d of %d documents", ErrBulkItemsFailed, failed, len(docs))
+	}
+
+	return created, nil
+}
+
 // DeleteBulk deletes multiple documents using Bulk API
 func (r *BaseRepository[T, ID]) DeleteBulk(ctx context.Context, docIDs []ID) error {
 	if len(docIDs) == 0 {
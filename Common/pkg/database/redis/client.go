@@ -173,6 +173,22 @@ func (r *RedisEngine) Incr(ctx context.Context, key string) (int64, error) {
 	return r.client.Incr(ctx, key).Result()
 }
 
+// incrWithTTL increments KEYS[1] and gives it a TTL of ARGV[1] milliseconds when it has none,
+// so a counter can never be left without one
+var incrWithTTL = redisV9.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// IncrWithTTL increments the key's value by one and, in the same step, sets the TTL when the key has none,
+// e.g. the counter of a fixed window that starts with its first hit
+func (r *RedisEngine) IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrWithTTL.Run(ctx, r.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
 // Decr decrements the key's value by one
 func (r *RedisEngine) Decr(ctx context.Context, key string) (int64, error) {
 	return r.client.Decr(ctx, key).Result()
@@ -187,6 +203,28 @@ func (r *RedisEngine) SetNX(ctx context.Context, key string, value any, ttl time
 	return r.client.SetNX(ctx, key, byteValue, ttl).Result()
 }
 
+// compareAndDelete deletes KEYS[1] only while it still holds ARGV[1]
+var compareAndDelete = redisV9.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// CompareAndDelete deletes the key only if it holds the value, e.g. a lock still owned by the caller.
+// Returns true if the key was deleted.
+func (r *RedisEngine) CompareAndDelete(ctx context.Context, key string, value any) (bool, error) {
+	byteValue, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := compareAndDelete.Run(ctx, r.client, []string{key}, byteValue).Int64()
+	if err != nil {
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
 // Expire sets a TTL on an existing key without modifying its value.
 func (r *RedisEngine) Expire(ctx context.Context, key string, ttl time.Duration) error {
 	return r.client.Expire(ctx, key, ttl).Err()
@@ -265,6 +303,36 @@ func (r *RedisEngine) ZAdd(ctx context.Context, key string, members ...*cache.ZM
 	return r.client.ZAdd(ctx, key, redisMembers...).Err()
 }
 
+// ZIncrBy adds increment to a member's score, creating the member if needed, and returns the new score
+func (r *RedisEngine) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	return r.client.ZIncrBy(ctx, key, increment, member).Result()
+}
+
+// ZRem removes the given members and returns how many of them were in the set
+func (r *RedisEngine) ZRem(ctx context.Context, key string, members ...string) (int64, error) {
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	interfaceMembers := make([]interface{}, len(members))
+	for i, m := range members {
+		interfaceMembers[i] = m
+	}
+	return r.client.ZRem(ctx, key, interfaceMembers...).Result()
+}
+
+// ZRemRangeByRank removes members ranked between start and stop, lowest score first
+func (r *RedisEngine) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) error {
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	return r.client.ZRemRangeByRank(ctx, key, start, stop).Err()
+}
+
 // ZRemRangeByScore removes members with scores within the given range
 func (r *RedisEngine) ZRemRangeByScore(ctx context.Context, key string, min, max string) error {
 	r.rwMutex.Lock()
@@ -282,6 +350,23 @@ func (r *RedisEngine) ZRange(ctx context.Context, key string, start, stop int64)
 	return r.client.ZRange(ctx, key, start, stop).Result()
 }
 
+// ZRangeWithScores returns a range of members from a sorted set with their scores, lowest first
+func (r *RedisEngine) ZRangeWithScores(ctx context.Context, key string, start, stop int64) ([]*cache.ZMember, error) {
+	res, err := r.client.ZRangeWithScores(ctx, key, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*cache.ZMember, len(res))
+	for i, z := range res {
+		members[i] = &cache.ZMember{
+			Score:  z.Score,
+			Member: z.Member,
+		}
+	}
+	return members, nil
+}
+
 // Keys returns all keys matching the pattern
 func (r *RedisEngine) Keys(ctx context.Context, pattern string) ([]string, error) {
 	return r.client.Keys(ctx, pattern).Result()
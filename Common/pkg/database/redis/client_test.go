@@ -74,6 +74,18 @@ func TestClient_Integration(t *testing.T) {
 	t.Run("InvalidatePrefix", func(t *testing.T) {
 		testInvalidatePrefix(t, ctx, engine)
 	})
+
+	t.Run("CompareAndDelete", func(t *testing.T) {
+		testCompareAndDelete(t, ctx, engine)
+	})
+
+	t.Run("ZIncrBy", func(t *testing.T) {
+		testZIncrBy(t, ctx, engine)
+	})
+
+	t.Run("IncrWithTTL", func(t *testing.T) {
+		testIncrWithTTL(t, ctx, engine)
+	})
 }
 
 func testSet(t *testing.T, ctx context.Context, engine *RedisEngine) {
@@ -161,6 +173,100 @@ func testInvalidatePrefix(t *testing.T, ctx context.Context, engine *RedisEngine
 	}
 }
 
+func testCompareAndDelete(t *testing.T, ctx context.Context, engine *RedisEngine) {
+	key := "test-cad-key"
+	engine.Set(ctx, key, "owner-1", 0)
+
+	deleted, err := engine.CompareAndDelete(ctx, key, "owner-2")
+	if err != nil {
+		t.Fatalf("Failed to compare and delete: %v", err)
+	}
+	if deleted {
+		t.Error("Key held by another owner should not be deleted")
+	}
+
+	deleted, err = engine.CompareAndDelete(ctx, key, "owner-1")
+	if err != nil {
+		t.Fatalf("Failed to compare and delete: %v", err)
+	}
+	if !deleted {
+		t.Error("Key held by the owner should be deleted")
+	}
+
+	_, found, _ := engine.Get(ctx, key)
+	if found {
+		t.Error("Key should not exist after compare and delete")
+	}
+}
+
+func testIncrWithTTL(t *testing.T, ctx context.Context, engine *RedisEngine) {
+	key := "test-incr-ttl-key"
+
+	// A counter left without a TTL gets one on its next increment
+	engine.Incr(ctx, key)
+
+	count, err := engine.IncrWithTTL(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to increment: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected count 2, got %d", count)
+	}
+
+	ttl, err := engine.Client().PTTL(ctx, key).Result()
+	if err != nil {
+		t.Fatalf("Failed to read TTL: %v", err)
+	}
+	if ttl <= 0 || ttl > time.Minute {
+		t.Errorf("Expected a TTL of at most a minute, got %v", ttl)
+	}
+
+	// Later increments keep the window's TTL
+	engine.Client().PExpire(ctx, key, 30*time.Second)
+	if _, err := engine.IncrWithTTL(ctx, key, time.Minute); err != nil {
+		t.Fatalf("Failed to increment: %v", err)
+	}
+	if ttl, _ := engine.Client().PTTL(ctx, key).Result(); ttl > 30*time.Second {
+		t.Errorf("Expected the TTL to be kept, got %v", ttl)
+	}
+}
+
+func testZIncrBy(t *testing.T, ctx context.Context, engine *RedisEngine) {
+	key := "test-zincr-key"
+	engine.ZIncrBy(ctx, key, 5, "a")
+	engine.ZIncrBy(ctx, key, 1, "b")
+	engine.ZIncrBy(ctx, key, 3, "c")
+
+	score, err := engine.ZIncrBy(ctx, key, 2, "a")
+	if err != nil {
+		t.Fatalf("Failed to increment member: %v", err)
+	}
+	if score != 7 {
+		t.Errorf("Expected score 7, got %v", score)
+	}
+
+	// Keep the two highest scores
+	if err := engine.ZRemRangeByRank(ctx, key, 0, -3); err != nil {
+		t.Fatalf("Failed to trim sorted set: %v", err)
+	}
+
+	members, err := engine.ZRange(ctx, key, 0, -1)
+	if err != nil {
+		t.Fatalf("Failed to read sorted set: %v", err)
+	}
+	if len(members) != 2 || members[0] != "c" || members[1] != "a" {
+		t.Errorf("Expected [c a], got %v", members)
+	}
+
+	removed, err := engine.ZRem(ctx, key, "a", "b")
+	if err != nil {
+		t.Fatalf("Failed to remove members: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 member removed, got %d", removed)
+	}
+}
+
 func setupRedisContainer(ctx context.Context) (string, func(), error) {
 	req := testcontainers.ContainerRequest{
 		Image:        redisImage,
@@ -0,0 +1,70 @@
+package widecolumn
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+const tokenColumn = "row_token"
+
+// TokenRange is an inclusive slice [Start, End] of the Murmur3 token ring.
+type TokenRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// SplitTokenRing divides the whole Murmur3 ring into n contiguous, non-overlapping ranges.
+func SplitTokenRing(n int) []TokenRange {
+	if n < 1 {
+		n = 1
+	}
+
+	// Work in uint64 offsets from MinInt64 so the ring width doesn't overflow
+	width := uint64(math.MaxUint64) / uint64(n)
+	ranges := make([]TokenRange, n)
+	start := uint64(0)
+	for i := range n {
+		end := start + width - 1
+		if i == n-1 {
+			end = math.MaxUint64
+		}
+		ranges[i] = TokenRange{
+			Start: int64(start ^ (1 << 63)),
+			End:   int64(end ^ (1 << 63)),
+		}
+		start = end + 1
+	}
+
+	return ranges
+}
+
+// ScanRange streams the rows whose partition token falls inside rng, in token order, page by page.
+// fn gets each row with its token; returning an error stops the scan and is returned as is.
+func (r *BaseRepository[T]) ScanRange(ctx context.Context, rng TokenRange, pageSize int, fn func(token int64, model *T) error) error {
+	stmt := fmt.Sprintf("SELECT token(%s) AS %s, * FROM %s WHERE token(%s) >= ? AND token(%s) <= ?",
+		IDColumn, tokenColumn, r.tableName, IDColumn, IDColumn)
+
+	iter := r.session.Query(stmt, rng.Start, rng.End).WithContext(ctx).PageSize(pageSize).Iter()
+	for {
+		row := make(map[string]any)
+		if !iter.MapScan(row) {
+			break
+		}
+
+		token, _ := row[tokenColumn].(int64)
+
+		var model T
+		if err := defaultMapper.Bind(row, &model); err != nil {
+			_ = iter.Close()
+			return err
+		}
+
+		if err := fn(token, &model); err != nil {
+			_ = iter.Close()
+			return err
+		}
+	}
+
+	return iter.Close()
+}
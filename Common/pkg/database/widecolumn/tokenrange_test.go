@@ -0,0 +1,45 @@
+package widecolumn
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplitTokenRing(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want int
+	}{
+		{"single", 1, 1},
+		{"zero_defaults_to_one", 0, 1},
+		{"even", 4, 4},
+		{"odd", 7, 7},
+		{"many", 1024, 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges := SplitTokenRing(tt.n)
+			if len(ranges) != tt.want {
+				t.Fatalf("len = %d, want %d", len(ranges), tt.want)
+			}
+
+			if ranges[0].Start != math.MinInt64 {
+				t.Errorf("first start = %d, want MinInt64", ranges[0].Start)
+			}
+			if last := ranges[len(ranges)-1].End; last != math.MaxInt64 {
+				t.Errorf("last end = %d, want MaxInt64", last)
+			}
+
+			for i, rng := range ranges {
+				if rng.Start > rng.End {
+					t.Errorf("range %d is empty: %+v", i, rng)
+				}
+				if i > 0 && ranges[i-1].End+1 != rng.Start {
+					t.Errorf("range %d does not follow range %d: %+v then %+v", i, i-1, ranges[i-1], rng)
+				}
+			}
+		})
+	}
+}
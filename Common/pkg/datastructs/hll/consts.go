@@ -0,0 +1,23 @@
+package hll
+
+const (
+	// MinPrecision and MaxPrecision bound the register index width p; a sketch has 2^p registers
+	MinPrecision = 4
+	MaxPrecision = 18
+	// DefaultPrecision gives a standard error of about 0.8% in 16 KiB
+	DefaultPrecision = 14
+
+	// sparsePrecision is the index width of the sparse representation, accurate up to the switch to dense
+	sparsePrecision = 25
+	// rhoBits holds a sparse entry's rank, at most 64-sparsePrecision+1 = 40
+	rhoBits = 6
+	rhoMask = 1<<rhoBits - 1
+
+	// tmpSize is how many unsorted sparse entries are buffered before being merged into the sorted list
+	tmpSize = 256
+
+	encodingVersion = 1
+	kindSparse      = 0
+	kindDense       = 1
+	headerSize      = 3
+)
@@ -0,0 +1,362 @@
+package hll
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+var (
+	ErrInvalidPrecision  = errors.New("hll: precision out of range")
+	ErrPrecisionMismatch = errors.New("hll: sketches have different precisions")
+	ErrInvalidData       = errors.New("hll: invalid encoded sketch")
+)
+
+// Sketch is a HyperLogLog++ cardinality estimator over 64-bit hashes.
+// Small sets are kept in a sparse list of 25-bit indexes, which is near exact;
+// once that list would outgrow the dense registers it switches to 2^p 6-bit ranks.
+// NOT thread-safe.
+type Sketch struct {
+	p      uint8
+	dense  []uint8  // one rank per register, nil while sparse
+	sparse []uint32 // sorted, one entry per sparse index: index<<rhoBits | rank
+	tmp    []uint32 // unsorted entries not yet merged into sparse
+}
+
+// New creates an empty sketch with 2^precision registers.
+func New(precision uint8) (*Sketch, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, ErrInvalidPrecision
+	}
+	return &Sketch{p: precision}, nil
+}
+
+// Precision returns the sketch's register index width.
+func (s *Sketch) Precision() uint8 {
+	return s.p
+}
+
+// IsSparse reports whether the sketch still uses the sparse representation.
+func (s *Sketch) IsSparse() bool {
+	return s.dense == nil
+}
+
+// Add records a hashed element. The hash must be uniformly distributed, e.g. xxhash.
+func (s *Sketch) Add(hash uint64) {
+	if s.dense != nil {
+		s.addDense(hash)
+		return
+	}
+
+	s.tmp = append(s.tmp, encodeSparse(hash))
+	if len(s.tmp) >= tmpSize {
+		s.flush()
+	}
+}
+
+// Count returns the estimated number of distinct elements added.
+func (s *Sketch) Count() uint64 {
+	s.flush()
+	if s.dense == nil {
+		return uint64(math.Round(linearCounting(1<<sparsePrecision, 1<<sparsePrecision-len(s.sparse))))
+	}
+	return uint64(math.Round(s.estimateDense()))
+}
+
+// Merge folds other into s, so s then counts the union of both sets.
+func (s *Sketch) Merge(other *Sketch) error {
+	if s.p != other.p {
+		return ErrPrecisionMismatch
+	}
+
+	if s.dense == nil && other.dense == nil {
+		other.flush()
+		s.tmp = append(s.tmp, other.sparse...)
+		s.flush()
+		return nil
+	}
+
+	s.toDense()
+	if other.dense != nil {
+		for i, r := range other.dense {
+			s.dense[i] = max(s.dense[i], r)
+		}
+		return nil
+	}
+
+	for _, k := range other.sparse {
+		s.insertSparseEntry(k)
+	}
+	for _, k := range other.tmp {
+		s.insertSparseEntry(k)
+	}
+	return nil
+}
+
+// Clone returns an independent copy of the sketch.
+func (s *Sketch) Clone() *Sketch {
+	return &Sketch{
+		p:      s.p,
+		dense:  slices.Clone(s.dense),
+		sparse: slices.Clone(s.sparse),
+		tmp:    slices.Clone(s.tmp),
+	}
+}
+
+func (s *Sketch) addDense(hash uint64) {
+	idx := hash >> (64 - s.p)
+	rank := uint8(bits.LeadingZeros64(hash<<s.p)) + 1
+	if maxRank := 65 - s.p; rank > maxRank {
+		rank = maxRank
+	}
+	if rank > s.dense[idx] {
+		s.dense[idx] = rank
+	}
+}
+
+// encodeSparse keeps the top 25 bits of the hash as the index and the rank of the rest
+func encodeSparse(hash uint64) uint32 {
+	idx := uint32(hash >> (64 - sparsePrecision))
+	rank := uint32(bits.LeadingZeros64(hash<<sparsePrecision)) + 1
+	if maxRank := uint32(64 - sparsePrecision + 1); rank > maxRank {
+		rank = maxRank
+	}
+	return idx<<rhoBits | rank
+}
+
+// insertSparseEntry applies a sparse entry to the dense registers. The index bits below
+// the dense precision are the start of the dense rank's bit string, so they decide the rank
+// unless they are all zero, in which case the sparse rank continues the run of zeros.
+func (s *Sketch) insertSparseEntry(k uint32) {
+	shift := sparsePrecision - s.p
+	sparseIdx := k >> rhoBits
+	idx := sparseIdx >> shift
+	low := sparseIdx & (1<<shift - 1)
+
+	var rank uint8
+	if low != 0 {
+		rank = uint8(bits.LeadingZeros32(low)-(32-int(shift))) + 1
+	} else {
+		rank = shift + uint8(k&rhoMask)
+	}
+	if rank > s.dense[idx] {
+		s.dense[idx] = rank
+	}
+}
+
+// flush sorts the buffered entries into the sparse list, keeping the highest rank per index,
+// and switches to dense once the list takes more room than the registers would.
+func (s *Sketch) flush() {
+	if len(s.tmp) == 0 {
+		return
+	}
+
+	slices.Sort(s.tmp)
+	merged := make([]uint32, 0, len(s.sparse)+len(s.tmp))
+	i, j := 0, 0
+	for i < len(s.sparse) || j < len(s.tmp) {
+		var k uint32
+		if j >= len(s.tmp) || (i < len(s.sparse) && s.sparse[i] <= s.tmp[j]) {
+			k = s.sparse[i]
+			i++
+		} else {
+			k = s.tmp[j]
+			j++
+		}
+
+		// Entries sort by index then rank, so a repeated index carries a rank at least as high
+		if n := len(merged); n > 0 && merged[n-1]>>rhoBits == k>>rhoBits {
+			merged[n-1] = k
+			continue
+		}
+		merged = append(merged, k)
+	}
+
+	s.sparse = merged
+	s.tmp = s.tmp[:0]
+
+	if len(s.sparse)*4 > 1<<s.p {
+		s.toDense()
+	}
+}
+
+func (s *Sketch) toDense() {
+	if s.dense != nil {
+		return
+	}
+
+	s.dense = make([]uint8, 1<<s.p)
+	for _, k := range s.sparse {
+		s.insertSparseEntry(k)
+	}
+	for _, k := range s.tmp {
+		s.insertSparseEntry(k)
+	}
+	s.sparse, s.tmp = nil, nil
+}
+
+// estimateDense uses Ertl's improved estimator ("New cardinality estimation algorithms for
+// HyperLogLog sketches", 2017), which corrects the small and large range bias of the raw
+// estimate without the empirical bias tables of the original HyperLogLog++ paper.
+func (s *Sketch) estimateDense() float64 {
+	q := 64 - int(s.p)
+	m := float64(len(s.dense))
+
+	counts := make([]int, q+2)
+	for _, r := range s.dense {
+		counts[r]++
+	}
+
+	z := m * tau(1-float64(counts[q+1])/m)
+	for k := q; k >= 1; k-- {
+		z += float64(counts[k])
+		z *= 0.5
+	}
+	z += m * sigma(float64(counts[0])/m)
+
+	return m * m / (2 * math.Ln2 * z)
+}
+
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
+
+func linearCounting(m, empty int) float64 {
+	return float64(m) * math.Log(float64(m)/float64(empty))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The layout is version, precision and kind, followed by either the dense registers
+// or the number of sparse entries and their varint-encoded deltas.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	s.flush()
+	if s.dense != nil {
+		buf := make([]byte, headerSize, headerSize+len(s.dense))
+		buf[0], buf[1], buf[2] = encodingVersion, s.p, kindDense
+		return append(buf, s.dense...), nil
+	}
+
+	buf := make([]byte, headerSize, headerSize+binary.MaxVarintLen32*(len(s.sparse)+1))
+	buf[0], buf[1], buf[2] = encodingVersion, s.p, kindSparse
+	buf = binary.AppendUvarint(buf, uint64(len(s.sparse)))
+
+	var prev uint32
+	for _, k := range s.sparse {
+		buf = binary.AppendUvarint(buf, uint64(k-prev))
+		prev = k
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || data[0] != encodingVersion {
+		return ErrInvalidData
+	}
+
+	p := data[1]
+	if p < MinPrecision || p > MaxPrecision {
+		return ErrInvalidData
+	}
+	body := data[headerSize:]
+
+	switch data[2] {
+	case kindDense:
+		if len(body) != 1<<p {
+			return ErrInvalidData
+		}
+		maxRank := 65 - p
+		for _, r := range body {
+			if r > maxRank {
+				return ErrInvalidData
+			}
+		}
+		*s = Sketch{p: p, dense: slices.Clone(body)}
+		return nil
+
+	case kindSparse:
+		n, read := binary.Uvarint(body)
+		if read <= 0 || n > 1<<sparsePrecision {
+			return ErrInvalidData
+		}
+		body = body[read:]
+
+		sparse := make([]uint32, 0, min(n, uint64(len(body))))
+		var prev uint64
+		for i := uint64(0); i < n; i++ {
+			delta, read := binary.Uvarint(body)
+			if read <= 0 || (i > 0 && delta == 0) {
+				return ErrInvalidData
+			}
+			body = body[read:]
+
+			k := prev + delta
+			rank := k & rhoMask
+			if k>>rhoBits >= 1<<sparsePrecision || rank == 0 || rank > 64-sparsePrecision+1 {
+				return ErrInvalidData
+			}
+			if i > 0 && k>>rhoBits == prev>>rhoBits {
+				return ErrInvalidData
+			}
+			sparse = append(sparse, uint32(k))
+			prev = k
+		}
+		if len(body) != 0 {
+			return ErrInvalidData
+		}
+
+		*s = Sketch{p: p, sparse: sparse}
+		return nil
+	}
+
+	return ErrInvalidData
+}
+
+// MarshalJSON implements json.Marshaler as the base64 of the binary encoding.
+func (s *Sketch) MarshalJSON() ([]byte, error) {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Sketch) UnmarshalJSON(data []byte) error {
+	var raw []byte
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return s.UnmarshalBinary(raw)
+}
@@ -0,0 +1,249 @@
+package hll
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+// Interface Compliance (compile-time check)
+var (
+	_ encoding.BinaryMarshaler   = (*Sketch)(nil)
+	_ encoding.BinaryUnmarshaler = (*Sketch)(nil)
+	_ json.Marshaler             = (*Sketch)(nil)
+	_ json.Unmarshaler           = (*Sketch)(nil)
+)
+
+// mix is splitmix64, spreading sequential integers over the whole hash space
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+func fill(s *Sketch, from, to uint64) {
+	for i := from; i < to; i++ {
+		s.Add(mix(i))
+	}
+}
+
+func relErr(got uint64, want int) float64 {
+	return math.Abs(float64(got)-float64(want)) / float64(want)
+}
+
+// =============================================================================
+// Constructor Tests: New()
+// =============================================================================
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision uint8
+		wantErr   bool
+	}{
+		{"default", DefaultPrecision, false},
+		{"min", MinPrecision, false},
+		{"max", MaxPrecision, false},
+		{"below_min", MinPrecision - 1, true},
+		{"above_max", MaxPrecision + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.precision)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (!s.IsSparse() || s.Count() != 0) {
+				t.Error("New() should return an empty sparse sketch")
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Count Tests
+// =============================================================================
+
+func TestCount(t *testing.T) {
+	t.Run("duplicates_counted_once", func(t *testing.T) {
+		s, _ := New(DefaultPrecision)
+		for i := 0; i < 10; i++ {
+			fill(s, 0, 100)
+		}
+		if got := s.Count(); got != 100 {
+			t.Errorf("Count() = %d, want 100", got)
+		}
+	})
+
+	t.Run("sparse_is_near_exact", func(t *testing.T) {
+		s, _ := New(DefaultPrecision)
+		fill(s, 0, 3000)
+		if !s.IsSparse() {
+			t.Fatal("expected sketch to still be sparse")
+		}
+		if e := relErr(s.Count(), 3000); e > 0.005 {
+			t.Errorf("Count() = %d, relative error %.4f", s.Count(), e)
+		}
+	})
+
+	t.Run("switches_to_dense", func(t *testing.T) {
+		s, _ := New(DefaultPrecision)
+		fill(s, 0, 10_000)
+		if s.IsSparse() {
+			t.Error("expected sketch to be dense")
+		}
+	})
+
+	for _, n := range []int{10_000, 100_000, 1_000_000} {
+		t.Run("dense_accuracy", func(t *testing.T) {
+			s, _ := New(DefaultPrecision)
+			fill(s, 0, uint64(n))
+			// Standard error is 1.04/sqrt(2^14) ≈ 0.8%; allow four of them
+			if e := relErr(s.Count(), n); e > 0.033 {
+				t.Errorf("n=%d: Count() = %d, relative error %.4f", n, s.Count(), e)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Merge Tests
+// =============================================================================
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		name       string
+		a, b, want [2]uint64
+	}{
+		{"sparse_sparse", [2]uint64{0, 1000}, [2]uint64{500, 1500}, [2]uint64{0, 1500}},
+		{"dense_sparse", [2]uint64{0, 50_000}, [2]uint64{49_000, 50_500}, [2]uint64{0, 50_500}},
+		{"sparse_dense", [2]uint64{0, 500}, [2]uint64{0, 50_000}, [2]uint64{0, 50_000}},
+		{"dense_dense", [2]uint64{0, 60_000}, [2]uint64{30_000, 90_000}, [2]uint64{0, 90_000}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := New(DefaultPrecision)
+			b, _ := New(DefaultPrecision)
+			want, _ := New(DefaultPrecision)
+			fill(a, tt.a[0], tt.a[1])
+			fill(b, tt.b[0], tt.b[1])
+			fill(want, tt.want[0], tt.want[1])
+
+			if err := a.Merge(b); err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+			// The union sketch holds the same registers whichever way it was built
+			if a.Count() != want.Count() {
+				t.Errorf("merged Count() = %d, direct Count() = %d", a.Count(), want.Count())
+			}
+		})
+	}
+
+	t.Run("precision_mismatch", func(t *testing.T) {
+		a, _ := New(12)
+		b, _ := New(14)
+		if err := a.Merge(b); !errors.Is(err, ErrPrecisionMismatch) {
+			t.Errorf("Merge() error = %v, want ErrPrecisionMismatch", err)
+		}
+	})
+
+	t.Run("other_unchanged", func(t *testing.T) {
+		a, _ := New(DefaultPrecision)
+		b, _ := New(DefaultPrecision)
+		fill(a, 0, 100)
+		fill(b, 1000, 1100)
+		_ = a.Merge(b)
+		if got := b.Count(); got != 100 {
+			t.Errorf("other Count() after Merge = %d, want 100", got)
+		}
+	})
+}
+
+// =============================================================================
+// Serialization Tests
+// =============================================================================
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, n := range []uint64{0, 1, 2000, 100_000} {
+		s, _ := New(DefaultPrecision)
+		fill(s, 0, n)
+
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("n=%d: MarshalBinary() error = %v", n, err)
+		}
+
+		var got Sketch
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("n=%d: UnmarshalBinary() error = %v", n, err)
+		}
+		if got.Count() != s.Count() || got.IsSparse() != s.IsSparse() {
+			t.Errorf("n=%d: round trip Count() = %d, want %d", n, got.Count(), s.Count())
+		}
+
+		// A restored sketch keeps accepting elements
+		fill(&got, n, n+10)
+		fill(s, n, n+10)
+		if got.Count() != s.Count() {
+			t.Errorf("n=%d: Count() after more adds = %d, want %d", n, got.Count(), s.Count())
+		}
+	}
+}
+
+func TestSparseIsSmallerThanDense(t *testing.T) {
+	s, _ := New(DefaultPrecision)
+	fill(s, 0, 1000)
+	data, _ := s.MarshalBinary()
+	if len(data) >= 1<<DefaultPrecision/2 {
+		t.Errorf("sparse encoding of 1000 elements is %d bytes", len(data))
+	}
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	s, _ := New(DefaultPrecision)
+	fill(s, 0, 10)
+	valid, _ := s.MarshalBinary()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad_version", []byte{9, DefaultPrecision, kindSparse, 0}},
+		{"bad_precision", []byte{encodingVersion, 30, kindSparse, 0}},
+		{"bad_kind", []byte{encodingVersion, DefaultPrecision, 7, 0}},
+		{"short_dense", []byte{encodingVersion, DefaultPrecision, kindDense, 1, 2}},
+		{"truncated_sparse", valid[:len(valid)-1]},
+		{"trailing_bytes", append(append([]byte{}, valid...), 1)},
+		{"zero_rank", []byte{encodingVersion, DefaultPrecision, kindSparse, 1, 64}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Sketch
+			if err := got.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("UnmarshalBinary() error = %v, want ErrInvalidData", err)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	s, _ := New(DefaultPrecision)
+	fill(s, 0, 500)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	got := &Sketch{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got.Count() != s.Count() {
+		t.Errorf("Count() = %d, want %d", got.Count(), s.Count())
+	}
+}
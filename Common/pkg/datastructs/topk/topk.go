@@ -0,0 +1,206 @@
+package topk
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+)
+
+var ErrInvalidCapacity = errors.New("topk: capacity must be greater than 0")
+
+// Item is a tracked key. Count may overestimate the true count by at most Error,
+// so the true count lies in [Count-Error, Count].
+type Item struct {
+	Key   string
+	Count uint64
+	Error uint64
+}
+
+// TopK finds the most frequent keys of a stream with the Space-Saving algorithm,
+// tracking at most capacity keys. Any key seen more than Total/capacity times is tracked.
+// NOT thread-safe.
+type TopK struct {
+	capacity int
+	items    minHeap
+	index    map[string]int
+	total    uint64
+}
+
+// New creates an empty TopK tracking at most capacity keys.
+func New(capacity int) (*TopK, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	t := &TopK{capacity: capacity}
+	t.Reset()
+	return t, nil
+}
+
+// Add counts n occurrences of key. When all slots are taken, the least counted key is evicted
+// and key inherits its count, which becomes key's Error.
+func (t *TopK) Add(key string, n uint64) {
+	if n == 0 {
+		return
+	}
+	t.total += n
+
+	if i, ok := t.index[key]; ok {
+		t.items.entries[i].Count += n
+		heap.Fix(&t.items, i)
+		return
+	}
+
+	if len(t.items.entries) < t.capacity {
+		heap.Push(&t.items, &Item{Key: key, Count: n})
+		return
+	}
+
+	min := t.items.entries[0]
+	delete(t.index, min.Key)
+	t.items.entries[0] = &Item{Key: key, Count: min.Count + n, Error: min.Count}
+	t.index[key] = 0
+	heap.Fix(&t.items, 0)
+}
+
+// Estimate returns the tracked count of key; for an untracked key it is the most it can have been seen.
+func (t *TopK) Estimate(key string) uint64 {
+	if i, ok := t.index[key]; ok {
+		return t.items.entries[i].Count
+	}
+	return t.floor()
+}
+
+// Top returns up to n items by count, highest first; n <= 0 returns every tracked item.
+// Ties are ordered by key so results are stable.
+func (t *TopK) Top(n int) []Item {
+	res := make([]Item, len(t.items.entries))
+	for i, it := range t.items.entries {
+		res[i] = *it
+	}
+	sortItems(res)
+
+	if n > 0 && n < len(res) {
+		res = res[:n]
+	}
+	return res
+}
+
+// Merge folds other into t, so t summarizes both streams, e.g. the buckets of a sliding window.
+// A key missing from a full summary may have been counted up to that summary's smallest count,
+// which is added to both its count and its error.
+func (t *TopK) Merge(other *TopK) {
+	floorT, floorO := t.floor(), other.floor()
+
+	merged := make(map[string]*Item, len(t.items.entries)+len(other.items.entries))
+	for _, it := range t.items.entries {
+		merged[it.Key] = &Item{Key: it.Key, Count: it.Count + floorO, Error: it.Error + floorO}
+	}
+	for _, it := range other.items.entries {
+		if m, ok := merged[it.Key]; ok {
+			m.Count += it.Count - floorO
+			m.Error += it.Error - floorO
+			continue
+		}
+		merged[it.Key] = &Item{Key: it.Key, Count: it.Count + floorT, Error: it.Error + floorT}
+	}
+
+	items := make([]Item, 0, len(merged))
+	for _, it := range merged {
+		items = append(items, *it)
+	}
+	sortItems(items)
+	if len(items) > t.capacity {
+		items = items[:t.capacity]
+	}
+
+	total := t.total + other.total
+	t.Reset()
+	t.total = total
+	for i := range items {
+		it := items[i]
+		t.index[it.Key] = len(t.items.entries)
+		t.items.entries = append(t.items.entries, &it)
+	}
+	heap.Init(&t.items)
+}
+
+// Clone returns an independent copy of t.
+func (t *TopK) Clone() *TopK {
+	c := &TopK{capacity: t.capacity}
+	c.Reset()
+	c.total = t.total
+	for _, it := range t.items.entries {
+		cp := *it
+		c.index[cp.Key] = len(c.items.entries)
+		c.items.entries = append(c.items.entries, &cp)
+	}
+	return c
+}
+
+// Reset forgets every key.
+func (t *TopK) Reset() {
+	t.index = make(map[string]int, t.capacity)
+	t.items = minHeap{index: t.index, entries: make([]*Item, 0, t.capacity)}
+	t.total = 0
+}
+
+// Len returns how many keys are tracked.
+func (t *TopK) Len() int {
+	return len(t.items.entries)
+}
+
+// Capacity returns the most keys t tracks.
+func (t *TopK) Capacity() int {
+	return t.capacity
+}
+
+// Total returns the sum of everything added, tracked or not.
+func (t *TopK) Total() uint64 {
+	return t.total
+}
+
+// floor is the most an untracked key can have been counted: the smallest count once every slot is taken
+func (t *TopK) floor() uint64 {
+	if len(t.items.entries) < t.capacity {
+		return 0
+	}
+	return t.items.entries[0].Count
+}
+
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Key < items[j].Key
+	})
+}
+
+// minHeap orders items by count, keeping index in step so a key's slot is found in O(1)
+type minHeap struct {
+	entries []*Item
+	index   map[string]int
+}
+
+func (h *minHeap) Len() int { return len(h.entries) }
+
+func (h *minHeap) Less(i, j int) bool { return h.entries[i].Count < h.entries[j].Count }
+
+func (h *minHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.index[h.entries[i].Key] = i
+	h.index[h.entries[j].Key] = j
+}
+
+func (h *minHeap) Push(x any) {
+	it := x.(*Item)
+	h.index[it.Key] = len(h.entries)
+	h.entries = append(h.entries, it)
+}
+
+func (h *minHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	delete(h.index, last.Key)
+	return last
+}
@@ -0,0 +1,230 @@
+package topk
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// zipfStream returns n keys drawn from a Zipf distribution over keys "k0".."k<keys-1>", with their true counts
+func zipfStream(seed int64, n int, keys uint64) ([]string, map[string]uint64) {
+	z := rand.NewZipf(rand.New(rand.NewSource(seed)), 1.2, 1, keys-1)
+	stream := make([]string, n)
+	counts := make(map[string]uint64)
+	for i := range stream {
+		k := "k" + strconv.FormatUint(z.Uint64(), 10)
+		stream[i] = k
+		counts[k]++
+	}
+	return stream, counts
+}
+
+// =============================================================================
+// Constructor Tests: New()
+// =============================================================================
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		wantErr  bool
+	}{
+		{"valid", 10, false},
+		{"one", 1, false},
+		{"zero", 0, true},
+		{"negative", -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk, err := New(tt.capacity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCapacity) {
+					t.Errorf("New() error = %v, want ErrInvalidCapacity", err)
+				}
+				return
+			}
+			if tk.Len() != 0 || tk.Total() != 0 || tk.Capacity() != tt.capacity {
+				t.Error("New() should return an empty summary")
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Counting Tests: Add(), Estimate(), Top()
+// =============================================================================
+
+func TestAdd_ExactUnderCapacity(t *testing.T) {
+	tk, _ := New(10)
+	tk.Add("a", 3)
+	tk.Add("b", 5)
+	tk.Add("a", 4)
+	tk.Add("c", 0) // ignored
+
+	top := tk.Top(0)
+	want := []Item{{Key: "a", Count: 7}, {Key: "b", Count: 5}}
+	if len(top) != len(want) {
+		t.Fatalf("Top() = %v, want %v", top, want)
+	}
+	for i := range want {
+		if top[i] != want[i] {
+			t.Errorf("Top()[%d] = %v, want %v", i, top[i], want[i])
+		}
+	}
+	if tk.Total() != 12 {
+		t.Errorf("Total() = %d, want 12", tk.Total())
+	}
+	if tk.Estimate("c") != 0 {
+		t.Errorf("Estimate(untracked) = %d, want 0 while not full", tk.Estimate("c"))
+	}
+}
+
+func TestAdd_Eviction(t *testing.T) {
+	tk, _ := New(2)
+	tk.Add("a", 5)
+	tk.Add("b", 2)
+	tk.Add("c", 1) // evicts b, inherits its count
+
+	if _, ok := tk.index["b"]; ok {
+		t.Fatal("least counted key should be evicted")
+	}
+	top := tk.Top(0)
+	if top[1] != (Item{Key: "c", Count: 3, Error: 2}) {
+		t.Errorf("new key = %v, want count 3 with error 2", top[1])
+	}
+	if tk.Estimate("b") != 3 {
+		t.Errorf("Estimate(evicted) = %d, want the floor 3", tk.Estimate("b"))
+	}
+}
+
+func TestTop_TiesAndLimit(t *testing.T) {
+	tk, _ := New(10)
+	for _, k := range []string{"c", "a", "b"} {
+		tk.Add(k, 1)
+	}
+	top := tk.Top(2)
+	if len(top) != 2 || top[0].Key != "a" || top[1].Key != "b" {
+		t.Errorf("Top(2) = %v, want a then b", top)
+	}
+}
+
+func TestHeavyHitters(t *testing.T) {
+	const capacity = 100
+	stream, counts := zipfStream(1, 200_000, 10_000)
+
+	tk, _ := New(capacity)
+	for _, k := range stream {
+		tk.Add(k, 1)
+	}
+
+	// Every tracked count brackets the true count
+	for _, it := range tk.Top(0) {
+		truth := counts[it.Key]
+		if it.Count < truth || it.Count-it.Error > truth {
+			t.Errorf("%s: count %d error %d, true count %d", it.Key, it.Count, it.Error, truth)
+		}
+	}
+
+	// Every key above Total/capacity is tracked
+	threshold := tk.Total() / capacity
+	for k, c := range counts {
+		if _, ok := tk.index[k]; c > threshold && !ok {
+			t.Errorf("%s seen %d times (> %d) is not tracked", k, c, threshold)
+		}
+	}
+
+	// The head of a skewed stream comes out in order
+	top := tk.Top(3)
+	for i, want := range []string{"k0", "k1", "k2"} {
+		if top[i].Key != want {
+			t.Errorf("Top(3)[%d] = %s, want %s", i, top[i].Key, want)
+		}
+	}
+}
+
+// =============================================================================
+// Merge Tests: Merge(), Clone()
+// =============================================================================
+
+func TestMerge_Exact(t *testing.T) {
+	a, _ := New(10)
+	b, _ := New(10)
+	a.Add("x", 3)
+	a.Add("y", 1)
+	b.Add("x", 2)
+	b.Add("z", 4)
+
+	a.Merge(b)
+	want := map[string]uint64{"x": 5, "z": 4, "y": 1}
+	for k, c := range want {
+		if a.Estimate(k) != c {
+			t.Errorf("Estimate(%s) = %d, want %d", k, a.Estimate(k), c)
+		}
+	}
+	if a.Total() != 10 {
+		t.Errorf("Total() = %d, want 10", a.Total())
+	}
+}
+
+func TestMerge_Bounds(t *testing.T) {
+	const capacity = 50
+	s1, c1 := zipfStream(2, 50_000, 5_000)
+	s2, c2 := zipfStream(3, 50_000, 5_000)
+
+	a, _ := New(capacity)
+	b, _ := New(capacity)
+	for _, k := range s1 {
+		a.Add(k, 1)
+	}
+	for _, k := range s2 {
+		b.Add(k, 1)
+	}
+	a.Merge(b)
+
+	if a.Len() > capacity {
+		t.Fatalf("Len() = %d, exceeds capacity", a.Len())
+	}
+	for _, it := range a.Top(0) {
+		truth := c1[it.Key] + c2[it.Key]
+		if it.Count < truth || it.Count-it.Error > truth {
+			t.Errorf("%s: count %d error %d, true count %d", it.Key, it.Count, it.Error, truth)
+		}
+	}
+	if a.Top(1)[0].Key != "k0" {
+		t.Errorf("Top(1) = %v, want k0", a.Top(1))
+	}
+}
+
+func TestClone(t *testing.T) {
+	a, _ := New(5)
+	a.Add("x", 2)
+
+	c := a.Clone()
+	c.Add("x", 3)
+	c.Add("y", 1)
+
+	if a.Estimate("x") != 2 || a.Len() != 1 || a.Total() != 2 {
+		t.Error("changing a clone should not change the original")
+	}
+	if c.Estimate("x") != 5 || c.Total() != 6 {
+		t.Errorf("clone = %v total %d, want x=5 total 6", c.Top(0), c.Total())
+	}
+}
+
+// =============================================================================
+// Benchmarks
+// =============================================================================
+
+func BenchmarkAdd(b *testing.B) {
+	stream, _ := zipfStream(4, 1<<16, 100_000)
+	tk, _ := New(1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tk.Add(stream[i&(len(stream)-1)], 1)
+	}
+}
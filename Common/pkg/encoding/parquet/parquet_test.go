@@ -0,0 +1,278 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Test Helpers: a Thrift compact decoder, enough to read back what the writer wrote
+// =============================================================================
+
+type compactReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *compactReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *compactReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *compactReader) value(typ byte) any {
+	switch typ {
+	case ctBoolTrue:
+		return true
+	case ctBoolFalse:
+		return false
+	case ctI32, ctI64:
+		return r.zigzag()
+	case ctBinary:
+		n := int(r.uvarint())
+		s := string(r.buf[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case ctList:
+		h := r.buf[r.pos]
+		r.pos++
+		size, elem := int(h>>4), h&0x0f
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case ctStruct:
+		return r.structure()
+	}
+	panic("unexpected thrift type")
+}
+
+func (r *compactReader) structure() map[int16]any {
+	fields := make(map[int16]any)
+	var last int16
+	for {
+		h := r.buf[r.pos]
+		r.pos++
+		if h == 0 {
+			return fields
+		}
+		typ := h & 0x0f
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		fields[id] = r.value(typ)
+		last = id
+	}
+}
+
+var testColumns = []Column{
+	{Name: "id", Type: String},
+	{Name: "count", Type: Int64},
+	{Name: "at", Type: Timestamp},
+	{Name: "bot", Type: Bool},
+	{Name: "score", Type: Double},
+}
+
+func testRow(i int) []any {
+	return []any{
+		string(rune('a' + i%26)),
+		int64(i * 10),
+		time.UnixMilli(int64(1_700_000_000_000 + i)),
+		i%3 == 0,
+		float64(i) / 2,
+	}
+}
+
+func writeFile(t *testing.T, rows, groupSize int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testColumns, groupSize)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	for i := 0; i < rows; i++ {
+		if err := w.Write(testRow(i)); err != nil {
+			t.Fatalf("Write(%d) error = %v", i, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func readFooter(t *testing.T, file []byte) map[int16]any {
+	t.Helper()
+	if string(file[:4]) != magic || string(file[len(file)-4:]) != magic {
+		t.Fatal("file should start and end with PAR1")
+	}
+	n := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := file[len(file)-8-n : len(file)-8]
+	r := &compactReader{buf: footer}
+	meta := r.structure()
+	if r.pos != len(footer) {
+		t.Fatalf("footer decoded %d of %d bytes", r.pos, len(footer))
+	}
+	return meta
+}
+
+// readColumn decodes the PLAIN values of one column chunk
+func readColumn(t *testing.T, file []byte, chunk map[int16]any, typ Type) []any {
+	t.Helper()
+	meta := chunk[3].(map[int16]any)
+	r := &compactReader{buf: file, pos: int(meta[9].(int64))}
+	header := r.structure()
+	data := header[5].(map[int16]any)
+	count := int(data[1].(int64))
+	page := file[r.pos : r.pos+int(header[3].(int64))]
+
+	values := make([]any, count)
+	for i := range values {
+		switch typ {
+		case String:
+			n := int(binary.LittleEndian.Uint32(page))
+			values[i], page = string(page[4:4+n]), page[4+n:]
+		case Int64:
+			values[i], page = int64(binary.LittleEndian.Uint64(page)), page[8:]
+		case Timestamp:
+			values[i], page = time.UnixMilli(int64(binary.LittleEndian.Uint64(page))), page[8:]
+		case Double:
+			values[i], page = math.Float64frombits(binary.LittleEndian.Uint64(page)), page[8:]
+		case Bool:
+			values[i] = page[i/8]&(1<<(i%8)) != 0
+		}
+	}
+	return values
+}
+
+// =============================================================================
+// Writer Tests
+// =============================================================================
+
+func TestNewWriter_NoColumns(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, nil, 0); !errors.Is(err, ErrNoColumns) {
+		t.Errorf("NewWriter() error = %v, want ErrNoColumns", err)
+	}
+}
+
+func TestWrite_Validation(t *testing.T) {
+	w, _ := NewWriter(&bytes.Buffer{}, testColumns, 0)
+
+	if err := w.Write([]any{"x"}); !errors.Is(err, ErrColumnCount) {
+		t.Errorf("short row error = %v, want ErrColumnCount", err)
+	}
+	bad := testRow(0)
+	bad[1] = 10 // int, not int64
+	if err := w.Write(bad); !errors.Is(err, ErrColumnType) {
+		t.Errorf("bad type error = %v, want ErrColumnType", err)
+	}
+
+	_ = w.Close()
+	if err := w.Write(testRow(0)); !errors.Is(err, ErrClosed) {
+		t.Errorf("write after close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestFooter_Schema(t *testing.T) {
+	meta := readFooter(t, writeFile(t, 3, 0))
+
+	if meta[1].(int64) != 1 || meta[3].(int64) != 3 {
+		t.Fatalf("version/num_rows = %v/%v, want 1/3", meta[1], meta[3])
+	}
+
+	schema := meta[2].([]any)
+	root := schema[0].(map[int16]any)
+	if root[4] != "schema" || root[5].(int64) != int64(len(testColumns)) {
+		t.Errorf("root element = %v", root)
+	}
+	wantConverted := map[string]int64{"id": convertedUTF8, "at": convertedTimestampMillis}
+	for i, c := range testColumns {
+		el := schema[i+1].(map[int16]any)
+		if el[4] != c.Name || el[1].(int64) != int64(c.Type.physical()) || el[3].(int64) != repetitionRequired {
+			t.Errorf("schema[%d] = %v, want %s", i+1, el, c.Name)
+		}
+		if conv, ok := wantConverted[c.Name]; ok && el[6] != conv {
+			t.Errorf("schema[%d] converted type = %v, want %d", i+1, el[6], conv)
+		}
+	}
+}
+
+func TestRoundTrip_RowGroups(t *testing.T) {
+	const rows, groupSize = 21, 8
+	file := writeFile(t, rows, groupSize)
+	meta := readFooter(t, file)
+
+	groups := meta[4].([]any)
+	if len(groups) != 3 {
+		t.Fatalf("row groups = %d, want 3", len(groups))
+	}
+
+	row := 0
+	for _, g := range groups {
+		group := g.(map[int16]any)
+		chunks := group[1].([]any)
+		n := int(group[3].(int64))
+
+		columns := make([][]any, len(testColumns))
+		for i, c := range testColumns {
+			columns[i] = readColumn(t, file, chunks[i].(map[int16]any), c.Type)
+			if len(columns[i]) != n {
+				t.Fatalf("column %s has %d values, want %d", c.Name, len(columns[i]), n)
+			}
+		}
+
+		for j := 0; j < n; j++ {
+			want := testRow(row)
+			for i := range testColumns {
+				got := columns[i][j]
+				if ts, ok := want[i].(time.Time); ok {
+					if !got.(time.Time).Equal(ts) {
+						t.Errorf("row %d %s = %v, want %v", row, testColumns[i].Name, got, ts)
+					}
+					continue
+				}
+				if got != want[i] {
+					t.Errorf("row %d %s = %v, want %v", row, testColumns[i].Name, got, want[i])
+				}
+			}
+			row++
+		}
+	}
+	if row != rows {
+		t.Errorf("read %d rows, want %d", row, rows)
+	}
+}
+
+func TestEmptyFile(t *testing.T) {
+	meta := readFooter(t, writeFile(t, 0, 0))
+	if meta[3].(int64) != 0 || len(meta[4].([]any)) != 0 {
+		t.Errorf("empty file metadata = %v", meta)
+	}
+}
+
+func TestCompactWriter_LongFieldDelta(t *testing.T) {
+	w := &compactWriter{lastID: []int16{0}}
+	w.i32(1, -3)
+	w.i64(20, 1<<40) // delta 19 needs the long form
+	w.bool(21, true)
+	w.buf = append(w.buf, 0)
+
+	got := (&compactReader{buf: w.buf}).structure()
+	if got[1] != int64(-3) || got[20] != int64(1<<40) || got[21] != true {
+		t.Errorf("decoded = %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package parquet
+
+import "encoding/binary"
+
+// Thrift compact protocol type IDs
+const (
+	ctBoolTrue  = 1
+	ctBoolFalse = 2
+	ctI32       = 5
+	ctI64       = 6
+	ctBinary    = 8
+	ctList      = 9
+	ctStruct    = 12
+)
+
+// compactWriter encodes the few Thrift structures of a Parquet footer and page headers
+// with the compact protocol. Field IDs must be written in increasing order within a struct.
+type compactWriter struct {
+	buf    []byte
+	lastID []int16 // last field ID of each open struct
+}
+
+func (w *compactWriter) fieldHeader(id int16, typ byte) {
+	last := w.lastID[len(w.lastID)-1]
+	if delta := id - last; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.varint(int64(id))
+	}
+	w.lastID[len(w.lastID)-1] = id
+}
+
+func (w *compactWriter) varint(v int64) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(v<<1^v>>63))
+}
+
+func (w *compactWriter) beginStruct() {
+	w.lastID = append(w.lastID, 0)
+}
+
+func (w *compactWriter) endStruct() {
+	w.buf = append(w.buf, 0)
+	w.lastID = w.lastID[:len(w.lastID)-1]
+}
+
+func (w *compactWriter) i32(id int16, v int32) {
+	w.fieldHeader(id, ctI32)
+	w.varint(int64(v))
+}
+
+func (w *compactWriter) i64(id int16, v int64) {
+	w.fieldHeader(id, ctI64)
+	w.varint(v)
+}
+
+func (w *compactWriter) bool(id int16, v bool) {
+	if v {
+		w.fieldHeader(id, ctBoolTrue)
+	} else {
+		w.fieldHeader(id, ctBoolFalse)
+	}
+}
+
+func (w *compactWriter) binary(id int16, v string) {
+	w.fieldHeader(id, ctBinary)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// field opens a nested struct field; close it with endStruct
+func (w *compactWriter) field(id int16) {
+	w.fieldHeader(id, ctStruct)
+	w.beginStruct()
+}
+
+func (w *compactWriter) listHeader(id int16, elemType byte, size int) {
+	w.fieldHeader(id, ctList)
+	if size < 15 {
+		w.buf = append(w.buf, byte(size)<<4|elemType)
+	} else {
+		w.buf = append(w.buf, 0xf0|elemType)
+		w.buf = binary.AppendUvarint(w.buf, uint64(size))
+	}
+}
+
+func (w *compactWriter) i32List(id int16, vs []int32) {
+	w.listHeader(id, ctI32, len(vs))
+	for _, v := range vs {
+		w.varint(int64(v))
+	}
+}
+
+func (w *compactWriter) binaryList(id int16, vs []string) {
+	w.listHeader(id, ctBinary, len(vs))
+	for _, v := range vs {
+		w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+		w.buf = append(w.buf, v...)
+	}
+}
+
+// structList writes a list of structs, calling each to write the fields of element i
+func (w *compactWriter) structList(id int16, size int, each func(i int)) {
+	w.listHeader(id, ctStruct, size)
+	for i := 0; i < size; i++ {
+		w.beginStruct()
+		each(i)
+		w.endStruct()
+	}
+}
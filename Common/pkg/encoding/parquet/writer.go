@@ -0,0 +1,326 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+var (
+	ErrNoColumns   = errors.New("parquet: schema has no columns")
+	ErrColumnCount = errors.New("parquet: row does not match the schema's column count")
+	ErrColumnType  = errors.New("parquet: value does not match the column type")
+	ErrClosed      = errors.New("parquet: writer is closed")
+)
+
+// Type is the type of a column's values.
+type Type int
+
+const (
+	String    Type = iota // string, stored as UTF8 BYTE_ARRAY
+	Int64                 // int64
+	Timestamp             // time.Time, stored as INT64 TIMESTAMP_MILLIS in UTC
+	Bool                  // bool
+	Double                // float64
+)
+
+// Column is one field of a flat schema; every column is required.
+type Column struct {
+	Name string
+	Type Type
+}
+
+const (
+	magic = "PAR1"
+
+	// DefaultRowGroupSize keeps a row group's pages in memory to a few MiB for typical event rows
+	DefaultRowGroupSize = 65536
+
+	createdBy = "go-link parquet writer"
+)
+
+// Parquet physical types, converted types and enums used by the writer
+const (
+	physicalBoolean   = 0
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+	pageTypeData       = 0
+)
+
+// columnChunk is what the footer needs to know about a written column chunk
+type columnChunk struct {
+	offset int64
+	size   int64
+	values int64
+}
+
+// rowGroup is a written row group
+type rowGroup struct {
+	rows    int64
+	size    int64
+	columns []columnChunk
+}
+
+// Writer streams rows into a Parquet file: flat schema, required columns, PLAIN encoding, no compression.
+// Each row group holds one data page per column. Close must be called to write the footer.
+// NOT thread-safe.
+type Writer struct {
+	w            io.Writer
+	columns      []Column
+	rowGroupSize int
+
+	offset int64
+	pages  [][]byte // PLAIN encoded values of the open row group, per column
+	rows   int      // rows in the open row group
+	groups []rowGroup
+	closed bool
+}
+
+// NewWriter writes the file header to w. rowGroupSize <= 0 uses DefaultRowGroupSize.
+func NewWriter(w io.Writer, columns []Column, rowGroupSize int) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, ErrNoColumns
+	}
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultRowGroupSize
+	}
+
+	pw := &Writer{
+		w:            w,
+		columns:      columns,
+		rowGroupSize: rowGroupSize,
+		pages:        make([][]byte, len(columns)),
+	}
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// Write appends one row; values follow the column order and types.
+func (pw *Writer) Write(row []any) error {
+	if pw.closed {
+		return ErrClosed
+	}
+	if len(row) != len(pw.columns) {
+		return ErrColumnCount
+	}
+
+	// Check the whole row first so a bad value does not leave the columns uneven
+	for i, v := range row {
+		if !pw.columns[i].Type.accepts(v) {
+			return fmt.Errorf("%w: column %q", ErrColumnType, pw.columns[i].Name)
+		}
+	}
+
+	for i, v := range row {
+		pw.pages[i] = pw.columns[i].Type.appendPlain(pw.pages[i], pw.rows, v)
+	}
+	pw.rows++
+
+	if pw.rows == pw.rowGroupSize {
+		return pw.flush()
+	}
+	return nil
+}
+
+// Close writes the open row group and the footer. It does not close the underlying writer.
+func (pw *Writer) Close() error {
+	if pw.closed {
+		return ErrClosed
+	}
+	pw.closed = true
+
+	if err := pw.flush(); err != nil {
+		return err
+	}
+
+	footer := pw.footer()
+	if err := pw.write(footer); err != nil {
+		return err
+	}
+	if err := pw.write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer)))); err != nil {
+		return err
+	}
+	return pw.write([]byte(magic))
+}
+
+// flush writes the open row group, one page per column
+func (pw *Writer) flush() error {
+	if pw.rows == 0 {
+		return nil
+	}
+
+	group := rowGroup{rows: int64(pw.rows), columns: make([]columnChunk, len(pw.columns))}
+	for i, page := range pw.pages {
+		header := pageHeader(pw.rows, len(page))
+		chunk := columnChunk{offset: pw.offset, size: int64(len(header) + len(page)), values: int64(pw.rows)}
+
+		if err := pw.write(header); err != nil {
+			return err
+		}
+		if err := pw.write(page); err != nil {
+			return err
+		}
+
+		group.columns[i] = chunk
+		group.size += chunk.size
+		pw.pages[i] = page[:0]
+	}
+
+	pw.groups = append(pw.groups, group)
+	pw.rows = 0
+	return nil
+}
+
+func (pw *Writer) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+	return err
+}
+
+func pageHeader(values, size int) []byte {
+	w := &compactWriter{lastID: []int16{0}}
+	w.i32(1, pageTypeData)
+	w.i32(2, int32(size))
+	w.i32(3, int32(size))
+	w.field(5)
+	w.i32(1, int32(values))
+	w.i32(2, encodingPlain)
+	w.i32(3, encodingRLE)
+	w.i32(4, encodingRLE)
+	w.endStruct()
+	w.buf = append(w.buf, 0)
+	return w.buf
+}
+
+// footer encodes the FileMetaData
+func (pw *Writer) footer() []byte {
+	var rows int64
+	for _, g := range pw.groups {
+		rows += g.rows
+	}
+
+	w := &compactWriter{lastID: []int16{0}}
+	w.i32(1, 1)
+	w.structList(2, len(pw.columns)+1, func(i int) {
+		if i == 0 {
+			w.binary(4, "schema")
+			w.i32(5, int32(len(pw.columns)))
+			return
+		}
+		c := pw.columns[i-1]
+		w.i32(1, c.Type.physical())
+		w.i32(3, repetitionRequired)
+		w.binary(4, c.Name)
+		if conv, ok := c.Type.converted(); ok {
+			w.i32(6, conv)
+		}
+	})
+	w.i64(3, rows)
+	w.structList(4, len(pw.groups), func(g int) {
+		group := pw.groups[g]
+		w.structList(1, len(group.columns), func(i int) {
+			chunk := group.columns[i]
+			w.i64(2, chunk.offset)
+			w.field(3)
+			w.i32(1, pw.columns[i].Type.physical())
+			w.i32List(2, []int32{encodingPlain})
+			w.binaryList(3, []string{pw.columns[i].Name})
+			w.i32(4, codecUncompressed)
+			w.i64(5, chunk.values)
+			w.i64(6, chunk.size)
+			w.i64(7, chunk.size)
+			w.i64(9, chunk.offset)
+			w.endStruct()
+		})
+		w.i64(2, group.size)
+		w.i64(3, group.rows)
+	})
+	w.binary(6, createdBy)
+	w.buf = append(w.buf, 0)
+	return w.buf
+}
+
+func (t Type) physical() int32 {
+	switch t {
+	case Int64, Timestamp:
+		return physicalInt64
+	case Bool:
+		return physicalBoolean
+	case Double:
+		return physicalDouble
+	default:
+		return physicalByteArray
+	}
+}
+
+func (t Type) converted() (int32, bool) {
+	switch t {
+	case String:
+		return convertedUTF8, true
+	case Timestamp:
+		return convertedTimestampMillis, true
+	default:
+		return 0, false
+	}
+}
+
+func (t Type) accepts(v any) bool {
+	switch t {
+	case String:
+		_, ok := v.(string)
+		return ok
+	case Int64:
+		_, ok := v.(int64)
+		return ok
+	case Timestamp:
+		_, ok := v.(time.Time)
+		return ok
+	case Bool:
+		_, ok := v.(bool)
+		return ok
+	case Double:
+		_, ok := v.(float64)
+		return ok
+	default:
+		return false
+	}
+}
+
+// appendPlain PLAIN encodes v as the row-th value of the page; booleans are bit-packed, least significant bit first
+func (t Type) appendPlain(page []byte, row int, v any) []byte {
+	switch t {
+	case String:
+		s := v.(string)
+		page = binary.LittleEndian.AppendUint32(page, uint32(len(s)))
+		return append(page, s...)
+	case Int64:
+		return binary.LittleEndian.AppendUint64(page, uint64(v.(int64)))
+	case Timestamp:
+		return binary.LittleEndian.AppendUint64(page, uint64(v.(time.Time).UnixMilli()))
+	case Double:
+		return binary.LittleEndian.AppendUint64(page, math.Float64bits(v.(float64)))
+	case Bool:
+		if row%8 == 0 {
+			page = append(page, 0)
+		}
+		if v.(bool) {
+			page[len(page)-1] |= 1 << (row % 8)
+		}
+		return page
+	}
+	return page
+}
@@ -0,0 +1,59 @@
+package geoip
+
+import "net/netip"
+
+// City is the part of a GeoIP2/GeoLite2 City record used for click analytics.
+type City struct {
+	CountryCode string // ISO 3166-1 alpha-2
+	Country     string
+	RegionCode  string // ISO 3166-2 subdivision code, without the country prefix
+	Region      string
+	City        string
+	Latitude    float64
+	Longitude   float64
+	HasLocation bool
+}
+
+// City looks addr up in a City (or Country) database and returns nil when it has no record.
+// Names are read in English, the one language every MaxMind database carries.
+func (r *Reader) City(addr netip.Addr) (*City, error) {
+	raw, ok, err := r.Lookup(addr)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	rec, _ := raw.(map[string]any)
+	c := &City{}
+
+	country, _ := rec["country"].(map[string]any)
+	if country == nil {
+		// Anonymous proxies and satellite providers only carry the registered country
+		country, _ = rec["registered_country"].(map[string]any)
+	}
+	c.CountryCode, _ = country["iso_code"].(string)
+	c.Country = englishName(country)
+
+	if subs, ok := rec["subdivisions"].([]any); ok && len(subs) > 0 {
+		sub, _ := subs[0].(map[string]any)
+		c.RegionCode, _ = sub["iso_code"].(string)
+		c.Region = englishName(sub)
+	}
+
+	city, _ := rec["city"].(map[string]any)
+	c.City = englishName(city)
+
+	if loc, ok := rec["location"].(map[string]any); ok {
+		lat, okLat := loc["latitude"].(float64)
+		lon, okLon := loc["longitude"].(float64)
+		if okLat && okLon {
+			c.Latitude, c.Longitude, c.HasLocation = lat, lon, true
+		}
+	}
+	return c, nil
+}
+
+func englishName(m map[string]any) string {
+	names, _ := m["names"].(map[string]any)
+	name, _ := names["en"].(string)
+	return name
+}
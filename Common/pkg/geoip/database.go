@@ -0,0 +1,107 @@
+package geoip
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReloadInterval is used when NewDatabase is given no interval.
+const DefaultReloadInterval = time.Hour
+
+// Database serves lookups from a .mmdb file and swaps in a new copy when the file changes,
+// so a weekly GeoLite2 update needs no restart. Lookups never block on a reload.
+type Database struct {
+	path     string
+	interval time.Duration
+	reader   atomic.Pointer[Reader]
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+	onError  func(error)
+}
+
+// NewDatabase loads the file at path; it fails if the first load fails.
+// interval is how often the file is checked for changes (DefaultReloadInterval if not positive), and onError, if set,
+// is told about reloads that fail while the previous copy stays in use.
+func NewDatabase(path string, interval time.Duration, onError func(error)) (*Database, error) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	db := &Database{
+		path:     path,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		onError:  onError,
+	}
+	if _, err := db.Reload(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// City looks addr up in the current copy of the database.
+func (db *Database) City(addr netip.Addr) (*City, error) {
+	return db.reader.Load().City(addr)
+}
+
+// Metadata returns the metadata of the current copy.
+func (db *Database) Metadata() Metadata {
+	return db.reader.Load().Metadata()
+}
+
+// Reload reads the file again if its size or modification time changed and reports whether it did.
+// Replace the file with a rename, so a reload never sees it half written.
+func (db *Database) Reload() (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	info, err := os.Stat(db.path)
+	if err != nil {
+		return false, err
+	}
+	if db.reader.Load() != nil && info.ModTime().Equal(db.modTime) && info.Size() == db.size {
+		return false, nil
+	}
+
+	r, err := Open(db.path)
+	if err != nil {
+		return false, err
+	}
+
+	db.reader.Store(r)
+	db.modTime, db.size = info.ModTime(), info.Size()
+	return true, nil
+}
+
+// Start checks the file for changes every interval until ctx is done or Stop is called.
+func (db *Database) Start(ctx context.Context) error {
+	ticker := time.NewTicker(db.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := db.Reload(); err != nil && db.onError != nil {
+				db.onError(err)
+			}
+		case <-db.stopChan:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Stop ends Start.
+func (db *Database) Stop() {
+	db.stopOnce.Do(func() { close(db.stopChan) })
+}
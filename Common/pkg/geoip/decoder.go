@@ -0,0 +1,220 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Data section field types, see https://maxmind.github.io/MaxMind-DB/
+const (
+	typeExtended  = 0
+	typePointer   = 1
+	typeString    = 2
+	typeDouble    = 3
+	typeBytes     = 4
+	typeUint16    = 5
+	typeUint32    = 6
+	typeMap       = 7
+	typeInt32     = 8
+	typeUint64    = 9
+	typeUint128   = 10
+	typeArray     = 11
+	typeContainer = 12
+	typeEnd       = 13
+	typeBool      = 14
+	typeFloat     = 15
+)
+
+// maxDepth bounds nesting so a corrupt file cannot recurse without end
+const maxDepth = 32
+
+// decoder reads values from a data section; pointers are offsets from its start
+type decoder struct {
+	buf []byte
+}
+
+// decode returns the value at offset and the offset just past it.
+// Maps decode to map[string]any, arrays to []any, unsigned integers to uint64
+// (uint128 to *big.Int), int32 to int64 and floats to float64.
+func (d *decoder) decode(offset uint, depth int) (any, uint, error) {
+	if depth > maxDepth {
+		return nil, 0, fmt.Errorf("%w: data nested too deeply", ErrInvalidDatabase)
+	}
+
+	typ, size, offset, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typ == typePointer {
+		ptr, next, err := d.pointer(size, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		v, _, err := d.decode(ptr, depth+1)
+		return v, next, err
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, size)
+		for i := uint(0); i < size; i++ {
+			k, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("%w: map key is not a string", ErrInvalidDatabase)
+			}
+			v, next, err := d.decode(next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			offset = next
+		}
+		return m, offset, nil
+
+	case typeArray:
+		a := make([]any, 0, min(size, uint(len(d.buf))))
+		for i := uint(0); i < size; i++ {
+			v, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			a = append(a, v)
+			offset = next
+		}
+		return a, offset, nil
+
+	case typeBool:
+		if size > 1 {
+			return nil, 0, fmt.Errorf("%w: bad boolean", ErrInvalidDatabase)
+		}
+		return size == 1, offset, nil
+	}
+
+	end := offset + size
+	if end > uint(len(d.buf)) || end < offset {
+		return nil, 0, fmt.Errorf("%w: value runs past the data section", ErrInvalidDatabase)
+	}
+	b := d.buf[offset:end]
+
+	switch typ {
+	case typeString:
+		return string(b), end, nil
+	case typeBytes:
+		return append([]byte(nil), b...), end, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: bad double", ErrInvalidDatabase)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), end, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: bad float", ErrInvalidDatabase)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), end, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("%w: bad unsigned integer", ErrInvalidDatabase)
+		}
+		return uintFrom(b), end, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: bad int32", ErrInvalidDatabase)
+		}
+		return int64(int32(uint32(uintFrom(b)))), end, nil
+	case typeUint128:
+		if size > 16 {
+			return nil, 0, fmt.Errorf("%w: bad uint128", ErrInvalidDatabase)
+		}
+		return new(big.Int).SetBytes(b), end, nil
+	}
+
+	return nil, 0, fmt.Errorf("%w: unknown data type %d", ErrInvalidDatabase, typ)
+}
+
+// control reads a field's control byte, its extended type and its size
+func (d *decoder) control(offset uint) (int, uint, uint, error) {
+	b, offset, err := d.bytes(offset, 1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	ctrl := b[0]
+	typ := int(ctrl >> 5)
+
+	if typ == typeExtended {
+		if b, offset, err = d.bytes(offset, 1); err != nil {
+			return 0, 0, 0, err
+		}
+		typ = 7 + int(b[0])
+		if typ <= typeMap || typ == typeContainer || typ == typeEnd || typ > typeFloat {
+			return 0, 0, 0, fmt.Errorf("%w: bad extended type %d", ErrInvalidDatabase, typ)
+		}
+	}
+
+	size := uint(ctrl & 0x1f)
+	if typ == typePointer {
+		// Pointers pack their own size bits, decoded by pointer
+		return typ, size, offset, nil
+	}
+
+	switch size {
+	case 29:
+		if b, offset, err = d.bytes(offset, 1); err != nil {
+			return 0, 0, 0, err
+		}
+		size = 29 + uint(b[0])
+	case 30:
+		if b, offset, err = d.bytes(offset, 2); err != nil {
+			return 0, 0, 0, err
+		}
+		size = 285 + uint(uintFrom(b))
+	case 31:
+		if b, offset, err = d.bytes(offset, 3); err != nil {
+			return 0, 0, 0, err
+		}
+		size = 65821 + uint(uintFrom(b))
+	}
+	return typ, size, offset, nil
+}
+
+// pointer decodes a pointer from the size bits of its control byte and the bytes after it
+func (d *decoder) pointer(size, offset uint) (uint, uint, error) {
+	n := (size>>3)&0x3 + 1
+	b, next, err := d.bytes(offset, n)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	v := uint(uintFrom(b))
+	switch n {
+	case 1:
+		v |= (size & 0x7) << 8
+	case 2:
+		v = v | (size&0x7)<<16 + 2048
+	case 3:
+		v = v | (size&0x7)<<24 + 526336
+	}
+	return v, next, nil
+}
+
+func (d *decoder) bytes(offset, n uint) ([]byte, uint, error) {
+	end := offset + n
+	if end > uint(len(d.buf)) || end < offset {
+		return nil, 0, fmt.Errorf("%w: unexpected end of data", ErrInvalidDatabase)
+	}
+	return d.buf[offset:end], end, nil
+}
+
+func uintFrom(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
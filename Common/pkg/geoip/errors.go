@@ -0,0 +1,8 @@
+package geoip
+
+import "errors"
+
+var (
+	ErrInvalidDatabase = errors.New("geoip: invalid MaxMind database")
+	ErrInvalidAddress  = errors.New("geoip: invalid IP address")
+)
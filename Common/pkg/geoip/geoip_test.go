@@ -0,0 +1,414 @@
+package geoip
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Test database writer
+// =============================================================================
+
+// testPointer encodes as a pointer to the given data section offset
+type testPointer uint
+
+type testEntry struct {
+	prefix string
+	value  any
+}
+
+func ctrl(typ int, size int) []byte {
+	var out []byte
+	first := byte(typ << 5)
+	if typ > 7 {
+		first = 0
+	}
+	switch {
+	case size < 29:
+		out = []byte{first | byte(size)}
+	case size < 285:
+		out = []byte{first | 29, byte(size - 29)}
+	case size < 65821:
+		s := size - 285
+		out = []byte{first | 30, byte(s >> 8), byte(s)}
+	default:
+		s := size - 65821
+		out = []byte{first | 31, byte(s >> 16), byte(s >> 8), byte(s)}
+	}
+	if typ > 7 {
+		out = append([]byte{out[0], byte(typ - 7)}, out[1:]...)
+	}
+	return out
+}
+
+func encode(v any) []byte {
+	switch x := v.(type) {
+	case string:
+		return append(ctrl(typeString, len(x)), x...)
+	case float64:
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, math.Float64bits(x))
+		return append(ctrl(typeDouble, 8), b...)
+	case uint64:
+		b := binary.BigEndian.AppendUint64(nil, x)
+		for len(b) > 0 && b[0] == 0 {
+			b = b[1:]
+		}
+		return append(ctrl(typeUint64, len(b)), b...)
+	case int:
+		return encode(uint64(x))
+	case bool:
+		size := 0
+		if x {
+			size = 1
+		}
+		return ctrl(typeBool, size)
+	case []any:
+		out := ctrl(typeArray, len(x))
+		for _, e := range x {
+			out = append(out, encode(e)...)
+		}
+		return out
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := ctrl(typeMap, len(x))
+		for _, k := range keys {
+			out = append(out, encode(k)...)
+			out = append(out, encode(x[k])...)
+		}
+		return out
+	case testPointer:
+		p := uint(x)
+		switch {
+		case p < 2048:
+			return []byte{1<<5 | byte(p>>8), byte(p)}
+		default:
+			p -= 2048
+			return []byte{1<<5 | 1<<3 | byte(p>>16), byte(p >> 8), byte(p)}
+		}
+	}
+	panic("unsupported test value")
+}
+
+// buildDB writes a MaxMind DB; IPv4 prefixes in an IPv6 tree go under ::/96 as real databases do
+func buildDB(t *testing.T, ipVersion, recordSize int, entries []testEntry) []byte {
+	t.Helper()
+
+	const (
+		recEmpty = iota
+		recNode
+		recData
+	)
+	type rec struct{ kind, v int }
+	nodes := [][2]rec{{}}
+
+	var data []byte
+	for _, e := range entries {
+		p := netip.MustParsePrefix(e.prefix)
+		ip, bits := p.Addr().AsSlice(), p.Bits()
+		if p.Addr().Is4() && ipVersion == 6 {
+			ip = append(make([]byte, 12), ip...)
+			bits += 96
+		}
+
+		offset := len(data)
+		data = append(data, encode(e.value)...)
+
+		node := 0
+		for i := 0; i < bits; i++ {
+			bit := int(ip[i/8]>>(7-i%8)) & 1
+			if i == bits-1 {
+				nodes[node][bit] = rec{recData, offset}
+				break
+			}
+			if nodes[node][bit].kind != recNode {
+				nodes = append(nodes, [2]rec{})
+				nodes[node][bit] = rec{recNode, len(nodes) - 1}
+			}
+			node = nodes[node][bit].v
+		}
+	}
+
+	n := len(nodes)
+	value := func(r rec) uint32 {
+		switch r.kind {
+		case recNode:
+			return uint32(r.v)
+		case recData:
+			return uint32(n + dataSectionSeparator + r.v)
+		}
+		return uint32(n)
+	}
+
+	var buf []byte
+	for _, nd := range nodes {
+		l, r := value(nd[0]), value(nd[1])
+		switch recordSize {
+		case 24:
+			buf = append(buf, byte(l>>16), byte(l>>8), byte(l), byte(r>>16), byte(r>>8), byte(r))
+		case 28:
+			buf = append(buf, byte(l>>16), byte(l>>8), byte(l), byte(l>>24)<<4|byte(r>>24)&0x0F, byte(r>>16), byte(r>>8), byte(r))
+		case 32:
+			buf = binary.BigEndian.AppendUint32(buf, l)
+			buf = binary.BigEndian.AppendUint32(buf, r)
+		}
+	}
+
+	buf = append(buf, make([]byte, dataSectionSeparator)...)
+	buf = append(buf, data...)
+	buf = append(buf, metadataMarker...)
+	buf = append(buf, encode(map[string]any{
+		"binary_format_major_version": 2,
+		"binary_format_minor_version": 0,
+		"build_epoch":                 1700000000,
+		"database_type":               "Test-City",
+		"ip_version":                  ipVersion,
+		"languages":                   []any{"en"},
+		"node_count":                  n,
+		"record_size":                 recordSize,
+	})...)
+	return buf
+}
+
+func cityRecord(countryCode, country, region, city string, lat, lon float64) map[string]any {
+	return map[string]any{
+		"country":      map[string]any{"iso_code": countryCode, "names": map[string]any{"en": country}},
+		"subdivisions": []any{map[string]any{"iso_code": "HN", "names": map[string]any{"en": region}}},
+		"city":         map[string]any{"names": map[string]any{"en": city}},
+		"location":     map[string]any{"latitude": lat, "longitude": lon},
+	}
+}
+
+var testEntries = []testEntry{
+	{"81.2.69.0/24", cityRecord("GB", "United Kingdom", "England", "London", 51.5142, -0.0931)},
+	{"14.160.0.0/12", cityRecord("VN", "Vietnam", "Hanoi", "Hanoi", 21.0292, 105.8526)},
+	{"2001:db8::/32", cityRecord("DE", "Germany", "Berlin", "Berlin", 52.52, 13.405)},
+}
+
+// =============================================================================
+// Reader Tests
+// =============================================================================
+
+func TestCityLookup(t *testing.T) {
+	for _, size := range []int{24, 28, 32} {
+		r, err := FromBytes(buildDB(t, 6, size, testEntries))
+		if err != nil {
+			t.Fatalf("record size %d: FromBytes() error = %v", size, err)
+		}
+
+		tests := []struct {
+			ip      string
+			want    string
+			wantLat float64
+		}{
+			{"81.2.69.160", "London", 51.5142},
+			{"::ffff:81.2.69.1", "London", 51.5142},
+			{"14.161.5.9", "Hanoi", 21.0292},
+			{"2001:db8:1::1", "Berlin", 52.52},
+			{"8.8.8.8", "", 0},
+			{"2001:db9::1", "", 0},
+		}
+		for _, tt := range tests {
+			c, err := r.City(netip.MustParseAddr(tt.ip))
+			if err != nil {
+				t.Fatalf("record size %d: City(%s) error = %v", size, tt.ip, err)
+			}
+			if tt.want == "" {
+				if c != nil {
+					t.Errorf("record size %d: City(%s) = %+v, want nil", size, tt.ip, c)
+				}
+				continue
+			}
+			if c == nil || c.City != tt.want || c.Latitude != tt.wantLat || !c.HasLocation {
+				t.Errorf("record size %d: City(%s) = %+v, want %s", size, tt.ip, c, tt.want)
+			}
+		}
+	}
+}
+
+func TestCityFields(t *testing.T) {
+	r, _ := FromBytes(buildDB(t, 6, 24, testEntries))
+	c, _ := r.City(netip.MustParseAddr("14.160.1.1"))
+	want := City{CountryCode: "VN", Country: "Vietnam", RegionCode: "HN", Region: "Hanoi", City: "Hanoi", Latitude: 21.0292, Longitude: 105.8526, HasLocation: true}
+	if c == nil || *c != want {
+		t.Errorf("City() = %+v, want %+v", c, want)
+	}
+}
+
+func TestIPv4Database(t *testing.T) {
+	r, err := FromBytes(buildDB(t, 4, 24, testEntries[:2]))
+	if err != nil {
+		t.Fatalf("FromBytes() error = %v", err)
+	}
+	if c, _ := r.City(netip.MustParseAddr("81.2.69.1")); c == nil || c.CountryCode != "GB" {
+		t.Errorf("City() = %+v, want GB", c)
+	}
+	if c, err := r.City(netip.MustParseAddr("2001:db8::1")); c != nil || err != nil {
+		t.Errorf("IPv6 lookup in an IPv4 database = %+v, %v; want nil, nil", c, err)
+	}
+}
+
+func TestPointerAndRegisteredCountry(t *testing.T) {
+	shared := map[string]any{"iso_code": "FR", "names": map[string]any{"en": "France"}}
+	entries := []testEntry{
+		{"10.0.0.0/8", shared},
+		// Points back at the map written for the first entry, as MaxMind databases deduplicate
+		{"11.0.0.0/8", map[string]any{"registered_country": testPointer(0)}},
+	}
+	r, err := FromBytes(buildDB(t, 6, 24, entries))
+	if err != nil {
+		t.Fatalf("FromBytes() error = %v", err)
+	}
+	c, err := r.City(netip.MustParseAddr("11.1.2.3"))
+	if err != nil || c == nil || c.CountryCode != "FR" || c.Country != "France" || c.HasLocation {
+		t.Errorf("City() = %+v, %v; want France without location", c, err)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	r, _ := FromBytes(buildDB(t, 6, 28, testEntries))
+	m := r.Metadata()
+	if m.DatabaseType != "Test-City" || m.IPVersion != 6 || m.RecordSize != 28 || m.BuildEpoch != 1700000000 || len(m.Languages) != 1 {
+		t.Errorf("Metadata() = %+v", m)
+	}
+}
+
+func TestFromBytesInvalid(t *testing.T) {
+	valid := buildDB(t, 6, 24, testEntries)
+	tests := []struct {
+		name string
+		buf  []byte
+	}{
+		{"empty", nil},
+		{"no_marker", []byte("not a database")},
+		{"truncated_tree", valid[len(valid)-200:]},
+		{"bad_metadata", append(append([]byte{}, metadataMarker...), 0xff)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromBytes(tt.buf); !errors.Is(err, ErrInvalidDatabase) {
+				t.Errorf("FromBytes() error = %v, want ErrInvalidDatabase", err)
+			}
+		})
+	}
+}
+
+func TestLookupInvalidAddress(t *testing.T) {
+	r, _ := FromBytes(buildDB(t, 6, 24, testEntries))
+	if _, _, err := r.Lookup(netip.Addr{}); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("Lookup() error = %v, want ErrInvalidAddress", err)
+	}
+}
+
+// =============================================================================
+// Decoder Tests
+// =============================================================================
+
+func TestDecodeLongString(t *testing.T) {
+	for _, n := range []int{28, 29, 300, 70000} {
+		s := string(make([]byte, n))
+		d := decoder{buf: encode(s)}
+		v, next, err := d.decode(0, 0)
+		if err != nil || v.(string) != s || next != uint(len(d.buf)) {
+			t.Errorf("len %d: decode() = %d bytes, next %d, err %v", n, len(v.(string)), next, err)
+		}
+	}
+}
+
+func TestDecodeLongPointer(t *testing.T) {
+	buf := make([]byte, 3000)
+	copy(buf[2500:], encode("far"))
+	ptr := encode(testPointer(2500))
+	d := decoder{buf: append(buf, ptr...)}
+	v, _, err := d.decode(3000, 0)
+	if err != nil || v != "far" {
+		t.Errorf("decode() = %v, %v; want far", v, err)
+	}
+}
+
+// =============================================================================
+// Database Tests
+// =============================================================================
+
+func TestDatabaseReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "city.mmdb")
+	if err := os.WriteFile(path, buildDB(t, 6, 24, testEntries[:1]), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := NewDatabase(path, 10*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+	if c, _ := db.City(netip.MustParseAddr("14.160.1.1")); c != nil {
+		t.Fatalf("City() before update = %+v, want nil", c)
+	}
+
+	if changed, _ := db.Reload(); changed {
+		t.Error("Reload() of an unchanged file reported a change")
+	}
+
+	// Replace the file the way updaters do: write aside, then rename over it
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buildDB(t, 6, 24, testEntries), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	go func() { _ = db.Start(ctx) }()
+	defer db.Stop()
+
+	for {
+		if c, _ := db.City(netip.MustParseAddr("14.160.1.1")); c != nil {
+			if c.City != "Hanoi" {
+				t.Errorf("City() after update = %+v, want Hanoi", c)
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("database was not reloaded")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestDatabaseKeepsOldCopyOnBadUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "city.mmdb")
+	_ = os.WriteFile(path, buildDB(t, 6, 24, testEntries), 0o644)
+
+	db, err := NewDatabase(path, time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+
+	_ = os.WriteFile(path, []byte("corrupt"), 0o644)
+	if _, err := db.Reload(); err == nil {
+		t.Error("Reload() of a corrupt file should fail")
+	}
+	if c, _ := db.City(netip.MustParseAddr("81.2.69.1")); c == nil || c.City != "London" {
+		t.Errorf("City() after failed reload = %+v, want London", c)
+	}
+}
+
+func TestNewDatabaseMissingFile(t *testing.T) {
+	if _, err := NewDatabase(filepath.Join(t.TempDir(), "missing.mmdb"), time.Second, nil); err == nil {
+		t.Error("NewDatabase() of a missing file should fail")
+	}
+}
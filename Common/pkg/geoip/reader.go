@@ -0,0 +1,199 @@
+package geoip
+
+import (
+	"bytes"
+	"fmt"
+	"net/netip"
+	"os"
+)
+
+// metadataMarker precedes the metadata map at the end of every MaxMind DB file
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparator is the run of zero bytes between the search tree and the data section
+const dataSectionSeparator = 16
+
+// Metadata describes a MaxMind DB file.
+type Metadata struct {
+	DatabaseType string
+	IPVersion    uint
+	RecordSize   uint
+	NodeCount    uint
+	BuildEpoch   uint64
+	Languages    []string
+}
+
+// Reader looks addresses up in an in-memory MaxMind DB (.mmdb) file.
+// It is safe for concurrent use; it never modifies the buffer it reads from.
+type Reader struct {
+	tree      []byte
+	data      decoder
+	meta      Metadata
+	ipv4Start uint
+	ipv4Bits  int
+}
+
+// Open reads a MaxMind DB file from disk.
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytes(buf)
+}
+
+// FromBytes parses a MaxMind DB file held in memory.
+func FromBytes(buf []byte) (*Reader, error) {
+	at := bytes.LastIndex(buf, metadataMarker)
+	if at < 0 {
+		return nil, fmt.Errorf("%w: metadata marker not found", ErrInvalidDatabase)
+	}
+
+	metaDecoder := decoder{buf: buf[at+len(metadataMarker):]}
+	raw, _, err := metaDecoder.decode(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := parseMetadata(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	treeSize := meta.RecordSize * 2 / 8 * meta.NodeCount
+	if treeSize+dataSectionSeparator > uint(at) {
+		return nil, fmt.Errorf("%w: search tree runs past the file", ErrInvalidDatabase)
+	}
+
+	r := &Reader{
+		tree: buf[:treeSize],
+		data: decoder{buf: buf[treeSize+dataSectionSeparator : at]},
+		meta: meta,
+	}
+
+	// IPv4 addresses live under ::/96 of an IPv6 tree, so walk the 96 zero bits once up front
+	if meta.IPVersion == 6 {
+		node := uint(0)
+		i := 0
+		for ; i < 96 && node < meta.NodeCount; i++ {
+			if node, err = r.record(node, 0); err != nil {
+				return nil, err
+			}
+		}
+		r.ipv4Start, r.ipv4Bits = node, i
+	}
+	return r, nil
+}
+
+// Metadata returns the database's metadata.
+func (r *Reader) Metadata() Metadata {
+	return r.meta
+}
+
+// Lookup returns the record for addr, decoded as in the MaxMind DB data section,
+// and false when the database has no record for it.
+func (r *Reader) Lookup(addr netip.Addr) (any, bool, error) {
+	if !addr.IsValid() {
+		return nil, false, ErrInvalidAddress
+	}
+	addr = addr.Unmap()
+
+	node, bitCount := uint(0), 128
+	if addr.Is4() {
+		bitCount = 32
+		if r.meta.IPVersion == 6 {
+			node = r.ipv4Start
+		}
+	} else if r.meta.IPVersion == 4 {
+		return nil, false, nil
+	}
+
+	ip := addr.AsSlice()
+	for i := 0; i < bitCount && node < r.meta.NodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-uint(i&7))) & 1
+		var err error
+		if node, err = r.record(node, bit); err != nil {
+			return nil, false, err
+		}
+	}
+
+	switch {
+	case node == r.meta.NodeCount:
+		return nil, false, nil
+	case node < r.meta.NodeCount:
+		return nil, false, fmt.Errorf("%w: search tree deeper than the address", ErrInvalidDatabase)
+	}
+
+	offset := node - r.meta.NodeCount - dataSectionSeparator
+	v, _, err := r.data.decode(offset, 0)
+	if err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+// record returns the left (bit 0) or right (bit 1) record of a search tree node
+func (r *Reader) record(node, bit uint) (uint, error) {
+	width := r.meta.RecordSize * 2 / 8
+	start := node * width
+	if start+width > uint(len(r.tree)) {
+		return 0, fmt.Errorf("%w: node out of range", ErrInvalidDatabase)
+	}
+	b := r.tree[start : start+width]
+
+	switch r.meta.RecordSize {
+	case 24:
+		if bit == 0 {
+			return uint(uintFrom(b[0:3])), nil
+		}
+		return uint(uintFrom(b[3:6])), nil
+	case 28:
+		// The middle byte holds the high nibble of both records
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(uintFrom(b[0:3])), nil
+		}
+		return uint(b[3]&0x0F)<<24 | uint(uintFrom(b[4:7])), nil
+	default:
+		if bit == 0 {
+			return uint(uintFrom(b[0:4])), nil
+		}
+		return uint(uintFrom(b[4:8])), nil
+	}
+}
+
+func parseMetadata(raw any) (Metadata, error) {
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return Metadata{}, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+
+	num := func(key string) uint {
+		v, _ := m[key].(uint64)
+		return uint(v)
+	}
+
+	meta := Metadata{
+		IPVersion:  num("ip_version"),
+		RecordSize: num("record_size"),
+		NodeCount:  num("node_count"),
+	}
+	meta.DatabaseType, _ = m["database_type"].(string)
+	meta.BuildEpoch, _ = m["build_epoch"].(uint64)
+	if langs, ok := m["languages"].([]any); ok {
+		for _, l := range langs {
+			if s, ok := l.(string); ok {
+				meta.Languages = append(meta.Languages, s)
+			}
+		}
+	}
+
+	if major := num("binary_format_major_version"); major != 2 {
+		return Metadata{}, fmt.Errorf("%w: unsupported format version %d", ErrInvalidDatabase, major)
+	}
+	if meta.RecordSize != 24 && meta.RecordSize != 28 && meta.RecordSize != 32 {
+		return Metadata{}, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, meta.RecordSize)
+	}
+	if meta.IPVersion != 4 && meta.IPVersion != 6 {
+		return Metadata{}, fmt.Errorf("%w: unsupported ip version %d", ErrInvalidDatabase, meta.IPVersion)
+	}
+	return meta, nil
+}
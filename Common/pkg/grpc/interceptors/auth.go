@@ -35,3 +35,25 @@ func ServerAuthInterceptor() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// ServerAuthStreamInterceptor extracts user context from gRPC metadata into the context of a server stream.
+func ServerAuthStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: metadata.ExtractIncomingContext(ss.Context())})
+	}
+}
+
+// contextStream is a server stream whose context carries the extracted user context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -29,6 +29,21 @@ func ServerErrorInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// ServerErrorStreamInterceptor returns a new stream server interceptor that handles error mapping.
+func ServerErrorStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := handler(srv, ss); err != nil {
+			return mapErrorToGRPCStatus(err)
+		}
+		return nil
+	}
+}
+
 // mapErrorToGRPCStatus maps app errors to gRPC status errors.
 func mapErrorToGRPCStatus(err error) error {
 	var appErr *apperr.AppError
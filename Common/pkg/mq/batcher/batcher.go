@@ -1,9 +1,15 @@
 package batcher
 
 import (
+	"runtime"
 	"sync"
+	"time"
 )
 
+// stripesPerProc bounds how many stripes a batcher keeps per P.
+// Beyond it, the pool hands out existing stripes again instead of allocating new ones.
+const stripesPerProc = 4
+
 // StripedBatcher is a high-performance, concurrent batcher using striped buffers.
 // It leverages sync.Pool to reduce contention (mutex-free mostly) and allocations.
 //
@@ -11,11 +17,24 @@ import (
 //   - Multiple goroutines can call Push() concurrently.
 //   - Items are batched into local "stripes" (buffers) per P (processor) ideally.
 //   - When a stripe is full, it is flushed to the Consumer immediately.
-//   - This is a "Lossy" design regarding graceful shutdown: items pending in stripes
-//     inside the pool are NOT guaranteed to be flushed on shutdown unless Consumer
-//     handles tracking. Use this for metrics, logs, or cache events where speed > absolute precision.
+//   - With Config.FlushInterval set, every stripe is also flushed on a timer, so
+//     partly filled stripes do not hold items indefinitely.
+//   - Flush() hands every pending item to the Consumer; Close() stops the timer
+//     and flushes once more, so nothing pushed before Close is lost on shutdown.
+//   - Without a FlushInterval and Close, items pending in stripes are NOT guaranteed
+//     to reach the Consumer. Use that mode for metrics, logs, or cache events where
+//     speed > absolute precision.
 type StripedBatcher[T any] struct {
 	pool *sync.Pool
+
+	mu         sync.Mutex
+	stripes    []*stripe[T]
+	next       int
+	maxStripes int
+
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+	closeOnce sync.Once
 }
 
 // New creates a new StripedBatcher for type T.
@@ -25,13 +44,24 @@ func New[T any](cons Consumer[T], cfg Config) *StripedBatcher[T] {
 		cfg.StripeSize = 512
 	}
 
-	return &StripedBatcher[T]{
-		pool: &sync.Pool{
-			New: func() any {
-				return newStripe[T](cons, cfg.StripeSize)
-			},
+	b := &StripedBatcher[T]{
+		maxStripes: runtime.GOMAXPROCS(0) * stripesPerProc,
+		stopChan:   make(chan struct{}),
+		doneChan:   make(chan struct{}),
+	}
+	b.pool = &sync.Pool{
+		New: func() any {
+			return b.stripe(cons, cfg.StripeSize)
 		},
 	}
+
+	if cfg.FlushInterval > 0 {
+		go b.flushLoop(cfg.FlushInterval)
+	} else {
+		close(b.doneChan)
+	}
+
+	return b
 }
 
 // Push adds an item to the batcher.
@@ -42,9 +72,65 @@ func (b *StripedBatcher[T]) Push(item T) {
 	//    minimizing contention.
 	s := b.pool.Get().(*stripe[T])
 
-	// 2. Push item to the stripe (not thread-safe, but we own it right now).
+	// 2. Push item to the stripe (guarded by the stripe's own, almost always uncontended, lock).
 	s.Push(item)
 
 	// 3. Return stripe to the pool.
 	b.pool.Put(s)
 }
+
+// Flush hands the items pending in every stripe to the Consumer.
+func (b *StripedBatcher[T]) Flush() {
+	b.mu.Lock()
+	stripes := make([]*stripe[T], len(b.stripes))
+	copy(stripes, b.stripes)
+	b.mu.Unlock()
+
+	for _, s := range stripes {
+		s.Flush()
+	}
+}
+
+// Close stops the flush timer and flushes the pending items one last time.
+// Items pushed after Close still reach the Consumer once their stripe fills or Flush is called.
+func (b *StripedBatcher[T]) Close() {
+	b.closeOnce.Do(func() {
+		close(b.stopChan)
+	})
+	<-b.doneChan
+	b.Flush()
+}
+
+// stripe registers a new stripe for the pool, or hands out an existing one once the limit is reached,
+// so stripes dropped by the pool are never lost to Flush and never pile up.
+func (b *StripedBatcher[T]) stripe(cons Consumer[T], capacity int) *stripe[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.stripes) < b.maxStripes {
+		s := newStripe[T](cons, capacity)
+		b.stripes = append(b.stripes, s)
+		return s
+	}
+
+	s := b.stripes[b.next]
+	b.next = (b.next + 1) % len(b.stripes)
+	return s
+}
+
+// flushLoop flushes every stripe on each tick until the batcher is closed.
+func (b *StripedBatcher[T]) flushLoop(interval time.Duration) {
+	defer close(b.doneChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.Flush()
+		case <-b.stopChan:
+			return
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package batcher
 
 import (
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // mockConsumer is a test Consumer that tracks received batches.
@@ -328,6 +330,101 @@ func TestFlush_SliceOwnership(t *testing.T) {
 	}
 }
 
+// --- Manual Flush / Close Tests ---
+
+func TestFlush_PartialStripe(t *testing.T) {
+	cons := &mockConsumer[int]{}
+	b := New[int](cons, Config{StripeSize: 10})
+
+	b.Push(1)
+	b.Push(2)
+	b.Flush()
+
+	if cons.totalItems() != 2 {
+		t.Fatalf("expected 2 items, got %d", cons.totalItems())
+	}
+
+	// Nothing pending: another flush must not call the consumer
+	calls := cons.calls.Load()
+	b.Flush()
+	if cons.calls.Load() != calls {
+		t.Errorf("expected no flush of empty stripes, got %d calls", cons.calls.Load()-calls)
+	}
+}
+
+func TestFlush_Interval(t *testing.T) {
+	cons := &mockConsumer[int]{}
+	b := New[int](cons, Config{StripeSize: 100, FlushInterval: 10 * time.Millisecond})
+	defer b.Close()
+
+	b.Push(1)
+
+	deadline := time.Now().Add(time.Second)
+	for cons.totalItems() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if cons.totalItems() != 1 {
+		t.Errorf("expected the timer to flush 1 item, got %d", cons.totalItems())
+	}
+}
+
+func TestClose_FlushesPending(t *testing.T) {
+	cons := &mockConsumer[int]{}
+	b := New[int](cons, Config{StripeSize: 100, FlushInterval: time.Hour})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				b.Push(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	b.Close()
+
+	if cons.totalItems() != 80 {
+		t.Errorf("expected all 80 items after Close, got %d", cons.totalItems())
+	}
+
+	// Close is idempotent
+	b.Close()
+}
+
+func TestClose_WithoutInterval(t *testing.T) {
+	cons := &mockConsumer[int]{}
+	b := New[int](cons, Config{StripeSize: 10})
+
+	b.Push(1)
+	b.Close()
+
+	if cons.totalItems() != 1 {
+		t.Errorf("expected 1 item after Close, got %d", cons.totalItems())
+	}
+}
+
+func TestStripes_Bounded(t *testing.T) {
+	cons := &mockConsumer[int]{}
+	b := New[int](cons, Config{StripeSize: 10})
+
+	// Drain the pool without returning stripes, as if the GC had dropped them
+	for i := 0; i < b.maxStripes*2; i++ {
+		b.pool.Get()
+	}
+
+	b.mu.Lock()
+	n := len(b.stripes)
+	b.mu.Unlock()
+
+	if n != runtime.GOMAXPROCS(0)*stripesPerProc {
+		t.Errorf("expected %d stripes, got %d", runtime.GOMAXPROCS(0)*stripesPerProc, n)
+	}
+}
+
 // --- Concurrency Tests ---
 
 func TestConcurrent_MultipleGoroutines(t *testing.T) {
@@ -1,5 +1,7 @@
 package batcher
 
+import "time"
+
 // Consumer is the interface that must be implemented by users of the Batcher.
 // It is responsible for processing a batch of items.
 type Consumer[T any] interface {
@@ -13,4 +15,9 @@ type Config struct {
 	// StripeSize is the capacity of a single stripe buffer.
 	// When a stripe reaches this size, it will be flushed to the Consumer.
 	StripeSize int
+
+	// FlushInterval, when set, flushes every stripe on a timer so that items in
+	// partly filled stripes reach the Consumer within this interval.
+	// Zero disables the timer; stripes are then flushed only when full or on Flush/Close.
+	FlushInterval time.Duration
 }
@@ -1,8 +1,12 @@
 package batcher
 
+import "sync"
+
 // stripe represents a single buffer stripe.
-// It is NOT thread-safe and is intended to be used via sync.Pool.
+// It is normally owned by one goroutine at a time via sync.Pool; the mutex only guards
+// against the periodic flush and the rare case where two goroutines share a stripe.
 type stripe[T any] struct {
+	mu   sync.Mutex
 	cons Consumer[T]
 	data []T
 	cap  int
@@ -20,17 +24,37 @@ func newStripe[T any](cons Consumer[T], capacity int) *stripe[T] {
 // Push appends an item to the stripe.
 // If the stripe becomes full, it flushes data to the consumer.
 func (s *stripe[T]) Push(item T) {
+	s.mu.Lock()
 	s.data = append(s.data, item)
 
+	var full []T
 	if len(s.data) >= s.cap {
-		// Flush to consumer
-		// Note: We ignore error here as this is a fire-and-forget pattern typically.
-		// Real error handling should be done inside the Consumer implementation.
-		_ = s.cons.Consume(s.data)
-
 		// Allocation strategy:
 		// We allocate a new slice to ensure the Consumer owns the passed data safely.
 		// This matches Ristretto's safety guarantee.
+		full = s.data
 		s.data = make([]T, 0, s.cap)
 	}
+	s.mu.Unlock()
+
+	if full != nil {
+		// Flush to consumer outside the lock.
+		// Note: We ignore error here as this is a fire-and-forget pattern typically.
+		// Real error handling should be done inside the Consumer implementation.
+		_ = s.cons.Consume(full)
+	}
+}
+
+// Flush hands whatever the stripe holds to the consumer, even if it is not full.
+func (s *stripe[T]) Flush() {
+	s.mu.Lock()
+	if len(s.data) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	pending := s.data
+	s.data = make([]T, 0, s.cap)
+	s.mu.Unlock()
+
+	_ = s.cons.Consume(pending)
 }
@@ -56,6 +56,10 @@ func TestClient_Integration(t *testing.T) {
 	t.Run("Consumer", func(t *testing.T) {
 		testConsumer(t, ctx, cfg)
 	})
+
+	t.Run("PartitionConsumer", func(t *testing.T) {
+		testPartitionConsumer(t, ctx, cfg)
+	})
 }
 
 func testSyncProducer(t *testing.T, ctx context.Context, cfg *Config) {
@@ -142,3 +146,51 @@ func testConsumer(t *testing.T, ctx context.Context, cfg *Config) {
 		t.Error("Consumer timed out waiting for message")
 	}
 }
+
+func testPartitionConsumer(t *testing.T, ctx context.Context, cfg *Config) {
+	// Without InitialOffset the consumer starts at the end, so it must not see what was published before it
+	liveCfg := *cfg
+	liveCfg.ConsumerInfo.InitialOffset = 0
+
+	received := make(chan string, 16)
+	handler := func(ctx context.Context, key, value []byte) error {
+		received <- string(value)
+		return nil
+	}
+
+	errHandler := func(err error) {
+		t.Logf("Partition consumer error: %v", err)
+	}
+
+	consumer, err := NewPartitionConsumer(&liveCfg)
+	if err != nil {
+		t.Fatalf("failed to create partition consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := consumer.Start(consumeCtx, []string{testTopic}, handler, errHandler); err != nil {
+		t.Fatalf("Partition consumer Start failed: %v", err)
+	}
+
+	producer, err := NewSyncProducer(cfg)
+	if err != nil {
+		t.Fatalf("failed to create sync producer: %v", err)
+	}
+	defer producer.Close()
+
+	if _, _, err := producer.Publish(ctx, testTopic, []byte("live-key"), []byte("live-value")); err != nil {
+		t.Fatalf("Sync Publish failed: %v", err)
+	}
+
+	select {
+	case v := <-received:
+		if v != "live-value" {
+			t.Errorf("expected only messages published after Start, got %q", v)
+		}
+	case <-consumeCtx.Done():
+		t.Error("Partition consumer timed out waiting for message")
+	}
+}
@@ -1,5 +1,7 @@
 package kafka
 
+import "time"
+
 const (
 	// HeaderRequestID is the header key for request ID
 	HeaderRequestID = "X-Request-ID"
@@ -17,3 +19,17 @@ const (
 	// ContextKeyTraceID is the context key for trace ID
 	ContextKeyTraceID   ContextKey = "trace_id"
 )
+
+const (
+	// defaultBatchSize is the batch size used when BatchConfig.Size is not set
+	defaultBatchSize = 100
+	// defaultBatchInterval is the flush interval used when BatchConfig.Interval is not set
+	defaultBatchInterval = 500 * time.Millisecond
+)
+
+const (
+	// OffsetNewest starts a new consumer group, or every partition consumer, at the end of the topic
+	OffsetNewest int64 = -1
+	// OffsetOldest starts a new consumer group at the beginning of the topic
+	OffsetOldest int64 = -2
+)
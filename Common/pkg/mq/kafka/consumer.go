@@ -29,6 +29,9 @@ func NewConsumer(cfg *Config, groupID string, mws ...Middleware) (ConsumerGroup,
 	// Rebalance Strategy
 	config.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategySticky()
 	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	if cfg.ConsumerInfo.InitialOffset != 0 {
+		config.Consumer.Offsets.Initial = cfg.ConsumerInfo.InitialOffset
+	}
 	config.Consumer.Group.Session.Timeout = utils.ToDurationMs(cfg.ConsumerInfo.SessionTimeout)
 	if cfg.ConsumerInfo.MaxProcessingTime > 0 {
 		config.Consumer.MaxProcessingTime = utils.ToDurationMs(cfg.ConsumerInfo.MaxProcessingTime)
@@ -59,13 +62,44 @@ func (c *consumerGroup) Start(ctx context.Context, topics []string, handler Hand
 		errHandler:  errHandler,
 	}
 
-	// Loop to handle rebalances
+	c.run(ctx, topics, consumer)
+
+	return nil
+}
+
+// StartBatch consumes messages in per-partition batches.
+// Offsets are marked only after handler succeeds for the whole batch; on error the
+// session is restarted and the batch is redelivered from the last marked offset.
+func (c *consumerGroup) StartBatch(ctx context.Context, topics []string, handler BatchHandler, cfg BatchConfig, errHandler ErrorHandler) error {
+	c.topics = topics
+	c.errHandler = errHandler
+
+	if cfg.Size <= 0 {
+		cfg.Size = defaultBatchSize
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultBatchInterval
+	}
+
+	consumer := &batchConsumerGroupHandler{
+		handlerFunc: handler,
+		errHandler:  errHandler,
+		cfg:         cfg,
+	}
+
+	c.run(ctx, topics, consumer)
+
+	return nil
+}
+
+// run consumes in the background and rejoins the group after every rebalance or error
+func (c *consumerGroup) run(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) {
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
 		attempts := 0
 		for {
-			err := c.client.Consume(ctx, topics, consumer)
+			err := c.client.Consume(ctx, topics, handler)
 			if err != nil {
 				if c.errHandler != nil {
 					c.errHandler(err)
@@ -84,8 +118,6 @@ func (c *consumerGroup) Start(ctx context.Context, topics []string, handler Hand
 			}
 		}
 	}()
-
-	return nil
 }
 
 // Close closes the consumer group
@@ -0,0 +1,120 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// partitionConsumer reads every partition of its topics directly, without a consumer group
+type partitionConsumer struct {
+	client      sarama.Consumer
+	offset      int64
+	middlewares []Middleware
+
+	mu         sync.Mutex
+	partitions []sarama.PartitionConsumer
+	wg         sync.WaitGroup
+}
+
+// NewPartitionConsumer creates a Consumer that reads every partition of its topics without joining a group.
+// Nothing is committed: each instance starts at ConsumerInfo.InitialOffset (OffsetNewest when unset) whenever it starts,
+// and leaves nothing behind on the broker once closed. Use it for fan-out, where every instance must see every message.
+// Partitions added to a topic after Start are not picked up until the next start.
+func NewPartitionConsumer(cfg *Config, mws ...Middleware) (Consumer, error) {
+	config := sarama.NewConfig()
+	config.ClientID = cfg.ClientID
+	config.Consumer.Return.Errors = true
+
+	offset := sarama.OffsetNewest
+	if cfg.ConsumerInfo.InitialOffset != 0 {
+		offset = cfg.ConsumerInfo.InitialOffset
+	}
+
+	client, err := sarama.NewConsumer(cfg.Brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partition consumer: %w", err)
+	}
+
+	return &partitionConsumer{
+		client:      client,
+		offset:      offset,
+		middlewares: mws,
+	}, nil
+}
+
+// Start opens every partition of the topics and consumes them in the background until ctx is done or Close is called
+func (c *partitionConsumer) Start(ctx context.Context, topics []string, handler Handler, errHandler ErrorHandler) error {
+	// Apply Middlewares
+	wrappedHandler := Chain(handler, c.middlewares...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, topic := range topics {
+		partitions, err := c.client.Partitions(topic)
+		if err != nil {
+			return fmt.Errorf("failed to list partitions of %s: %w", topic, err)
+		}
+
+		for _, partition := range partitions {
+			pc, err := c.client.ConsumePartition(topic, partition, c.offset)
+			if err != nil {
+				return fmt.Errorf("failed to consume partition %d of %s: %w", partition, topic, err)
+			}
+			c.partitions = append(c.partitions, pc)
+
+			c.wg.Add(2)
+			go c.consume(ctx, pc, wrappedHandler, errHandler)
+			go c.errors(pc, errHandler)
+		}
+	}
+
+	return nil
+}
+
+// consume hands the partition's messages to the handler; failed messages are reported and skipped
+func (c *partitionConsumer) consume(ctx context.Context, pc sarama.PartitionConsumer, handler Handler, errHandler ErrorHandler) {
+	defer c.wg.Done()
+	for {
+		select {
+		case msg, ok := <-pc.Messages():
+			if !ok {
+				return
+			}
+			if err := handler(buildContext(msg.Headers), msg.Key, msg.Value); err != nil && errHandler != nil {
+				errHandler(fmt.Errorf("process message error: %w", err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// errors reports the partition's consumer errors until it is closed
+func (c *partitionConsumer) errors(pc sarama.PartitionConsumer, errHandler ErrorHandler) {
+	defer c.wg.Done()
+	for err := range pc.Errors() {
+		if errHandler != nil {
+			errHandler(err)
+		}
+	}
+}
+
+// Close closes every partition, waits for in-flight messages and closes the consumer
+func (c *partitionConsumer) Close() error {
+	c.mu.Lock()
+	partitions := c.partitions
+	c.partitions = nil
+	c.mu.Unlock()
+
+	// A closed partition closes its Messages and Errors channels, which ends both of its goroutines
+	for _, pc := range partitions {
+		pc.AsyncClose()
+	}
+	c.wg.Wait()
+
+	return c.client.Close()
+}
@@ -1,7 +1,10 @@
 package kafka
 
 import (
+	"context"
 	"fmt"
+	"runtime/debug"
+	"time"
 
 	"github.com/IBM/sarama"
 )
@@ -33,3 +36,83 @@ func (h consumerGroupHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, cla
 	}
 	return nil
 }
+
+// batchConsumerGroupHandler implements sarama.ConsumerGroupHandler with batched, commit-after-success delivery
+type batchConsumerGroupHandler struct {
+	handlerFunc BatchHandler
+	errHandler  ErrorHandler
+	cfg         BatchConfig
+}
+
+// Setup is called before the consumer group session starts
+func (batchConsumerGroupHandler) Setup(_ sarama.ConsumerGroupSession) error { return nil }
+
+// Cleanup is called after the consumer group session ends
+func (batchConsumerGroupHandler) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
+
+// ConsumeClaim groups messages of one partition and marks the last offset only once a batch succeeds
+func (h batchConsumerGroupHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	batch := make([]*Message, 0, h.cfg.Size)
+	var last *sarama.ConsumerMessage
+
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := h.handle(sess.Context(), batch); err != nil {
+			if h.errHandler != nil {
+				h.errHandler(fmt.Errorf("process batch error: %w", err))
+			}
+			// Leave the batch unmarked; ending the claim makes the group redeliver it
+			return err
+		}
+
+		sess.MarkMessage(last, "")
+		batch = make([]*Message, 0, h.cfg.Size)
+		return nil
+	}
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return flush()
+			}
+
+			batch = append(batch, &Message{
+				Topic:     msg.Topic,
+				Partition: msg.Partition,
+				Offset:    msg.Offset,
+				Key:       msg.Key,
+				Value:     msg.Value,
+			})
+			last = msg
+
+			if len(batch) >= h.cfg.Size {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		case <-sess.Context().Done():
+			return nil
+		}
+	}
+}
+
+// handle calls the batch handler, turning panics into errors
+func (h batchConsumerGroupHandler) handle(ctx context.Context, batch []*Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered in kafka batch handler: %v\nStack: %s", r, string(debug.Stack()))
+		}
+	}()
+	return h.handlerFunc(ctx, batch)
+}
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"time"
 )
 
 // Producer defines the contract for async message publishing
@@ -17,15 +18,41 @@ type SyncProducer interface {
 	Close() error
 }
 
+// Consumer defines the contract for consuming messages without a consumer group
+type Consumer interface {
+	Start(ctx context.Context, topics []string, handler Handler, errHandler ErrorHandler) error
+	Close() error
+}
+
 // ConsumerGroup defines the contract for consuming messages
 type ConsumerGroup interface {
 	Start(ctx context.Context, topics []string, handler Handler, errHandler ErrorHandler) error
+	StartBatch(ctx context.Context, topics []string, handler BatchHandler, cfg BatchConfig, errHandler ErrorHandler) error
 	Close() error
 }
 
 // Handler processed messages
 type Handler func(ctx context.Context, key, value []byte) error
 
+// BatchHandler processes a batch of messages from a single partition, in offset order.
+// Returning an error leaves the batch uncommitted so it is delivered again.
+type BatchHandler func(ctx context.Context, msgs []*Message) error
+
+// Message is a consumed record with its position in the topic
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+}
+
+// BatchConfig controls how many messages are grouped per BatchHandler call
+type BatchConfig struct {
+	Size     int           // Size flushes the batch once it holds this many messages
+	Interval time.Duration // Interval flushes a non-empty batch after this long
+}
+
 // ErrorHandler handles internal errors (e.g. from consumer loop or async producer)
 type ErrorHandler func(err error)
 
@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/IBM/sarama"
@@ -50,6 +51,21 @@ func buildHeaders(ctx context.Context) []sarama.RecordHeader {
 	return headers
 }
 
+// FailedMessage returns the topic and value of the message an async producer error reports,
+// so the caller can send it again; ok is false for errors that carry no message
+func FailedMessage(err error) (topic string, value []byte, ok bool) {
+	var producerErr *sarama.ProducerError
+	if !errors.As(err, &producerErr) || producerErr.Msg == nil || producerErr.Msg.Value == nil {
+		return "", nil, false
+	}
+
+	value, err = producerErr.Msg.Value.Encode()
+	if err != nil {
+		return "", nil, false
+	}
+	return producerErr.Msg.Topic, value, true
+}
+
 // PublishJSON serializes data to JSON and publishes it to the specified topic.
 // It uses a key extracted from the keyFunc or empty if nil.
 func PublishJSON[T any](ctx context.Context, producer Producer, topic string, keyFunc func(T) string, data T) error {
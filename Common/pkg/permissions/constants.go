@@ -15,6 +15,7 @@ const (
 	ResourceKeyInvoice             = "invoices"
 	ResourceKeyPlan                = "plans"
 	ResourceKeySubscription        = "subscriptions"
+	ResourceKeyAnalytics           = "analytics"
 )
 
 // Permission Scopes (Bitmask)
@@ -0,0 +1,158 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// ClickIDParam is the query parameter that carries a click ID on the destination URL
+const ClickIDParam = "glclid"
+
+const (
+	clickIDVersion   = 1
+	clickIDNonceSize = 8
+	clickIDMACSize   = 12
+	clickIDMaxField  = 64
+)
+
+var ErrClickIDInvalid = errors.New("click id is invalid")
+
+// ClickID identifies one redirect and what it should be attributed to.
+// It is self-contained and signed, so a conversion can be credited without looking the click up.
+type ClickID struct {
+	TenantID  int
+	ShortCode string
+	Variant   string
+	Campaign  string
+	IssuedAt  time.Time
+	Nonce     [clickIDNonceSize]byte
+}
+
+// NewClickID stamps a click with the current time and a random nonce, so every redirect gets a distinct ID.
+func NewClickID(tenantID int, shortCode, variant, campaign string, now time.Time) (ClickID, error) {
+	c := ClickID{
+		TenantID:  tenantID,
+		ShortCode: shortCode,
+		Variant:   truncate(variant, clickIDMaxField),
+		Campaign:  truncate(campaign, clickIDMaxField),
+		IssuedAt:  now,
+	}
+	if _, err := rand.Read(c.Nonce[:]); err != nil {
+		return ClickID{}, err
+	}
+	return c, nil
+}
+
+// EncodeClickID packs the click into a compact binary payload, appends a truncated HMAC and base64url-encodes both.
+func EncodeClickID(key []byte, c ClickID) string {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, clickIDVersion)
+	buf = binary.AppendUvarint(buf, uint64(c.TenantID))
+	buf = binary.AppendVarint(buf, c.IssuedAt.UnixMilli())
+	buf = append(buf, c.Nonce[:]...)
+	buf = appendField(buf, c.ShortCode)
+	buf = appendField(buf, c.Variant)
+	buf = appendField(buf, c.Campaign)
+
+	buf = append(buf, clickIDMAC(key, buf)...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodeClickID verifies the HMAC in constant time and unpacks the click.
+func DecodeClickID(key []byte, s string) (ClickID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(raw) <= clickIDMACSize {
+		return ClickID{}, ErrClickIDInvalid
+	}
+
+	payload, mac := raw[:len(raw)-clickIDMACSize], raw[len(raw)-clickIDMACSize:]
+	if !hmac.Equal(mac, clickIDMAC(key, payload)) {
+		return ClickID{}, ErrClickIDInvalid
+	}
+
+	if payload[0] != clickIDVersion {
+		return ClickID{}, ErrClickIDInvalid
+	}
+	r := clickIDReader{buf: payload[1:]}
+
+	var c ClickID
+	c.TenantID = int(r.uvarint())
+	c.IssuedAt = time.UnixMilli(r.varint())
+	copy(c.Nonce[:], r.bytes(clickIDNonceSize))
+	c.ShortCode = r.field()
+	c.Variant = r.field()
+	c.Campaign = r.field()
+
+	if r.err || len(r.buf) != 0 || c.ShortCode == "" {
+		return ClickID{}, ErrClickIDInvalid
+	}
+	return c, nil
+}
+
+func clickIDMAC(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:clickIDMACSize]
+}
+
+func appendField(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// clickIDReader consumes a payload and remembers whether it ran short
+type clickIDReader struct {
+	buf []byte
+	err bool
+}
+
+func (r *clickIDReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = true
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *clickIDReader) varint() int64 {
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.err = true
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *clickIDReader) bytes(n int) []byte {
+	if r.err || len(r.buf) < n {
+		r.err = true
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *clickIDReader) field() string {
+	n := r.uvarint()
+	if r.err || n > uint64(len(r.buf)) {
+		r.err = true
+		return ""
+	}
+	return string(r.bytes(int(n)))
+}
@@ -0,0 +1,79 @@
+package security
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClickIDRoundTrip(t *testing.T) {
+	key := []byte("click-key")
+	now := time.UnixMilli(1_700_000_000_123)
+
+	c, err := NewClickID(42, "abc123", "b", "spring-sale", now)
+	if err != nil {
+		t.Fatalf("NewClickID: %v", err)
+	}
+
+	got, err := DecodeClickID(key, EncodeClickID(key, c))
+	if err != nil {
+		t.Fatalf("DecodeClickID: %v", err)
+	}
+	if got.TenantID != 42 || got.ShortCode != "abc123" || got.Variant != "b" || got.Campaign != "spring-sale" {
+		t.Errorf("decoded %+v, want the original click", got)
+	}
+	if !got.IssuedAt.Equal(now) {
+		t.Errorf("IssuedAt = %v, want %v", got.IssuedAt, now)
+	}
+	if got.Nonce != c.Nonce {
+		t.Error("nonce must survive the round trip")
+	}
+}
+
+func TestClickIDUnique(t *testing.T) {
+	key := []byte("click-key")
+	now := time.Now()
+
+	a, _ := NewClickID(1, "abc", "", "", now)
+	b, _ := NewClickID(1, "abc", "", "", now)
+	if EncodeClickID(key, a) == EncodeClickID(key, b) {
+		t.Fatal("two clicks on the same link at the same instant must get different IDs")
+	}
+}
+
+func TestClickIDTruncatesLongFields(t *testing.T) {
+	c, _ := NewClickID(1, "abc", "", strings.Repeat("x", 500), time.Now())
+	if len(c.Campaign) != clickIDMaxField {
+		t.Errorf("campaign length = %d, want %d", len(c.Campaign), clickIDMaxField)
+	}
+}
+
+func TestDecodeClickIDRejects(t *testing.T) {
+	key := []byte("click-key")
+	c, _ := NewClickID(7, "abc", "", "", time.Now())
+	valid := EncodeClickID(key, c)
+
+	tampered := []byte(valid)
+	tampered[3] ^= 1
+
+	tests := []struct {
+		name string
+		key  []byte
+		id   string
+	}{
+		{"wrong_key", []byte("other-key"), valid},
+		{"tampered", key, string(tampered)},
+		{"truncated", key, valid[:len(valid)-4]},
+		{"not_base64", key, "!!!"},
+		{"empty", key, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeClickID(tt.key, tt.id); !errors.Is(err, ErrClickIDInvalid) {
+				t.Errorf("err = %v, want ErrClickIDInvalid", err)
+			}
+		})
+	}
+}
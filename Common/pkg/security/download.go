@@ -0,0 +1,10 @@
+package security
+
+const downloadKeyContext = "golink:download:"
+
+// DeriveDownloadKey derives the key a tenant's expiring download links are signed with.
+// Download links are signed like short links (SignLink, VerifyLink) with the file ID in place of the short code,
+// but a key of their own, so a signature for one can never be replayed as the other.
+func DeriveDownloadKey(master []byte, tenantID int) []byte {
+	return deriveKey(master, downloadKeyContext, tenantID)
+}
@@ -0,0 +1,29 @@
+package security
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeriveDownloadKey(t *testing.T) {
+	if string(DeriveDownloadKey(testMaster, 1)) == string(DeriveTenantKey(testMaster, 1)) {
+		t.Fatal("download and short link keys must differ")
+	}
+	if string(DeriveDownloadKey(testMaster, 1)) == string(DeriveDownloadKey(testMaster, 2)) {
+		t.Fatal("different tenants must get different keys")
+	}
+}
+
+func TestVerifyLink_DownloadKey(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	link := SignedLink{ExpiresAt: now.Add(time.Hour)}
+	sig := SignLink(DeriveDownloadKey(testMaster, 4), "export-1", link)
+
+	if err := VerifyLink(DeriveDownloadKey(testMaster, 4), "export-1", link, sig, now); err != nil {
+		t.Fatalf("VerifyLink() error = %v", err)
+	}
+	if err := VerifyLink(DeriveTenantKey(testMaster, 4), "export-1", link, sig, now); !errors.Is(err, ErrSignedLinkInvalid) {
+		t.Errorf("short link key error = %v, want ErrSignedLinkInvalid", err)
+	}
+}
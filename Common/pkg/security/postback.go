@@ -0,0 +1,46 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+)
+
+// PostbackParamSignature carries the HMAC of a conversion postback
+const PostbackParamSignature = "sig"
+
+const postbackKeyContext = "golink:postback:"
+
+var ErrPostbackInvalid = errors.New("postback signature is invalid")
+
+// DerivePostbackKey derives the key a tenant signs its conversion postbacks with.
+func DerivePostbackKey(master []byte, tenantID int) []byte {
+	return deriveKey(master, postbackKeyContext, tenantID)
+}
+
+// SignPostback signs every parameter except the signature itself.
+// The message is the canonical query string: keys sorted, values URL-encoded, as url.Values.Encode produces it,
+// so any HTTP client can reproduce it.
+func SignPostback(key []byte, params url.Values) string {
+	unsigned := make(url.Values, len(params))
+	for k, v := range params {
+		if k != PostbackParamSignature {
+			unsigned[k] = v
+		}
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyPostback checks the signature parameter in constant time.
+func VerifyPostback(key []byte, params url.Values) error {
+	sig := params.Get(PostbackParamSignature)
+	if sig == "" || !hmac.Equal([]byte(sig), []byte(SignPostback(key, params))) {
+		return ErrPostbackInvalid
+	}
+	return nil
+}
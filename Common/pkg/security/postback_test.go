@@ -0,0 +1,50 @@
+package security
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestVerifyPostback(t *testing.T) {
+	key := DerivePostbackKey(testMaster, 3)
+
+	params := url.Values{"glclid": {"abc"}, "value": {"19.99"}, "event": {"purchase"}}
+	params.Set(PostbackParamSignature, SignPostback(key, params))
+
+	if err := VerifyPostback(key, params); err != nil {
+		t.Fatalf("valid postback rejected: %v", err)
+	}
+
+	tampered := url.Values{}
+	for k, v := range params {
+		tampered[k] = v
+	}
+	tampered.Set("value", "1999")
+
+	unsigned := url.Values{"glclid": {"abc"}}
+
+	tests := []struct {
+		name   string
+		key    []byte
+		params url.Values
+	}{
+		{"tampered_value", key, tampered},
+		{"other_tenant", DerivePostbackKey(testMaster, 4), params},
+		{"missing_signature", key, unsigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyPostback(tt.key, tt.params); !errors.Is(err, ErrPostbackInvalid) {
+				t.Errorf("err = %v, want ErrPostbackInvalid", err)
+			}
+		})
+	}
+}
+
+func TestPostbackKeyIsPurposeBound(t *testing.T) {
+	if string(DerivePostbackKey(testMaster, 1)) == string(DeriveTenantKey(testMaster, 1)) {
+		t.Fatal("postback and signed-link keys must differ for the same tenant")
+	}
+}
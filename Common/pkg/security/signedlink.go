@@ -0,0 +1,106 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Query parameters of a signed short link
+const (
+	SignedLinkParamExpiry    = "exp"
+	SignedLinkParamNonce     = "nonce"
+	SignedLinkParamSignature = "sig"
+)
+
+const signedLinkKeyContext = "golink:signed-link:"
+
+var (
+	ErrSignedLinkMalformed = errors.New("signed link parameters are malformed")
+	ErrSignedLinkExpired   = errors.New("signed link has expired")
+	ErrSignedLinkInvalid   = errors.New("signed link signature is invalid")
+)
+
+// SignedLink is the signed part of a short link: an expiry and, for single-use links, a nonce.
+type SignedLink struct {
+	ExpiresAt time.Time
+	Nonce     string
+}
+
+// DeriveTenantKey derives a tenant's signing key from the master secret,
+// so every tenant gets its own key without storing one per tenant.
+func DeriveTenantKey(master []byte, tenantID int) []byte {
+	return deriveKey(master, signedLinkKeyContext, tenantID)
+}
+
+// deriveKey binds a per-tenant key to its purpose, so keys derived for one use never verify another
+func deriveKey(master []byte, context string, tenantID int) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte(context + strconv.Itoa(tenantID)))
+	return mac.Sum(nil)
+}
+
+// NewNonce returns a random nonce for a single-use link.
+func NewNonce() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// SignLink signs the short code together with the expiry and nonce.
+func SignLink(key []byte, shortCode string, link SignedLink) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(shortCode))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatInt(link.ExpiresAt.Unix(), 10)))
+	mac.Write([]byte{0})
+	mac.Write([]byte(link.Nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignedLinkQuery builds the query string that carries a signed link.
+func SignedLinkQuery(key []byte, shortCode string, link SignedLink) url.Values {
+	q := url.Values{}
+	q.Set(SignedLinkParamExpiry, strconv.FormatInt(link.ExpiresAt.Unix(), 10))
+	if link.Nonce != "" {
+		q.Set(SignedLinkParamNonce, link.Nonce)
+	}
+	q.Set(SignedLinkParamSignature, SignLink(key, shortCode, link))
+	return q
+}
+
+// ParseSignedLink reads the expiry, nonce and signature from a query string.
+func ParseSignedLink(q url.Values) (SignedLink, string, error) {
+	sig := q.Get(SignedLinkParamSignature)
+	exp, err := strconv.ParseInt(q.Get(SignedLinkParamExpiry), 10, 64)
+	if sig == "" || err != nil {
+		return SignedLink{}, "", ErrSignedLinkMalformed
+	}
+
+	return SignedLink{
+		ExpiresAt: time.Unix(exp, 0),
+		Nonce:     q.Get(SignedLinkParamNonce),
+	}, sig, nil
+}
+
+// VerifyLink checks the signature in constant time, then the expiry.
+func VerifyLink(key []byte, shortCode string, link SignedLink, sig string, now time.Time) error {
+	want := SignLink(key, shortCode, link)
+	if !hmac.Equal([]byte(want), []byte(sig)) {
+		return ErrSignedLinkInvalid
+	}
+
+	if !now.Before(link.ExpiresAt) {
+		return ErrSignedLinkExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package security
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var testMaster = []byte("test-master-secret")
+
+func TestDeriveTenantKey(t *testing.T) {
+	a := DeriveTenantKey(testMaster, 1)
+	b := DeriveTenantKey(testMaster, 2)
+
+	if string(a) == string(b) {
+		t.Fatal("different tenants must get different keys")
+	}
+	if string(a) != string(DeriveTenantKey(testMaster, 1)) {
+		t.Fatal("key derivation must be deterministic")
+	}
+}
+
+func TestVerifyLink(t *testing.T) {
+	key := DeriveTenantKey(testMaster, 7)
+	now := time.Unix(1_700_000_000, 0)
+	link := SignedLink{ExpiresAt: now.Add(time.Hour), Nonce: "n1"}
+	sig := SignLink(key, "abc", link)
+
+	tests := []struct {
+		name      string
+		key       []byte
+		shortCode string
+		link      SignedLink
+		sig       string
+		now       time.Time
+		wantErr   error
+	}{
+		{"valid", key, "abc", link, sig, now, nil},
+		{"expired", key, "abc", link, sig, now.Add(2 * time.Hour), ErrSignedLinkExpired},
+		{"expiry_boundary", key, "abc", link, sig, link.ExpiresAt, ErrSignedLinkExpired},
+		{"other_code", key, "abd", link, sig, now, ErrSignedLinkInvalid},
+		{"other_tenant", DeriveTenantKey(testMaster, 8), "abc", link, sig, now, ErrSignedLinkInvalid},
+		{"extended_expiry", key, "abc", SignedLink{ExpiresAt: link.ExpiresAt.Add(time.Hour), Nonce: "n1"}, sig, now, ErrSignedLinkInvalid},
+		{"dropped_nonce", key, "abc", SignedLink{ExpiresAt: link.ExpiresAt}, sig, now, ErrSignedLinkInvalid},
+		{"empty_signature", key, "abc", link, "", now, ErrSignedLinkInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyLink(tt.key, tt.shortCode, tt.link, tt.sig, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyLink() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignedLinkQuery_RoundTrip(t *testing.T) {
+	key := DeriveTenantKey(testMaster, 3)
+	link := SignedLink{ExpiresAt: time.Unix(1_800_000_000, 0), Nonce: "abc123"}
+
+	q, err := url.ParseQuery(SignedLinkQuery(key, "xyz", link).Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, sig, err := ParseSignedLink(q)
+	if err != nil {
+		t.Fatalf("ParseSignedLink() error = %v", err)
+	}
+	if !parsed.ExpiresAt.Equal(link.ExpiresAt) || parsed.Nonce != link.Nonce {
+		t.Errorf("ParseSignedLink() = %+v, want %+v", parsed, link)
+	}
+	if err := VerifyLink(key, "xyz", parsed, sig, time.Unix(1_700_000_000, 0)); err != nil {
+		t.Errorf("VerifyLink() error = %v", err)
+	}
+}
+
+func TestParseSignedLink_Malformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"empty", ""},
+		{"missing_signature", "exp=1800000000"},
+		{"missing_expiry", "sig=abc"},
+		{"non_numeric_expiry", "exp=soon&sig=abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			if _, _, err := ParseSignedLink(q); !errors.Is(err, ErrSignedLinkMalformed) {
+				t.Errorf("ParseSignedLink() error = %v, want %v", err, ErrSignedLinkMalformed)
+			}
+		})
+	}
+}
@@ -17,12 +17,22 @@ type Config struct {
 	Google        Google        `mapstructure:"google"`
 	Resend        Resend        `mapstructure:"resend"`
 	FCM           FCM           `mapstructure:"fcm"`
+	SignedLink    SignedLink    `mapstructure:"signed_link"`
+	PrivateLink   PrivateLink   `mapstructure:"private_link"`
+	Conversion    Conversion    `mapstructure:"conversion"`
+	Enrichment    Enrichment    `mapstructure:"enrichment"`
+	GeoIP         GeoIP         `mapstructure:"geoip"`
+	Export        Export        `mapstructure:"export"`
+	LinkCache     LinkCache     `mapstructure:"link_cache"`
+	Click         Click         `mapstructure:"click"`
 }
 
 type Services struct {
-	IdentityService GRPCService `mapstructure:"identity_service"`
-	BillingService  GRPCService `mapstructure:"billing_service"`
-	PaymentService  GRPCService `mapstructure:"payment_service"`
+	IdentityService   GRPCService `mapstructure:"identity_service"`
+	BillingService    GRPCService `mapstructure:"billing_service"`
+	PaymentService    GRPCService `mapstructure:"payment_service"`
+	GenerationService GRPCService `mapstructure:"generation_service"`
+	AnalyticsService  GRPCService `mapstructure:"analytics_service"`
 }
 
 type GRPCService struct {
@@ -38,6 +48,51 @@ type JWT struct {
 	PublicKey      *rsa.PublicKey  `mapstructure:"-"`
 }
 
+type SignedLink struct {
+	Secret string `mapstructure:"secret"`
+}
+
+type PrivateLink struct {
+	LoginURL      string   `mapstructure:"login_url"`
+	TokenCookie   string   `mapstructure:"token_cookie"`
+	CallbackHosts []string `mapstructure:"callback_hosts"` // Redirection hosts Identity may hand access tokens to
+}
+
+// Click holds the salt behind the client IP hashes in click events
+type Click struct {
+	IPHashSalt string `mapstructure:"ip_hash_salt"`
+}
+
+// Conversion holds the secrets behind click IDs and conversion postbacks
+type Conversion struct {
+	ClickIDSecret  string `mapstructure:"click_id_secret"`
+	PostbackSecret string `mapstructure:"postback_secret"`
+}
+
+// Enrichment points at rule files that replace the ones built into Analytics; empty paths keep the built-in rules
+type Enrichment struct {
+	UserAgentRulesPath string `mapstructure:"user_agent_rules_path"`
+	ReferrerRulesPath  string `mapstructure:"referrer_rules_path"`
+}
+
+// GeoIP locates clicks with a MaxMind City database; an empty path turns it off
+type GeoIP struct {
+	DatabasePath   string `mapstructure:"database_path"`
+	ReloadInterval int    `mapstructure:"reload_interval"` // Seconds
+}
+
+// LinkCache tunes how Redirection fills its link cache
+type LinkCache struct {
+	LoadLock bool `mapstructure:"load_lock"` // Lets one instance at a time load a short code from the database; on unless a config turns it off
+}
+
+// Export is where generated export files live and how their download links are signed
+type Export struct {
+	Directory      string `mapstructure:"directory"`
+	DownloadSecret string `mapstructure:"download_secret"`
+	LinkTTL        int    `mapstructure:"link_ttl"` // Seconds
+}
+
 // WideColumn is the configuration for Wide Column databases (Cassandra/ScyllaDB)
 type WideColumn struct {
 	Hosts    []string `mapstructure:"hosts"`
@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -74,6 +75,26 @@ func GenerateToken(
 	return token.SignedString(privateKey)
 }
 
+// ParseToken validates an RS256 token against the public key and returns its claims
+func ParseToken(tokenString string, publicKey interface{}) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return publicKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token claims")
+	}
+
+	return claims, nil
+}
+
 // ParseRSAPrivateKey parses a PEM encoded private key
 func ParseRSAPrivateKey(key []byte) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode(key)
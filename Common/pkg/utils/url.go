@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SetQueryParams sets params on rawURL, keeping its fragment and the rest of its query as is.
+// Parameters already present under the same key are replaced; the others keep their order and encoding,
+// so destinations that sign their own query keep working. New parameters are appended, sorted by key.
+func SetQueryParams(rawURL string, params url.Values) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var added []string
+	for _, k := range keys {
+		for _, v := range params[k] {
+			added = append(added, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	if len(added) == 0 {
+		return rawURL, nil
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(key); err == nil && params.Has(k) {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+
+	u.RawQuery = strings.Join(append(kept, added...), "&")
+	u.ForceQuery = false
+	return u.String(), nil
+}
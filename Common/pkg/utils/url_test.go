@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSetQueryParams(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"no query", "https://shop.example/p", "https://shop.example/p?glclid=abc"},
+		{"keeps order and encoding", "https://shop.example/p?z=1&a=%7E&sig=XyZ", "https://shop.example/p?z=1&a=%7E&sig=XyZ&glclid=abc"},
+		{"keeps semicolon pairs", "https://shop.example/p?a=1;b=2", "https://shop.example/p?a=1;b=2&glclid=abc"},
+		{"replaces same key", "https://shop.example/p?glclid=old&a=1", "https://shop.example/p?a=1&glclid=abc"},
+		{"keeps fragment", "https://shop.example/p?a=1#top", "https://shop.example/p?a=1&glclid=abc#top"},
+		{"trailing ampersand", "https://shop.example/p?a=1&", "https://shop.example/p?a=1&glclid=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetQueryParams(tt.url, url.Values{"glclid": {"abc"}})
+			if err != nil {
+				t.Fatalf("SetQueryParams: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"go-link/generation/internal/constant"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/infrastructure"
+)
+
+func main() {
+	req := &dto.ReindexRequest{}
+	flag.IntVar(&req.Ranges, "ranges", constant.ReindexDefaultRanges, "number of token ranges to split the ring into")
+	flag.IntVar(&req.Workers, "workers", constant.ReindexDefaultWorkers, "ranges processed concurrently")
+	flag.Parse()
+
+	if err := infrastructure.RunReindex(req); err != nil {
+		log.Fatalf("reindex failed: %v", err)
+	}
+}
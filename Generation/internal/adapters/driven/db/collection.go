@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/generation/global"
+	"go-link/generation/internal/adapters/driven/db/models"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/ports"
+)
+
+type CollectionRepository struct {
+	session *gocql.Session
+	repo    *widecolumn.BaseRepository[models.Collection]
+}
+
+// NewCollectionRepository creates a new instance of CollectionRepository
+func NewCollectionRepository() ports.CollectionRepository {
+	session := global.WideColumnClient.GetSession()
+	return &CollectionRepository{
+		session: session,
+		repo:    widecolumn.NewBaseRepository(session, models.Collection{}),
+	}
+}
+
+// Save upserts a collection
+func (r *CollectionRepository) Save(ctx context.Context, collection *entity.Collection) error {
+	return r.repo.Create(ctx, models.FromCollection(collection))
+}
+
+func (r *CollectionRepository) Get(ctx context.Context, tenantID int, id string) (*entity.Collection, error) {
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s = ?",
+		strings.Join(models.Collection{}.ColumnNames(), ", "), models.CollectionTableName, models.TenantIDColumn, widecolumn.IDColumn)
+
+	model := &models.Collection{BaseModel: &widecolumn.BaseModel[string]{}}
+	if err := r.session.Query(stmt, tenantID, id).WithContext(ctx).Scan(model.ScanDest()...); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, widecolumn.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// List returns the tenant's collections ordered by ID
+func (r *CollectionRepository) List(ctx context.Context, tenantID int) ([]*entity.Collection, error) {
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?",
+		strings.Join(models.Collection{}.ColumnNames(), ", "), models.CollectionTableName, models.TenantIDColumn)
+
+	iter := r.session.Query(stmt, tenantID).WithContext(ctx).Iter()
+	var collections []*entity.Collection
+	for {
+		model := &models.Collection{BaseModel: &widecolumn.BaseModel[string]{}}
+		if !iter.Scan(model.ScanDest()...) {
+			break
+		}
+		collections = append(collections, model.ToEntity())
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *CollectionRepository) Delete(ctx context.Context, tenantID int, id string) error {
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND %s = ?", models.CollectionTableName, models.TenantIDColumn, widecolumn.IDColumn)
+	return r.session.Query(stmt, tenantID, id).WithContext(ctx).Exec()
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"go-link/generation/global"
+	"go-link/generation/internal/adapters/driven/db/models"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/ports"
+)
+
+type CollectionLinkRepository struct {
+	session *gocql.Session
+}
+
+// NewCollectionLinkRepository creates a new instance of CollectionLinkRepository
+func NewCollectionLinkRepository() ports.CollectionLinkRepository {
+	return &CollectionLinkRepository{session: global.WideColumnClient.GetSession()}
+}
+
+func (r *CollectionLinkRepository) Links(ctx context.Context, collectionID string) ([]*entity.CollectionLink, error) {
+	stmt := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s = ?",
+		models.LinkIDColumn, models.AddedAtColumn, models.CollectionLinkTableName, models.CollectionIDColumn)
+
+	iter := r.session.Query(stmt, collectionID).WithContext(ctx).Iter()
+	var (
+		links   []*entity.CollectionLink
+		linkID  string
+		addedAt time.Time
+	)
+	for iter.Scan(&linkID, &addedAt) {
+		links = append(links, &entity.CollectionLink{CollectionID: collectionID, LinkID: linkID, AddedAt: addedAt})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *CollectionLinkRepository) Count(ctx context.Context, collectionID string) (int, error) {
+	stmt := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = ?", models.CollectionLinkTableName, models.CollectionIDColumn)
+
+	var count int
+	if err := r.session.Query(stmt, collectionID).WithContext(ctx).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *CollectionLinkRepository) Collections(ctx context.Context, linkID string) ([]string, error) {
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?",
+		models.CollectionIDColumn, models.LinkCollectionTableName, models.LinkIDColumn)
+
+	iter := r.session.Query(stmt, linkID).WithContext(ctx).Iter()
+	var (
+		ids []string
+		id  string
+	)
+	for iter.Scan(&id) {
+		ids = append(ids, id)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Add writes each membership both ways in its own logged batch, so the two tables never disagree about a link.
+// Adding a link that is already in the collection keeps it there and refreshes when it was added.
+func (r *CollectionLinkRepository) Add(ctx context.Context, collectionID string, linkIDs []string) error {
+	byCollection := fmt.Sprintf("INSERT INTO %s (%s, %s, %s) VALUES (?, ?, ?)",
+		models.CollectionLinkTableName, models.CollectionIDColumn, models.LinkIDColumn, models.AddedAtColumn)
+	byLink := fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?)",
+		models.LinkCollectionTableName, models.LinkIDColumn, models.CollectionIDColumn)
+
+	now := time.Now()
+	for _, linkID := range linkIDs {
+		batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+		batch.Query(byCollection, collectionID, linkID, now)
+		batch.Query(byLink, linkID, collectionID)
+		if err := r.session.ExecuteBatch(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CollectionLinkRepository) Remove(ctx context.Context, collectionID string, linkIDs []string) error {
+	for _, linkID := range linkIDs {
+		if err := r.remove(ctx, collectionID, linkID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CollectionLinkRepository) RemoveCollection(ctx context.Context, collectionID string) error {
+	links, err := r.Links(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		if err := r.remove(ctx, collectionID, l.LinkID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CollectionLinkRepository) RemoveLink(ctx context.Context, linkID string) error {
+	collectionIDs, err := r.Collections(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	for _, collectionID := range collectionIDs {
+		if err := r.remove(ctx, collectionID, linkID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CollectionLinkRepository) remove(ctx context.Context, collectionID, linkID string) error {
+	byCollection := fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND %s = ?",
+		models.CollectionLinkTableName, models.CollectionIDColumn, models.LinkIDColumn)
+	byLink := fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND %s = ?",
+		models.LinkCollectionTableName, models.LinkIDColumn, models.CollectionIDColumn)
+
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(byCollection, collectionID, linkID)
+	batch.Query(byLink, linkID, collectionID)
+	return r.session.ExecuteBatch(batch)
+}
@@ -2,10 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
 
 	"go-link/common/pkg/database/widecolumn"
 	"go-link/generation/global"
 	"go-link/generation/internal/adapters/driven/db/models"
+	"go-link/generation/internal/constant"
 	"go-link/generation/internal/core/entity"
 	"go-link/generation/internal/ports"
 )
@@ -15,22 +23,25 @@ const (
 )
 
 type LinkRepository struct {
-	repo *widecolumn.BaseRepository[models.Link]
+	session *gocql.Session
+	repo    *widecolumn.BaseRepository[models.Link]
 }
 
 // NewLinkRepository creates a new instance of LinkRepository
 func NewLinkRepository() ports.LinkRepository {
+	session := global.WideColumnClient.GetSession()
 	return &LinkRepository{
-		repo: widecolumn.NewBaseRepository(global.WideColumnClient.GetSession(), models.Link{}),
+		session: session,
+		repo:    widecolumn.NewBaseRepository(session, models.Link{}),
 	}
 }
 
-// Create a link with TTL
+// Create a link with TTL, together with its search index rows
 func (l *LinkRepository) Create(ctx context.Context, link *entity.Link, ttl int) error {
 	if ttl == 0 {
 		ttl = defaultTTL
 	}
-	return l.repo.CreateWithTTL(ctx, models.FromEntity(link), ttl)
+	return l.write(ctx, link, nil, ttl)
 }
 
 func (l *LinkRepository) Get(ctx context.Context, id string) (*entity.Link, error) {
@@ -42,9 +53,253 @@ func (l *LinkRepository) Get(ctx context.Context, id string) (*entity.Link, erro
 }
 
 func (l *LinkRepository) Delete(ctx context.Context, id string) error {
-	_, err := l.repo.Get(ctx, id)
+	model, err := l.repo.Get(ctx, id)
 	if err != nil {
 		return err
 	}
-	return l.repo.Delete(ctx, id)
+	link := model.ToEntity()
+
+	batch := l.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", models.TableName, widecolumn.IDColumn), id)
+	if link.TenantID != 0 {
+		batch.Query(fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND %s = ?", models.TenantLinkTableName, models.TenantIDColumn, models.LinkIDColumn), link.TenantID, id)
+		for _, tag := range link.Tags {
+			batch.Query(deleteTagLinkStmt(), link.TenantID, tag, id)
+		}
+	}
+	return l.session.ExecuteBatch(batch)
+}
+
+// SetTags replaces the link's tags and the index rows that point at it
+func (l *LinkRepository) SetTags(ctx context.Context, link *entity.Link, tags []string) error {
+	updated := *link
+	updated.Tags = tags
+	return l.rewrite(ctx, link, &updated)
+}
+
+// SetDisabled turns the link off or back on
+func (l *LinkRepository) SetDisabled(ctx context.Context, link *entity.Link, disabled bool) error {
+	updated := *link
+	updated.Disabled = disabled
+	return l.rewrite(ctx, link, &updated)
+}
+
+// rewrite writes the whole updated row rather than the changed columns alone, so the CDC change carries every column,
+// and keeps what is left of the link's TTL. On success link takes the updated values.
+func (l *LinkRepository) rewrite(ctx context.Context, link, updated *entity.Link) error {
+	stmt := fmt.Sprintf("SELECT TTL(%s) FROM %s WHERE %s = ?", models.OriginalURLColumn, models.TableName, widecolumn.IDColumn)
+
+	var ttl *int
+	if err := l.session.Query(stmt, link.ID).WithContext(ctx).Scan(&ttl); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return widecolumn.ErrNotFound
+		}
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+
+	// A TTL of 0 keeps the row forever, as it was
+	remaining := 0
+	if ttl != nil {
+		remaining = *ttl
+	}
+	if err := l.write(ctx, updated, link.Tags, remaining); err != nil {
+		return err
+	}
+
+	*link = *updated
+	return nil
+}
+
+// write inserts the link and its index rows in one logged batch with the same TTL, so they appear and expire together.
+// Index rows of oldTags the link no longer carries are removed.
+func (l *LinkRepository) write(ctx context.Context, link *entity.Link, oldTags []string, ttl int) error {
+	model := models.FromEntity(link)
+	cols := model.ColumnNames()
+
+	batch := l.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) USING TTL ?",
+		models.TableName, strings.Join(cols, ", "), placeholders(len(cols))),
+		append(model.ColumnValues(), ttl)...)
+
+	if link.TenantID != 0 {
+		batch.Query(insertTenantLinkStmt(), link.TenantID, link.ID, ttl)
+		for _, tag := range link.Tags {
+			batch.Query(insertTagLinkStmt(), link.TenantID, tag, link.ID, ttl)
+		}
+		for _, tag := range oldTags {
+			if !slices.Contains(link.Tags, tag) {
+				batch.Query(deleteTagLinkStmt(), link.TenantID, tag, link.ID)
+			}
+		}
+	}
+
+	return l.session.ExecuteBatch(batch)
+}
+
+// ScanRange reads the TTL off original_url, which every write of the row sets
+func (l *LinkRepository) ScanRange(ctx context.Context, rng widecolumn.TokenRange, fn func(link *entity.Link, ttl int) error) error {
+	stmt := fmt.Sprintf("SELECT %s, %s, %s, %s, %s, TTL(%s) FROM %s WHERE token(%s) >= ? AND token(%s) <= ?",
+		widecolumn.IDColumn, models.TenantIDColumn, models.TagsColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn,
+		models.OriginalURLColumn, models.TableName, widecolumn.IDColumn, widecolumn.IDColumn)
+
+	iter := l.session.Query(stmt, rng.Start, rng.End).WithContext(ctx).PageSize(constant.ReindexScanPageSize).Iter()
+	for {
+		var (
+			link entity.Link
+			ttl  *int
+		)
+		if !iter.Scan(&link.ID, &link.TenantID, &link.Tags, &link.CreatedAt, &link.UpdatedAt, &ttl) {
+			break
+		}
+
+		remaining := 0
+		if ttl != nil {
+			remaining = *ttl
+		}
+		if err := fn(&link, remaining); err != nil {
+			_ = iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// Index stamps the rows with the link's update time rather than now, so an index row removed since
+// (with its tag, or with the whole link) stays removed, and writing a row that exists changes nothing
+func (l *LinkRepository) Index(ctx context.Context, link *entity.Link, ttl int) error {
+	if link.TenantID == 0 {
+		return nil
+	}
+
+	stamp := link.UpdatedAt
+	if stamp.IsZero() {
+		stamp = link.CreatedAt
+	}
+	// Scylla write timestamps are in microseconds
+	ts := stamp.UnixMicro()
+
+	batch := l.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(insertTenantLinkStmt()+" AND TIMESTAMP ?", link.TenantID, link.ID, ttl, ts)
+	for _, tag := range link.Tags {
+		batch.Query(insertTagLinkStmt()+" AND TIMESTAMP ?", link.TenantID, tag, link.ID, ttl, ts)
+	}
+	return l.session.ExecuteBatch(batch)
+}
+
+// Search walks the index of the first tag, or of the whole tenant without tags, in link ID order,
+// and keeps the links that also carry every other tag. It returns the cursor of the next page, empty on the last one.
+func (l *LinkRepository) Search(ctx context.Context, query *entity.LinkQuery) ([]*entity.Link, string, error) {
+	var (
+		scan    string
+		args    []any
+		matches []string
+	)
+	if len(query.Tags) == 0 {
+		scan = fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s > ? LIMIT ?",
+			models.LinkIDColumn, models.TenantLinkTableName, models.TenantIDColumn, models.LinkIDColumn)
+		args = []any{query.TenantID}
+	} else {
+		scan = fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s = ? AND %s > ? LIMIT ?",
+			models.LinkIDColumn, models.TagLinkTableName, models.TenantIDColumn, models.TagColumn, models.LinkIDColumn)
+		args = []any{query.TenantID, query.Tags[0]}
+	}
+
+	after := query.After
+	for len(matches) < query.Limit {
+		page, err := l.scanIDs(ctx, scan, append(slices.Clone(args), after, constant.LinkSearchPageSize)...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		candidates := page
+		for _, tag := range query.Tags[min(1, len(query.Tags)):] {
+			if len(candidates) == 0 {
+				break
+			}
+			if candidates, err = l.tagged(ctx, query.TenantID, tag, candidates); err != nil {
+				return nil, "", err
+			}
+		}
+		matches = append(matches, candidates...)
+
+		if len(page) < constant.LinkSearchPageSize {
+			break
+		}
+		after = page[len(page)-1]
+	}
+
+	next := ""
+	if len(matches) >= query.Limit {
+		matches = matches[:query.Limit]
+		next = matches[len(matches)-1]
+	}
+
+	links := make([]*entity.Link, 0, len(matches))
+	for _, id := range matches {
+		link, err := l.Get(ctx, id)
+		if err != nil {
+			// The link expired between the index read and this one
+			if errors.Is(err, widecolumn.ErrNotFound) {
+				continue
+			}
+			return nil, "", err
+		}
+		links = append(links, link)
+	}
+	return links, next, nil
+}
+
+// tagged returns the candidates that carry the tag, in their order
+func (l *LinkRepository) tagged(ctx context.Context, tenantID int, tag string, candidates []string) ([]string, error) {
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s = ? AND %s IN ?",
+		models.LinkIDColumn, models.TagLinkTableName, models.TenantIDColumn, models.TagColumn, models.LinkIDColumn)
+
+	found, err := l.scanIDs(ctx, stmt, tenantID, tag, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]string, 0, len(candidates))
+	for _, id := range candidates {
+		if slices.Contains(found, id) {
+			kept = append(kept, id)
+		}
+	}
+	return kept, nil
+}
+
+func (l *LinkRepository) scanIDs(ctx context.Context, stmt string, args ...any) ([]string, error) {
+	iter := l.session.Query(stmt, args...).WithContext(ctx).Iter()
+	var (
+		ids []string
+		id  string
+	)
+	for iter.Scan(&id) {
+		ids = append(ids, id)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func insertTenantLinkStmt() string {
+	return fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?) USING TTL ?",
+		models.TenantLinkTableName, models.TenantIDColumn, models.LinkIDColumn)
+}
+
+func insertTagLinkStmt() string {
+	return fmt.Sprintf("INSERT INTO %s (%s, %s, %s) VALUES (?, ?, ?) USING TTL ?",
+		models.TagLinkTableName, models.TenantIDColumn, models.TagColumn, models.LinkIDColumn)
+}
+
+func deleteTagLinkStmt() string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND %s = ? AND %s = ?",
+		models.TagLinkTableName, models.TenantIDColumn, models.TagColumn, models.LinkIDColumn)
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
 }
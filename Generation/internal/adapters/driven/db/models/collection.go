@@ -0,0 +1,99 @@
+package models
+
+import (
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/generation/internal/core/entity"
+)
+
+const (
+	CollectionTableName = "collections"
+	NameColumn          = "name"
+	DescriptionColumn   = "description"
+	UTMSourceColumn     = "utm_source"
+	UTMMediumColumn     = "utm_medium"
+	UTMCampaignColumn   = "utm_campaign"
+	UTMTermColumn       = "utm_term"
+	UTMContentColumn    = "utm_content"
+	ExpiresInColumn     = "expires_in"
+
+	CollectionLinkTableName = "collection_links"
+	LinkCollectionTableName = "link_collections"
+	CollectionIDColumn      = "collection_id"
+	LinkIDColumn            = "link_id"
+	AddedAtColumn           = "added_at"
+)
+
+// Collection is partitioned by tenant, so ID is only unique within TenantID
+type Collection struct {
+	*widecolumn.BaseModel[string]
+	TenantID    int    `json:"tenant_id"`
+	UserID      int    `json:"user_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UTMSource   string `json:"utm_source"`
+	UTMMedium   string `json:"utm_medium"`
+	UTMCampaign string `json:"utm_campaign"`
+	UTMTerm     string `json:"utm_term"`
+	UTMContent  string `json:"utm_content"`
+	Domain      string `json:"domain"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func (Collection) TableName() string {
+	return CollectionTableName
+}
+
+func (Collection) ColumnNames() []string {
+	return []string{TenantIDColumn, widecolumn.IDColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn, UserIDColumn, NameColumn, DescriptionColumn, UTMSourceColumn, UTMMediumColumn, UTMCampaignColumn, UTMTermColumn, UTMContentColumn, DomainColumn, ExpiresInColumn}
+}
+
+func (c Collection) ColumnValues() []any {
+	return []any{c.TenantID, c.ID, c.CreatedAt, c.UpdatedAt, c.UserID, c.Name, c.Description, c.UTMSource, c.UTMMedium, c.UTMCampaign, c.UTMTerm, c.UTMContent, c.Domain, c.ExpiresIn}
+}
+
+// ScanDest returns pointers to the fields in ColumnNames order
+func (c *Collection) ScanDest() []any {
+	return []any{&c.TenantID, &c.ID, &c.CreatedAt, &c.UpdatedAt, &c.UserID, &c.Name, &c.Description, &c.UTMSource, &c.UTMMedium, &c.UTMCampaign, &c.UTMTerm, &c.UTMContent, &c.Domain, &c.ExpiresIn}
+}
+
+func FromCollection(e *entity.Collection) *Collection {
+	return &Collection{
+		BaseModel: &widecolumn.BaseModel[string]{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		},
+		TenantID:    e.TenantID,
+		UserID:      e.UserID,
+		Name:        e.Name,
+		Description: e.Description,
+		UTMSource:   e.UTM.Source,
+		UTMMedium:   e.UTM.Medium,
+		UTMCampaign: e.UTM.Campaign,
+		UTMTerm:     e.UTM.Term,
+		UTMContent:  e.UTM.Content,
+		Domain:      e.Domain,
+		ExpiresIn:   e.ExpiresIn,
+	}
+}
+
+func (c *Collection) ToEntity() *entity.Collection {
+	return &entity.Collection{
+		ID:          c.ID,
+		TenantID:    c.TenantID,
+		UserID:      c.UserID,
+		Name:        c.Name,
+		Description: c.Description,
+		UTM: entity.UTM{
+			Source:   c.UTMSource,
+			Medium:   c.UTMMedium,
+			Campaign: c.UTMCampaign,
+			Term:     c.UTMTerm,
+			Content:  c.UTMContent,
+		},
+		Domain:    c.Domain,
+		ExpiresIn: c.ExpiresIn,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
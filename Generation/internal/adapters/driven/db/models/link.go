@@ -1,22 +1,44 @@
 package models
 
 import (
+	"time"
+
 	"go-link/common/pkg/database/widecolumn"
 	"go-link/generation/internal/core/entity"
 )
 
 const (
-	TableName         = "links"
-	OriginalURLColumn = "original_url"
-	UserIDColumn      = "user_id"
-	TenantIDColumn    = "tenant_id"
+	TableName              = "links"
+	OriginalURLColumn      = "original_url"
+	UserIDColumn           = "user_id"
+	TenantIDColumn         = "tenant_id"
+	ExpiresAtColumn        = "expires_at"
+	DisabledColumn         = "disabled"
+	PasswordHashColumn     = "password_hash"
+	RequireSignatureColumn = "require_signature"
+	VisibilityColumn       = "visibility"
+	AppendClickIDColumn    = "append_click_id"
+	DomainColumn           = "domain"
+	TagsColumn             = "tags"
+
+	TenantLinkTableName = "tenant_links"
+	TagLinkTableName    = "tag_links"
+	TagColumn           = "tag"
 )
 
 type Link struct {
 	*widecolumn.BaseModel[string]
-	OriginalURL string `json:"original_url"`
-	UserID      int    `json:"user_id"`
-	TenantID    int    `json:"tenant_id"`
+	OriginalURL      string     `json:"original_url"`
+	UserID           int        `json:"user_id"`
+	TenantID         int        `json:"tenant_id"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	Disabled         bool       `json:"disabled"`
+	PasswordHash     string     `json:"password_hash"`
+	RequireSignature bool       `json:"require_signature"`
+	Visibility       string     `json:"visibility"`
+	AppendClickID    bool       `json:"append_click_id"`
+	Domain           string     `json:"domain"`
+	Tags             []string   `json:"tags"`
 }
 
 func (Link) TableName() string {
@@ -24,11 +46,11 @@ func (Link) TableName() string {
 }
 
 func (Link) ColumnNames() []string {
-	return []string{widecolumn.IDColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn, OriginalURLColumn, UserIDColumn, TenantIDColumn}
+	return []string{widecolumn.IDColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn, OriginalURLColumn, UserIDColumn, TenantIDColumn, ExpiresAtColumn, DisabledColumn, PasswordHashColumn, RequireSignatureColumn, VisibilityColumn, AppendClickIDColumn, DomainColumn, TagsColumn}
 }
 
 func (l Link) ColumnValues() []any {
-	return []any{l.ID, l.CreatedAt, l.UpdatedAt, l.OriginalURL, l.UserID, l.TenantID}
+	return []any{l.ID, l.CreatedAt, l.UpdatedAt, l.OriginalURL, l.UserID, l.TenantID, l.ExpiresAt, l.Disabled, l.PasswordHash, l.RequireSignature, l.Visibility, l.AppendClickID, l.Domain, l.Tags}
 }
 
 func FromEntity(e *entity.Link) *Link {
@@ -38,19 +60,43 @@ func FromEntity(e *entity.Link) *Link {
 			CreatedAt: e.CreatedAt,
 			UpdatedAt: e.UpdatedAt,
 		},
-		OriginalURL: e.OriginalURL,
-		UserID:      e.UserID,
-		TenantID:    e.TenantID,
+		OriginalURL:      e.OriginalURL,
+		UserID:           e.UserID,
+		TenantID:         e.TenantID,
+		ExpiresAt:        e.ExpiresAt,
+		Disabled:         e.Disabled,
+		PasswordHash:     e.PasswordHash,
+		RequireSignature: e.RequireSignature,
+		Visibility:       e.Visibility,
+		AppendClickID:    e.AppendClickID,
+		Domain:           e.Domain,
+		Tags:             e.Tags,
 	}
 }
 
 func (l *Link) ToEntity() *entity.Link {
 	return &entity.Link{
-		ID:          l.ID,
-		OriginalURL: l.OriginalURL,
-		UserID:      l.UserID,
-		TenantID:    l.TenantID,
-		CreatedAt:   l.CreatedAt,
-		UpdatedAt:   l.UpdatedAt,
+		ID:               l.ID,
+		OriginalURL:      l.OriginalURL,
+		UserID:           l.UserID,
+		TenantID:         l.TenantID,
+		ExpiresAt:        expiresAt(l.ExpiresAt),
+		Disabled:         l.Disabled,
+		PasswordHash:     l.PasswordHash,
+		RequireSignature: l.RequireSignature,
+		Visibility:       l.Visibility,
+		AppendClickID:    l.AppendClickID,
+		Domain:           l.Domain,
+		Tags:             l.Tags,
+		CreatedAt:        l.CreatedAt,
+		UpdatedAt:        l.UpdatedAt,
+	}
+}
+
+// expiresAt maps the zero timestamp a null column scans into back to "never expires"
+func expiresAt(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
 	}
+	return t
 }
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/generation/internal/core/entity"
+)
+
+const (
+	TagPresetTableName = "tag_presets"
+	UTMPresetTableName = "utm_presets"
+)
+
+// UTMPreset is partitioned by tenant and keyed by name
+type UTMPreset struct {
+	TenantID    int       `json:"tenant_id"`
+	Name        string    `json:"name"`
+	UTMSource   string    `json:"utm_source"`
+	UTMMedium   string    `json:"utm_medium"`
+	UTMCampaign string    `json:"utm_campaign"`
+	UTMTerm     string    `json:"utm_term"`
+	UTMContent  string    `json:"utm_content"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (UTMPreset) TableName() string {
+	return UTMPresetTableName
+}
+
+func (UTMPreset) ColumnNames() []string {
+	return []string{TenantIDColumn, NameColumn, UTMSourceColumn, UTMMediumColumn, UTMCampaignColumn, UTMTermColumn, UTMContentColumn, widecolumn.UpdatedAtColumn}
+}
+
+func (p UTMPreset) ColumnValues() []any {
+	return []any{p.TenantID, p.Name, p.UTMSource, p.UTMMedium, p.UTMCampaign, p.UTMTerm, p.UTMContent, p.UpdatedAt}
+}
+
+// ScanDest returns pointers to the fields in ColumnNames order
+func (p *UTMPreset) ScanDest() []any {
+	return []any{&p.TenantID, &p.Name, &p.UTMSource, &p.UTMMedium, &p.UTMCampaign, &p.UTMTerm, &p.UTMContent, &p.UpdatedAt}
+}
+
+func FromUTMPreset(e *entity.UTMPreset) *UTMPreset {
+	return &UTMPreset{
+		TenantID:    e.TenantID,
+		Name:        e.Name,
+		UTMSource:   e.UTM.Source,
+		UTMMedium:   e.UTM.Medium,
+		UTMCampaign: e.UTM.Campaign,
+		UTMTerm:     e.UTM.Term,
+		UTMContent:  e.UTM.Content,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+func (p *UTMPreset) ToEntity() *entity.UTMPreset {
+	return &entity.UTMPreset{
+		TenantID: p.TenantID,
+		Name:     p.Name,
+		UTM: entity.UTM{
+			Source:   p.UTMSource,
+			Medium:   p.UTMMedium,
+			Campaign: p.UTMCampaign,
+			Term:     p.UTMTerm,
+			Content:  p.UTMContent,
+		},
+		UpdatedAt: p.UpdatedAt,
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/generation/global"
+	"go-link/generation/internal/adapters/driven/db/models"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/ports"
+)
+
+type PresetRepository struct {
+	session *gocql.Session
+	utm     *widecolumn.BaseRepository[models.UTMPreset]
+}
+
+// NewPresetRepository creates a new instance of PresetRepository
+func NewPresetRepository() ports.PresetRepository {
+	session := global.WideColumnClient.GetSession()
+	return &PresetRepository{
+		session: session,
+		utm:     widecolumn.NewBaseRepository(session, models.UTMPreset{}),
+	}
+}
+
+// Get reads the tenant's tag presets and UTM presets, each ordered by name
+func (r *PresetRepository) Get(ctx context.Context, tenantID int) (*entity.Presets, error) {
+	presets := &entity.Presets{TenantID: tenantID}
+
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?", models.TagColumn, models.TagPresetTableName, models.TenantIDColumn)
+	iter := r.session.Query(stmt, tenantID).WithContext(ctx).Iter()
+	var tag string
+	for iter.Scan(&tag) {
+		presets.Tags = append(presets.Tags, tag)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	stmt = fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?",
+		strings.Join(models.UTMPreset{}.ColumnNames(), ", "), models.UTMPresetTableName, models.TenantIDColumn)
+	iter = r.session.Query(stmt, tenantID).WithContext(ctx).Iter()
+	for {
+		model := &models.UTMPreset{}
+		if !iter.Scan(model.ScanDest()...) {
+			break
+		}
+		presets.UTM = append(presets.UTM, model.ToEntity())
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return presets, nil
+}
+
+// SetTags replaces the tenant's tag presets in one batch; the rows share a partition, so it applies as a whole.
+// The old rows are removed a microsecond before the new ones are written, since a tombstone wins a timestamp tie.
+func (r *PresetRepository) SetTags(ctx context.Context, tenantID int, tags []string) error {
+	now := time.Now().UnixMicro()
+
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(fmt.Sprintf("DELETE FROM %s USING TIMESTAMP ? WHERE %s = ?", models.TagPresetTableName, models.TenantIDColumn), now-1, tenantID)
+
+	insert := fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?) USING TIMESTAMP ?", models.TagPresetTableName, models.TenantIDColumn, models.TagColumn)
+	for _, tag := range tags {
+		batch.Query(insert, tenantID, tag, now)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *PresetRepository) GetUTM(ctx context.Context, tenantID int, name string) (*entity.UTMPreset, error) {
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s = ?",
+		strings.Join(models.UTMPreset{}.ColumnNames(), ", "), models.UTMPresetTableName, models.TenantIDColumn, models.NameColumn)
+
+	model := &models.UTMPreset{}
+	if err := r.session.Query(stmt, tenantID, name).WithContext(ctx).Scan(model.ScanDest()...); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, widecolumn.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// SaveUTM upserts a UTM preset
+func (r *PresetRepository) SaveUTM(ctx context.Context, preset *entity.UTMPreset) error {
+	return r.utm.Create(ctx, models.FromUTMPreset(preset))
+}
+
+func (r *PresetRepository) DeleteUTM(ctx context.Context, tenantID int, name string) error {
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND %s = ?", models.UTMPresetTableName, models.TenantIDColumn, models.NameColumn)
+	return r.session.Query(stmt, tenantID, name).WithContext(ctx).Exec()
+}
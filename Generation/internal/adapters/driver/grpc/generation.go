@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	generationv1 "go-link/common/gen/go/generation/v1"
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/common/pkg/grpc/metadata"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/ports"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GenerationServer struct {
+	generationv1.UnimplementedGenerationServiceServer
+	linkService       ports.LinkService
+	collectionService ports.CollectionService
+}
+
+func NewGenerationServer(linkService ports.LinkService, collectionService ports.CollectionService) *GenerationServer {
+	return &GenerationServer{
+		linkService:       linkService,
+		collectionService: collectionService,
+	}
+}
+
+// GetLink serves read-your-writes lookups for links Redirection hasn't received through CDC yet
+func (s *GenerationServer) GetLink(ctx context.Context, req *generationv1.GetLinkRequest) (*generationv1.GetLinkResponse, error) {
+	ctx = metadata.ExtractIncomingContext(ctx)
+
+	link, err := s.linkService.Get(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, widecolumn.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "link not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &generationv1.GetLinkResponse{
+		Link: toLinkProto(link),
+	}, nil
+}
+
+// ListCollectionLinks lets Analytics aggregate the clicks of every link in a collection
+func (s *GenerationServer) ListCollectionLinks(ctx context.Context, req *generationv1.ListCollectionLinksRequest) (*generationv1.ListCollectionLinksResponse, error) {
+	ctx = metadata.ExtractIncomingContext(ctx)
+
+	linkIDs, err := s.collectionService.LinkIDs(ctx, int(req.TenantId), req.CollectionId)
+	if err != nil {
+		if errors.Is(err, widecolumn.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "collection not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &generationv1.ListCollectionLinksResponse{
+		LinkIds: linkIDs,
+	}, nil
+}
+
+func toLinkProto(l *entity.Link) *generationv1.Link {
+	return &generationv1.Link{
+		Id:               l.ID,
+		OriginalUrl:      l.OriginalURL,
+		UserId:           int64(l.UserID),
+		TenantId:         int64(l.TenantID),
+		ExpiresAt:        unixMilli(l.ExpiresAt),
+		Disabled:         l.Disabled,
+		PasswordHash:     l.PasswordHash,
+		RequireSignature: l.RequireSignature,
+		Visibility:       l.Visibility,
+		CreatedAt:        l.CreatedAt.UnixMilli(),
+		UpdatedAt:        l.UpdatedAt.UnixMilli(),
+		AppendClickId:    l.AppendClickID,
+	}
+}
+
+func unixMilli(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,15 @@
+package grpc
+
+import (
+	generationv1 "go-link/common/gen/go/generation/v1"
+	"go-link/generation/internal/ports"
+
+	"google.golang.org/grpc"
+)
+
+// V1Routes registers the generation service routes
+func V1Routes(linkService ports.LinkService, collectionService ports.CollectionService) func(srv *grpc.Server) {
+	return func(srv *grpc.Server) {
+		generationv1.RegisterGenerationServiceServer(srv, NewGenerationServer(linkService, collectionService))
+	}
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/ports"
+)
+
+type CollectionHandler interface {
+	Create(ctx context.Context, req *dto.CreateCollectionRequest) (*dto.CollectionResponse, error)
+	Get(ctx context.Context, req *dto.GetCollectionRequest) (*dto.CollectionResponse, error)
+	List(ctx context.Context, req *dto.ListCollectionsRequest) (*dto.ListCollectionsResponse, error)
+	Update(ctx context.Context, req *dto.UpdateCollectionRequest) (*dto.CollectionResponse, error)
+	Delete(ctx context.Context, req *dto.DeleteCollectionRequest) (*dto.CollectionResponse, error)
+	ListLinks(ctx context.Context, req *dto.ListCollectionLinksRequest) (*dto.ListCollectionLinksResponse, error)
+	MoveLinks(ctx context.Context, req *dto.MoveLinksRequest) (*dto.MoveLinksResponse, error)
+}
+
+type collectionHandler struct {
+	handler.BaseHandler
+	collectionService ports.CollectionService
+}
+
+func NewCollectionHandler(collectionService ports.CollectionService) CollectionHandler {
+	return &collectionHandler{
+		collectionService: collectionService,
+	}
+}
+
+// Create creates a collection whose defaults new links inherit
+func (h *collectionHandler) Create(ctx context.Context, req *dto.CreateCollectionRequest) (*dto.CollectionResponse, error) {
+	return h.collectionService.Create(ctx, req)
+}
+
+// Get returns a collection of the caller's tenant
+func (h *collectionHandler) Get(ctx context.Context, req *dto.GetCollectionRequest) (*dto.CollectionResponse, error) {
+	return h.collectionService.Get(ctx, req)
+}
+
+// List returns the collections of the caller's tenant
+func (h *collectionHandler) List(ctx context.Context, req *dto.ListCollectionsRequest) (*dto.ListCollectionsResponse, error) {
+	return h.collectionService.List(ctx, req)
+}
+
+// Update replaces a collection's name, description and defaults
+func (h *collectionHandler) Update(ctx context.Context, req *dto.UpdateCollectionRequest) (*dto.CollectionResponse, error) {
+	return h.collectionService.Update(ctx, req)
+}
+
+// Delete deletes a collection, keeping its links
+func (h *collectionHandler) Delete(ctx context.Context, req *dto.DeleteCollectionRequest) (*dto.CollectionResponse, error) {
+	return nil, h.collectionService.Delete(ctx, req)
+}
+
+// ListLinks returns the links in a collection
+func (h *collectionHandler) ListLinks(ctx context.Context, req *dto.ListCollectionLinksRequest) (*dto.ListCollectionLinksResponse, error) {
+	return h.collectionService.ListLinks(ctx, req)
+}
+
+// MoveLinks moves links between collections in bulk
+func (h *collectionHandler) MoveLinks(ctx context.Context, req *dto.MoveLinksRequest) (*dto.MoveLinksResponse, error) {
+	return h.collectionService.MoveLinks(ctx, req)
+}
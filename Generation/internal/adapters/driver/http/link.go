@@ -11,6 +11,11 @@ import (
 type LinkHandler interface {
 	Create(ctx context.Context, req *dto.CreateLinkRequest) (*dto.LinkResponse, error)
 	Delete(ctx context.Context, req *dto.DeleteLinkRequest) (*dto.LinkResponse, error)
+	Sign(ctx context.Context, req *dto.SignLinkRequest) (*dto.SignedLinkResponse, error)
+	Search(ctx context.Context, req *dto.SearchLinksRequest) (*dto.SearchLinksResponse, error)
+	SetTags(ctx context.Context, req *dto.SetLinkTagsRequest) (*dto.LinkSummaryResponse, error)
+	SetStatus(ctx context.Context, req *dto.SetLinkStatusRequest) (*dto.LinkSummaryResponse, error)
+	BuildUTM(ctx context.Context, req *dto.BuildUTMRequest) (*dto.BuildUTMResponse, error)
 }
 
 type linkHandler struct {
@@ -33,3 +38,28 @@ func (h *linkHandler) Create(ctx context.Context, req *dto.CreateLinkRequest) (*
 func (h *linkHandler) Delete(ctx context.Context, req *dto.DeleteLinkRequest) (*dto.LinkResponse, error) {
 	return nil, h.linkService.Delete(ctx, req)
 }
+
+// Sign mints a self-expiring signed URL for a short link
+func (h *linkHandler) Sign(ctx context.Context, req *dto.SignLinkRequest) (*dto.SignedLinkResponse, error) {
+	return h.linkService.Sign(ctx, req)
+}
+
+// Search lists the tenant's links, optionally filtered by tags
+func (h *linkHandler) Search(ctx context.Context, req *dto.SearchLinksRequest) (*dto.SearchLinksResponse, error) {
+	return h.linkService.Search(ctx, req)
+}
+
+// SetTags replaces the tags of a short link
+func (h *linkHandler) SetTags(ctx context.Context, req *dto.SetLinkTagsRequest) (*dto.LinkSummaryResponse, error) {
+	return h.linkService.SetTags(ctx, req)
+}
+
+// SetStatus disables a short link or enables it again
+func (h *linkHandler) SetStatus(ctx context.Context, req *dto.SetLinkStatusRequest) (*dto.LinkSummaryResponse, error) {
+	return h.linkService.SetStatus(ctx, req)
+}
+
+// BuildUTM previews a destination tagged with UTM parameters
+func (h *linkHandler) BuildUTM(ctx context.Context, req *dto.BuildUTMRequest) (*dto.BuildUTMResponse, error) {
+	return h.linkService.BuildUTM(ctx, req)
+}
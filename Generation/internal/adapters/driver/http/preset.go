@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/ports"
+)
+
+type PresetHandler interface {
+	Get(ctx context.Context, req *dto.GetPresetsRequest) (*dto.PresetsResponse, error)
+	SetTags(ctx context.Context, req *dto.SetTagPresetsRequest) (*dto.PresetsResponse, error)
+	SaveUTM(ctx context.Context, req *dto.SaveUTMPresetRequest) (*dto.UTMPresetResponse, error)
+	DeleteUTM(ctx context.Context, req *dto.DeleteUTMPresetRequest) (*dto.UTMPresetResponse, error)
+}
+
+type presetHandler struct {
+	handler.BaseHandler
+	presetService ports.PresetService
+}
+
+func NewPresetHandler(presetService ports.PresetService) PresetHandler {
+	return &presetHandler{
+		presetService: presetService,
+	}
+}
+
+// Get returns the tag and UTM presets of the caller's tenant
+func (h *presetHandler) Get(ctx context.Context, req *dto.GetPresetsRequest) (*dto.PresetsResponse, error) {
+	return h.presetService.Get(ctx, req)
+}
+
+// SetTags replaces the tenant's tag presets
+func (h *presetHandler) SetTags(ctx context.Context, req *dto.SetTagPresetsRequest) (*dto.PresetsResponse, error) {
+	return h.presetService.SetTags(ctx, req)
+}
+
+// SaveUTM creates or replaces a UTM preset
+func (h *presetHandler) SaveUTM(ctx context.Context, req *dto.SaveUTMPresetRequest) (*dto.UTMPresetResponse, error) {
+	return h.presetService.SaveUTM(ctx, req)
+}
+
+// DeleteUTM deletes a UTM preset
+func (h *presetHandler) DeleteUTM(ctx context.Context, req *dto.DeleteUTMPresetRequest) (*dto.UTMPresetResponse, error) {
+	return nil, h.presetService.DeleteUTM(ctx, req)
+}
@@ -0,0 +1,10 @@
+package constant
+
+const (
+	// CollectionMaxLinks keeps a collection small enough for Analytics to filter on all its links in one query
+	CollectionMaxLinks = 10_000
+	// LinkMaxCollections caps the collections a new link can be created in
+	LinkMaxCollections = 20
+	// MoveLinksMaxBatch caps the links one move request touches
+	MoveLinksMaxBatch = 500
+)
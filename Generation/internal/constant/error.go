@@ -7,4 +7,22 @@ const (
 	MsgInternalError          = "internal error"
 	MsgInsufficientPermission = "insufficient permission"
 	MsgVerifyPermissionFailed = "failed to verify permission"
+	MsgExpiryInPast           = "expiry must be in the future"
+	MsgHashPasswordFailed     = "failed to hash password"
+	MsgSignedLinkNeedsTenant  = "signed links require a link owned by a tenant"
+	MsgSigningNotConfigured   = "signed links are not configured"
+	MsgGenerateNonceFailed    = "failed to generate nonce"
+	MsgPrivateLinkNeedsTenant = "private links require a signed-in tenant member"
+	MsgTenantRequired         = "a signed-in tenant member is required"
+	MsgIdentityUnavailable    = "identity client not available"
+	MsgDomainNotVerified      = "domain is not a verified domain of the tenant"
+	MsgVerifyDomainFailed     = "failed to verify domain"
+	MsgInvalidDestination     = "invalid destination URL"
+	MsgCollectionNotFound     = "collection %s not found"
+	MsgCollectionFull         = "collection would exceed %d links"
+	MsgLinkNotInTenant        = "link %s does not belong to the tenant"
+	MsgMoveNeedsCollection    = "a source or target collection is required"
+	MsgMoveSameCollection     = "source and target collections must differ"
+	MsgUTMPresetNotFound      = "utm preset %s not found"
+	MsgTooManyUTMPresets      = "utm preset limit of %d reached"
 )
@@ -0,0 +1,7 @@
+package constant
+
+const (
+	ReindexScanPageSize   = 1_000
+	ReindexDefaultRanges  = 256
+	ReindexDefaultWorkers = 8
+)
@@ -0,0 +1,11 @@
+package constant
+
+const (
+	// UTMPresetsMax caps the UTM presets of a tenant
+	UTMPresetsMax = 50
+
+	LinkSearchDefaultLimit = 20
+	// LinkSearchPageSize is how many index rows a search reads at once; it also bounds the IN list
+	// used to check a page against the other tags, which Scylla caps at 100 by default
+	LinkSearchPageSize = 100
+)
@@ -0,0 +1,82 @@
+package dto
+
+import "time"
+
+// UTMParams are the utm_* parameters added to a destination; each is left out when empty
+type UTMParams struct {
+	Source   string `json:"utm_source" validate:"omitempty,max=255"`
+	Medium   string `json:"utm_medium" validate:"omitempty,max=255"`
+	Campaign string `json:"utm_campaign" validate:"omitempty,max=255"`
+	Term     string `json:"utm_term" validate:"omitempty,max=255"`
+	Content  string `json:"utm_content" validate:"omitempty,max=255"`
+}
+
+// CreateCollectionRequest sets the defaults links created in the collection inherit.
+// Domain must be one of the tenant's verified custom domains; ExpiresIn is in seconds, 0 for links that don't expire.
+type CreateCollectionRequest struct {
+	Name        string    `json:"name" validate:"required,max=100"`
+	Description string    `json:"description" validate:"omitempty,max=500"`
+	UTM         UTMParams `json:"utm"`
+	Domain      string    `json:"domain" validate:"omitempty,fqdn,max=253"`
+	ExpiresIn   int       `json:"expires_in" validate:"omitempty,min=60,max=31536000"`
+}
+
+// UpdateCollectionRequest replaces the collection's name, description and defaults; links already in it keep theirs
+type UpdateCollectionRequest struct {
+	ID          string    `uri:"id" validate:"required"`
+	Name        string    `json:"name" validate:"required,max=100"`
+	Description string    `json:"description" validate:"omitempty,max=500"`
+	UTM         UTMParams `json:"utm"`
+	Domain      string    `json:"domain" validate:"omitempty,fqdn,max=253"`
+	ExpiresIn   int       `json:"expires_in" validate:"omitempty,min=60,max=31536000"`
+}
+
+type GetCollectionRequest struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+type DeleteCollectionRequest struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+type ListCollectionsRequest struct{}
+
+type CollectionResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	UTM         UTMParams `json:"utm"`
+	Domain      string    `json:"domain,omitempty"`
+	ExpiresIn   int       `json:"expires_in,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ListCollectionsResponse struct {
+	Collections []*CollectionResponse `json:"collections"`
+}
+
+type ListCollectionLinksRequest struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+type CollectionLinkResponse struct {
+	ID      string    `json:"id"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+type ListCollectionLinksResponse struct {
+	Links []*CollectionLinkResponse `json:"links"`
+}
+
+// MoveLinksRequest moves links between collections of the caller's tenant.
+// Without a source the links are only added to the target; without a target they are only removed from the source.
+type MoveLinksRequest struct {
+	LinkIDs []string `json:"link_ids" validate:"required,min=1,max=500,dive,required"`
+	From    string   `json:"from_collection_id"`
+	To      string   `json:"to_collection_id"`
+}
+
+type MoveLinksResponse struct {
+	Moved int `json:"moved"`
+}
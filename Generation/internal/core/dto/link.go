@@ -1,13 +1,88 @@
 package dto
 
+import "time"
+
 type CreateLinkRequest struct {
-	OriginalURL string `json:"original_url"`
+	OriginalURL string     `json:"original_url"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	Password    string     `json:"password" validate:"omitempty,min=4,max=72"`
+	// RequireSignature makes the short link resolvable only through minted signed URLs
+	RequireSignature bool   `json:"require_signature"`
+	Visibility       string `json:"visibility" validate:"omitempty,oneof=public private"`
+	// AppendClickID adds a glclid click ID to the destination for conversion tracking
+	AppendClickID bool `json:"append_click_id"`
+	// CollectionIDs puts the link in these collections; it inherits the defaults of the first one
+	CollectionIDs []string `json:"collection_ids" validate:"omitempty,max=20,dive,required"`
+	// Tags are free-form and stored lowercased
+	Tags []string `json:"tags" validate:"omitempty,max=10,dive,required,max=32"`
+	// UTM parameters replace any of the same name in OriginalURL; the collection's fill in the rest
+	UTM *UTMParams `json:"utm"`
+	// UTMPreset names a tenant preset to start from; parameters set in UTM win over it
+	UTMPreset string `json:"utm_preset" validate:"omitempty,max=64"`
 }
 
 type LinkResponse struct {
 	ShortLink string `json:"short_link"`
 }
 
+// SearchLinksRequest pages through the caller's tenant links by ID; repeat tags to require several
+type SearchLinksRequest struct {
+	Tags  []string `form:"tags" validate:"omitempty,max=10,dive,required,max=32"`
+	After string   `form:"after"`
+	Limit int      `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type LinkSummaryResponse struct {
+	ID          string     `json:"id"`
+	ShortLink   string     `json:"short_link"`
+	OriginalURL string     `json:"original_url"`
+	Tags        []string   `json:"tags"`
+	Visibility  string     `json:"visibility"`
+	Disabled    bool       `json:"disabled"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// SearchLinksResponse holds one page; pass NextCursor as after to get the next, it is empty on the last page
+type SearchLinksResponse struct {
+	Links      []*LinkSummaryResponse `json:"links"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// SetLinkTagsRequest replaces the link's tags; an empty list removes them all
+type SetLinkTagsRequest struct {
+	ID   string   `uri:"id" validate:"required"`
+	Tags []string `json:"tags" validate:"max=10,dive,required,max=32"`
+}
+
+// SetLinkStatusRequest disables the link or enables it again
+type SetLinkStatusRequest struct {
+	ID       string `uri:"id" validate:"required"`
+	Disabled *bool  `json:"disabled" validate:"required"`
+}
+
+// BuildUTMRequest previews the destination a link would get from the UTM builder
+type BuildUTMRequest struct {
+	URL    string    `json:"url" validate:"required,url,max=2048"`
+	UTM    UTMParams `json:"utm"`
+	Preset string    `json:"preset" validate:"omitempty,max=64"`
+}
+
+type BuildUTMResponse struct {
+	URL string `json:"url"`
+}
+
 type DeleteLinkRequest struct {
 	ID string `json:"id"`
 }
+
+type SignLinkRequest struct {
+	ID        string `uri:"id" validate:"required"`
+	ExpiresIn int    `json:"expires_in" validate:"required,min=1,max=2592000"`
+	SingleUse bool   `json:"single_use"`
+}
+
+type SignedLinkResponse struct {
+	SignedLink string    `json:"signed_link"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
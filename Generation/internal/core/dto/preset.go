@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type GetPresetsRequest struct{}
+
+// SetTagPresetsRequest replaces the tags offered to the tenant's members
+type SetTagPresetsRequest struct {
+	Tags []string `json:"tags" validate:"max=100,dive,required,max=32"`
+}
+
+// SaveUTMPresetRequest creates or replaces the named UTM preset
+type SaveUTMPresetRequest struct {
+	Name string    `uri:"name" validate:"required,max=64"`
+	UTM  UTMParams `json:"utm"`
+}
+
+type DeleteUTMPresetRequest struct {
+	Name string `uri:"name" validate:"required,max=64"`
+}
+
+type UTMPresetResponse struct {
+	Name      string    `json:"name"`
+	UTM       UTMParams `json:"utm"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PresetsResponse struct {
+	Tags []string             `json:"tags"`
+	UTM  []*UTMPresetResponse `json:"utm"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type ReindexRequest struct {
+	Ranges  int `json:"ranges"`
+	Workers int `json:"workers"`
+}
+
+// ReindexReport counts the links read and the tenant links whose index rows were written
+type ReindexReport struct {
+	Ranges   int    `json:"ranges"`
+	Scanned  int64  `json:"scanned"`
+	Indexed  int64  `json:"indexed"`
+	Duration string `json:"duration"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Collection groups a tenant's links, e.g. a campaign or a folder. Links created in it inherit its defaults.
+type Collection struct {
+	ID          string
+	TenantID    int
+	UserID      int
+	Name        string
+	Description string
+	UTM         UTM
+	// Domain is the verified custom domain new links are served from, empty for the shared one
+	Domain string
+	// ExpiresIn is the lifetime in seconds given to new links that don't set an expiry, 0 for none
+	ExpiresIn int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CollectionLink is a link's membership in a collection
+type CollectionLink struct {
+	CollectionID string
+	LinkID       string
+	AddedAt      time.Time
+}
@@ -4,11 +4,28 @@ import (
 	"time"
 )
 
+// Link visibility: private links only resolve for signed-in members of the owning tenant
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
 type Link struct {
-	ID          string    `json:"id"`
-	OriginalURL string    `json:"original_url"`
-	UserID      int       `json:"user_id"`
-	TenantID    int       `json:"tenant_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           string     `json:"id"`
+	OriginalURL  string     `json:"original_url"`
+	UserID       int        `json:"user_id"`
+	TenantID     int        `json:"tenant_id"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Disabled     bool       `json:"disabled"`
+	PasswordHash string     `json:"password_hash,omitempty"`
+	// RequireSignature rejects visits that don't carry a valid signed-link query
+	RequireSignature bool   `json:"require_signature"`
+	Visibility       string `json:"visibility"`
+	// AppendClickID tags the destination with a glclid click ID so conversions can be attributed
+	AppendClickID bool `json:"append_click_id"`
+	// Domain is the custom domain the short link is served from, empty for the shared one
+	Domain    string    `json:"domain,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
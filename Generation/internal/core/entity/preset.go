@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// UTMPreset is a named set of UTM parameters any member of the tenant can build links with
+type UTMPreset struct {
+	TenantID  int
+	Name      string
+	UTM       UTM
+	UpdatedAt time.Time
+}
+
+// Presets are what a tenant offers its members when they tag links and build destinations
+type Presets struct {
+	TenantID int
+	Tags     []string
+	UTM      []*UTMPreset
+}
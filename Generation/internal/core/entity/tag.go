@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+)
+
+// NormalizeTags trims and lowercases tags and drops empty and repeated ones, so a filter matches however a tag was typed
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			normalized = append(normalized, t)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// LinkQuery searches a tenant's links, ordered by ID. Links must carry every tag in Tags;
+// After is the last ID of the previous page.
+type LinkQuery struct {
+	TenantID int
+	Tags     []string
+	After    string
+	Limit    int
+}
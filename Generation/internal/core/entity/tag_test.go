@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"nil", nil, []string{}},
+		{"trims and lowercases", []string{"  Spring ", "SALE"}, []string{"sale", "spring"}},
+		{"drops empty", []string{"", "   ", "a"}, []string{"a"}},
+		{"drops repeated after normalizing", []string{"Promo", "promo ", "PROMO"}, []string{"promo"}},
+		{"sorts", []string{"c", "a", "b"}, []string{"a", "b", "c"}},
+		{"keeps inner spaces", []string{"black  friday"}, []string{"black  friday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeTags(tt.tags)
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"net/url"
+	"strings"
+)
+
+// UTM query parameters a destination is tagged with for campaign attribution
+const (
+	UTMSourceParam   = "utm_source"
+	UTMMediumParam   = "utm_medium"
+	UTMCampaignParam = "utm_campaign"
+	UTMTermParam     = "utm_term"
+	UTMContentParam  = "utm_content"
+)
+
+// UTM holds the utm_* parameters of a destination; empty fields are left out
+type UTM struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Term     string
+	Content  string
+}
+
+// params lists the parameters in the order they are added to a destination
+func (u UTM) params() [][2]string {
+	return [][2]string{
+		{UTMSourceParam, u.Source},
+		{UTMMediumParam, u.Medium},
+		{UTMCampaignParam, u.Campaign},
+		{UTMTermParam, u.Term},
+		{UTMContentParam, u.Content},
+	}
+}
+
+func (u UTM) IsZero() bool {
+	return u == UTM{}
+}
+
+// Merge returns u with every parameter set in over replaced by over's
+func (u UTM) Merge(over UTM) UTM {
+	if over.Source != "" {
+		u.Source = over.Source
+	}
+	if over.Medium != "" {
+		u.Medium = over.Medium
+	}
+	if over.Campaign != "" {
+		u.Campaign = over.Campaign
+	}
+	if over.Term != "" {
+		u.Term = over.Term
+	}
+	if over.Content != "" {
+		u.Content = over.Content
+	}
+	return u
+}
+
+// Apply sets the parameters on the destination, replacing any it already carries under the same name.
+// The rest of its query keeps its order and encoding, and the fragment stays last.
+func (u UTM) Apply(destination string) (string, error) {
+	dest, err := url.Parse(destination)
+	if err != nil {
+		return "", err
+	}
+
+	set := make(map[string]bool)
+	var added []string
+	for _, p := range u.params() {
+		if p[1] == "" {
+			continue
+		}
+		set[p[0]] = true
+		added = append(added, p[0]+"="+url.QueryEscape(p[1]))
+	}
+	if len(added) == 0 {
+		return destination, nil
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(dest.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(key); err == nil && set[k] {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+
+	dest.RawQuery = strings.Join(append(kept, added...), "&")
+	dest.ForceQuery = false
+	return dest.String(), nil
+}
+
+// Fill adds the parameters the destination doesn't carry yet; one it carries with an empty value counts as missing.
+// The query it already has keeps its order and encoding, so destinations that check their own query keep working.
+func (u UTM) Fill(destination string) (string, error) {
+	dest, err := url.Parse(destination)
+	if err != nil {
+		return "", err
+	}
+	present := dest.Query()
+
+	missing := u
+	for param, value := range map[string]*string{
+		UTMSourceParam:   &missing.Source,
+		UTMMediumParam:   &missing.Medium,
+		UTMCampaignParam: &missing.Campaign,
+		UTMTermParam:     &missing.Term,
+		UTMContentParam:  &missing.Content,
+	} {
+		if present.Get(param) != "" {
+			*value = ""
+		}
+	}
+	return missing.Apply(destination)
+}
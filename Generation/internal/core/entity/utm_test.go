@@ -0,0 +1,77 @@
+package entity
+
+import "testing"
+
+func TestUTMApply(t *testing.T) {
+	utm := UTM{Source: "newsletter", Campaign: "spring sale"}
+
+	tests := []struct {
+		name        string
+		destination string
+		want        string
+	}{
+		{"no query", "https://shop.example/p", "https://shop.example/p?utm_source=newsletter&utm_campaign=spring+sale"},
+		{"keeps existing query", "https://shop.example/p?z=1&a=%7E", "https://shop.example/p?z=1&a=%7E&utm_source=newsletter&utm_campaign=spring+sale"},
+		{"replaces same name", "https://shop.example/p?utm_source=ads&a=1", "https://shop.example/p?a=1&utm_source=newsletter&utm_campaign=spring+sale"},
+		{"replaces percent-encoded name", "https://shop.example/p?utm%5Fsource=ads", "https://shop.example/p?utm_source=newsletter&utm_campaign=spring+sale"},
+		{"replaces empty value", "https://shop.example/p?utm_source=&a=1", "https://shop.example/p?a=1&utm_source=newsletter&utm_campaign=spring+sale"},
+		{"keeps other utm parameters", "https://shop.example/p?utm_medium=email", "https://shop.example/p?utm_medium=email&utm_source=newsletter&utm_campaign=spring+sale"},
+		{"keeps fragment last", "https://shop.example/p?a=1#top", "https://shop.example/p?a=1&utm_source=newsletter&utm_campaign=spring+sale#top"},
+		{"drops trailing ampersand", "https://shop.example/p?a=1&", "https://shop.example/p?a=1&utm_source=newsletter&utm_campaign=spring+sale"},
+		{"drops bare question mark", "https://shop.example/p?", "https://shop.example/p?utm_source=newsletter&utm_campaign=spring+sale"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utm.Apply(tt.destination)
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUTMApplyEmpty(t *testing.T) {
+	destination := "https://shop.example/p?a=1&"
+	got, err := UTM{}.Apply(destination)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got != destination {
+		t.Errorf("expected the destination untouched, got %q", got)
+	}
+}
+
+func TestUTMFill(t *testing.T) {
+	utm := UTM{Source: "newsletter", Medium: "email"}
+
+	tests := []struct {
+		name        string
+		destination string
+		want        string
+	}{
+		{"no query", "https://shop.example/p", "https://shop.example/p?utm_source=newsletter&utm_medium=email"},
+		{"keeps existing query", "https://shop.example/p?z=1&a=%7E", "https://shop.example/p?z=1&a=%7E&utm_source=newsletter&utm_medium=email"},
+		{"keeps same name", "https://shop.example/p?utm_source=ads", "https://shop.example/p?utm_source=ads&utm_medium=email"},
+		{"keeps percent-encoded name", "https://shop.example/p?utm%5Fsource=ads", "https://shop.example/p?utm%5Fsource=ads&utm_medium=email"},
+		{"fills empty value", "https://shop.example/p?utm_source=&a=1", "https://shop.example/p?a=1&utm_source=newsletter&utm_medium=email"},
+		{"keeps fragment last", "https://shop.example/p?a=1#top", "https://shop.example/p?a=1&utm_source=newsletter&utm_medium=email#top"},
+		{"drops trailing ampersand", "https://shop.example/p?a=1&", "https://shop.example/p?a=1&utm_source=newsletter&utm_medium=email"},
+		{"nothing to fill", "https://shop.example/p?utm_medium=sms&utm_source=ads#top", "https://shop.example/p?utm_medium=sms&utm_source=ads#top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utm.Fill(tt.destination)
+			if err != nil {
+				t.Fatalf("Fill: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package mapper
+
+import (
+	"time"
+
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+)
+
+func ToUTM(p dto.UTMParams) entity.UTM {
+	return entity.UTM{
+		Source:   p.Source,
+		Medium:   p.Medium,
+		Campaign: p.Campaign,
+		Term:     p.Term,
+		Content:  p.Content,
+	}
+}
+
+func ToUTMParams(u entity.UTM) dto.UTMParams {
+	return dto.UTMParams{
+		Source:   u.Source,
+		Medium:   u.Medium,
+		Campaign: u.Campaign,
+		Term:     u.Term,
+		Content:  u.Content,
+	}
+}
+
+func ToCollectionEntityFromReq(req *dto.CreateCollectionRequest) *entity.Collection {
+	now := time.Now()
+	return &entity.Collection{
+		Name:        req.Name,
+		Description: req.Description,
+		UTM:         ToUTM(req.UTM),
+		Domain:      req.Domain,
+		ExpiresIn:   req.ExpiresIn,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// ApplyCollectionUpdate replaces the collection's editable fields with the request's
+func ApplyCollectionUpdate(c *entity.Collection, req *dto.UpdateCollectionRequest) {
+	c.Name = req.Name
+	c.Description = req.Description
+	c.UTM = ToUTM(req.UTM)
+	c.Domain = req.Domain
+	c.ExpiresIn = req.ExpiresIn
+	c.UpdatedAt = time.Now()
+}
+
+func ToCollectionResponse(c *entity.Collection) *dto.CollectionResponse {
+	return &dto.CollectionResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		UTM:         ToUTMParams(c.UTM),
+		Domain:      c.Domain,
+		ExpiresIn:   c.ExpiresIn,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func ToListCollectionsResponse(collections []*entity.Collection) *dto.ListCollectionsResponse {
+	resp := &dto.ListCollectionsResponse{Collections: make([]*dto.CollectionResponse, len(collections))}
+	for i, c := range collections {
+		resp.Collections[i] = ToCollectionResponse(c)
+	}
+	return resp
+}
+
+func ToListCollectionLinksResponse(links []*entity.CollectionLink) *dto.ListCollectionLinksResponse {
+	resp := &dto.ListCollectionLinksResponse{Links: make([]*dto.CollectionLinkResponse, len(links))}
+	for i, l := range links {
+		resp.Links[i] = &dto.CollectionLinkResponse{ID: l.LinkID, AddedAt: l.AddedAt}
+	}
+	return resp
+}
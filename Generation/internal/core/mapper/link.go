@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"net/url"
 	"time"
 
 	"go-link/generation/internal/constant"
@@ -10,14 +11,58 @@ import (
 
 func ToLinkEntityFromReq(req *dto.CreateLinkRequest) *entity.Link {
 	return &entity.Link{
-		OriginalURL: req.OriginalURL,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		OriginalURL:      req.OriginalURL,
+		ExpiresAt:        req.ExpiresAt,
+		RequireSignature: req.RequireSignature,
+		Visibility:       req.Visibility,
+		AppendClickID:    req.AppendClickID,
+		Tags:             entity.NormalizeTags(req.Tags),
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+}
+
+func ToSignedLinkResponse(l *entity.Link, query url.Values, expiresAt time.Time) *dto.SignedLinkResponse {
+	return &dto.SignedLinkResponse{
+		SignedLink: host(l) + "/" + l.ID + "?" + query.Encode(),
+		ExpiresAt:  expiresAt,
 	}
 }
 
 func ToLinkResponse(l *entity.Link) *dto.LinkResponse {
 	return &dto.LinkResponse{
-		ShortLink: constant.URL + "/" + l.ID,
+		ShortLink: host(l) + "/" + l.ID,
+	}
+}
+
+func ToLinkSummaryResponse(l *entity.Link) *dto.LinkSummaryResponse {
+	return &dto.LinkSummaryResponse{
+		ID:          l.ID,
+		ShortLink:   host(l) + "/" + l.ID,
+		OriginalURL: l.OriginalURL,
+		Tags:        l.Tags,
+		Visibility:  l.Visibility,
+		Disabled:    l.Disabled,
+		ExpiresAt:   l.ExpiresAt,
+		CreatedAt:   l.CreatedAt,
+	}
+}
+
+func ToSearchLinksResponse(links []*entity.Link, next string) *dto.SearchLinksResponse {
+	resp := &dto.SearchLinksResponse{
+		Links:      make([]*dto.LinkSummaryResponse, len(links)),
+		NextCursor: next,
+	}
+	for i, l := range links {
+		resp.Links[i] = ToLinkSummaryResponse(l)
+	}
+	return resp
+}
+
+// host is the link's custom domain, or the shared one
+func host(l *entity.Link) string {
+	if l.Domain != "" {
+		return l.Domain
 	}
+	return constant.URL
 }
@@ -0,0 +1,39 @@
+package mapper
+
+import (
+	"time"
+
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+)
+
+func ToUTMPresetEntityFromReq(tenantID int, req *dto.SaveUTMPresetRequest) *entity.UTMPreset {
+	return &entity.UTMPreset{
+		TenantID:  tenantID,
+		Name:      req.Name,
+		UTM:       ToUTM(req.UTM),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func ToUTMPresetResponse(p *entity.UTMPreset) *dto.UTMPresetResponse {
+	return &dto.UTMPresetResponse{
+		Name:      p.Name,
+		UTM:       ToUTMParams(p.UTM),
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func ToPresetsResponse(p *entity.Presets) *dto.PresetsResponse {
+	resp := &dto.PresetsResponse{
+		Tags: p.Tags,
+		UTM:  make([]*dto.UTMPresetResponse, len(p.UTM)),
+	}
+	if resp.Tags == nil {
+		resp.Tags = []string{}
+	}
+	for i, u := range p.UTM {
+		resp.UTM[i] = ToUTMPresetResponse(u)
+	}
+	return resp
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	identityv1 "go-link/common/gen/go/identity/v1"
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/common/pkg/security"
+
+	"go-link/generation/internal/constant"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/core/mapper"
+	"go-link/generation/internal/ports"
+)
+
+const collectionServiceName = "CollectionService"
+
+type collectionService struct {
+	collectionRepo ports.CollectionRepository
+	membershipRepo ports.CollectionLinkRepository
+	linkRepo       ports.LinkRepository
+	identityClient identityv1.IdentityServiceClient
+}
+
+func NewCollectionService(
+	collectionRepo ports.CollectionRepository,
+	membershipRepo ports.CollectionLinkRepository,
+	linkRepo ports.LinkRepository,
+	identityClient identityv1.IdentityServiceClient,
+) ports.CollectionService {
+	return &collectionService{
+		collectionRepo: collectionRepo,
+		membershipRepo: membershipRepo,
+		linkRepo:       linkRepo,
+		identityClient: identityClient,
+	}
+}
+
+// Create creates a collection in the caller's tenant
+func (s *collectionService) Create(ctx context.Context, req *dto.CreateCollectionRequest) (*dto.CollectionResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+	if err := s.checkDomain(ctx, tenantID, req.Domain); err != nil {
+		return nil, err
+	}
+
+	id, err := security.NewNonce()
+	if err != nil {
+		return nil, apperr.NewError(collectionServiceName, response.CodeInternalError, constant.MsgGenerateNonceFailed, http.StatusInternalServerError, err)
+	}
+
+	collection := mapper.ToCollectionEntityFromReq(req)
+	collection.ID = id
+	collection.TenantID = tenantID
+	collection.UserID, _ = ctx.Value(constraints.ContextKeyUserID).(int)
+
+	if err := s.collectionRepo.Save(ctx, collection); err != nil {
+		return nil, apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgCreateFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToCollectionResponse(collection), nil
+}
+
+func (s *collectionService) Get(ctx context.Context, req *dto.GetCollectionRequest) (*dto.CollectionResponse, error) {
+	collection, err := s.get(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToCollectionResponse(collection), nil
+}
+
+func (s *collectionService) List(ctx context.Context, _ *dto.ListCollectionsRequest) (*dto.ListCollectionsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	collections, err := s.collectionRepo.List(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	return mapper.ToListCollectionsResponse(collections), nil
+}
+
+// Update replaces the collection's defaults; links created before keep the ones they inherited
+func (s *collectionService) Update(ctx context.Context, req *dto.UpdateCollectionRequest) (*dto.CollectionResponse, error) {
+	collection, err := s.get(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Domain != collection.Domain {
+		if err := s.checkDomain(ctx, collection.TenantID, req.Domain); err != nil {
+			return nil, err
+		}
+	}
+
+	mapper.ApplyCollectionUpdate(collection, req)
+	if err := s.collectionRepo.Save(ctx, collection); err != nil {
+		return nil, apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToCollectionResponse(collection), nil
+}
+
+// Delete removes the collection and its memberships; the links themselves are kept
+func (s *collectionService) Delete(ctx context.Context, req *dto.DeleteCollectionRequest) error {
+	collection, err := s.get(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	// Memberships go first, so a failed delete can be retried without leaving any behind
+	if err := s.membershipRepo.RemoveCollection(ctx, collection.ID); err != nil {
+		return apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	if err := s.collectionRepo.Delete(ctx, collection.TenantID, collection.ID); err != nil {
+		return apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	return nil
+}
+
+func (s *collectionService) ListLinks(ctx context.Context, req *dto.ListCollectionLinksRequest) (*dto.ListCollectionLinksResponse, error) {
+	collection, err := s.get(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.membershipRepo.Links(ctx, collection.ID)
+	if err != nil {
+		return nil, apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	return mapper.ToListCollectionLinksResponse(links), nil
+}
+
+// MoveLinks adds the links to the target before removing them from the source,
+// so a move that fails halfway leaves links in both collections rather than in neither.
+func (s *collectionService) MoveLinks(ctx context.Context, req *dto.MoveLinksRequest) (*dto.MoveLinksResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+	if req.From == "" && req.To == "" {
+		return nil, apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgMoveNeedsCollection, http.StatusBadRequest, nil)
+	}
+	if req.From == req.To {
+		return nil, apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgMoveSameCollection, http.StatusBadRequest, nil)
+	}
+
+	linkIDs := slices.Clone(req.LinkIDs)
+	slices.Sort(linkIDs)
+	linkIDs = slices.Compact(linkIDs)
+
+	for _, id := range []string{req.From, req.To} {
+		if id == "" {
+			continue
+		}
+		if _, err := s.get(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkLinks(ctx, tenantID, linkIDs); err != nil {
+		return nil, err
+	}
+
+	if req.To != "" {
+		if err := s.checkCapacity(ctx, req.To, len(linkIDs)); err != nil {
+			return nil, err
+		}
+		if err := s.membershipRepo.Add(ctx, req.To, linkIDs); err != nil {
+			return nil, apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError, err)
+		}
+	}
+	if req.From != "" {
+		if err := s.membershipRepo.Remove(ctx, req.From, linkIDs); err != nil {
+			return nil, apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError, err)
+		}
+	}
+
+	return &dto.MoveLinksResponse{Moved: len(linkIDs)}, nil
+}
+
+func (s *collectionService) LinkIDs(ctx context.Context, tenantID int, collectionID string) ([]string, error) {
+	if _, err := s.collectionRepo.Get(ctx, tenantID, collectionID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.membershipRepo.Links(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(links))
+	for i, l := range links {
+		ids[i] = l.LinkID
+	}
+	return ids, nil
+}
+
+// get reads a collection of the caller's tenant; other tenants' collections read as missing
+func (s *collectionService) get(ctx context.Context, id string) (*entity.Collection, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	collection, err := s.collectionRepo.Get(ctx, tenantID, id)
+	if err != nil {
+		if errors.Is(err, widecolumn.ErrNotFound) {
+			return nil, apperr.NewError(collectionServiceName, response.CodeNotFound, fmt.Sprintf(constant.MsgCollectionNotFound, id), http.StatusNotFound, err)
+		}
+		return nil, apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	return collection, nil
+}
+
+// checkLinks makes sure every link exists and belongs to the tenant
+func (s *collectionService) checkLinks(ctx context.Context, tenantID int, linkIDs []string) error {
+	for _, id := range linkIDs {
+		link, err := s.linkRepo.Get(ctx, id)
+		if err != nil && !errors.Is(err, widecolumn.ErrNotFound) {
+			return apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+		if err != nil || link.TenantID != tenantID {
+			return apperr.NewError(collectionServiceName, response.CodeBadRequest, fmt.Sprintf(constant.MsgLinkNotInTenant, id), http.StatusBadRequest, err)
+		}
+	}
+	return nil
+}
+
+// checkCapacity counts the links as if none were in the collection yet, which only errs on the safe side
+func (s *collectionService) checkCapacity(ctx context.Context, collectionID string, adding int) error {
+	count, err := s.membershipRepo.Count(ctx, collectionID)
+	if err != nil {
+		return apperr.NewError(collectionServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	if count+adding > constant.CollectionMaxLinks {
+		return apperr.NewError(collectionServiceName, response.CodeBadRequest, fmt.Sprintf(constant.MsgCollectionFull, constant.CollectionMaxLinks), http.StatusBadRequest, nil)
+	}
+	return nil
+}
+
+// checkDomain accepts only custom domains the tenant has verified, so links are never served from someone else's host
+func (s *collectionService) checkDomain(ctx context.Context, tenantID int, domain string) error {
+	if domain == "" {
+		return nil
+	}
+	if s.identityClient == nil {
+		return apperr.NewError(collectionServiceName, response.CodeInternalError, constant.MsgIdentityUnavailable, http.StatusInternalServerError, nil)
+	}
+
+	resp, err := s.identityClient.ResolveDomain(ctx, &identityv1.ResolveDomainRequest{Domain: domain})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgDomainNotVerified, http.StatusBadRequest, err)
+		}
+		return apperr.NewError(collectionServiceName, response.CodeInternalError, constant.MsgVerifyDomainFailed, http.StatusInternalServerError, err)
+	}
+	if int(resp.TenantId) != tenantID || !resp.IsVerified {
+		return apperr.NewError(collectionServiceName, response.CodeBadRequest, constant.MsgDomainNotVerified, http.StatusBadRequest, nil)
+	}
+	return nil
+}
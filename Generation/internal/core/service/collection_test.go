@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/database/widecolumn"
+
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/ports"
+)
+
+type fakeCollections struct {
+	collections map[string]*entity.Collection
+}
+
+func (f *fakeCollections) Save(_ context.Context, c *entity.Collection) error {
+	f.collections[c.ID] = c
+	return nil
+}
+
+func (f *fakeCollections) Get(_ context.Context, tenantID int, id string) (*entity.Collection, error) {
+	c, ok := f.collections[id]
+	if !ok || c.TenantID != tenantID {
+		return nil, widecolumn.ErrNotFound
+	}
+	return c, nil
+}
+
+func (f *fakeCollections) List(_ context.Context, tenantID int) ([]*entity.Collection, error) {
+	var list []*entity.Collection
+	for _, c := range f.collections {
+		if c.TenantID == tenantID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeCollections) Delete(_ context.Context, _ int, id string) error {
+	delete(f.collections, id)
+	return nil
+}
+
+// fakeMemberships maps a collection to its links
+type fakeMemberships struct {
+	links map[string][]string
+}
+
+func (f *fakeMemberships) Links(_ context.Context, collectionID string) ([]*entity.CollectionLink, error) {
+	var links []*entity.CollectionLink
+	for _, id := range f.links[collectionID] {
+		links = append(links, &entity.CollectionLink{CollectionID: collectionID, LinkID: id})
+	}
+	return links, nil
+}
+
+func (f *fakeMemberships) Count(_ context.Context, collectionID string) (int, error) {
+	return len(f.links[collectionID]), nil
+}
+
+func (f *fakeMemberships) Collections(_ context.Context, linkID string) ([]string, error) {
+	var ids []string
+	for id, links := range f.links {
+		if slices.Contains(links, linkID) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (f *fakeMemberships) Add(_ context.Context, collectionID string, linkIDs []string) error {
+	for _, id := range linkIDs {
+		if !slices.Contains(f.links[collectionID], id) {
+			f.links[collectionID] = append(f.links[collectionID], id)
+		}
+	}
+	return nil
+}
+
+func (f *fakeMemberships) Remove(_ context.Context, collectionID string, linkIDs []string) error {
+	f.links[collectionID] = slices.DeleteFunc(f.links[collectionID], func(id string) bool {
+		return slices.Contains(linkIDs, id)
+	})
+	return nil
+}
+
+func (f *fakeMemberships) RemoveCollection(_ context.Context, collectionID string) error {
+	delete(f.links, collectionID)
+	return nil
+}
+
+func (f *fakeMemberships) RemoveLink(_ context.Context, linkID string) error {
+	for id := range f.links {
+		f.links[id] = slices.DeleteFunc(f.links[id], func(l string) bool { return l == linkID })
+	}
+	return nil
+}
+
+// fakeLinks only answers Get; the other methods panic through the nil embedded interface
+type fakeLinks struct {
+	ports.LinkRepository
+	links map[string]*entity.Link
+}
+
+func (f *fakeLinks) Get(_ context.Context, id string) (*entity.Link, error) {
+	if l, ok := f.links[id]; ok {
+		return l, nil
+	}
+	return nil, widecolumn.ErrNotFound
+}
+
+func tenantContext(tenantID int) context.Context {
+	return context.WithValue(context.Background(), constraints.ContextKeyTenantID, tenantID)
+}
+
+func httpStatus(err error) int {
+	var appErr *apperr.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus
+	}
+	return 0
+}
+
+func newMoveService() (*collectionService, *fakeMemberships) {
+	memberships := &fakeMemberships{links: map[string][]string{
+		"spring": {"a", "b"},
+		"summer": {"c"},
+	}}
+	return &collectionService{
+		collectionRepo: &fakeCollections{collections: map[string]*entity.Collection{
+			"spring": {ID: "spring", TenantID: 1},
+			"summer": {ID: "summer", TenantID: 1},
+			"other":  {ID: "other", TenantID: 2},
+		}},
+		membershipRepo: memberships,
+		linkRepo: &fakeLinks{links: map[string]*entity.Link{
+			"a": {ID: "a", TenantID: 1},
+			"b": {ID: "b", TenantID: 1},
+			"c": {ID: "c", TenantID: 1},
+			"x": {ID: "x", TenantID: 2},
+		}},
+	}, memberships
+}
+
+func TestMoveLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *dto.MoveLinksRequest
+		status int
+		want   map[string][]string
+	}{
+		{
+			name: "between collections",
+			req:  &dto.MoveLinksRequest{LinkIDs: []string{"a"}, From: "spring", To: "summer"},
+			want: map[string][]string{"spring": {"b"}, "summer": {"c", "a"}},
+		},
+		{
+			name: "only from removes",
+			req:  &dto.MoveLinksRequest{LinkIDs: []string{"a", "b"}, From: "spring"},
+			want: map[string][]string{"spring": {}, "summer": {"c"}},
+		},
+		{
+			name: "only to adds",
+			req:  &dto.MoveLinksRequest{LinkIDs: []string{"b", "b"}, To: "summer"},
+			want: map[string][]string{"spring": {"a", "b"}, "summer": {"c", "b"}},
+		},
+		{
+			name:   "neither collection",
+			req:    &dto.MoveLinksRequest{LinkIDs: []string{"a"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "same collection",
+			req:    &dto.MoveLinksRequest{LinkIDs: []string{"a"}, From: "spring", To: "spring"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "other tenant's collection",
+			req:    &dto.MoveLinksRequest{LinkIDs: []string{"a"}, To: "other"},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "other tenant's link",
+			req:    &dto.MoveLinksRequest{LinkIDs: []string{"x"}, To: "summer"},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, memberships := newMoveService()
+
+			res, err := s.MoveLinks(tenantContext(1), tt.req)
+			if tt.status != 0 {
+				if got := httpStatus(err); got != tt.status {
+					t.Fatalf("expected status %d, got %d (%v)", tt.status, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MoveLinks: %v", err)
+			}
+
+			if want := len(slices.Compact(slices.Sorted(slices.Values(tt.req.LinkIDs)))); res.Moved != want {
+				t.Errorf("expected %d moved, got %d", want, res.Moved)
+			}
+			for id, want := range tt.want {
+				if got := memberships.links[id]; !slices.Equal(got, want) {
+					t.Errorf("collection %s: expected %v, got %v", id, want, got)
+				}
+			}
+		})
+	}
+}
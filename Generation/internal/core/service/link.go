@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -13,6 +16,8 @@ import (
 	"go-link/common/pkg/common/cache"
 	"go-link/common/pkg/common/http/response"
 	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/common/pkg/security"
 	"go-link/common/pkg/utils"
 
 	"go-link/generation/global"
@@ -25,6 +30,9 @@ import (
 
 type linkService struct {
 	linkRepo       ports.LinkRepository
+	collectionRepo ports.CollectionRepository
+	membershipRepo ports.CollectionLinkRepository
+	presetRepo     ports.PresetRepository
 	linkCache      ports.LinkCacheRepository
 	codePool       ports.ShortCodePool
 	localCache     cache.LocalCache[string, int]
@@ -34,6 +42,9 @@ type linkService struct {
 
 func NewLinkService(
 	linkRepo ports.LinkRepository,
+	collectionRepo ports.CollectionRepository,
+	membershipRepo ports.CollectionLinkRepository,
+	presetRepo ports.PresetRepository,
 	codePool ports.ShortCodePool,
 	linkCache ports.LinkCacheRepository,
 	localCache cache.LocalCache[string, int],
@@ -42,6 +53,9 @@ func NewLinkService(
 ) ports.LinkService {
 	return &linkService{
 		linkRepo:       linkRepo,
+		collectionRepo: collectionRepo,
+		membershipRepo: membershipRepo,
+		presetRepo:     presetRepo,
 		linkCache:      linkCache,
 		codePool:       codePool,
 		localCache:     localCache,
@@ -55,11 +69,59 @@ const serviceName = "LinkService"
 // Create creates a new link
 // Create creates a new link
 func (s *linkService) Create(ctx context.Context, req *dto.CreateLinkRequest) (*dto.LinkResponse, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgExpiryInPast, http.StatusBadRequest, nil)
+	}
+
+	var err error
 	link := mapper.ToLinkEntityFromReq(req)
+	if req.Password != "" {
+		hash, err := security.HashPassword(req.Password)
+		if err != nil {
+			return nil, apperr.NewError(serviceName, response.CodeInternalServer, constant.MsgHashPasswordFailed, http.StatusInternalServerError, err)
+		}
+		link.PasswordHash = hash
+	}
+
 	shortCode := s.codePool.GetOrGenerate()
 	link.ID = shortCode
 
 	claims, isUser := ctx.Value(constraints.ContextKeyClaims).(*utils.Claims)
+	if link.Visibility == "" {
+		link.Visibility = entity.VisibilityPublic
+	}
+	if link.Visibility == entity.VisibilityPrivate && (!isUser || claims.TenantID == 0) {
+		return nil, apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgPrivateLinkNeedsTenant, http.StatusBadRequest, nil)
+	}
+
+	// The builder's parameters go on first, so the collection's defaults only fill in what they leave out
+	if req.UTM != nil || req.UTMPreset != "" {
+		tenantID := 0
+		if isUser {
+			tenantID = claims.TenantID
+		}
+		if link.OriginalURL, err = s.buildUTM(ctx, tenantID, link.OriginalURL, req.UTMPreset, req.UTM); err != nil {
+			return nil, err
+		}
+	}
+
+	collectionIDs := slices.Clone(req.CollectionIDs)
+	slices.Sort(collectionIDs)
+	collectionIDs = slices.Compact(collectionIDs)
+	if len(collectionIDs) > 0 {
+		if !isUser || claims.TenantID == 0 {
+			return nil, apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+		}
+		// Defaults come from the first collection the caller listed, not the first in sorted order
+		first, err := s.checkCollections(ctx, claims.TenantID, req.CollectionIDs[0], collectionIDs)
+		if err != nil {
+			return nil, err
+		}
+		if err := inherit(link, first); err != nil {
+			return nil, err
+		}
+	}
+
 	if !isUser {
 		// Guest User
 		link.UserID = 0
@@ -81,6 +143,16 @@ func (s *linkService) Create(ctx context.Context, req *dto.CreateLinkRequest) (*
 		return nil, apperr.MapError(serviceName, err, response.CodeDatabaseError, apperr.MsgCreateFailed, http.StatusInternalServerError)
 	}
 
+	for _, collectionID := range collectionIDs {
+		if err := s.membershipRepo.Add(ctx, collectionID, []string{link.ID}); err != nil {
+			// Undo the link rather than leave it outside collections the caller asked for
+			_ = s.membershipRepo.RemoveLink(ctx, link.ID)
+			_ = s.linkRepo.Delete(ctx, link.ID)
+			s.linkCache.DecrementQuota(ctx, claims.TenantID)
+			return nil, apperr.NewError(serviceName, response.CodeDatabaseError, apperr.MsgCreateFailed, http.StatusInternalServerError, err)
+		}
+	}
+
 	if err := s.linkCache.Set(ctx, link); err != nil {
 		// TODO: Log error
 	}
@@ -109,6 +181,225 @@ func (s *linkService) Delete(ctx context.Context, req *dto.DeleteLinkRequest) er
 
 	s.linkCache.DecrementQuota(ctx, link.TenantID)
 
+	if err := s.membershipRepo.RemoveLink(ctx, req.ID); err != nil {
+		global.LoggerZap.Warn("Failed to remove link from its collections", zap.String("link_id", req.ID), zap.Error(err))
+	}
+
+	return nil
+}
+
+// Sign mints a signed URL for the link that expires after req.ExpiresIn seconds.
+// The signing key is derived per tenant, so Redirection can verify it without a lookup.
+func (s *linkService) Sign(ctx context.Context, req *dto.SignLinkRequest) (*dto.SignedLinkResponse, error) {
+	if global.Config.SignedLink.Secret == "" {
+		return nil, apperr.NewError(serviceName, response.CodeInternalError, constant.MsgSigningNotConfigured, http.StatusInternalServerError, nil)
+	}
+
+	link, err := s.linkRepo.Get(ctx, req.ID)
+	if err != nil {
+		return nil, apperr.NewError(serviceName, response.CodeNotFound, apperr.MsgNotFound, http.StatusNotFound, err)
+	}
+
+	if link.TenantID == 0 {
+		return nil, apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgSignedLinkNeedsTenant, http.StatusBadRequest, nil)
+	}
+
+	userID, _ := ctx.Value(constraints.ContextKeyUserID).(int)
+	roleLevel, _ := ctx.Value(constraints.ContextKeyRoleLevel).(int)
+	tenantID, _ := ctx.Value(constraints.ContextKeyTenantID).(int)
+
+	if err := s.checkPermission(ctx, link, userID, roleLevel, tenantID); err != nil {
+		return nil, err
+	}
+
+	signed := security.SignedLink{
+		ExpiresAt: time.Now().Add(time.Duration(req.ExpiresIn) * time.Second).Truncate(time.Second),
+	}
+	if link.ExpiresAt != nil && link.ExpiresAt.Before(signed.ExpiresAt) {
+		signed.ExpiresAt = link.ExpiresAt.Truncate(time.Second)
+	}
+
+	if req.SingleUse {
+		if signed.Nonce, err = security.NewNonce(); err != nil {
+			return nil, apperr.NewError(serviceName, response.CodeInternalError, constant.MsgGenerateNonceFailed, http.StatusInternalServerError, err)
+		}
+	}
+
+	key := security.DeriveTenantKey([]byte(global.Config.SignedLink.Secret), link.TenantID)
+	query := security.SignedLinkQuery(key, link.ID, signed)
+
+	return mapper.ToSignedLinkResponse(link, query, signed.ExpiresAt), nil
+}
+
+// Search pages through the caller's tenant links by ID, keeping those that carry every tag asked for
+func (s *linkService) Search(ctx context.Context, req *dto.SearchLinksRequest) (*dto.SearchLinksResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	query := &entity.LinkQuery{
+		TenantID: tenantID,
+		Tags:     entity.NormalizeTags(req.Tags),
+		After:    req.After,
+		Limit:    req.Limit,
+	}
+	if query.Limit <= 0 {
+		query.Limit = constant.LinkSearchDefaultLimit
+	}
+
+	links, next, err := s.linkRepo.Search(ctx, query)
+	if err != nil {
+		return nil, apperr.NewError(serviceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	return mapper.ToSearchLinksResponse(links, next), nil
+}
+
+// SetTags replaces a link's tags; the same members who may delete the link may tag it
+func (s *linkService) SetTags(ctx context.Context, req *dto.SetLinkTagsRequest) (*dto.LinkSummaryResponse, error) {
+	link, err := s.linkRepo.Get(ctx, req.ID)
+	if err != nil {
+		return nil, apperr.NewError(serviceName, response.CodeNotFound, apperr.MsgNotFound, http.StatusNotFound, err)
+	}
+
+	userID, _ := ctx.Value(constraints.ContextKeyUserID).(int)
+	roleLevel, _ := ctx.Value(constraints.ContextKeyRoleLevel).(int)
+	tenantID, _ := ctx.Value(constraints.ContextKeyTenantID).(int)
+
+	if err := s.checkPermission(ctx, link, userID, roleLevel, tenantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.linkRepo.SetTags(ctx, link, entity.NormalizeTags(req.Tags)); err != nil {
+		return nil, apperr.MapError(serviceName, err, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError)
+	}
+
+	if err := s.linkCache.Set(ctx, link); err != nil {
+		global.LoggerZap.Warn("Failed to cache link", zap.String("link_id", link.ID), zap.Error(err))
+	}
+
+	return mapper.ToLinkSummaryResponse(link), nil
+}
+
+// SetStatus disables or enables a link; the same members who may delete the link may turn it off.
+// Redirection picks the change up through CDC and serves its "disabled" page meanwhile.
+func (s *linkService) SetStatus(ctx context.Context, req *dto.SetLinkStatusRequest) (*dto.LinkSummaryResponse, error) {
+	link, err := s.linkRepo.Get(ctx, req.ID)
+	if err != nil {
+		return nil, apperr.NewError(serviceName, response.CodeNotFound, apperr.MsgNotFound, http.StatusNotFound, err)
+	}
+
+	userID, _ := ctx.Value(constraints.ContextKeyUserID).(int)
+	roleLevel, _ := ctx.Value(constraints.ContextKeyRoleLevel).(int)
+	tenantID, _ := ctx.Value(constraints.ContextKeyTenantID).(int)
+
+	if err := s.checkPermission(ctx, link, userID, roleLevel, tenantID); err != nil {
+		return nil, err
+	}
+
+	if link.Disabled == *req.Disabled {
+		return mapper.ToLinkSummaryResponse(link), nil
+	}
+
+	if err := s.linkRepo.SetDisabled(ctx, link, *req.Disabled); err != nil {
+		return nil, apperr.MapError(serviceName, err, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError)
+	}
+
+	if err := s.linkCache.Set(ctx, link); err != nil {
+		global.LoggerZap.Warn("Failed to cache link", zap.String("link_id", link.ID), zap.Error(err))
+	}
+
+	return mapper.ToLinkSummaryResponse(link), nil
+}
+
+// BuildUTM returns the destination Create would compose from the URL, preset and parameters
+func (s *linkService) BuildUTM(ctx context.Context, req *dto.BuildUTMRequest) (*dto.BuildUTMResponse, error) {
+	tenantID, _ := ctx.Value(constraints.ContextKeyTenantID).(int)
+
+	destination, err := s.buildUTM(ctx, tenantID, req.URL, req.Preset, &req.UTM)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.BuildUTMResponse{URL: destination}, nil
+}
+
+// buildUTM applies the tenant's named preset overlaid with the given parameters to the destination.
+// Parameters it sets replace those of the same name already in the destination; the rest of its query is kept as is.
+func (s *linkService) buildUTM(ctx context.Context, tenantID int, destination, presetName string, params *dto.UTMParams) (string, error) {
+	var utm entity.UTM
+	if presetName != "" {
+		if tenantID == 0 {
+			return "", apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+		}
+		preset, err := s.presetRepo.GetUTM(ctx, tenantID, presetName)
+		if err != nil {
+			if errors.Is(err, widecolumn.ErrNotFound) {
+				return "", apperr.NewError(serviceName, response.CodeNotFound, fmt.Sprintf(constant.MsgUTMPresetNotFound, presetName), http.StatusNotFound, err)
+			}
+			return "", apperr.NewError(serviceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+		utm = preset.UTM
+	}
+	if params != nil {
+		utm = utm.Merge(mapper.ToUTM(*params))
+	}
+
+	result, err := utm.Apply(destination)
+	if err != nil {
+		return "", apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgInvalidDestination, http.StatusBadRequest, err)
+	}
+	return result, nil
+}
+
+// Get returns the stored link; a missing link surfaces as widecolumn.ErrNotFound
+func (s *linkService) Get(ctx context.Context, id string) (*entity.Link, error) {
+	return s.linkRepo.Get(ctx, id)
+}
+
+// checkCollections makes sure every collection belongs to the tenant and has room for one more link, and returns the one with firstID
+func (s *linkService) checkCollections(ctx context.Context, tenantID int, firstID string, collectionIDs []string) (*entity.Collection, error) {
+	var first *entity.Collection
+	for _, id := range collectionIDs {
+		collection, err := s.collectionRepo.Get(ctx, tenantID, id)
+		if err != nil {
+			if errors.Is(err, widecolumn.ErrNotFound) {
+				return nil, apperr.NewError(serviceName, response.CodeNotFound, fmt.Sprintf(constant.MsgCollectionNotFound, id), http.StatusNotFound, err)
+			}
+			return nil, apperr.NewError(serviceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+
+		count, err := s.membershipRepo.Count(ctx, id)
+		if err != nil {
+			return nil, apperr.NewError(serviceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+		}
+		if count >= constant.CollectionMaxLinks {
+			return nil, apperr.NewError(serviceName, response.CodeBadRequest, fmt.Sprintf(constant.MsgCollectionFull, constant.CollectionMaxLinks), http.StatusBadRequest, nil)
+		}
+
+		if id == firstID {
+			first = collection
+		}
+	}
+	return first, nil
+}
+
+// inherit fills what the request left open from the collection's defaults.
+// UTM parameters the destination already carries are kept.
+func inherit(link *entity.Link, collection *entity.Collection) error {
+	if !collection.UTM.IsZero() {
+		destination, err := collection.UTM.Fill(link.OriginalURL)
+		if err != nil {
+			return apperr.NewError(serviceName, response.CodeBadRequest, constant.MsgInvalidDestination, http.StatusBadRequest, err)
+		}
+		link.OriginalURL = destination
+	}
+
+	link.Domain = collection.Domain
+
+	if link.ExpiresAt == nil && collection.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(collection.ExpiresIn) * time.Second)
+		link.ExpiresAt = &expiresAt
+	}
 	return nil
 }
 
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-link/common/pkg/constraints"
+
+	"go-link/generation/internal/constant"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/ports"
+)
+
+func TestCheckCollections(t *testing.T) {
+	full := make([]string, constant.CollectionMaxLinks)
+	s := &linkService{
+		collectionRepo: &fakeCollections{collections: map[string]*entity.Collection{
+			"a":     {ID: "a", TenantID: 1, Domain: "a.example"},
+			"b":     {ID: "b", TenantID: 1, Domain: "b.example"},
+			"full":  {ID: "full", TenantID: 1},
+			"other": {ID: "other", TenantID: 2},
+		}},
+		membershipRepo: &fakeMemberships{links: map[string][]string{"full": full}},
+	}
+
+	tests := []struct {
+		name          string
+		firstID       string
+		collectionIDs []string
+		want          string
+		status        int
+	}{
+		{name: "first listed wins over sorted order", firstID: "b", collectionIDs: []string{"a", "b"}, want: "b"},
+		{name: "single collection", firstID: "a", collectionIDs: []string{"a"}, want: "a"},
+		{name: "missing collection", firstID: "a", collectionIDs: []string{"a", "missing"}, status: http.StatusNotFound},
+		{name: "other tenant's collection", firstID: "other", collectionIDs: []string{"other"}, status: http.StatusNotFound},
+		{name: "full collection", firstID: "a", collectionIDs: []string{"a", "full"}, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := s.checkCollections(context.Background(), 1, tt.firstID, tt.collectionIDs)
+			if tt.status != 0 {
+				if got := httpStatus(err); got != tt.status {
+					t.Fatalf("expected status %d, got %d (%v)", tt.status, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkCollections: %v", err)
+			}
+			if first == nil || first.ID != tt.want {
+				t.Fatalf("expected collection %s, got %+v", tt.want, first)
+			}
+		})
+	}
+}
+
+func TestInherit(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	collection := &entity.Collection{
+		UTM:       entity.UTM{Source: "newsletter", Medium: "email"},
+		Domain:    "go.example",
+		ExpiresIn: 86400,
+	}
+
+	tests := []struct {
+		name        string
+		link        *entity.Link
+		destination string
+		expiresAt   *time.Time
+	}{
+		{
+			name:        "fills defaults",
+			link:        &entity.Link{OriginalURL: "https://shop.example/p"},
+			destination: "https://shop.example/p?utm_source=newsletter&utm_medium=email",
+		},
+		{
+			name:        "link's own parameters and expiry win",
+			link:        &entity.Link{OriginalURL: "https://shop.example/p?utm_source=ads", ExpiresAt: &expiresAt},
+			destination: "https://shop.example/p?utm_source=ads&utm_medium=email",
+			expiresAt:   &expiresAt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now()
+			if err := inherit(tt.link, collection); err != nil {
+				t.Fatalf("inherit: %v", err)
+			}
+
+			if tt.link.OriginalURL != tt.destination {
+				t.Errorf("expected destination %q, got %q", tt.destination, tt.link.OriginalURL)
+			}
+			if tt.link.Domain != collection.Domain {
+				t.Errorf("expected domain %q, got %q", collection.Domain, tt.link.Domain)
+			}
+
+			switch {
+			case tt.link.ExpiresAt == nil:
+				t.Fatal("expected an expiry")
+			case tt.expiresAt != nil:
+				if !tt.link.ExpiresAt.Equal(*tt.expiresAt) {
+					t.Errorf("expected expiry %v, got %v", *tt.expiresAt, *tt.link.ExpiresAt)
+				}
+			default:
+				if got := tt.link.ExpiresAt.Sub(before); got < 24*time.Hour || got > 24*time.Hour+time.Minute {
+					t.Errorf("expected expiry a day out, got %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestInheritWithoutDefaults(t *testing.T) {
+	link := &entity.Link{OriginalURL: "https://shop.example/p?a=1"}
+	if err := inherit(link, &entity.Collection{}); err != nil {
+		t.Fatalf("inherit: %v", err)
+	}
+	if link.OriginalURL != "https://shop.example/p?a=1" || link.Domain != "" || link.ExpiresAt != nil {
+		t.Errorf("expected the link untouched, got %+v", link)
+	}
+}
+
+// statusLinks records the rows SetDisabled rewrites
+type statusLinks struct {
+	fakeLinks
+	writes int
+}
+
+func (f *statusLinks) SetDisabled(_ context.Context, link *entity.Link, disabled bool) error {
+	f.writes++
+	link.Disabled = disabled
+	return nil
+}
+
+// fakeLinkCache only answers Set
+type fakeLinkCache struct {
+	ports.LinkCacheRepository
+	links map[string]*entity.Link
+}
+
+func (f *fakeLinkCache) Set(_ context.Context, link *entity.Link) error {
+	f.links[link.ID] = link
+	return nil
+}
+
+func TestSetStatus(t *testing.T) {
+	disable, enable := true, false
+
+	tests := []struct {
+		name     string
+		userID   int
+		tenantID int
+		was      bool
+		disabled *bool
+		status   int
+		want     bool
+		writes   int
+	}{
+		{name: "owner disables", userID: 7, tenantID: 1, disabled: &disable, want: true, writes: 1},
+		{name: "owner enables", userID: 7, tenantID: 1, was: true, disabled: &enable, want: false, writes: 1},
+		{name: "already enabled", userID: 7, tenantID: 1, disabled: &enable, want: false},
+		{name: "other tenant", userID: 8, tenantID: 2, disabled: &disable, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link := &entity.Link{ID: "abc", UserID: 7, TenantID: 1, Disabled: tt.was}
+			links := &statusLinks{fakeLinks: fakeLinks{links: map[string]*entity.Link{"abc": link}}}
+			cached := &fakeLinkCache{links: map[string]*entity.Link{}}
+			s := &linkService{linkRepo: links, linkCache: cached}
+
+			ctx := context.WithValue(tenantContext(tt.tenantID), constraints.ContextKeyUserID, tt.userID)
+			res, err := s.SetStatus(ctx, &dto.SetLinkStatusRequest{ID: "abc", Disabled: tt.disabled})
+			if tt.status != 0 {
+				if got := httpStatus(err); got != tt.status {
+					t.Fatalf("expected status %d, got %d (%v)", tt.status, got, err)
+				}
+				if links.writes != 0 || link.Disabled {
+					t.Fatalf("expected the link untouched, got %+v", link)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetStatus: %v", err)
+			}
+			if res.Disabled != tt.want || link.Disabled != tt.want {
+				t.Errorf("expected disabled=%v, got response %v and link %v", tt.want, res.Disabled, link.Disabled)
+			}
+			if links.writes != tt.writes {
+				t.Errorf("expected %d row writes, got %d", tt.writes, links.writes)
+			}
+			if tt.writes > 0 && cached.links["abc"] == nil {
+				t.Error("expected the updated link in the cache")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+
+	"go-link/generation/internal/constant"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/core/mapper"
+	"go-link/generation/internal/ports"
+)
+
+const presetServiceName = "PresetService"
+
+type presetService struct {
+	presetRepo ports.PresetRepository
+}
+
+func NewPresetService(presetRepo ports.PresetRepository) ports.PresetService {
+	return &presetService{
+		presetRepo: presetRepo,
+	}
+}
+
+func (s *presetService) Get(ctx context.Context, _ *dto.GetPresetsRequest) (*dto.PresetsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(presetServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	presets, err := s.presetRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(presetServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	return mapper.ToPresetsResponse(presets), nil
+}
+
+// SetTags replaces the tenant's tag presets, normalized like link tags so picking one matches the links carrying it
+func (s *presetService) SetTags(ctx context.Context, req *dto.SetTagPresetsRequest) (*dto.PresetsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(presetServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	if err := s.presetRepo.SetTags(ctx, tenantID, entity.NormalizeTags(req.Tags)); err != nil {
+		return nil, apperr.NewError(presetServiceName, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError, err)
+	}
+
+	presets, err := s.presetRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(presetServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	return mapper.ToPresetsResponse(presets), nil
+}
+
+// SaveUTM creates or replaces a UTM preset; only new names count against the limit
+func (s *presetService) SaveUTM(ctx context.Context, req *dto.SaveUTMPresetRequest) (*dto.UTMPresetResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(presetServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	presets, err := s.presetRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(presetServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+	exists := slices.ContainsFunc(presets.UTM, func(p *entity.UTMPreset) bool { return p.Name == req.Name })
+	if !exists && len(presets.UTM) >= constant.UTMPresetsMax {
+		return nil, apperr.NewError(presetServiceName, response.CodeBadRequest, fmt.Sprintf(constant.MsgTooManyUTMPresets, constant.UTMPresetsMax), http.StatusBadRequest, nil)
+	}
+
+	preset := mapper.ToUTMPresetEntityFromReq(tenantID, req)
+	if err := s.presetRepo.SaveUTM(ctx, preset); err != nil {
+		return nil, apperr.NewError(presetServiceName, response.CodeDatabaseError, apperr.MsgUpdateFailed, http.StatusInternalServerError, err)
+	}
+	return mapper.ToUTMPresetResponse(preset), nil
+}
+
+func (s *presetService) DeleteUTM(ctx context.Context, req *dto.DeleteUTMPresetRequest) error {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return apperr.NewError(presetServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	if err := s.presetRepo.DeleteUTM(ctx, tenantID, req.Name); err != nil {
+		return apperr.NewError(presetServiceName, response.CodeDatabaseError, apperr.MsgDeleteFailed, http.StatusInternalServerError, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"go-link/common/pkg/database/widecolumn"
+
+	"go-link/generation/global"
+	"go-link/generation/internal/constant"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+	"go-link/generation/internal/ports"
+)
+
+type reindexService struct {
+	linkRepo ports.LinkRepository
+}
+
+func NewReindexService(linkRepo ports.LinkRepository) ports.ReindexService {
+	return &reindexService{
+		linkRepo: linkRepo,
+	}
+}
+
+// Reindex writes the tenant and tag index rows of every tenant link, range by range.
+// Rows are written as of each link's last update, so running it alongside live traffic, or again after a failure, is harmless.
+func (s *reindexService) Reindex(ctx context.Context, req *dto.ReindexRequest) (*dto.ReindexReport, error) {
+	startedAt := time.Now()
+	if req.Ranges <= 0 {
+		req.Ranges = constant.ReindexDefaultRanges
+	}
+	if req.Workers <= 0 {
+		req.Workers = constant.ReindexDefaultWorkers
+	}
+
+	ranges := widecolumn.SplitTokenRing(req.Ranges)
+	global.LoggerZap.Info("Starting link search reindex", zap.Int("ranges", len(ranges)))
+
+	var scanned, indexed atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(req.Workers)
+	for _, rng := range ranges {
+		g.Go(func() error {
+			err := s.linkRepo.ScanRange(gctx, rng, func(link *entity.Link, ttl int) error {
+				scanned.Add(1)
+				if link.TenantID == 0 {
+					return nil
+				}
+				if err := s.linkRepo.Index(gctx, link, ttl); err != nil {
+					return err
+				}
+				indexed.Add(1)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("reindex links in range [%d, %d]: %w", rng.Start, rng.End, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &dto.ReindexReport{
+		Ranges:   len(ranges),
+		Scanned:  scanned.Load(),
+		Indexed:  indexed.Load(),
+		Duration: time.Since(startedAt).Round(time.Millisecond).String(),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package di
+
+import (
+	db "go-link/generation/internal/adapters/driven/db"
+	driverHttp "go-link/generation/internal/adapters/driver/http"
+	"go-link/generation/internal/core/service"
+	"go-link/generation/internal/ports"
+)
+
+type CollectionContainer struct {
+	Repository           ports.CollectionRepository
+	MembershipRepository ports.CollectionLinkRepository
+	Service              ports.CollectionService
+	Handler              driverHttp.CollectionHandler
+}
+
+func InitCollectionDependencies(clientContainer *ClientContainer) *CollectionContainer {
+	// Repository
+	repository := db.NewCollectionRepository()
+	membershipRepository := db.NewCollectionLinkRepository()
+
+	// Service
+	service := service.NewCollectionService(
+		repository,
+		membershipRepository,
+		db.NewLinkRepository(),
+		clientContainer.IdentityClient,
+	)
+
+	// Handler
+	handler := driverHttp.NewCollectionHandler(service)
+
+	return &CollectionContainer{
+		Repository:           repository,
+		MembershipRepository: membershipRepository,
+		Service:              service,
+		Handler:              handler,
+	}
+}
@@ -1,8 +1,10 @@
 package di
 
 type Container struct {
-	LinkContainer   *LinkContainer
-	ClientContainer *ClientContainer
+	LinkContainer       *LinkContainer
+	CollectionContainer *CollectionContainer
+	PresetContainer     *PresetContainer
+	ClientContainer     *ClientContainer
 }
 
 var GlobalContainer *Container
@@ -20,7 +20,7 @@ type LinkContainer struct {
 	CodePool   *pool.ShortCode
 }
 
-func InitLinkDependencies(clientContainer *ClientContainer) *LinkContainer {
+func InitLinkDependencies(clientContainer *ClientContainer, collectionContainer *CollectionContainer, presetContainer *PresetContainer) *LinkContainer {
 	// Node
 	node, _ := unique.NewSnowflakeNode(global.Config.SnowflakeNode, global.Time1s)
 
@@ -41,6 +41,9 @@ func InitLinkDependencies(clientContainer *ClientContainer) *LinkContainer {
 	// Service
 	service := service.NewLinkService(
 		repository,
+		collectionContainer.Repository,
+		collectionContainer.MembershipRepository,
+		presetContainer.Repository,
 		pool,
 		cache,
 		localCache,
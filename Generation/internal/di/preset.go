@@ -0,0 +1,31 @@
+package di
+
+import (
+	db "go-link/generation/internal/adapters/driven/db"
+	driverHttp "go-link/generation/internal/adapters/driver/http"
+	"go-link/generation/internal/core/service"
+	"go-link/generation/internal/ports"
+)
+
+type PresetContainer struct {
+	Repository ports.PresetRepository
+	Service    ports.PresetService
+	Handler    driverHttp.PresetHandler
+}
+
+func InitPresetDependencies() *PresetContainer {
+	// Repository
+	repository := db.NewPresetRepository()
+
+	// Service
+	service := service.NewPresetService(repository)
+
+	// Handler
+	handler := driverHttp.NewPresetHandler(service)
+
+	return &PresetContainer{
+		Repository: repository,
+		Service:    service,
+		Handler:    handler,
+	}
+}
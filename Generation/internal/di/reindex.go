@@ -0,0 +1,24 @@
+package di
+
+import (
+	db "go-link/generation/internal/adapters/driven/db"
+	"go-link/generation/internal/core/service"
+	"go-link/generation/internal/ports"
+)
+
+type ReindexContainer struct {
+	Service ports.ReindexService
+}
+
+// InitReindexDependencies wires the offline reindex tool
+func InitReindexDependencies() *ReindexContainer {
+	// Repository
+	repository := db.NewLinkRepository()
+
+	// Service
+	service := service.NewReindexService(repository)
+
+	return &ReindexContainer{
+		Service: service,
+	}
+}
@@ -2,11 +2,15 @@ package di
 
 func SetupDependencies() *Container {
 	clientContainer := InitClients()
-	linkContainer := InitLinkDependencies(clientContainer)
+	collectionContainer := InitCollectionDependencies(clientContainer)
+	presetContainer := InitPresetDependencies()
+	linkContainer := InitLinkDependencies(clientContainer, collectionContainer, presetContainer)
 
 	container := &Container{
-		LinkContainer:   linkContainer,
-		ClientContainer: clientContainer,
+		LinkContainer:       linkContainer,
+		CollectionContainer: collectionContainer,
+		PresetContainer:     presetContainer,
+		ClientContainer:     clientContainer,
 	}
 	GlobalContainer = container
 	return container
@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go-link/generation/global"
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/di"
+)
+
+// RunReindex writes the link search index rows of links created before the index existed and prints the report as JSON
+func RunReindex(req *dto.ReindexRequest) error {
+	LoadConfig()
+	SetupLogger()
+	SetupWideColumn()
+	defer global.WideColumnClient.Close()
+
+	container := di.InitReindexDependencies()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := container.Service.Reindex(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
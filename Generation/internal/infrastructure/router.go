@@ -5,6 +5,7 @@ import (
 
 	"go-link/common/pkg/common/http/handler"
 	"go-link/common/pkg/common/http/middlewares"
+	"go-link/common/pkg/permissions"
 
 	"github.com/gin-gonic/gin"
 
@@ -14,15 +15,21 @@ import (
 
 // RouterGroup contains all routes
 type RouterGroup struct {
-	LinkHandler driverHttp.LinkHandler
+	LinkHandler       driverHttp.LinkHandler
+	CollectionHandler driverHttp.CollectionHandler
+	PresetHandler     driverHttp.PresetHandler
 }
 
 // NewRouterGroup creates a new RouterGroup
 func NewRouterGroup(
 	linkHandler driverHttp.LinkHandler,
+	collectionHandler driverHttp.CollectionHandler,
+	presetHandler driverHttp.PresetHandler,
 ) *RouterGroup {
 	return &RouterGroup{
-		LinkHandler: linkHandler,
+		LinkHandler:       linkHandler,
+		CollectionHandler: collectionHandler,
+		PresetHandler:     presetHandler,
 	}
 }
 
@@ -31,6 +38,33 @@ func (rg *RouterGroup) registerRoutes(r *gin.Engine) {
 	links := r.Group("/links")
 	{
 		links.POST("", handler.Wrap(rg.LinkHandler.Create))
+		links.POST("/:id/sign", middlewares.Authentication(global.Config.JWT.PublicKey), handler.Wrap(rg.LinkHandler.Sign))
+		links.GET("", middlewares.Authentication(global.Config.JWT.PublicKey), middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeRead), driverHttp.WrapQuery(rg.LinkHandler.Search))
+		links.PUT("/:id/tags", middlewares.Authentication(global.Config.JWT.PublicKey), handler.Wrap(rg.LinkHandler.SetTags))
+		links.PUT("/:id/status", middlewares.Authentication(global.Config.JWT.PublicKey), handler.Wrap(rg.LinkHandler.SetStatus))
+		links.POST("/utm", middlewares.Authentication(global.Config.JWT.PublicKey), handler.Wrap(rg.LinkHandler.BuildUTM))
+	}
+
+	collections := r.Group("/collections")
+	collections.Use(middlewares.Authentication(global.Config.JWT.PublicKey))
+	{
+		collections.POST("", middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeCreate), handler.Wrap(rg.CollectionHandler.Create))
+		collections.GET("", middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeRead), handler.Wrap(rg.CollectionHandler.List))
+		collections.POST("/links/move", middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeUpdate), handler.Wrap(rg.CollectionHandler.MoveLinks))
+		collections.GET("/:id", middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeRead), handler.Wrap(rg.CollectionHandler.Get))
+		collections.PUT("/:id", middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeUpdate), handler.Wrap(rg.CollectionHandler.Update))
+		collections.DELETE("/:id", middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeDelete), handler.Wrap(rg.CollectionHandler.Delete))
+		collections.GET("/:id/links", middlewares.RequirePermission(permissions.ResourceKeyGeneration, permissions.PermissionScopeRead), handler.Wrap(rg.CollectionHandler.ListLinks))
+	}
+
+	// Presets are read by every member and managed by those who manage the tenant
+	presets := r.Group("/presets")
+	presets.Use(middlewares.Authentication(global.Config.JWT.PublicKey))
+	{
+		presets.GET("", handler.Wrap(rg.PresetHandler.Get))
+		presets.PUT("/tags", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeUpdate), handler.Wrap(rg.PresetHandler.SetTags))
+		presets.PUT("/utm/:name", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeUpdate), handler.Wrap(rg.PresetHandler.SaveUTM))
+		presets.DELETE("/utm/:name", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeUpdate), handler.Wrap(rg.PresetHandler.DeleteUTM))
 	}
 }
 
@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
 	"go-link/generation/internal/di"
 )
 
@@ -9,9 +10,19 @@ func Run() error {
 	LoadConfig()
 	SetupLogger()
 	SetupTimer()
+	SetupKeys()
 	SetupRedis()
 	SetupWideColumn()
 	di.SetupDependencies()
+
+	// Start gRPC Server
+	grpcServer := NewGRPCServer()
+	go func() {
+		if err := grpcServer.Run(); err != nil {
+			panic(fmt.Sprintf("Failed to run gRPC server: %v", err))
+		}
+	}()
+
 	http := NewHTTPServer()
 
 	di.GlobalContainer.LinkContainer.CodePool.Start(context.Background())
@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+)
+
+type CollectionRepository interface {
+	Save(ctx context.Context, collection *entity.Collection) error
+	// Get returns widecolumn.ErrNotFound when the tenant has no such collection.
+	Get(ctx context.Context, tenantID int, id string) (*entity.Collection, error)
+	List(ctx context.Context, tenantID int) ([]*entity.Collection, error)
+	Delete(ctx context.Context, tenantID int, id string) error
+}
+
+// CollectionLinkRepository keeps which links belong to which collections.
+// Memberships outlive expired links, so a collection's stats keep counting their clicks.
+type CollectionLinkRepository interface {
+	// Links lists the links of a collection, ordered by link ID.
+	Links(ctx context.Context, collectionID string) ([]*entity.CollectionLink, error)
+	Count(ctx context.Context, collectionID string) (int, error)
+	// Collections lists the IDs of the collections a link belongs to.
+	Collections(ctx context.Context, linkID string) ([]string, error)
+	Add(ctx context.Context, collectionID string, linkIDs []string) error
+	Remove(ctx context.Context, collectionID string, linkIDs []string) error
+	// RemoveCollection drops every membership of a collection.
+	RemoveCollection(ctx context.Context, collectionID string) error
+	// RemoveLink drops every membership of a link.
+	RemoveLink(ctx context.Context, linkID string) error
+}
+
+type CollectionService interface {
+	Create(ctx context.Context, req *dto.CreateCollectionRequest) (*dto.CollectionResponse, error)
+	Get(ctx context.Context, req *dto.GetCollectionRequest) (*dto.CollectionResponse, error)
+	List(ctx context.Context, req *dto.ListCollectionsRequest) (*dto.ListCollectionsResponse, error)
+	Update(ctx context.Context, req *dto.UpdateCollectionRequest) (*dto.CollectionResponse, error)
+	Delete(ctx context.Context, req *dto.DeleteCollectionRequest) error
+	ListLinks(ctx context.Context, req *dto.ListCollectionLinksRequest) (*dto.ListCollectionLinksResponse, error)
+	// MoveLinks moves links of the caller's tenant from one collection to another.
+	MoveLinks(ctx context.Context, req *dto.MoveLinksRequest) (*dto.MoveLinksResponse, error)
+	// LinkIDs lists the links of a tenant's collection for other services; a missing collection is widecolumn.ErrNotFound.
+	LinkIDs(ctx context.Context, tenantID int, collectionID string) ([]string, error)
+}
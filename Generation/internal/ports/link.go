@@ -2,6 +2,9 @@ package ports
 
 import (
 	"context"
+
+	"go-link/common/pkg/database/widecolumn"
+
 	"go-link/generation/internal/core/dto"
 	"go-link/generation/internal/core/entity"
 )
@@ -10,6 +13,18 @@ type LinkRepository interface {
 	Create(ctx context.Context, link *entity.Link, ttl int) error
 	Get(ctx context.Context, id string) (*entity.Link, error)
 	Delete(ctx context.Context, id string) error
+	// SetTags replaces the link's tags, keeping what is left of its TTL, and updates link in place.
+	SetTags(ctx context.Context, link *entity.Link, tags []string) error
+	// SetDisabled turns the link off or back on, keeping what is left of its TTL, and updates link in place.
+	SetDisabled(ctx context.Context, link *entity.Link, disabled bool) error
+	// Search lists a tenant's links that carry every tag of the query, with the cursor of the next page.
+	// Links created before the index existed are found once cmd/reindex has written their rows.
+	Search(ctx context.Context, query *entity.LinkQuery) ([]*entity.Link, string, error)
+	// ScanRange streams the links whose partition token falls inside rng with the TTL left on each, 0 for none.
+	// Links carry only what the search index needs: ID, tenant, tags and timestamps.
+	ScanRange(ctx context.Context, rng widecolumn.TokenRange, fn func(link *entity.Link, ttl int) error) error
+	// Index writes the link's search index rows with the given TTL, as of the link's last update.
+	Index(ctx context.Context, link *entity.Link, ttl int) error
 }
 
 type LinkCacheRepository interface {
@@ -27,4 +42,13 @@ type ShortCodePool interface {
 type LinkService interface {
 	Create(ctx context.Context, req *dto.CreateLinkRequest) (*dto.LinkResponse, error)
 	Delete(ctx context.Context, req *dto.DeleteLinkRequest) error
+	Sign(ctx context.Context, req *dto.SignLinkRequest) (*dto.SignedLinkResponse, error)
+	Get(ctx context.Context, id string) (*entity.Link, error)
+	// Search lists the caller's tenant links, optionally only those carrying every given tag.
+	Search(ctx context.Context, req *dto.SearchLinksRequest) (*dto.SearchLinksResponse, error)
+	SetTags(ctx context.Context, req *dto.SetLinkTagsRequest) (*dto.LinkSummaryResponse, error)
+	// SetStatus disables the link, so Redirection answers it with its "disabled" page, or enables it again.
+	SetStatus(ctx context.Context, req *dto.SetLinkStatusRequest) (*dto.LinkSummaryResponse, error)
+	// BuildUTM composes a destination from a base URL and UTM parameters without creating a link.
+	BuildUTM(ctx context.Context, req *dto.BuildUTMRequest) (*dto.BuildUTMResponse, error)
 }
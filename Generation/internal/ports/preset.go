@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/generation/internal/core/dto"
+	"go-link/generation/internal/core/entity"
+)
+
+type PresetRepository interface {
+	Get(ctx context.Context, tenantID int) (*entity.Presets, error)
+	SetTags(ctx context.Context, tenantID int, tags []string) error
+	// GetUTM returns widecolumn.ErrNotFound when the tenant has no preset of that name.
+	GetUTM(ctx context.Context, tenantID int, name string) (*entity.UTMPreset, error)
+	SaveUTM(ctx context.Context, preset *entity.UTMPreset) error
+	DeleteUTM(ctx context.Context, tenantID int, name string) error
+}
+
+// PresetService manages the tags and UTM presets a tenant offers all its members.
+type PresetService interface {
+	Get(ctx context.Context, req *dto.GetPresetsRequest) (*dto.PresetsResponse, error)
+	SetTags(ctx context.Context, req *dto.SetTagPresetsRequest) (*dto.PresetsResponse, error)
+	SaveUTM(ctx context.Context, req *dto.SaveUTMPresetRequest) (*dto.UTMPresetResponse, error)
+	DeleteUTM(ctx context.Context, req *dto.DeleteUTMPresetRequest) error
+}
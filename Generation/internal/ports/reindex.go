@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/generation/internal/core/dto"
+)
+
+// ReindexService writes the link search index rows of links created before the index existed.
+type ReindexService interface {
+	Reindex(ctx context.Context, req *dto.ReindexRequest) (*dto.ReindexReport, error)
+}
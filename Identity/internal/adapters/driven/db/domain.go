@@ -91,6 +91,15 @@ func (r *DomainRepository) Get(ctx context.Context, id int) (*entity.Domain, err
 	return mapper.ToDomainEntity(record), nil
 }
 
+// GetByName retrieves a domain by its host name.
+func (r *DomainRepository) GetByName(ctx context.Context, name string) (*entity.Domain, error) {
+	record, err := r.client.DB(ctx).Domain.Query().Where(domain.DomainEQ(name)).Only(ctx)
+	if err != nil {
+		return nil, commonEnt.MapEntError(err, domainRepoName)
+	}
+	return mapper.ToDomainEntity(record), nil
+}
+
 // Create creates a new domain.
 func (r *DomainRepository) Create(ctx context.Context, e *entity.Domain) error {
 	create := builder.BuildCreateDomain(ctx, e)
@@ -13,17 +13,20 @@ type IdentityServer struct {
 	userService   ports.UserService
 	authService   ports.AuthenticationService
 	tenantService ports.TenantService
+	domainService ports.DomainService
 }
 
 func NewIdentityServer(
 	userService ports.UserService,
 	authService ports.AuthenticationService,
 	tenantService ports.TenantService,
+	domainService ports.DomainService,
 ) *IdentityServer {
 	return &IdentityServer{
 		userService:   userService,
 		authService:   authService,
 		tenantService: tenantService,
+		domainService: domainService,
 	}
 }
 
@@ -83,3 +86,15 @@ func (s *IdentityServer) UpdateTenantPlan(ctx context.Context, req *identityv1.U
 		Success: true,
 	}, nil
 }
+
+func (s *IdentityServer) ResolveDomain(ctx context.Context, req *identityv1.ResolveDomainRequest) (*identityv1.ResolveDomainResponse, error) {
+	domain, err := s.domainService.Resolve(ctx, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &identityv1.ResolveDomainResponse{
+		TenantId:   int64(domain.TenantID),
+		IsVerified: domain.IsVerified,
+	}, nil
+}
@@ -12,8 +12,9 @@ func V1Routes(
 	userService ports.UserService,
 	authService ports.AuthenticationService,
 	tenantService ports.TenantService,
+	domainService ports.DomainService,
 ) func(srv *grpc.Server) {
 	return func(srv *grpc.Server) {
-		identityv1.RegisterIdentityServiceServer(srv, NewIdentityServer(userService, authService, tenantService))
+		identityv1.RegisterIdentityServiceServer(srv, NewIdentityServer(userService, authService, tenantService, domainService))
 	}
 }
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/handler"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/common/http/validation"
+
+	"go-link/identity/internal/core/dto"
+	"go-link/identity/internal/ports"
+)
+
+// handoffPage posts the access token and state on to the callback as soon as it loads
+var handoffPage = template.Must(template.New("handoff").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta name="referrer" content="no-referrer"><title>Signing in</title></head>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.RedirectURI}}">
+<input type="hidden" name="access_token" value="{{.AccessToken}}">
+<input type="hidden" name="state" value="{{.State}}">
+<noscript><button type="submit">Continue</button></noscript>
+</form>
+</body>
+</html>
+`))
+
+// HandoffHandler defines the private link sign-in HTTP handler interface.
+type HandoffHandler interface {
+	LinkHandoff(c *gin.Context)
+}
+
+type handoffHandler struct {
+	handler.BaseHandler
+	handoffService ports.HandoffService
+}
+
+// NewHandoffHandler creates a new HandoffHandler instance.
+func NewHandoffHandler(handoffService ports.HandoffService) HandoffHandler {
+	return &handoffHandler{
+		handoffService: handoffService,
+	}
+}
+
+// LinkHandoff receives the login page's form post and answers with a page that posts the token to the callback.
+func (h *handoffHandler) LinkHandoff(c *gin.Context) {
+	var req dto.LinkHandoffRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.ErrorResponse(c, response.CodeParamInvalid, apperr.New(response.CodeParamInvalid, err.Error(), 0, err))
+		return
+	}
+	if ok, msg := validation.IsRequestValid(req); !ok {
+		response.ErrorResponse(c, response.CodeValidationFailed, apperr.New(response.CodeValidationFailed, msg, 0, nil))
+		return
+	}
+
+	res, err := h.handoffService.LinkHandoff(c.Request.Context(), &req)
+	if err != nil {
+		response.ErrorResponse(c, response.CodeInternalServer, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := handoffPage.Execute(c.Writer, res); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -18,4 +18,5 @@ const (
 	MsgTokenAlreadyUsed   = "reset token already used"
 	MsgRateLimitForgot    = "please wait a moment before requesting another reset link"
 	MsgForgotPasswordMsg  = "if the account exists and has a linked email, a reset link has been sent"
+	MsgInvalidHandoffURI  = "redirect_uri is not a private link callback"
 )
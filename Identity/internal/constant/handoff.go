@@ -0,0 +1,7 @@
+package constant
+
+// Private link sign-in: the login page posts the user's access token here,
+// and Identity posts it on to the Redirection callback it was asked to return to.
+const (
+	HandoffCallbackPath = "/auth/callback"
+)
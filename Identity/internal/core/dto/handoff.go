@@ -0,0 +1,16 @@
+package dto
+
+// LinkHandoffRequest is form-posted by the login page once the user has signed in for a private link.
+// State is the value Redirection passed to the login page and is handed back untouched.
+type LinkHandoffRequest struct {
+	AccessToken string `form:"access_token" validate:"required"`
+	RedirectURI string `form:"redirect_uri" validate:"required,url"`
+	State       string `form:"state" validate:"required,max=128"`
+}
+
+// LinkHandoffResponse is what gets posted on to the private link callback
+type LinkHandoffResponse struct {
+	RedirectURI string
+	AccessToken string
+	State       string
+}
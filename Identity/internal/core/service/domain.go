@@ -71,6 +71,16 @@ func (s *domainService) Get(ctx context.Context, id int) (*dto.DomainResponse, e
 	return mapper.ToDomainResponse(domain), nil
 }
 
+// Resolve retrieves a domain by its host name.
+func (s *domainService) Resolve(ctx context.Context, name string) (*dto.DomainResponse, error) {
+	domain, err := s.domainRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToDomainResponse(domain), nil
+}
+
 // Create creates a new domain.
 func (s *domainService) Create(ctx context.Context, req *dto.CreateDomainRequest) (*dto.DomainResponse, error) {
 	domain := mapper.ToDomainEntityFromCreate(req)
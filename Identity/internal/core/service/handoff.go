@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/utils"
+
+	"go-link/identity/global"
+	"go-link/identity/internal/constant"
+	"go-link/identity/internal/core/dto"
+	"go-link/identity/internal/ports"
+)
+
+const handoffServiceName = "HandoffService"
+
+type handoffService struct {
+	domainService ports.DomainService
+}
+
+// NewHandoffService creates a new HandoffService instance.
+func NewHandoffService(domainService ports.DomainService) ports.HandoffService {
+	return &handoffService{domainService: domainService}
+}
+
+// LinkHandoff validates the access token and the callback it is to be posted to.
+// Only the Redirection callback on a configured host or a verified custom domain may receive it,
+// so the login page cannot be used to post tokens anywhere else.
+func (s *handoffService) LinkHandoff(ctx context.Context, req *dto.LinkHandoffRequest) (*dto.LinkHandoffResponse, error) {
+	claims, err := utils.ParseToken(req.AccessToken, global.Config.JWT.PublicKey)
+	if err != nil || claims.Type != utils.AccessToken {
+		return nil, apperr.NewError(handoffServiceName, response.CodeUnauthorized, constant.MsgUnauthorized, http.StatusUnauthorized, err)
+	}
+
+	if !s.isCallback(ctx, req.RedirectURI) {
+		return nil, apperr.NewError(handoffServiceName, response.CodeBadRequest, constant.MsgInvalidHandoffURI, http.StatusBadRequest, nil)
+	}
+
+	return &dto.LinkHandoffResponse{
+		RedirectURI: req.RedirectURI,
+		AccessToken: req.AccessToken,
+		State:       req.State,
+	}, nil
+}
+
+func (s *handoffService) isCallback(ctx context.Context, redirectURI string) bool {
+	u, err := url.Parse(redirectURI)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.User != nil || u.Path != constant.HandoffCallbackPath {
+		return false
+	}
+
+	if slices.Contains(global.Config.PrivateLink.CallbackHosts, u.Host) {
+		return true
+	}
+
+	// Custom domains serve private links too; they need HTTPS and verification
+	if u.Scheme != "https" {
+		return false
+	}
+	domain, err := s.domainService.Resolve(ctx, u.Hostname())
+	return err == nil && domain.IsVerified
+}
@@ -14,6 +14,7 @@ type Container struct {
 	TenantMemberContainer        TenantMemberContainer
 	AttributeDefinitionContainer AttributeDefinitionContainer
 	UserAttributeValueContainer  UserAttributeValueContainer
+	HandoffContainer             HandoffContainer
 }
 
 // GlobalContainer is the global instance of Container.
@@ -0,0 +1,24 @@
+package di
+
+import (
+	driverHttp "go-link/identity/internal/adapters/driver/http"
+	"go-link/identity/internal/core/service"
+	"go-link/identity/internal/ports"
+)
+
+// HandoffContainer holds private link sign-in dependencies.
+type HandoffContainer struct {
+	Service ports.HandoffService
+	Handler driverHttp.HandoffHandler
+}
+
+// InitHandoffDependencies initializes private link sign-in dependencies.
+func InitHandoffDependencies(domainService ports.DomainService) HandoffContainer {
+	service := service.NewHandoffService(domainService)
+	handler := driverHttp.NewHandoffHandler(service)
+
+	return HandoffContainer{
+		Service: service,
+		Handler: handler,
+	}
+}
@@ -22,6 +22,7 @@ func SetupDependencies() *Container {
 	resourceContainer := InitResourceDependencies(client, cacheContainer.Service)
 	domainContainer := InitDomainDependencies(client, global.Tinylfu)
 	federatedIdentityContainer := InitFederatedIdentityDependencies(client)
+	handoffContainer := InitHandoffDependencies(domainContainer.Service)
 
 	userRepo := InitUserRepository(client)
 
@@ -79,6 +80,7 @@ func SetupDependencies() *Container {
 		TenantMemberContainer:        tenantMemberContainer,
 		AttributeDefinitionContainer: attrDefinitionContainer,
 		UserAttributeValueContainer:  attrValueContainer,
+		HandoffContainer:             handoffContainer,
 	}
 
 	GlobalContainer = container
@@ -24,8 +24,9 @@ func NewGRPCServer() *GRPCServer {
 	userService := di.GlobalContainer.UserContainer.Service
 	authService := di.GlobalContainer.AuthenticationContainer.Service
 	tenantService := di.GlobalContainer.TenantContainer.Service
+	domainService := di.GlobalContainer.DomainContainer.Service
 
-	serverRoutes := grpcConf.V1Routes(userService, authService, tenantService)
+	serverRoutes := grpcConf.V1Routes(userService, authService, tenantService, domainService)
 	srv := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptors.ServerAuthInterceptor(),
@@ -23,6 +23,7 @@ type RouterGroup struct {
 	AttributeDefinitionHandler driverHttp.AttributeDefinitionHandler
 	AuthenticationHandler      driverHttp.AuthenticationHandler
 	UserHandler                driverHttp.UserHandler
+	HandoffHandler             driverHttp.HandoffHandler
 }
 
 // NewRouterGroup creates a new RouterGroup.
@@ -35,6 +36,7 @@ func NewRouterGroup(
 	attrDefHandler driverHttp.AttributeDefinitionHandler,
 	authHandler driverHttp.AuthenticationHandler,
 	userHandler driverHttp.UserHandler,
+	handoffHandler driverHttp.HandoffHandler,
 ) *RouterGroup {
 	return &RouterGroup{
 		TenantHandler:              tenantHandler,
@@ -45,6 +47,7 @@ func NewRouterGroup(
 		AttributeDefinitionHandler: attrDefHandler,
 		AuthenticationHandler:      authHandler,
 		UserHandler:                userHandler,
+		HandoffHandler:             handoffHandler,
 	}
 }
 
@@ -61,6 +64,8 @@ func (rg *RouterGroup) registerRoutes(r *gin.Engine) {
 		public.POST("/oauth/:provider/register", handler.Wrap(rg.AuthenticationHandler.OAuthRegister))
 		public.POST("/forgot-password", handler.Wrap(rg.AuthenticationHandler.ForgotPassword))
 		public.POST("/reset-password", handler.Wrap(rg.AuthenticationHandler.ResetPassword))
+		// Form-posted by the login page; the access token travels in the form
+		public.POST("/link-handoff", rg.HandoffHandler.LinkHandoff)
 	}
 
 	// Protected Routes
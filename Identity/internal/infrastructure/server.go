@@ -43,6 +43,7 @@ func NewHTTPServer() *Server {
 		c.AttributeDefinitionContainer.Handler,
 		c.AuthenticationContainer.Handler,
 		c.UserContainer.Handler,
+		c.HandoffContainer.Handler,
 	)
 
 	// Create Gin engine
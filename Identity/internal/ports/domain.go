@@ -13,6 +13,7 @@ import (
 type DomainRepository interface {
 	Find(ctx context.Context, opts *d.QueryOptions) (*d.Paginated[*entity.Domain], error)
 	Get(ctx context.Context, id int) (*entity.Domain, error)
+	GetByName(ctx context.Context, name string) (*entity.Domain, error)
 	Create(ctx context.Context, e *entity.Domain) error
 	Update(ctx context.Context, e *entity.Domain) error
 	Delete(ctx context.Context, id int) error
@@ -23,6 +24,7 @@ type DomainRepository interface {
 type DomainService interface {
 	Find(ctx context.Context, opts *d.QueryOptions) (*d.Paginated[*dto.DomainResponse], error)
 	Get(ctx context.Context, id int) (*dto.DomainResponse, error)
+	Resolve(ctx context.Context, name string) (*dto.DomainResponse, error)
 	Create(ctx context.Context, req *dto.CreateDomainRequest) (*dto.DomainResponse, error)
 	Update(ctx context.Context, id int, req *dto.UpdateDomainRequest) (*dto.DomainResponse, error)
 	Delete(ctx context.Context, id int) error
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/identity/internal/core/dto"
+)
+
+// HandoffService checks a private link sign-in before the access token is handed to Redirection.
+type HandoffService interface {
+	LinkHandoff(ctx context.Context, req *dto.LinkHandoffRequest) (*dto.LinkHandoffResponse, error)
+}
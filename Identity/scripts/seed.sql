@@ -138,7 +138,8 @@ INSERT INTO resources (id, key, description, created_at, updated_at, deleted_at,
 (7, 'resource', 'Resource management', NOW(), NOW(), NULL, NULL),
 (8, 'attribute_definition', 'Attribute definition management', NOW(), NOW(), NULL, NULL),
 (9, 'billing', 'Billing management', NOW(), NOW(), NULL, NULL),
-(10, 'payment', 'Payment management', NOW(), NOW(), NULL, NULL)
+(10, 'payment', 'Payment management', NOW(), NOW(), NULL, NULL),
+(11, 'analytics', 'Link analytics and reports', NOW(), NOW(), NULL, NULL)
 ON CONFLICT (id) DO NOTHING;
 
 INSERT INTO attribute_definitions (id, key, data_type, description, created_at, updated_at, deleted_at, deleted_by) VALUES
@@ -161,7 +162,8 @@ INSERT INTO permissions (id, role_id, resource_id, description, scopes, created_
 (4, 1, 2, 'Owner: Delete domain', 8, NOW(), NOW(), NULL, NULL),
 (5, 3, 1, 'Member: Create generation', 1, NOW(), NOW(), NULL, NULL),
 (6, 3, 1, 'Member: Read generation', 2, NOW(), NOW(), NULL, NULL),
-(7, 3, 1, 'Member: Delete generation', 8, NOW(), NOW(), NULL, NULL)
+(7, 3, 1, 'Member: Delete generation', 8, NOW(), NOW(), NULL, NULL),
+(8, 3, 11, 'Member: Read analytics', 2, NOW(), NOW(), NULL, NULL)
 ON CONFLICT (id) DO NOTHING;
 
 -- reset sequences
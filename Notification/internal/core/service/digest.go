@@ -10,7 +10,10 @@ import (
 	"go-link/notification/internal/ports"
 )
 
-const digestKeyPrefix = "notification:digest:"
+const (
+	digestKeyPrefix   = "notification:digest:"
+	collapseKeyPrefix = "notification:collapse:"
+)
 
 // DigestService handles notification aggregation using Redis.
 type DigestService struct {
@@ -45,6 +48,16 @@ func (s *DigestService) AddToDigest(ctx context.Context, n *entity.Notification)
 	return s.cache.Expire(ctx, key, 24*time.Hour)
 }
 
+// Collapse opens a window for the recipient and collapse key; only the notification that opened it is delivered.
+func (s *DigestService) Collapse(ctx context.Context, n *entity.Notification, window time.Duration) (bool, error) {
+	if n.CollapseKey == "" {
+		return true, nil
+	}
+
+	key := fmt.Sprintf("%s%s:%s", collapseKeyPrefix, n.Recipient.UserID, n.CollapseKey)
+	return s.cache.SetNX(ctx, key, n.Type, window)
+}
+
 // ScanPendingDigests scans for all keys matching the digest prefix.
 func (s *DigestService) ScanPendingDigests(ctx context.Context) ([]string, error) {
 	return s.cache.Keys(ctx, digestKeyPrefix+"*")
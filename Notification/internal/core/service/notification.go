@@ -20,6 +20,8 @@ import (
 const (
 	rateLimitPerHour int64 = 100
 	rateLimitWindow        = time.Hour
+	// collapseWindow is how long later notifications with the same collapse key are dropped
+	collapseWindow = time.Hour
 )
 
 // templateMapping for types
@@ -31,6 +33,8 @@ var templateMapping = map[string]struct {
 	"account-alert":       {Subject: "Security Alert", Template: "Your account was accessed from a new device."},
 	"welcome-email":       {Subject: "Welcome to GoLink!", Template: "Hi {{name}}, thanks for joining us!"},
 	"digest-summary":      {Subject: "Notification Summary", Template: "You have {{notification_count}} new updates for {{collapse_key}}."},
+	"analytics-report":    {Template: "analytics-report", Subject: "Your GoLink analytics report"},
+	"link-anomaly":        {Template: "link-anomaly", Subject: "Unusual click activity on your link"},
 }
 
 type notificationService struct {
@@ -79,6 +83,23 @@ func (s *notificationService) ProcessNotification(ctx context.Context, evt *noti
 		}
 	}
 
+	// Collapse repeats, e.g. the same alert raised again while the first one is still fresh
+	if evt.CollapseKey != "" {
+		first, err := s.digestService.Collapse(ctx, &entity.Notification{
+			Type:        evt.Type,
+			CollapseKey: evt.CollapseKey,
+			Recipient:   entity.Recipient{UserID: evt.Recipient.UserID},
+		}, collapseWindow)
+		if err != nil {
+			global.LoggerZap.Warn("Collapse check failed, proceeding", zap.Error(err))
+		} else if !first {
+			global.LoggerZap.Info("Notification collapsed",
+				zap.String("user_id", evt.Recipient.UserID),
+				zap.String("collapse_key", evt.CollapseKey))
+			return nil
+		}
+	}
+
 	// Prepare channels to send
 	channels := evt.Channels
 	if len(channels) == 0 && evt.Channel != "" {
@@ -148,6 +169,7 @@ func (s *notificationService) ProcessNotification(ctx context.Context, evt *noti
 			},
 			Subject:      subject,
 			Body:         body,
+			CollapseKey:  evt.CollapseKey,
 			TemplateData: make(map[string]any),
 		}
 		for k, v := range evt.TemplateData {
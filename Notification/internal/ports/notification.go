@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"go-link/notification/internal/core/dto"
 	"go-link/notification/internal/core/entity"
@@ -44,6 +45,8 @@ type DigestService interface {
 	AddToDigest(ctx context.Context, notification *entity.Notification) error
 	ScanPendingDigests(ctx context.Context) ([]string, error)
 	ConsumeDigest(ctx context.Context, key string) ([]string, error)
+	// Collapse reports whether the notification is the first of its collapse key for the recipient within the window.
+	Collapse(ctx context.Context, notification *entity.Notification, window time.Duration) (bool, error)
 }
 
 // DigestWorker defines the contract for periodic digest processing.
@@ -5,26 +5,70 @@ import (
 	"embed"
 	"fmt"
 	"html/template"
+	"io/fs"
+	"strings"
 )
 
 //go:embed *.html
 var templateFS embed.FS
 
-var tmpl *template.Template
+// layoutFile is shared by every page; each page defines its own "content" block
+const layoutFile = "base-layout.html"
+
+// pages holds one template set per page so their "content" blocks do not collide
+var pages map[string]*template.Template
+
+var funcs = template.FuncMap{
+	"rows": rows,
+}
 
 func init() {
-	var err error
-	tmpl, err = template.ParseFS(templateFS, "*.html")
+	files, err := fs.Glob(templateFS, "*.html")
 	if err != nil {
-		panic(fmt.Sprintf("failed to parse notification templates: %v", err))
+		panic(fmt.Sprintf("failed to list notification templates: %v", err))
+	}
+
+	pages = make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		if file == layoutFile {
+			continue
+		}
+		name := strings.TrimSuffix(file, ".html")
+		tmpl, err := template.New(name).Funcs(funcs).ParseFS(templateFS, layoutFile, file)
+		if err != nil {
+			panic(fmt.Sprintf("failed to parse notification template %s: %v", name, err))
+		}
+		pages[name] = tmpl
 	}
 }
 
 // Render renders a named template with the provided data and returns the HTML string.
 func Render(templateName string, data map[string]any) (string, error) {
+	tmpl, ok := pages[templateName]
+	if !ok {
+		return "", fmt.Errorf("render template %s: template not found", templateName)
+	}
+
 	var buf bytes.Buffer
 	if err := tmpl.ExecuteTemplate(&buf, templateName, data); err != nil {
 		return "", fmt.Errorf("render template %s: %w", templateName, err)
 	}
 	return buf.String(), nil
 }
+
+// rows splits tabular template data, one row per line and cells separated by tabs.
+// Template data is a flat string map, so lists travel in this form.
+func rows(v any) [][]string {
+	s, _ := v.(string)
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	out := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		if line != "" {
+			out = append(out, strings.Split(line, "\t"))
+		}
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/infrastructure"
+)
+
+func main() {
+	opts := &infrastructure.RebuildOptions{}
+	flag.StringVar(&opts.Mode, "mode", constant.RebuildModeRebuild, "rebuild copies links into the redirect store, diff only reports drift")
+	flag.StringVar(&opts.SourceKeyspace, "source-keyspace", constant.RebuildDefaultSource, "keyspace holding Generation's links table")
+	flag.StringVar(&opts.CheckpointPath, "checkpoint", constant.RebuildDefaultCheckpoint, "file that records rebuild progress")
+	ranges := flag.Int("ranges", constant.RebuildDefaultRanges, "number of token ranges to split the ring into")
+	workers := flag.Int("workers", constant.RebuildDefaultWorkers, "ranges processed concurrently")
+	flag.IntVar(&opts.Rebuild.BatchSize, "batch", constant.RebuildDefaultBatchSize, "links written per batch")
+	flag.BoolVar(&opts.Rebuild.Resume, "resume", true, "continue from the checkpoint if one exists")
+	flag.IntVar(&opts.Rebuild.WarmTop, "warm", 0, "pre-warm the cache with the N most clicked links")
+	flag.IntVar(&opts.Diff.SampleSize, "sample", constant.RebuildDefaultSampleSize, "short codes listed per diff category")
+	flag.Parse()
+
+	opts.Rebuild.Ranges, opts.Diff.Ranges = *ranges, *ranges
+	opts.Rebuild.Workers, opts.Diff.Workers = *workers, *workers
+
+	if err := infrastructure.RunRebuild(opts); err != nil {
+		log.Fatalf("rebuild failed: %v", err)
+	}
+}
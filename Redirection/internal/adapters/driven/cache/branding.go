@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+type brandingCache struct {
+	redis cache.CacheEngine
+}
+
+func NewBranding(redis cache.CacheEngine) ports.BrandingCacheRepository {
+	return &brandingCache{
+		redis: redis,
+	}
+}
+
+func (b *brandingCache) getKey(tenantID int) string {
+	return constant.BrandingCachePrefix + strconv.Itoa(tenantID)
+}
+
+func (b *brandingCache) getDomainKey(host string) string {
+	return constant.DomainTenantCachePrefix + strings.ToLower(host)
+}
+
+func (b *brandingCache) Get(ctx context.Context, tenantID int) (*entity.Branding, error) {
+	var branding entity.Branding
+
+	if err := cache.HandleHitCache(ctx, &branding, b.redis, b.getKey(tenantID)); err != nil {
+		return nil, err
+	}
+
+	return &branding, nil
+}
+
+func (b *brandingCache) Set(ctx context.Context, branding *entity.Branding) error {
+	return cache.HandleSetCache(ctx, branding, b.redis, b.getKey(branding.TenantID), constant.BrandingCacheTTL)
+}
+
+func (b *brandingCache) Delete(ctx context.Context, tenantID int) error {
+	return cache.HandleDeleteCache(ctx, b.redis, b.getKey(tenantID))
+}
+
+// GetDomainTenant returns the tenant owning a custom domain; 0 means the host is known not to be one
+func (b *brandingCache) GetDomainTenant(ctx context.Context, host string) (int, bool) {
+	data, exists, err := b.redis.Get(ctx, b.getDomainKey(host))
+	if err != nil || !exists {
+		return 0, false
+	}
+
+	var tenantID int
+	if err := json.Unmarshal(data, &tenantID); err != nil {
+		return 0, false
+	}
+
+	return tenantID, true
+}
+
+func (b *brandingCache) SetDomainTenant(ctx context.Context, host string, tenantID int) error {
+	ttl := constant.DomainTenantCacheTTL
+	if tenantID == 0 {
+		ttl = constant.DomainMissCacheTTL
+	}
+	return cache.HandleSetCache(ctx, tenantID, b.redis, b.getDomainKey(host), ttl)
+}
@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+)
+
+// fallbackLimiter implements a fixed-window counter of Generation lookups shared by every instance.
+type fallbackLimiter struct {
+	redis cache.CacheEngine
+}
+
+// NewFallbackLimiter creates a new fallback limiter backed by Redis
+func NewFallbackLimiter(redis cache.CacheEngine) ports.FallbackLimiter {
+	return &fallbackLimiter{
+		redis: redis,
+	}
+}
+
+// Allow counts a lookup and reports whether it fits the budget of the current window.
+// On Redis error, fails open like the miss limiter; the per-client limit still applies.
+func (f *fallbackLimiter) Allow(ctx context.Context) bool {
+	count, err := f.redis.IncrWithTTL(ctx, constant.LinkFallbackRateKey, constant.LinkFallbackRateWindow)
+	if err != nil {
+		return true
+	}
+	return count <= constant.LinkFallbackRateMax
+}
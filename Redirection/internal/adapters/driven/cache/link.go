@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	"go-link/common/pkg/common/cache"
 
@@ -14,6 +15,13 @@ type linkCache struct {
 	redis cache.CacheEngine
 }
 
+// linkEntry is the cached representation of a link.
+// RefreshAt marks the soft expiry after which the entry is served stale while it is reloaded.
+type linkEntry struct {
+	entity.Link
+	RefreshAt time.Time `json:"refresh_at"`
+}
+
 func NewLink(redis cache.CacheEngine) ports.LinkCacheRepository {
 	return &linkCache{
 		redis: redis,
@@ -24,18 +32,30 @@ func (l *linkCache) getKey(id string) string {
 	return constant.LinkCachePrefix + id
 }
 
+func (l *linkCache) getMissKey(id string) string {
+	return constant.LinkMissCachePrefix + id
+}
+
+func (l *linkCache) getLockKey(id string) string {
+	return constant.LinkLoadLockPrefix + id
+}
+
 func (l *linkCache) Set(ctx context.Context, link *entity.Link) error {
-	return cache.HandleSetCache(ctx, link, l.redis, l.getKey(link.ID), constant.LinkCacheTTL)
+	entry := &linkEntry{
+		Link:      *link,
+		RefreshAt: time.Now().Add(constant.LinkCacheSoftTTL),
+	}
+	return cache.HandleSetCache(ctx, entry, l.redis, l.getKey(link.ID), constant.LinkCacheTTL)
 }
 
-func (l *linkCache) Get(ctx context.Context, id string) (*entity.Link, error) {
-	var link entity.Link
+func (l *linkCache) Get(ctx context.Context, id string) (*entity.Link, bool, error) {
+	var entry linkEntry
 
-	if err := cache.HandleHitCache(ctx, &link, l.redis, l.getKey(id)); err != nil {
-		return nil, err
+	if err := cache.HandleHitCache(ctx, &entry, l.redis, l.getKey(id)); err != nil {
+		return nil, false, err
 	}
 
-	return &link, nil
+	return &entry.Link, time.Now().After(entry.RefreshAt), nil
 }
 
 func (l *linkCache) DeleteBulk(ctx context.Context, ids []string) error {
@@ -45,3 +65,42 @@ func (l *linkCache) DeleteBulk(ctx context.Context, ids []string) error {
 	}
 	return l.redis.DeleteBulk(ctx, idKeys)
 }
+
+// SetMiss records that the short code does not exist
+func (l *linkCache) SetMiss(ctx context.Context, id string) error {
+	return cache.HandleSetCache(ctx, constant.LinkMissCacheValue, l.redis, l.getMissKey(id), constant.LinkMissCacheTTL)
+}
+
+// IsMiss reports whether the short code was recently looked up and not found
+func (l *linkCache) IsMiss(ctx context.Context, id string) bool {
+	_, exists, err := l.redis.Get(ctx, l.getMissKey(id))
+	return exists && err == nil
+}
+
+// DeleteMissBulk clears negative entries for codes that have just been created
+func (l *linkCache) DeleteMissBulk(ctx context.Context, ids []string) error {
+	missKeys := make([]string, len(ids))
+	for i, id := range ids {
+		missKeys[i] = l.getMissKey(id)
+	}
+	return l.redis.DeleteBulk(ctx, missKeys)
+}
+
+// AcquireLoadLock claims the right to load the short code from the database across instances.
+// The token identifies the holder, so only it can release the lock.
+func (l *linkCache) AcquireLoadLock(ctx context.Context, id, token string) (bool, error) {
+	return l.redis.SetNX(ctx, l.getLockKey(id), token, constant.LinkLoadLockTTL)
+}
+
+// ConsumeNonce marks a single-use nonce as spent; it reports false when it already was.
+// The key lives until the signature expires, after which the link is rejected anyway.
+func (l *linkCache) ConsumeNonce(ctx context.Context, id, nonce string, ttl time.Duration) (bool, error) {
+	return l.redis.SetNX(ctx, constant.LinkNonceCachePrefix+id+":"+nonce, constant.LinkNonceCacheValue, ttl)
+}
+
+// ReleaseLoadLock releases the load lock so the next miss can load immediately.
+// A lock that expired and was taken by another request is left alone.
+func (l *linkCache) ReleaseLoadLock(ctx context.Context, id, token string) error {
+	_, err := l.redis.CompareAndDelete(ctx, l.getLockKey(id), token)
+	return err
+}
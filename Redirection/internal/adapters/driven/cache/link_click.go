@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+// linkClickCache keeps click totals in a Redis sorted set, outside the keyspace a rebuild repopulates
+type linkClickCache struct {
+	redis cache.CacheEngine
+}
+
+func NewLinkClicks(redis cache.CacheEngine) ports.LinkClickRepository {
+	return &linkClickCache{
+		redis: redis,
+	}
+}
+
+// AddClicks adds each window's click count to the running totals and trims the set to the busiest links
+func (l *linkClickCache) AddClicks(ctx context.Context, links []*entity.HotLink) error {
+	added := false
+	for _, link := range links {
+		if link.Clicks <= 0 {
+			continue
+		}
+		if _, err := l.redis.ZIncrBy(ctx, constant.LinkClicksKey, float64(link.Clicks), link.ShortCode); err != nil {
+			return err
+		}
+		added = true
+	}
+	if !added {
+		return nil
+	}
+
+	return l.redis.ZRemRangeByRank(ctx, constant.LinkClicksKey, 0, -constant.LinkClicksMaxTracked-1)
+}
+
+// Top returns the limit most clicked short codes, busiest first
+func (l *linkClickCache) Top(ctx context.Context, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	ids, err := l.redis.ZRange(ctx, constant.LinkClicksKey, -int64(limit), -1)
+	if err != nil {
+		return nil, err
+	}
+
+	// ZRange returns lowest scores first
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+	return ids, nil
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+)
+
+type membershipCache struct {
+	redis cache.CacheEngine
+}
+
+func NewMembership(redis cache.CacheEngine) ports.MembershipCacheRepository {
+	return &membershipCache{
+		redis: redis,
+	}
+}
+
+func (m *membershipCache) getKey(tenantID, userID int) string {
+	return fmt.Sprintf("%s%d:%d", constant.MembershipCachePrefix, tenantID, userID)
+}
+
+// Get returns the cached membership of the user in the tenant
+func (m *membershipCache) Get(ctx context.Context, tenantID, userID int) (bool, bool) {
+	data, exists, err := m.redis.Get(ctx, m.getKey(tenantID, userID))
+	if err != nil || !exists {
+		return false, false
+	}
+
+	var member bool
+	if err := json.Unmarshal(data, &member); err != nil {
+		return false, false
+	}
+
+	return member, true
+}
+
+// Set caches the membership; refusals expire sooner so newly invited members get in quickly
+func (m *membershipCache) Set(ctx context.Context, tenantID, userID int, member bool) error {
+	ttl := constant.MembershipCacheTTL
+	if !member {
+		ttl = constant.NonMembershipCacheTTL
+	}
+	return cache.HandleSetCache(ctx, member, m.redis, m.getKey(tenantID, userID), ttl)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+)
+
+// missLimiter implements a fixed-window counter of lookups for unknown codes per client IP.
+type missLimiter struct {
+	redis cache.CacheEngine
+}
+
+// NewMissLimiter creates a new miss limiter backed by Redis
+func NewMissLimiter(redis cache.CacheEngine) ports.MissLimiter {
+	return &missLimiter{
+		redis: redis,
+	}
+}
+
+func (m *missLimiter) getKey(clientIP string) string {
+	return constant.MissRateLimitPrefix + clientIP
+}
+
+// Blocked reports whether the client exceeded the miss budget for the current window.
+// On Redis error, fails open to avoid blocking legitimate traffic.
+func (m *missLimiter) Blocked(ctx context.Context, clientIP string) bool {
+	if clientIP == "" {
+		return false
+	}
+
+	data, exists, err := m.redis.Get(ctx, m.getKey(clientIP))
+	if err != nil || !exists {
+		return false
+	}
+
+	var count int64
+	if err := json.Unmarshal(data, &count); err != nil {
+		return false
+	}
+
+	return count >= constant.MissRateLimitMax
+}
+
+// Record counts a miss for the client, starting a new window on the first one.
+// The window's TTL is set in the same step as the count, so a client is never blocked for good.
+func (m *missLimiter) Record(ctx context.Context, clientIP string) {
+	if clientIP == "" {
+		return
+	}
+
+	_, _ = m.redis.IncrWithTTL(ctx, m.getKey(clientIP), constant.MissRateLimitWindow)
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+
+	"go-link/common/pkg/common/cache"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+type trafficSettingsCache struct {
+	redis cache.CacheEngine
+}
+
+func NewTrafficSettings(redis cache.CacheEngine) ports.TrafficSettingsCacheRepository {
+	return &trafficSettingsCache{
+		redis: redis,
+	}
+}
+
+func (t *trafficSettingsCache) getKey(tenantID int) string {
+	return constant.TrafficSettingsCachePrefix + strconv.Itoa(tenantID)
+}
+
+func (t *trafficSettingsCache) Get(ctx context.Context, tenantID int) (*entity.TrafficSettings, error) {
+	var settings entity.TrafficSettings
+
+	if err := cache.HandleHitCache(ctx, &settings, t.redis, t.getKey(tenantID)); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (t *trafficSettingsCache) Set(ctx context.Context, settings *entity.TrafficSettings) error {
+	return cache.HandleSetCache(ctx, settings, t.redis, t.getKey(settings.TenantID), constant.TrafficSettingsCacheTTL)
+}
+
+func (t *trafficSettingsCache) Delete(ctx context.Context, tenantID int) error {
+	return cache.HandleDeleteCache(ctx, t.redis, t.getKey(tenantID))
+}
@@ -2,23 +2,35 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
 
 	"go-link/common/pkg/database/widecolumn"
 
 	"go-link/redirection/global"
 	"go-link/redirection/internal/adapters/driven/db/models"
+	"go-link/redirection/internal/constant"
 	"go-link/redirection/internal/core/entity"
 	"go-link/redirection/internal/ports"
 )
 
 type LinkRepository struct {
-	repo *widecolumn.BaseRepository[models.Link]
+	session *gocql.Session
+	repo    *widecolumn.BaseRepository[models.Link]
 }
 
 // NewLinkRepository creates a new instance of LinkRepository
 func NewLinkRepository() ports.LinkRepository {
+	return NewLinkRepositoryWithSession(global.WideColumnClient.GetSession())
+}
+
+// NewLinkRepositoryWithSession creates a LinkRepository over a given session, e.g. one bound to another keyspace
+func NewLinkRepositoryWithSession(session *gocql.Session) ports.LinkRepository {
 	return &LinkRepository{
-		repo: widecolumn.NewBaseRepository(global.WideColumnClient.GetSession(), models.Link{}),
+		session: session,
+		repo:    widecolumn.NewBaseRepository(session, models.Link{}),
 	}
 }
 
@@ -40,6 +52,7 @@ func (l *LinkRepository) CreateBulk(ctx context.Context, links []*entity.Link) e
 	return l.repo.CreateBulk(ctx, m)
 }
 
+// DeleteBulk removes multiple links in a batch
 func (l *LinkRepository) DeleteBulk(ctx context.Context, ids []string) error {
 	args := make([]any, len(ids))
 	for i, v := range ids {
@@ -47,3 +60,61 @@ func (l *LinkRepository) DeleteBulk(ctx context.Context, ids []string) error {
 	}
 	return l.repo.DeleteBulk(ctx, args)
 }
+
+// ScanIDs streams every short code in the table, page by page
+func (l *LinkRepository) ScanIDs(ctx context.Context, fn func(id string)) error {
+	stmt := fmt.Sprintf("SELECT %s FROM %s", widecolumn.IDColumn, models.TableName)
+	iter := l.session.Query(stmt).WithContext(ctx).PageSize(constant.LinkFilterScanPageSize).Iter()
+
+	var id string
+	for iter.Scan(&id) {
+		fn(id)
+	}
+
+	return iter.Close()
+}
+
+// ScanRange streams the links whose partition token falls inside rng, in token order
+func (l *LinkRepository) ScanRange(ctx context.Context, rng widecolumn.TokenRange, fn func(token int64, link *entity.Link) error) error {
+	return l.repo.ScanRange(ctx, rng, constant.RebuildScanPageSize, func(token int64, model *models.Link) error {
+		return fn(token, model.ToEntity())
+	})
+}
+
+// ApplyChanges writes upserts and deletes in one batch, each stamped with its change version
+func (l *LinkRepository) ApplyChanges(ctx context.Context, changes []*entity.LinkChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	cols := models.Link{}.ColumnNames()
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+	insertStmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", models.TableName, strings.Join(cols, ", "), placeholders)
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", models.TableName, widecolumn.IDColumn)
+	insertVersionedStmt := insertStmt + " USING TIMESTAMP ?"
+	deleteVersionedStmt := fmt.Sprintf("DELETE FROM %s USING TIMESTAMP ? WHERE %s = ?", models.TableName, widecolumn.IDColumn)
+
+	batch := l.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	for _, change := range changes {
+		// Scylla write timestamps are in microseconds
+		ts := change.Version * 1000
+
+		if change.Deleted {
+			if ts > 0 {
+				batch.Query(deleteVersionedStmt, ts, change.Link.ID)
+			} else {
+				batch.Query(deleteStmt, change.Link.ID)
+			}
+			continue
+		}
+
+		values := models.FromEntity(change.Link).ColumnValues()
+		if ts > 0 {
+			batch.Query(insertVersionedStmt, append(values, ts)...)
+		} else {
+			batch.Query(insertStmt, values...)
+		}
+	}
+
+	return l.session.ExecuteBatch(batch)
+}
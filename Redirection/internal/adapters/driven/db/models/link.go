@@ -1,18 +1,36 @@
 package models
 
 import (
+	"time"
+
 	"go-link/common/pkg/database/widecolumn"
 	"go-link/redirection/internal/core/entity"
 )
 
 const (
-	TableName         = "links"
-	OriginalURLColumn = "original_url"
+	TableName              = "links"
+	OriginalURLColumn      = "original_url"
+	UserIDColumn           = "user_id"
+	TenantIDColumn         = "tenant_id"
+	ExpiresAtColumn        = "expires_at"
+	DisabledColumn         = "disabled"
+	PasswordHashColumn     = "password_hash"
+	RequireSignatureColumn = "require_signature"
+	VisibilityColumn       = "visibility"
+	AppendClickIDColumn    = "append_click_id"
 )
 
 type Link struct {
 	*widecolumn.BaseModel[string]
-	OriginalURL string `json:"original_url"`
+	OriginalURL      string     `json:"original_url"`
+	UserID           int        `json:"user_id"`
+	TenantID         int        `json:"tenant_id"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	Disabled         bool       `json:"disabled"`
+	PasswordHash     string     `json:"password_hash"`
+	RequireSignature bool       `json:"require_signature"`
+	Visibility       string     `json:"visibility"`
+	AppendClickID    bool       `json:"append_click_id"`
 }
 
 func (Link) TableName() string {
@@ -20,11 +38,11 @@ func (Link) TableName() string {
 }
 
 func (Link) ColumnNames() []string {
-	return []string{widecolumn.IDColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn, OriginalURLColumn}
+	return []string{widecolumn.IDColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn, OriginalURLColumn, UserIDColumn, TenantIDColumn, ExpiresAtColumn, DisabledColumn, PasswordHashColumn, RequireSignatureColumn, VisibilityColumn, AppendClickIDColumn}
 }
 
 func (l Link) ColumnValues() []any {
-	return []any{l.ID, l.CreatedAt, l.UpdatedAt, l.OriginalURL}
+	return []any{l.ID, l.CreatedAt, l.UpdatedAt, l.OriginalURL, l.UserID, l.TenantID, l.ExpiresAt, l.Disabled, l.PasswordHash, l.RequireSignature, l.Visibility, l.AppendClickID}
 }
 
 func (l *Link) ToEntity() *entity.Link {
@@ -32,7 +50,15 @@ func (l *Link) ToEntity() *entity.Link {
 		return nil
 	}
 	e := &entity.Link{
-		OriginalURL: l.OriginalURL,
+		OriginalURL:      l.OriginalURL,
+		UserID:           l.UserID,
+		TenantID:         l.TenantID,
+		ExpiresAt:        expiresAt(l.ExpiresAt),
+		Disabled:         l.Disabled,
+		PasswordHash:     l.PasswordHash,
+		RequireSignature: l.RequireSignature,
+		Visibility:       l.Visibility,
+		AppendClickID:    l.AppendClickID,
 	}
 	if l.BaseModel != nil {
 		e.ID = l.ID
@@ -49,6 +75,22 @@ func FromEntity(e *entity.Link) *Link {
 			CreatedAt: e.CreatedAt,
 			UpdatedAt: e.UpdatedAt,
 		},
-		OriginalURL: e.OriginalURL,
+		OriginalURL:      e.OriginalURL,
+		UserID:           e.UserID,
+		TenantID:         e.TenantID,
+		ExpiresAt:        e.ExpiresAt,
+		Disabled:         e.Disabled,
+		PasswordHash:     e.PasswordHash,
+		RequireSignature: e.RequireSignature,
+		Visibility:       e.Visibility,
+		AppendClickID:    e.AppendClickID,
+	}
+}
+
+// expiresAt maps the zero timestamp a null column scans into back to "never expires"
+func expiresAt(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
 	}
+	return t
 }
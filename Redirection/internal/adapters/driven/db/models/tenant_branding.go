@@ -0,0 +1,66 @@
+package models
+
+import (
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/redirection/internal/core/entity"
+)
+
+const (
+	TenantBrandingTableName = "tenant_brandings"
+	NameColumn              = "name"
+	LogoURLColumn           = "logo_url"
+	PrimaryColorColumn      = "primary_color"
+	BackgroundColorColumn   = "background_color"
+	FallbackURLColumn       = "fallback_url"
+)
+
+type TenantBranding struct {
+	*widecolumn.BaseModel[int]
+	Name            string `json:"name"`
+	LogoURL         string `json:"logo_url"`
+	PrimaryColor    string `json:"primary_color"`
+	BackgroundColor string `json:"background_color"`
+	FallbackURL     string `json:"fallback_url"`
+}
+
+func (TenantBranding) TableName() string {
+	return TenantBrandingTableName
+}
+
+func (TenantBranding) ColumnNames() []string {
+	return []string{widecolumn.IDColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn, NameColumn, LogoURLColumn, PrimaryColorColumn, BackgroundColorColumn, FallbackURLColumn}
+}
+
+func (t TenantBranding) ColumnValues() []any {
+	return []any{t.ID, t.CreatedAt, t.UpdatedAt, t.Name, t.LogoURL, t.PrimaryColor, t.BackgroundColor, t.FallbackURL}
+}
+
+func (t *TenantBranding) ToEntity() *entity.Branding {
+	if t == nil {
+		return nil
+	}
+	b := &entity.Branding{
+		Name:            t.Name,
+		LogoURL:         t.LogoURL,
+		PrimaryColor:    t.PrimaryColor,
+		BackgroundColor: t.BackgroundColor,
+		FallbackURL:     t.FallbackURL,
+	}
+	if t.BaseModel != nil {
+		b.TenantID = t.ID
+	}
+	return b
+}
+
+func FromBranding(b *entity.Branding) *TenantBranding {
+	return &TenantBranding{
+		BaseModel: &widecolumn.BaseModel[int]{
+			ID: b.TenantID,
+		},
+		Name:            b.Name,
+		LogoURL:         b.LogoURL,
+		PrimaryColor:    b.PrimaryColor,
+		BackgroundColor: b.BackgroundColor,
+		FallbackURL:     b.FallbackURL,
+	}
+}
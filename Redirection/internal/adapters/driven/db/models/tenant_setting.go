@@ -0,0 +1,50 @@
+package models
+
+import (
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/redirection/internal/core/entity"
+)
+
+const (
+	TenantSettingTableName = "tenant_settings"
+	ExcludeBotsColumn      = "exclude_bots"
+)
+
+type TenantSetting struct {
+	*widecolumn.BaseModel[int]
+	ExcludeBots bool `json:"exclude_bots"`
+}
+
+func (TenantSetting) TableName() string {
+	return TenantSettingTableName
+}
+
+func (TenantSetting) ColumnNames() []string {
+	return []string{widecolumn.IDColumn, widecolumn.CreatedAtColumn, widecolumn.UpdatedAtColumn, ExcludeBotsColumn}
+}
+
+func (t TenantSetting) ColumnValues() []any {
+	return []any{t.ID, t.CreatedAt, t.UpdatedAt, t.ExcludeBots}
+}
+
+func (t *TenantSetting) ToTrafficSettings() *entity.TrafficSettings {
+	if t == nil {
+		return nil
+	}
+	s := &entity.TrafficSettings{
+		ExcludeBots: t.ExcludeBots,
+	}
+	if t.BaseModel != nil {
+		s.TenantID = t.ID
+	}
+	return s
+}
+
+func FromTrafficSettings(s *entity.TrafficSettings) *TenantSetting {
+	return &TenantSetting{
+		BaseModel: &widecolumn.BaseModel[int]{
+			ID: s.TenantID,
+		},
+		ExcludeBots: s.ExcludeBots,
+	}
+}
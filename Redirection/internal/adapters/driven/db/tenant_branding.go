@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-link/common/pkg/database/widecolumn"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/adapters/driven/db/models"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+type TenantBrandingRepository struct {
+	repo *widecolumn.BaseRepository[models.TenantBranding]
+}
+
+// NewTenantBrandingRepository creates a new instance of TenantBrandingRepository
+func NewTenantBrandingRepository() ports.BrandingRepository {
+	return &TenantBrandingRepository{
+		repo: widecolumn.NewBaseRepository(global.WideColumnClient.GetSession(), models.TenantBranding{}),
+	}
+}
+
+// Get retrieves the branding of a tenant
+func (r *TenantBrandingRepository) Get(ctx context.Context, tenantID int) (*entity.Branding, error) {
+	model, err := r.repo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// Save upserts the branding of a tenant, keeping the original creation time
+func (r *TenantBrandingRepository) Save(ctx context.Context, branding *entity.Branding) error {
+	now := time.Now()
+	model := models.FromBranding(branding)
+	model.CreatedAt = now
+	model.UpdatedAt = now
+
+	existing, err := r.repo.Get(ctx, branding.TenantID)
+	switch {
+	case err == nil && existing.BaseModel != nil:
+		model.CreatedAt = existing.CreatedAt
+	case err != nil && !errors.Is(err, widecolumn.ErrNotFound):
+		return err
+	}
+
+	return r.repo.Update(ctx, model)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-link/common/pkg/database/widecolumn"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/adapters/driven/db/models"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+type TenantSettingRepository struct {
+	repo *widecolumn.BaseRepository[models.TenantSetting]
+}
+
+// NewTenantSettingRepository creates a new instance of TenantSettingRepository
+func NewTenantSettingRepository() ports.TrafficSettingsRepository {
+	return &TenantSettingRepository{
+		repo: widecolumn.NewBaseRepository(global.WideColumnClient.GetSession(), models.TenantSetting{}),
+	}
+}
+
+// Get retrieves the traffic settings of a tenant
+func (r *TenantSettingRepository) Get(ctx context.Context, tenantID int) (*entity.TrafficSettings, error) {
+	model, err := r.repo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return model.ToTrafficSettings(), nil
+}
+
+// Save upserts the traffic settings of a tenant, keeping the original creation time
+func (r *TenantSettingRepository) Save(ctx context.Context, settings *entity.TrafficSettings) error {
+	now := time.Now()
+	model := models.FromTrafficSettings(settings)
+	model.CreatedAt = now
+	model.UpdatedAt = now
+
+	existing, err := r.repo.Get(ctx, settings.TenantID)
+	switch {
+	case err == nil && existing.BaseModel != nil:
+		model.CreatedAt = existing.CreatedAt
+	case err != nil && !errors.Is(err, widecolumn.ErrNotFound):
+		return err
+	}
+
+	return r.repo.Update(ctx, model)
+}
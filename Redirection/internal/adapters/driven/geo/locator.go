@@ -0,0 +1,46 @@
+package geo
+
+import (
+	"math"
+	"net/netip"
+
+	"go-link/common/pkg/geoip"
+
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+// coordinatePrecision rounds coordinates to two decimals, about a kilometre
+const coordinatePrecision = 100
+
+type locator struct {
+	db *geoip.Database
+}
+
+func NewLocator(db *geoip.Database) ports.GeoLocator {
+	return &locator{
+		db: db,
+	}
+}
+
+// Locate looks the IP up in the GeoIP database; lookup errors are treated as unknown
+func (l *locator) Locate(ip string) *entity.GeoLocation {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil
+	}
+
+	city, err := l.db.City(addr)
+	if err != nil || city == nil {
+		return nil
+	}
+
+	return &entity.GeoLocation{
+		CountryCode: city.CountryCode,
+		Region:      city.Region,
+		City:        city.City,
+		Latitude:    math.Round(city.Latitude*coordinatePrecision) / coordinatePrecision,
+		Longitude:   math.Round(city.Longitude*coordinatePrecision) / coordinatePrecision,
+		HasLocation: city.HasLocation,
+	}
+}
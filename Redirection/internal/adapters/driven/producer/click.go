@@ -0,0 +1,189 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"go-link/common/pkg/datastructs/queue"
+	"go-link/common/pkg/mq/batcher"
+	"go-link/common/pkg/mq/kafka"
+
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+	"github.com/huynhanx03/GoLink/events-contract/topics"
+)
+
+// clickPublisher batches click events in striped buffers and hands the batches to the async Kafka producer
+// from a background goroutine, so a slow Kafka never holds a redirect. A batch is handed over once a stripe fills,
+// on every flush tick of the batcher, and once more when the publisher stops.
+// While Kafka is failing, events go to a bounded spill queue that is drained once the producer recovers;
+// events the producer failed to deliver are spilled too. When either queue is full the newest events are dropped.
+type clickPublisher struct {
+	producer       kafka.Producer
+	batcher        *batcher.StripedBatcher[*linkv1.LinkClickedEvent]
+	batches        chan []*linkv1.LinkClickedEvent
+	spill          *queue.MPMC[*linkv1.LinkClickedEvent]
+	unhealthyUntil atomic.Int64
+	dropped        atomic.Int64
+	stopChan       chan struct{}
+	doneChan       chan struct{}
+}
+
+// NewClickPublisher creates a new click publisher on top of the given producer
+func NewClickPublisher(producer kafka.Producer) ports.ClickPublisher {
+	p := &clickPublisher{
+		producer: producer,
+		batches:  make(chan []*linkv1.LinkClickedEvent, constant.ClickBatchQueueSize),
+		spill:    queue.NewMPMC[*linkv1.LinkClickedEvent](constant.ClickSpillCapacity),
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+	}
+	p.batcher = batcher.New[*linkv1.LinkClickedEvent](p, batcher.Config{
+		StripeSize:    constant.ClickStripeSize,
+		FlushInterval: constant.ClickFlushInterval,
+	})
+	return p
+}
+
+// Publish enqueues the event; it never blocks on Kafka
+func (p *clickPublisher) Publish(evt *linkv1.LinkClickedEvent) {
+	p.batcher.Push(evt)
+}
+
+// Consume implements batcher.Consumer; it only hands the batch to the sending goroutine
+func (p *clickPublisher) Consume(batch []*linkv1.LinkClickedEvent) error {
+	select {
+	case p.batches <- batch:
+	default:
+		p.dropped.Add(int64(len(batch)))
+	}
+	return nil
+}
+
+// Start sends batched events, watches producer errors and drains the spill queue until stopped
+func (p *clickPublisher) Start(ctx context.Context) error {
+	defer close(p.doneChan)
+	go p.watchErrors()
+
+	ticker := time.NewTicker(constant.ClickSpillDrainInterval)
+	defer ticker.Stop()
+
+	buf := make([]*linkv1.LinkClickedEvent, constant.ClickSpillDrainBatch)
+	for {
+		select {
+		case batch := <-p.batches:
+			p.sendBatch(batch)
+		case <-ticker.C:
+			p.drain(buf)
+		case <-p.stopChan:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Stop flushes the batcher and hands every pending batch to the producer before returning;
+// the producer itself is closed by its owner afterwards
+func (p *clickPublisher) Stop() {
+	p.batcher.Close()
+	close(p.stopChan)
+	<-p.doneChan
+
+	for {
+		select {
+		case batch := <-p.batches:
+			p.sendBatch(batch)
+		default:
+			return
+		}
+	}
+}
+
+func (p *clickPublisher) healthy() bool {
+	return time.Now().UnixNano() >= p.unhealthyUntil.Load()
+}
+
+func (p *clickPublisher) markUnhealthy() {
+	p.unhealthyUntil.Store(time.Now().Add(constant.ClickProducerBackoff).UnixNano())
+}
+
+func (p *clickPublisher) sendBatch(batch []*linkv1.LinkClickedEvent) {
+	for _, evt := range batch {
+		p.send(evt)
+	}
+}
+
+func (p *clickPublisher) send(evt *linkv1.LinkClickedEvent) {
+	if !p.healthy() {
+		p.toSpill(evt)
+		return
+	}
+
+	value, err := json.Marshal(evt)
+	if err != nil {
+		global.LoggerZap.Error("Failed to marshal click event", zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constant.ClickPublishTimeout)
+	defer cancel()
+
+	p.producer.Publish(ctx, topics.LinkClicked, []byte(evt.ShortCode), value)
+
+	// The producer input stayed full for the whole timeout: treat Kafka as unavailable.
+	if ctx.Err() != nil {
+		p.markUnhealthy()
+		p.toSpill(evt)
+	}
+}
+
+func (p *clickPublisher) toSpill(evt *linkv1.LinkClickedEvent) {
+	if !p.spill.Enqueue(evt) {
+		p.dropped.Add(1)
+	}
+}
+
+func (p *clickPublisher) drain(buf []*linkv1.LinkClickedEvent) {
+	if dropped := p.dropped.Swap(0); dropped > 0 {
+		global.LoggerZap.Warn("Click queue full, events dropped", zap.Int64("dropped", dropped))
+	}
+
+	for p.healthy() && !p.spill.IsEmpty() {
+		n := p.spill.DequeueBatch(buf)
+		if n == 0 {
+			return
+		}
+		for i := 0; i < n; i++ {
+			p.send(buf[i])
+			buf[i] = nil
+		}
+	}
+}
+
+// watchErrors backs off from a failing producer and spills the click events it could not deliver,
+// so they are sent again once it recovers
+func (p *clickPublisher) watchErrors() {
+	for err := range p.producer.Errors() {
+		p.markUnhealthy()
+		global.LoggerZap.Error("Event producer error", zap.Error(err))
+
+		topic, value, ok := kafka.FailedMessage(err)
+		if !ok || topic != topics.LinkClicked {
+			continue
+		}
+		var evt linkv1.LinkClickedEvent
+		if err := json.Unmarshal(value, &evt); err != nil {
+			global.LoggerZap.Error("Failed to unmarshal undelivered click event", zap.Error(err))
+			continue
+		}
+		p.toSpill(&evt)
+	}
+}
@@ -0,0 +1,36 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-link/common/pkg/mq/kafka"
+
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+	"github.com/huynhanx03/GoLink/events-contract/topics"
+)
+
+type spikePublisher struct {
+	producer kafka.Producer
+}
+
+// NewSpikePublisher creates a publisher for link spike alerts
+func NewSpikePublisher(producer kafka.Producer) ports.SpikePublisher {
+	return &spikePublisher{
+		producer: producer,
+	}
+}
+
+func (p *spikePublisher) Publish(ctx context.Context, evt *linkv1.LinkSpikeEvent) {
+	value, err := json.Marshal(evt)
+	if err != nil {
+		global.LoggerZap.Error("Failed to marshal spike event", zap.Error(err))
+		return
+	}
+	p.producer.Publish(ctx, topics.LinkSpike, []byte(evt.ShortCode), value)
+}
@@ -2,10 +2,13 @@ package link
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"go-link/common/pkg/cdc"
-	"go-link/common/pkg/common/workerpool"
+	"go-link/common/pkg/hash"
 	"go-link/common/pkg/mq/kafka"
 	"go-link/common/pkg/utils"
 
@@ -19,29 +22,20 @@ import (
 	"github.com/huynhanx03/GoLink/events-contract/topics"
 )
 
-const (
-	poolSize = 10
-)
+// change pairs a parsed CDC payload with the record it came from, for the DLQ
+type change struct {
+	msg     *kafka.Message
+	payload *cdc.DebeziumPayload[entity.Link]
+}
 
 type CDCConsumer struct {
 	consumer    kafka.ConsumerGroup
 	linkService ports.LinkService
-	workerPool  *workerpool.GenericPool[[]*cdc.DebeziumPayload[entity.Link]]
+	dlqProducer kafka.SyncProducer
 }
 
-func NewCDCConsumer(cfg *kafka.Config, linkService ports.LinkService) (ports.LinkConsumer, error) {
-	c, err := kafka.NewConsumer(cfg, constant.ConsumerGroupLinkCDC, kafka.Recovery)
-	if err != nil {
-		return nil, err
-	}
-
-	taskFunc := func(batch []*cdc.DebeziumPayload[entity.Link]) {
-		if err := linkService.HandleLinkBatchChange(context.Background(), batch); err != nil {
-			global.LoggerZap.Error("Failed to process link batch", zap.Error(err))
-		}
-	}
-
-	pool, err := workerpool.NewGenericPool(poolSize, taskFunc)
+func NewCDCConsumer(cfg *kafka.Config, linkService ports.LinkService, dlqProducer kafka.SyncProducer) (ports.LinkConsumer, error) {
+	c, err := kafka.NewConsumer(cfg, constant.ConsumerGroupLinkCDC)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +43,7 @@ func NewCDCConsumer(cfg *kafka.Config, linkService ports.LinkService) (ports.Lin
 	return &CDCConsumer{
 		consumer:    c,
 		linkService: linkService,
-		workerPool:  pool,
+		dlqProducer: dlqProducer,
 	}, nil
 }
 
@@ -57,90 +51,104 @@ func NewCDCConsumer(cfg *kafka.Config, linkService ports.LinkService) (ports.Lin
 func (c *CDCConsumer) Start(ctx context.Context) error {
 	batchSize := global.Config.Kafka.ConsumerBatchSize
 	if batchSize <= 0 {
-		batchSize = 100
+		batchSize = constant.CDCBatchSize
 	}
 
 	batchInterval := utils.ToDurationMs(global.Config.Kafka.ConsumerBatchInterval)
 	if batchInterval <= 0 {
-		batchInterval = 500 * time.Millisecond
+		batchInterval = constant.CDCBatchInterval
 	}
 
-	batchChan := make(chan *cdc.DebeziumPayload[entity.Link], batchSize*2)
+	errHandler := func(err error) {
+		global.LoggerZap.Error("LinkCDCConsumer error", zap.Error(err))
+	}
 
-	go c.processBatchLoop(ctx, batchChan, batchSize, batchInterval)
+	global.LoggerZap.Info("Starting Link CDC Consumer (Batch Mode)", zap.String("topic", topics.LinkCDC))
+	return c.consumer.StartBatch(ctx, []string{topics.LinkCDC}, c.handleBatch, kafka.BatchConfig{
+		Size:     batchSize,
+		Interval: batchInterval,
+	}, errHandler)
+}
 
-	handler := func(ctx context.Context, key, value []byte) error {
-		_, payload, err := c.extractPayload(key, value)
+func (c *CDCConsumer) Stop() error {
+	return c.consumer.Close()
+}
+
+// handleBatch splits the batch by short code so every code is applied by exactly one
+// worker in offset order, and returns only once every partition is applied or dead-lettered.
+func (c *CDCConsumer) handleBatch(ctx context.Context, msgs []*kafka.Message) error {
+	partitions := make([][]*change, constant.CDCPartitions)
+
+	for _, msg := range msgs {
+		id, payload, err := c.extractPayload(msg.Key, msg.Value)
 		if err != nil {
 			global.LoggerZap.Warn("Skipping malformed CDC message", zap.Error(err))
-			return nil
+			continue
 		}
 
 		if payload == nil {
-			return nil
-		}
-
-		select {
-		case batchChan <- payload:
-		case <-ctx.Done():
-			return ctx.Err()
+			continue
 		}
 
-		return nil
+		_, h := hash.KeyToHash(id)
+		idx := h % constant.CDCPartitions
+		partitions[idx] = append(partitions[idx], &change{msg: msg, payload: payload})
 	}
 
-	errHandler := func(err error) {
-		global.LoggerZap.Error("LinkCDCConsumer error", zap.Error(err))
+	g, gctx := errgroup.WithContext(ctx)
+	for _, part := range partitions {
+		if len(part) == 0 {
+			continue
+		}
+		g.Go(func() error {
+			return c.apply(gctx, part)
+		})
 	}
 
-	global.LoggerZap.Info("Starting Link CDC Consumer (Batch Mode)", zap.String("topic", topics.LinkCDC))
-	return c.consumer.Start(ctx, []string{topics.LinkCDC}, handler, errHandler)
+	return g.Wait()
 }
 
-func (c *CDCConsumer) Stop() error {
-	return c.consumer.Close()
-}
+// apply retries a partition with backoff and dead-letters it once attempts run out
+func (c *CDCConsumer) apply(ctx context.Context, part []*change) error {
+	batch := make([]*cdc.DebeziumPayload[entity.Link], len(part))
+	for i, ch := range part {
+		batch[i] = ch.payload
+	}
 
-func (c *CDCConsumer) processBatchLoop(
-	ctx context.Context,
-	batchChan <-chan *cdc.DebeziumPayload[entity.Link],
-	batchSize int,
-	batchInterval time.Duration,
-) {
-	batch := make([]*cdc.DebeziumPayload[entity.Link], 0, batchSize)
-	ticker := time.NewTicker(batchInterval)
-	defer ticker.Stop()
-
-	flush := func() {
-		size := len(batch)
-		if size == 0 {
-			return
+	var err error
+	for attempt := 0; attempt < constant.CDCMaxAttempts; attempt++ {
+		if err = c.linkService.HandleLinkBatchChange(ctx, batch); err == nil {
+			return nil
 		}
 
-		finalBatch := make([]*cdc.DebeziumPayload[entity.Link], size)
-		copy(finalBatch, batch)
+		global.LoggerZap.Warn("Failed to apply link batch, retrying",
+			zap.Int("attempt", attempt+1),
+			zap.Int("size", len(batch)),
+			zap.Error(err),
+		)
 
-		if err := c.workerPool.Invoke(finalBatch); err != nil {
-			global.LoggerZap.Error("Failed to submit batch to worker pool", zap.Error(err))
+		select {
+		case <-time.After(utils.CalculateBackoffByAttempt(attempt, constant.CDCRetryBaseDelay, constant.CDCMaxAttempts)):
+		case <-ctx.Done():
+			return ctx.Err()
 		}
-
-		batch = batch[:0]
 	}
 
-	for {
-		select {
-		case msg := <-batchChan:
-			batch = append(batch, msg)
-			if len(batch) >= batchSize {
-				flush()
-			}
-		case <-ticker.C:
-			flush()
-		case <-ctx.Done():
-			flush()
-			return
+	return c.sendToDLQ(ctx, part, err)
+}
+
+// sendToDLQ publishes the original records to the dead letter topic.
+// An error here keeps the batch uncommitted so nothing is lost.
+func (c *CDCConsumer) sendToDLQ(ctx context.Context, part []*change, cause error) error {
+	global.LoggerZap.Error("Sending link batch to DLQ", zap.Int("size", len(part)), zap.Error(cause))
+
+	for _, ch := range part {
+		if _, _, err := c.dlqProducer.Publish(ctx, topics.LinkCDCDLQ, ch.msg.Key, ch.msg.Value); err != nil {
+			return fmt.Errorf("failed to publish to link cdc dlq: %w", err)
 		}
 	}
+
+	return nil
 }
 
 func (c *CDCConsumer) extractPayload(key, value []byte) (string, *cdc.DebeziumPayload[entity.Link], error) {
@@ -0,0 +1,52 @@
+package link
+
+import (
+	"testing"
+
+	"go-link/redirection/internal/core/mapper"
+)
+
+// A link disabled in Generation is rewritten as a whole row; the change must reach Redirection with the flag set
+func TestExtractPayloadDisabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{
+			name: "wrapped columns",
+			value: `{"payload":{"op":"u","ts_ms":1760900000000,"source":{"ts_ms":1760899999000},
+				"before":{"id":"abc","original_url":{"value":"https://example.com"},"tenant_id":{"value":1},"disabled":{"value":false}},
+				"after":{"id":"abc","original_url":{"value":"https://example.com"},"tenant_id":{"value":1},"disabled":{"value":true}}}}`,
+		},
+		{
+			name: "plain columns",
+			value: `{"op":"u","ts_ms":1760900000000,
+				"before":{"id":"abc","original_url":"https://example.com","tenant_id":1,"disabled":false},
+				"after":{"id":"abc","original_url":"https://example.com","tenant_id":1,"disabled":true}}`,
+		},
+	}
+
+	c := &CDCConsumer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, payload, err := c.extractPayload([]byte("abc"), []byte(tt.value))
+			if err != nil {
+				t.Fatalf("extractPayload: %v", err)
+			}
+			if id != "abc" {
+				t.Fatalf("expected id abc, got %q", id)
+			}
+
+			change := mapper.ToLinkChange(payload)
+			if change == nil || change.Deleted {
+				t.Fatalf("expected an upsert, got %+v", change)
+			}
+			if !change.Link.Disabled {
+				t.Error("expected the link disabled")
+			}
+			if payload.Before.SameState(change.Link) {
+				t.Error("expected disabling to count as a state change")
+			}
+		})
+	}
+}
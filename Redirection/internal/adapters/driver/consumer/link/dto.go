@@ -8,10 +8,18 @@ import (
 )
 
 type CDCLink struct {
-	ID          string    `json:"id"`
-	OriginalURL CDCString `json:"original_url"`
-	CreatedAt   CDCTime   `json:"created_at"`
-	UpdatedAt   CDCTime   `json:"updated_at"`
+	ID               string    `json:"id"`
+	OriginalURL      CDCString `json:"original_url"`
+	UserID           CDCInt    `json:"user_id"`
+	TenantID         CDCInt    `json:"tenant_id"`
+	ExpiresAt        CDCTime   `json:"expires_at"`
+	Disabled         CDCBool   `json:"disabled"`
+	PasswordHash     CDCString `json:"password_hash"`
+	RequireSignature CDCBool   `json:"require_signature"`
+	Visibility       CDCString `json:"visibility"`
+	AppendClickID    CDCBool   `json:"append_click_id"`
+	CreatedAt        CDCTime   `json:"created_at"`
+	UpdatedAt        CDCTime   `json:"updated_at"`
 }
 
 type CDCString struct {
@@ -37,6 +45,56 @@ func (s *CDCString) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+type CDCInt struct {
+	Value int
+}
+
+func (i *CDCInt) UnmarshalJSON(b []byte) error {
+	// Try plain number
+	var num int
+	if err := json.Unmarshal(b, &num); err == nil {
+		i.Value = num
+		return nil
+	}
+
+	// Try wrapped object {"value": ...}
+	var obj struct {
+		Value *int `json:"value"`
+	}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+	if obj.Value != nil {
+		i.Value = *obj.Value
+	}
+	return nil
+}
+
+type CDCBool struct {
+	Value bool
+}
+
+func (b *CDCBool) UnmarshalJSON(data []byte) error {
+	// Try plain bool
+	var v bool
+	if err := json.Unmarshal(data, &v); err == nil {
+		b.Value = v
+		return nil
+	}
+
+	// Try wrapped object {"value": ...}
+	var obj struct {
+		Value *bool `json:"value"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if obj.Value != nil {
+		b.Value = *obj.Value
+	}
+	return nil
+}
+
 type CDCTime struct {
 	time.Time
 }
@@ -67,10 +125,23 @@ func (t *CDCTime) UnmarshalJSON(b []byte) error {
 }
 
 func (c *CDCLink) ToEntity() *entity.Link {
+	var expiresAt *time.Time
+	if !c.ExpiresAt.IsZero() {
+		expiresAt = &c.ExpiresAt.Time
+	}
+
 	return &entity.Link{
-		ID:          c.ID,
-		OriginalURL: c.OriginalURL.Value,
-		CreatedAt:   c.CreatedAt.Time,
-		UpdatedAt:   c.UpdatedAt.Time,
+		ID:               c.ID,
+		OriginalURL:      c.OriginalURL.Value,
+		UserID:           c.UserID.Value,
+		TenantID:         c.TenantID.Value,
+		ExpiresAt:        expiresAt,
+		Disabled:         c.Disabled.Value,
+		PasswordHash:     c.PasswordHash.Value,
+		RequireSignature: c.RequireSignature.Value,
+		Visibility:       c.Visibility.Value,
+		AppendClickID:    c.AppendClickID.Value,
+		CreatedAt:        c.CreatedAt.Time,
+		UpdatedAt:        c.UpdatedAt.Time,
 	}
 }
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/ports"
+)
+
+type BrandingHandler interface {
+	GetBranding(ctx context.Context, req *dto.GetBrandingRequest) (*dto.BrandingResponse, error)
+	UpdateBranding(ctx context.Context, req *dto.UpdateBrandingRequest) (*dto.BrandingResponse, error)
+}
+
+type brandingHandler struct {
+	handler.BaseHandler
+	brandingService ports.BrandingService
+}
+
+func NewBrandingHandler(brandingService ports.BrandingService) BrandingHandler {
+	return &brandingHandler{
+		brandingService: brandingService,
+	}
+}
+
+// GetBranding returns the tenant's status page branding
+func (h *brandingHandler) GetBranding(ctx context.Context, req *dto.GetBrandingRequest) (*dto.BrandingResponse, error) {
+	return h.brandingService.GetBranding(ctx, req)
+}
+
+// UpdateBranding updates the tenant's status page branding
+func (h *brandingHandler) UpdateBranding(ctx context.Context, req *dto.UpdateBrandingRequest) (*dto.BrandingResponse, error) {
+	return h.brandingService.UpdateBranding(ctx, req)
+}
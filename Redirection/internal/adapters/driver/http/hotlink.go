@@ -0,0 +1,49 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/handler"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/common/http/validation"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/ports"
+)
+
+type HotLinkHandler interface {
+	GetTop(c *gin.Context)
+}
+
+type hotLinkHandler struct {
+	handler.BaseHandler
+	hotLinkService ports.HotLinkService
+}
+
+func NewHotLinkHandler(hotLinkService ports.HotLinkService) HotLinkHandler {
+	return &hotLinkHandler{
+		hotLinkService: hotLinkService,
+	}
+}
+
+// GetTop returns the live top-K of hot links; the limit comes in the query string
+func (h *hotLinkHandler) GetTop(c *gin.Context) {
+	var req dto.HotLinksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorResponse(c, response.CodeParamInvalid, apperr.New(response.CodeParamInvalid, err.Error(), 0, err))
+		return
+	}
+	if ok, msg := validation.IsRequestValid(req); !ok {
+		response.ErrorResponse(c, response.CodeParamInvalid, apperr.New(response.CodeValidationFailed, string(msg), 0, nil))
+		return
+	}
+
+	res, err := h.hotLinkService.GetTop(c.Request.Context(), &req)
+	if err != nil {
+		response.ErrorResponse(c, response.CodeInternalServer, err)
+		return
+	}
+
+	response.SuccessResponse(c, response.CodeSuccess, res)
+}
@@ -1,42 +1,288 @@
 package http
 
 import (
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"go-link/common/pkg/common/apperr"
 	"go-link/common/pkg/common/http/handler"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/security"
+
+	"go-link/redirection/internal/adapters/driver/http/pages"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/dto"
 	"go-link/redirection/internal/ports"
 )
 
 type LinkHandler interface {
 	Redirect(c *gin.Context)
+	AuthCallback(c *gin.Context)
 }
 
 type linkHandler struct {
 	handler.BaseHandler
-	linkService ports.LinkService
+	linkService       ports.LinkService
+	brandingService   ports.BrandingService
+	membershipService ports.MembershipService
 }
 
-func NewLinkHandler(linkService ports.LinkService) LinkHandler {
+func NewLinkHandler(linkService ports.LinkService, brandingService ports.BrandingService, membershipService ports.MembershipService) LinkHandler {
 	return &linkHandler{
-		linkService: linkService,
+		linkService:       linkService,
+		brandingService:   brandingService,
+		membershipService: membershipService,
 	}
 }
 
-// Redirect handles the redirection to original URL
+// Redirect handles the redirection to original URL.
+// POST carries the password form of a protected link.
 func (h *linkHandler) Redirect(c *gin.Context) {
 	shortCode := c.Param("shortCode")
 	if shortCode == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid short code"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": constant.MsgInvalidShortCode})
+		return
+	}
+
+	req := &dto.RedirectRequest{
+		ShortCode:      shortCode,
+		ClientIP:       c.ClientIP(),
+		UserAgent:      c.Request.UserAgent(),
+		Referrer:       c.Request.Referer(),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		UTMSource:      c.Query(constant.UTMSourceParam),
+		UTMMedium:      c.Query(constant.UTMMediumParam),
+		UTMContent:     c.Query(constant.UTMContentParam),
+		Expiry:         c.Query(security.SignedLinkParamExpiry),
+		Nonce:          c.Query(security.SignedLinkParamNonce),
+		Signature:      c.Query(security.SignedLinkParamSignature),
+		AccessToken:    h.accessToken(c),
+	}
+	if c.Request.Method == http.MethodPost {
+		req.Password = c.PostForm("password")
+	}
+
+	res, err := h.linkService.GetOriginalURL(c.Request.Context(), req)
+	if err != nil {
+		if h.redirectToLogin(c, err) {
+			return
+		}
+		h.renderError(c, shortCode, err)
+		return
+	}
+
+	if res.Preview {
+		c.Header("Cache-Control", "public, max-age=300")
+		h.renderHTML(c, http.StatusOK, func(c *gin.Context) error {
+			return pages.RenderPreview(c.Writer, res.URL)
+		})
+		return
+	}
+
+	if c.Request.Method == http.MethodPost {
+		c.Redirect(http.StatusSeeOther, res.URL)
+		return
+	}
+
+	c.Redirect(http.StatusFound, res.URL)
+}
+
+// AuthCallback receives the access token Identity posts back after sign-in,
+// keeps it in an HttpOnly cookie and sends the visitor on to the private link.
+// The posted state must match the nonce set by redirectToLogin, so a token posted from another site is refused.
+func (h *linkHandler) AuthCallback(c *gin.Context) {
+	state, _ := c.Cookie(constant.AuthStateCookie)
+	setStateCookie(c, "", -1)
+	posted := c.PostForm(constant.AuthStateField)
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(posted)) != 1 {
+		h.renderError(c, "", apperr.NewError("LinkHandler", response.CodeUnauthorized, constant.MsgLoginRequired, http.StatusUnauthorized, constant.ErrLoginRequired))
 		return
 	}
 
-	url, err := h.linkService.GetOriginalURL(c.Request.Context(), shortCode)
+	token := c.PostForm(constant.AuthAccessTokenField)
+	claims, err := h.membershipService.ParseAccessToken(token)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		h.renderError(c, "", apperr.NewError("LinkHandler", response.CodeUnauthorized, constant.MsgLoginRequired, http.StatusUnauthorized, constant.ErrLoginRequired))
+		return
+	}
+
+	maxAge := int(time.Until(claims.ExpiresAt.Time).Seconds())
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(h.membershipService.TokenCookie(), token, maxAge, "/", "", isSecure(c), true)
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusSeeOther, safeNext(c.Query(constant.AuthNextParam)))
+}
+
+// accessToken reads the Identity access token from the Authorization header, falling back to the cookie
+func (h *linkHandler) accessToken(c *gin.Context) string {
+	if header := c.GetHeader(constraints.HeaderAuthorization); header != "" {
+		if scheme, token, ok := strings.Cut(header, " "); ok && scheme == constraints.TokenTypeBearer {
+			return token
+		}
+	}
+
+	token, _ := c.Cookie(h.membershipService.TokenCookie())
+	return token
+}
+
+// redirectToLogin sends browsers that need to sign in for a private link to the Identity login page.
+// API clients, and deployments without a login page, get the regular error instead.
+func (h *linkHandler) redirectToLogin(c *gin.Context, err error) bool {
+	var appErr *apperr.AppError
+	if !errors.As(err, &appErr) || appErr.RootCause != constant.ErrLoginRequired {
+		return false
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) != gin.MIMEHTML {
+		return false
+	}
+
+	callback := url.URL{
+		Scheme:   scheme(c),
+		Host:     c.Request.Host,
+		Path:     constant.AuthCallbackPath,
+		RawQuery: url.Values{constant.AuthNextParam: {c.Request.URL.RequestURI()}}.Encode(),
+	}
+
+	state, err := security.NewNonce()
+	if err != nil {
+		return false
+	}
+
+	loginURL, ok := h.membershipService.LoginURL(callback.String(), state)
+	if !ok {
+		return false
+	}
+
+	setStateCookie(c, state, int(constant.AuthStateTTL.Seconds()))
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, loginURL)
+	return true
+}
+
+// safeNext only allows same-origin paths as the post-login destination
+func safeNext(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
+
+// setStateCookie scopes the login state to the callback. Identity posts back from another site,
+// which only SameSite=None cookies survive; plain-HTTP setups share a site with the login page, so Lax is enough.
+func setStateCookie(c *gin.Context, state string, maxAge int) {
+	secure := isSecure(c)
+	if secure {
+		c.SetSameSite(http.SameSiteNoneMode)
+	} else {
+		c.SetSameSite(http.SameSiteLaxMode)
+	}
+	c.SetCookie(constant.AuthStateCookie, state, maxAge, constant.AuthCallbackPath, "", secure, true)
+}
+
+func isSecure(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+func scheme(c *gin.Context) string {
+	if isSecure(c) {
+		return "https"
+	}
+	return "http"
+}
+
+// statusPage describes how a failed redirect is shown to the visitor
+type statusPage struct {
+	page    pages.Page
+	title   string
+	status  int
+	message string
+}
+
+var (
+	notFoundPage    = statusPage{pages.NotFound, "Link not found", http.StatusNotFound, constant.MsgLinkNotFound}
+	tooManyPage     = statusPage{pages.Error, "Too many requests", http.StatusTooManyRequests, constant.MsgTooManyMisses}
+	unavailablePage = statusPage{pages.Error, "Service unavailable", http.StatusServiceUnavailable, constant.MsgServiceUnavailable}
+	causeStatusPage = map[error]statusPage{
+		constant.ErrLinkExpired:      {pages.Expired, "Link expired", http.StatusGone, constant.MsgLinkExpired},
+		constant.ErrLinkDisabled:     {pages.Disabled, "Link disabled", http.StatusGone, constant.MsgLinkDisabled},
+		constant.ErrPasswordRequired: {pages.Password, "Password required", http.StatusUnauthorized, constant.MsgPasswordRequired},
+		constant.ErrInvalidPassword:  {pages.Password, "Password required", http.StatusUnauthorized, constant.MsgInvalidPassword},
+		constant.ErrInvalidSignature: {pages.Invalid, "Link not valid", http.StatusForbidden, constant.MsgInvalidSignature},
+		constant.ErrLinkUsed:         {pages.Used, "Link already used", http.StatusGone, constant.MsgLinkUsed},
+		constant.ErrLoginRequired:    {pages.Private, "Sign in required", http.StatusUnauthorized, constant.MsgLoginRequired},
+		constant.ErrNotTenantMember:  {pages.Private, "Private link", http.StatusForbidden, constant.MsgNotTenantMember},
+	}
+)
+
+// statusPageFor picks the page for a redirect error; anything unrecognised is a not found
+func statusPageFor(err error) statusPage {
+	var appErr *apperr.AppError
+	if !errors.As(err, &appErr) {
+		return notFoundPage
+	}
+
+	if appErr.HTTPStatus == http.StatusTooManyRequests {
+		return tooManyPage
+	}
+
+	if appErr.HTTPStatus == http.StatusServiceUnavailable {
+		return unavailablePage
+	}
+
+	if sp, ok := causeStatusPage[appErr.RootCause]; ok {
+		return sp
+	}
+
+	return notFoundPage
+}
+
+// renderError answers API clients with JSON and browsers with a status page,
+// branded when the request came in on a tenant's custom domain.
+func (h *linkHandler) renderError(c *gin.Context, shortCode string, err error) {
+	sp := statusPageFor(err)
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) != gin.MIMEHTML {
+		c.JSON(sp.status, gin.H{"error": sp.message})
 		return
 	}
 
-	c.Redirect(http.StatusFound, url)
+	data := &pages.StatusData{
+		Title:           sp.title,
+		Status:          sp.status,
+		Message:         sp.message,
+		ShortCode:       shortCode,
+		InvalidPassword: sp.message == constant.MsgInvalidPassword,
+	}
+
+	if branding := h.brandingService.ForHost(c.Request.Context(), c.Request.Host); branding != nil {
+		data.Brand = pages.Brand{
+			Name:            branding.Name,
+			LogoURL:         branding.LogoURL,
+			PrimaryColor:    branding.PrimaryColor,
+			BackgroundColor: branding.BackgroundColor,
+			FallbackURL:     branding.FallbackURL,
+		}
+	}
+
+	c.Header("Cache-Control", "no-store")
+	h.renderHTML(c, sp.status, func(c *gin.Context) error {
+		return pages.RenderStatus(c.Writer, sp.page, data)
+	})
+}
+
+func (h *linkHandler) renderHTML(c *gin.Context, status int, render func(c *gin.Context) error) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(status)
+	if err := render(c); err != nil {
+		_ = c.Error(err)
+	}
 }
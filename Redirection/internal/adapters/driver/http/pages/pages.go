@@ -0,0 +1,102 @@
+package pages
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"net/url"
+)
+
+// Page names a status page template.
+type Page string
+
+const (
+	NotFound Page = "not_found"
+	Expired  Page = "expired"
+	Disabled Page = "disabled"
+	Password Page = "password"
+	Used     Page = "used"
+	Invalid  Page = "invalid_signature"
+	Private  Page = "private"
+	Error    Page = "error"
+)
+
+const (
+	defaultBrandName       = "GoLink"
+	defaultPrimaryColor    = "#2563eb"
+	defaultBackgroundColor = "#f5f7fa"
+)
+
+//go:embed templates/*.html
+var files embed.FS
+
+var (
+	statusTemplates = map[Page]*template.Template{}
+	previewTemplate = template.Must(template.ParseFS(files, "templates/preview.html"))
+)
+
+func init() {
+	for _, page := range []Page{NotFound, Expired, Disabled, Password, Used, Invalid, Private, Error} {
+		statusTemplates[page] = template.Must(template.ParseFS(files, "templates/layout.html", "templates/"+string(page)+".html"))
+	}
+}
+
+// Brand is the look of a status page; empty fields fall back to the defaults.
+type Brand struct {
+	Name            string
+	LogoURL         string
+	PrimaryColor    string
+	BackgroundColor string
+	FallbackURL     string
+}
+
+// StatusData is what a status page renders.
+type StatusData struct {
+	Title           string
+	Status          int
+	Message         string
+	ShortCode       string
+	InvalidPassword bool
+	Brand           Brand
+}
+
+// RenderStatus writes the status page with the brand defaults applied.
+func RenderStatus(w io.Writer, page Page, data *StatusData) error {
+	tmpl, ok := statusTemplates[page]
+	if !ok {
+		tmpl = statusTemplates[Error]
+	}
+
+	if data.Brand.Name == "" {
+		data.Brand.Name = defaultBrandName
+	}
+	if data.Brand.PrimaryColor == "" {
+		data.Brand.PrimaryColor = defaultPrimaryColor
+	}
+	if data.Brand.BackgroundColor == "" {
+		data.Brand.BackgroundColor = defaultBackgroundColor
+	}
+
+	return tmpl.ExecuteTemplate(w, "layout", data)
+}
+
+type previewData struct {
+	URL   string
+	Title string
+	Site  string
+}
+
+// RenderPreview writes the OpenGraph-only card served to link unfurlers.
+// It carries no redirect, so previews neither follow the link nor count as visits.
+func RenderPreview(w io.Writer, destination string) error {
+	data := previewData{
+		URL:   destination,
+		Title: destination,
+	}
+	if u, err := url.Parse(destination); err == nil && u.Host != "" {
+		data.Site = u.Host
+		data.Title = u.Host + u.Path
+	}
+
+	return previewTemplate.Execute(w, data)
+}
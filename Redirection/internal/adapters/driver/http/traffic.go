@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+
+	"go-link/common/pkg/common/http/handler"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/ports"
+)
+
+type TrafficHandler interface {
+	GetSettings(ctx context.Context, req *dto.GetTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error)
+	UpdateSettings(ctx context.Context, req *dto.UpdateTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error)
+}
+
+type trafficHandler struct {
+	handler.BaseHandler
+	trafficService ports.TrafficService
+}
+
+func NewTrafficHandler(trafficService ports.TrafficService) TrafficHandler {
+	return &trafficHandler{
+		trafficService: trafficService,
+	}
+}
+
+// GetSettings returns the tenant's traffic settings
+func (h *trafficHandler) GetSettings(ctx context.Context, req *dto.GetTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error) {
+	return h.trafficService.GetSettings(ctx, req)
+}
+
+// UpdateSettings updates the tenant's traffic settings
+func (h *trafficHandler) UpdateSettings(ctx context.Context, req *dto.UpdateTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error) {
+	return h.trafficService.UpdateSettings(ctx, req)
+}
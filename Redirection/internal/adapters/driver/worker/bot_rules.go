@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+)
+
+type botRulesWorker struct {
+	classifier     ports.BotClassifier
+	reloadInterval time.Duration
+	windowInterval time.Duration
+	stopChan       chan struct{}
+}
+
+// NewBotRulesWorker creates a worker that reloads the bot rules file when it changes
+// and rolls the classifier's burst window.
+func NewBotRulesWorker(classifier ports.BotClassifier) ports.BotRulesWorker {
+	return &botRulesWorker{
+		classifier:     classifier,
+		reloadInterval: constant.BotRulesReloadInterval,
+		windowInterval: constant.BotBurstWindow,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+func (w *botRulesWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting bot rules worker", zap.Duration("interval", w.reloadInterval))
+
+	w.reload()
+
+	reloadTicker := time.NewTicker(w.reloadInterval)
+	defer reloadTicker.Stop()
+
+	windowTicker := time.NewTicker(w.windowInterval)
+	defer windowTicker.Stop()
+
+	for {
+		select {
+		case <-windowTicker.C:
+			w.classifier.ResetWindow()
+		case <-reloadTicker.C:
+			w.reload()
+		case <-w.stopChan:
+			global.LoggerZap.Info("Bot rules worker stopped")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *botRulesWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *botRulesWorker) reload() {
+	reloaded, err := w.classifier.Reload()
+	if err != nil {
+		global.LoggerZap.Error("Failed to reload bot rules, keeping the current rules", zap.Error(err))
+		return
+	}
+	if reloaded {
+		global.LoggerZap.Info("Bot rules reloaded", zap.String("path", constant.BotRulesPath))
+	}
+}
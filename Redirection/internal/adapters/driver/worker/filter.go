@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+)
+
+type filterWorker struct {
+	linkService ports.LinkService
+	interval    time.Duration
+	stopChan    chan struct{}
+}
+
+// NewFilterWorker creates a worker that periodically rebuilds the link existence filter from the database.
+func NewFilterWorker(linkService ports.LinkService) ports.LinkFilterWorker {
+	return &filterWorker{
+		linkService: linkService,
+		interval:    constant.LinkFilterRebuildInterval,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+func (w *filterWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting link filter worker", zap.Duration("interval", w.interval))
+
+	w.run(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.run(ctx)
+		case <-w.stopChan:
+			global.LoggerZap.Info("Link filter worker stopped")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *filterWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *filterWorker) run(ctx context.Context) {
+	start := time.Now()
+	if err := w.linkService.RebuildFilter(ctx); err != nil {
+		global.LoggerZap.Error("Failed to rebuild link filter", zap.Error(err))
+		return
+	}
+	global.LoggerZap.Info("Link filter rebuilt", zap.Duration("took", time.Since(start)))
+}
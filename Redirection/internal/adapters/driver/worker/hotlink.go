@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+)
+
+type hotLinkWorker struct {
+	hotLinkService ports.HotLinkService
+	interval       time.Duration
+	stopChan       chan struct{}
+}
+
+// NewHotLinkWorker creates a worker that closes a hot link tracking window on every tick.
+func NewHotLinkWorker(hotLinkService ports.HotLinkService) ports.HotLinkWorker {
+	return &hotLinkWorker{
+		hotLinkService: hotLinkService,
+		interval:       constant.HotLinkWindow,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+func (w *hotLinkWorker) Start(ctx context.Context) error {
+	global.LoggerZap.Info("Starting hot link worker", zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.hotLinkService.Rotate(ctx); err != nil {
+				global.LoggerZap.Error("Failed to rotate hot links", zap.Error(err))
+			}
+		case <-w.stopChan:
+			global.LoggerZap.Info("Hot link worker stopped")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *hotLinkWorker) Stop() {
+	close(w.stopChan)
+}
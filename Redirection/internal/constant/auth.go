@@ -0,0 +1,16 @@
+package constant
+
+import "time"
+
+// Login round trip for private links: the visitor is sent to the Identity login page with a state nonce,
+// which Identity posts back to the callback with the access token; the token is kept only if the state matches.
+const (
+	AuthCallbackPath         = "/auth/callback"
+	AuthRedirectURIParam     = "redirect_uri"
+	AuthNextParam            = "next"
+	AuthAccessTokenField     = "access_token"
+	AuthStateField           = "state"
+	AuthStateCookie          = "golink_auth_state"
+	AuthStateTTL             = 10 * time.Minute
+	DefaultAccessTokenCookie = "golink_access_token"
+)
@@ -0,0 +1,14 @@
+package constant
+
+import "time"
+
+const (
+	// BotRulesPath overrides the embedded bot rules when present
+	BotRulesPath           = "./config/bot_rules.txt"
+	BotRulesReloadInterval = 1 * time.Minute
+
+	BotBurstWindow    = 10 * time.Second
+	BotBurstCounters  = 1 << 16
+	BotBurstShards    = 32 // power of two; each shard counts its own share of client IPs
+	BotBurstThreshold = 12 // redirects per client IP within a window, sketch counters saturate at 15
+)
@@ -3,6 +3,49 @@ package constant
 import "time"
 
 const (
-	LinkCachePrefix = "link::"
-	LinkCacheTTL    = 1 * time.Hour
+	LinkCachePrefix  = "link::"
+	LinkCacheTTL     = 1 * time.Hour
+	LinkCacheSoftTTL = 10 * time.Minute
+
+	LinkLoadLockPrefix   = "link:lock::"
+	LinkLoadLockTTL      = 5 * time.Second
+	LinkLoadWaitTimeout  = 300 * time.Millisecond
+	LinkLoadWaitInterval = 20 * time.Millisecond
+	LinkRefreshTimeout   = 5 * time.Second
+
+	LinkMissCachePrefix = "link:miss::"
+	LinkMissCacheTTL    = 30 * time.Second
+	LinkMissCacheValue  = 1
+
+	MissRateLimitPrefix = "ratelimit:miss::"
+
+	LinkNonceCachePrefix = "link:nonce::"
+	LinkNonceCacheValue  = 1
+
+	// Running click totals live in Redis so a rebuild of the redirect keyspace can still warm the busiest links
+	LinkClicksKey        = "link:clicks"
+	LinkClicksMaxTracked = 100_000
+)
+
+const (
+	TrafficSettingsCachePrefix = "tenant:traffic::"
+	TrafficSettingsCacheTTL    = 5 * time.Minute
+	TrafficSettingsLocalTTL    = 30 * time.Second // in-process copy read on every automated click
+)
+
+const (
+	MembershipCachePrefix  = "tenant:member::"
+	MembershipCacheTTL     = 5 * time.Minute
+	NonMembershipCacheTTL  = 1 * time.Minute
+	MembershipCheckTimeout = 500 * time.Millisecond
+)
+
+const (
+	BrandingCachePrefix = "tenant:branding::"
+	BrandingCacheTTL    = 10 * time.Minute
+
+	DomainTenantCachePrefix = "domain:tenant::"
+	DomainTenantCacheTTL    = 10 * time.Minute
+	DomainMissCacheTTL      = 1 * time.Minute
+	DomainResolveTimeout    = 300 * time.Millisecond
 )
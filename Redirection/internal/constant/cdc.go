@@ -0,0 +1,11 @@
+package constant
+
+import "time"
+
+const (
+	CDCPartitions     = 10
+	CDCMaxAttempts    = 3
+	CDCRetryBaseDelay = 200 * time.Millisecond
+	CDCBatchSize      = 100
+	CDCBatchInterval  = 500 * time.Millisecond
+)
@@ -0,0 +1,22 @@
+package constant
+
+import "time"
+
+const (
+	ClickStripeSize         = 256
+	ClickBatchQueueSize     = 256
+	ClickFlushInterval      = 200 * time.Millisecond
+	ClickSpillCapacity      = 65_536
+	ClickSpillDrainInterval = 1 * time.Second
+	ClickSpillDrainBatch    = 512
+	ClickPublishTimeout     = 50 * time.Millisecond
+	ClickProducerBackoff    = 5 * time.Second
+)
+
+// UTM parameters read off the short link visit itself; they take precedence over the destination's
+const (
+	UTMSourceParam = "utm_source"
+	UTMMediumParam = "utm_medium"
+	// UTMContentParam names the A/B variant being served, e.g. two links to one page with utm_content=a and b
+	UTMContentParam = "utm_content"
+)
@@ -0,0 +1,37 @@
+package constant
+
+import "errors"
+
+const (
+	MsgLinkNotFound     = "link not found"
+	MsgTooManyMisses    = "too many requests for unknown links"
+	MsgInvalidShortCode = "invalid short code"
+	MsgLinkExpired      = "link has expired"
+	MsgLinkDisabled     = "link is disabled"
+	MsgPasswordRequired = "password required"
+	MsgInvalidPassword  = "incorrect password"
+	MsgInvalidSignature = "invalid link signature"
+	MsgLinkUsed         = "link has already been used"
+	MsgConsumeNonce     = "failed to consume link nonce"
+
+	MsgLoginRequired      = "sign in to open this link"
+	MsgNotTenantMember    = "this link is private to its team"
+	MsgNotAccessToken     = "token is not an access token"
+	MsgServiceUnavailable = "service temporarily unavailable, please try again"
+)
+
+const (
+	MsgTenantRequired = "tenant is required"
+)
+
+// Sentinel causes that tell the redirect handler which status page to render
+var (
+	ErrLinkExpired      = errors.New(MsgLinkExpired)
+	ErrLinkDisabled     = errors.New(MsgLinkDisabled)
+	ErrPasswordRequired = errors.New(MsgPasswordRequired)
+	ErrInvalidPassword  = errors.New(MsgInvalidPassword)
+	ErrInvalidSignature = errors.New(MsgInvalidSignature)
+	ErrLinkUsed         = errors.New(MsgLinkUsed)
+	ErrLoginRequired    = errors.New(MsgLoginRequired)
+	ErrNotTenantMember  = errors.New(MsgNotTenantMember)
+)
@@ -0,0 +1,24 @@
+package constant
+
+import "time"
+
+// Read-your-writes fallback: a miss is looked up in Generation, since CDC may not have delivered the row yet.
+// A short code says nothing about when its link was created (codes wait in Generation's pool), so every miss
+// qualifies; the lookups are capped across instances and a confirmed miss is cached.
+const (
+	LinkFallbackTimeout = 300 * time.Millisecond
+	LinkFallbackKey     = "fallback:"
+
+	LinkFallbackRateKey    = "ratelimit:fallback"
+	LinkFallbackRateMax    = 200
+	LinkFallbackRateWindow = 1 * time.Second
+)
+
+// Outcomes of a Generation fallback lookup, as counted in the link metrics
+const (
+	FallbackOutcomeHit   = "hit"
+	FallbackOutcomeMiss  = "miss"
+	FallbackOutcomeError = "error"
+	// The lookup was skipped because the shared budget for the window was spent
+	FallbackOutcomeLimited = "limited"
+)
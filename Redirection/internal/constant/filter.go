@@ -0,0 +1,13 @@
+package constant
+
+import "time"
+
+const (
+	LinkFilterCapacity        = 10_000_000
+	LinkFilterFPRate          = 0.01
+	LinkFilterRebuildInterval = 6 * time.Hour
+	LinkFilterScanPageSize    = 5_000
+
+	MissRateLimitMax    = 60
+	MissRateLimitWindow = 1 * time.Minute
+)
@@ -0,0 +1,17 @@
+package constant
+
+import "time"
+
+const (
+	HotLinkWindow         = 10 * time.Second
+	HotLinkSketchCounters = 1 << 20
+	HotLinkAdmitThreshold = 8 // sketch estimate within a window before a code is counted exactly
+	HotLinkMaxCandidates  = 10_000
+	HotLinkShards         = 64 // power of two; each shard holds its own share of the counters and candidates
+	HotLinkTopK           = 100
+	HotLinkPinMinClicks   = 50
+	HotLinkSpikeFactor    = 5
+	HotLinkSpikeMinClicks = 500
+	HotLinkSpikeCooldown  = 10 * time.Minute
+	HotLinkDefaultLimit   = 20
+)
@@ -0,0 +1,17 @@
+package constant
+
+import "time"
+
+const (
+	RebuildScanPageSize      = 1_000
+	RebuildDefaultRanges     = 256
+	RebuildDefaultWorkers    = 8
+	RebuildDefaultBatchSize  = 50
+	RebuildDefaultSampleSize = 20
+	RebuildCheckpointEvery   = 1 * time.Second
+	RebuildDefaultCheckpoint = "./storages/rebuild.checkpoint.json"
+	RebuildDefaultSource     = "generation_ks"
+
+	RebuildModeRebuild = "rebuild"
+	RebuildModeDiff    = "diff"
+)
@@ -0,0 +1,20 @@
+package dto
+
+type GetBrandingRequest struct{}
+
+type UpdateBrandingRequest struct {
+	Name            string `json:"name" validate:"omitempty,max=100"`
+	LogoURL         string `json:"logo_url" validate:"omitempty,url,max=2048"`
+	PrimaryColor    string `json:"primary_color" validate:"omitempty,hexcolor"`
+	BackgroundColor string `json:"background_color" validate:"omitempty,hexcolor"`
+	FallbackURL     string `json:"fallback_url" validate:"omitempty,url,max=2048"`
+}
+
+type BrandingResponse struct {
+	TenantID        int    `json:"tenant_id"`
+	Name            string `json:"name"`
+	LogoURL         string `json:"logo_url"`
+	PrimaryColor    string `json:"primary_color"`
+	BackgroundColor string `json:"background_color"`
+	FallbackURL     string `json:"fallback_url"`
+}
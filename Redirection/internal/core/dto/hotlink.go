@@ -0,0 +1,16 @@
+package dto
+
+type HotLinksRequest struct {
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type HotLinkResponse struct {
+	ShortCode  string `json:"short_code"`
+	Clicks     int64  `json:"clicks"`
+	PrevClicks int64  `json:"prev_clicks"`
+}
+
+type HotLinksResponse struct {
+	WindowSeconds int64              `json:"window_seconds"`
+	Links         []*HotLinkResponse `json:"links"`
+}
@@ -7,3 +7,28 @@ type CreateLinkRequest struct {
 type LinkResponse struct {
 	ShortLink string `json:"short_link"`
 }
+
+type RedirectRequest struct {
+	ShortCode      string
+	ClientIP       string
+	UserAgent      string
+	Referrer       string
+	AcceptLanguage string
+	Password       string
+	// UTM parameters on the short link itself, e.g. when it is shared as ?utm_source=newsletter
+	UTMSource  string
+	UTMMedium  string
+	UTMContent string
+	// Signed-link query parameters, empty for plain visits
+	Expiry    string
+	Nonce     string
+	Signature string
+	// AccessToken is the Identity access token presented for private links
+	AccessToken string
+}
+
+type RedirectResponse struct {
+	URL string
+	// Preview asks for an OpenGraph card instead of a redirect, for link unfurlers
+	Preview bool
+}
@@ -0,0 +1,35 @@
+package dto
+
+type RebuildRequest struct {
+	Ranges    int  `json:"ranges"`
+	Workers   int  `json:"workers"`
+	BatchSize int  `json:"batch_size"`
+	Resume    bool `json:"resume"`
+	WarmTop   int  `json:"warm_top"`
+}
+
+type RebuildReport struct {
+	Ranges   int    `json:"ranges"`
+	Resumed  bool   `json:"resumed"`
+	Loaded   int64  `json:"loaded"`
+	Warmed   int    `json:"warmed"`
+	Duration string `json:"duration"`
+}
+
+type DiffRequest struct {
+	Ranges     int `json:"ranges"`
+	Workers    int `json:"workers"`
+	SampleSize int `json:"sample_size"`
+}
+
+type DiffReport struct {
+	Source        int64    `json:"source"`
+	Target        int64    `json:"target"`
+	Missing       int64    `json:"missing"`
+	Changed       int64    `json:"changed"`
+	Extra         int64    `json:"extra"`
+	MissingSample []string `json:"missing_sample,omitempty"`
+	ChangedSample []string `json:"changed_sample,omitempty"`
+	ExtraSample   []string `json:"extra_sample,omitempty"`
+	Duration      string   `json:"duration"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type GetTrafficSettingsRequest struct{}
+
+type UpdateTrafficSettingsRequest struct {
+	ExcludeBots *bool `json:"exclude_bots" validate:"required"`
+}
+
+type TrafficSettingsResponse struct {
+	TenantID    int  `json:"tenant_id"`
+	ExcludeBots bool `json:"exclude_bots"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+// Branding is how a tenant's status pages look on its custom domains.
+type Branding struct {
+	TenantID        int    `json:"tenant_id"`
+	Name            string `json:"name"`
+	LogoURL         string `json:"logo_url"`
+	PrimaryColor    string `json:"primary_color"`
+	BackgroundColor string `json:"background_color"`
+	FallbackURL     string `json:"fallback_url"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+// LinkChange is a CDC change reduced to the final state of one short code.
+// Version is the Debezium event time in milliseconds and becomes the write timestamp,
+// so replays and late arrivals never override a newer state.
+type LinkChange struct {
+	Link    *Link
+	Deleted bool
+	Version int64
+}
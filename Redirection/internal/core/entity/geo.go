@@ -0,0 +1,11 @@
+package entity
+
+// GeoLocation is where a client IP was located, at city level.
+type GeoLocation struct {
+	CountryCode string
+	Region      string
+	City        string
+	Latitude    float64
+	Longitude   float64
+	HasLocation bool
+}
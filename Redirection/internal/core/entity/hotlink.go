@@ -0,0 +1,14 @@
+package entity
+
+// HotLink is a short code with its click count over the last tracking window.
+type HotLink struct {
+	ShortCode  string `json:"short_code"`
+	Clicks     int64  `json:"clicks"`
+	PrevClicks int64  `json:"prev_clicks"`
+}
+
+// HotLinkWindow is the result of closing a tracking window.
+type HotLinkWindow struct {
+	Top    []*HotLink
+	Spikes []*HotLink
+}
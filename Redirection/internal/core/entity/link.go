@@ -1,12 +1,104 @@
 package entity
 
 import (
+	"net/url"
 	"time"
 )
 
+// UTM parameters of the destination that clicks are attributed by
+const (
+	utmCampaignParam = "utm_campaign"
+	utmSourceParam   = "utm_source"
+	utmMediumParam   = "utm_medium"
+	utmContentParam  = "utm_content"
+)
+
+// Link visibility: private links only resolve for signed-in members of the owning tenant
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
 type Link struct {
-	ID          string    `json:"id"`
-	OriginalURL string    `json:"original_url"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           string     `json:"id"`
+	OriginalURL  string     `json:"original_url"`
+	UserID       int        `json:"user_id"`
+	TenantID     int        `json:"tenant_id"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Disabled     bool       `json:"disabled"`
+	PasswordHash string     `json:"password_hash,omitempty"`
+	// RequireSignature rejects visits that don't carry a valid signed-link query
+	RequireSignature bool   `json:"require_signature"`
+	Visibility       string `json:"visibility"`
+	// AppendClickID tags the destination with a glclid click ID so conversions can be attributed
+	AppendClickID bool      `json:"append_click_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// IsExpired reports whether the link has passed its expiry time
+func (l *Link) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// NeedsSignature reports whether the visit has to be checked against a signed-link query
+func (l *Link) NeedsSignature(signature string) bool {
+	return l.RequireSignature || signature != ""
+}
+
+// SameState reports whether both copies would resolve a visit the same way; timestamps are ignored
+func (l *Link) SameState(o *Link) bool {
+	if l.OriginalURL != o.OriginalURL ||
+		l.UserID != o.UserID ||
+		l.TenantID != o.TenantID ||
+		l.Disabled != o.Disabled ||
+		l.PasswordHash != o.PasswordHash ||
+		l.RequireSignature != o.RequireSignature ||
+		l.Visibility != o.Visibility ||
+		l.AppendClickID != o.AppendClickID {
+		return false
+	}
+	if l.ExpiresAt == nil || o.ExpiresAt == nil {
+		return l.ExpiresAt == nil && o.ExpiresAt == nil
+	}
+	// Scylla keeps timestamps at millisecond precision
+	return l.ExpiresAt.UnixMilli() == o.ExpiresAt.UnixMilli()
+}
+
+// Campaign returns the utm_campaign of the destination, used to group clicks and conversions
+func (l *Link) Campaign() string {
+	return l.utm(utmCampaignParam)
+}
+
+// UTMSource returns the utm_source of the destination
+func (l *Link) UTMSource() string {
+	return l.utm(utmSourceParam)
+}
+
+// UTMMedium returns the utm_medium of the destination
+func (l *Link) UTMMedium() string {
+	return l.utm(utmMediumParam)
+}
+
+// Variant returns the utm_content of the destination, which names the A/B variant the link serves
+func (l *Link) Variant() string {
+	return l.utm(utmContentParam)
+}
+
+func (l *Link) utm(param string) string {
+	u, err := url.Parse(l.OriginalURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get(param)
+}
+
+// IsPrivate reports whether only members of the owning tenant may follow the link
+func (l *Link) IsPrivate() bool {
+	return l.Visibility == VisibilityPrivate
+}
+
+// HasPassword reports whether visitors must enter a password before being redirected
+func (l *Link) HasPassword() bool {
+	return l.PasswordHash != ""
 }
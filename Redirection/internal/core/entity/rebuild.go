@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// RebuildCheckpoint records how far a store rebuild got through each token range, so an interrupted run can resume.
+type RebuildCheckpoint struct {
+	Ranges    []*RebuildRange `json:"ranges"`
+	Loaded    int64           `json:"loaded"`
+	StartedAt time.Time       `json:"started_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// RebuildRange is one token range of a rebuild; Next is the first token still to be copied.
+type RebuildRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	Next  int64 `json:"next"`
+	Done  bool  `json:"done"`
+}
+
+// Pending counts the ranges that haven't been fully copied yet
+func (c *RebuildCheckpoint) Pending() int {
+	n := 0
+	for _, r := range c.Ranges {
+		if !r.Done {
+			n++
+		}
+	}
+	return n
+}
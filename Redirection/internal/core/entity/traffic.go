@@ -0,0 +1,28 @@
+package entity
+
+// TrafficClass tells who is behind a click.
+type TrafficClass string
+
+const (
+	TrafficHuman   TrafficClass = "human"
+	TrafficBot     TrafficClass = "bot"
+	TrafficPreview TrafficClass = "preview"
+)
+
+// IsHuman reports whether the click comes from a person rather than an automated client.
+func (c TrafficClass) IsHuman() bool {
+	return c == TrafficHuman
+}
+
+// ClickSignal is what the classifier gets to look at for a single redirect.
+type ClickSignal struct {
+	ClientIP       string
+	UserAgent      string
+	AcceptLanguage string
+}
+
+// TrafficSettings are the per-tenant traffic preferences.
+type TrafficSettings struct {
+	TenantID    int  `json:"tenant_id"`
+	ExcludeBots bool `json:"exclude_bots"`
+}
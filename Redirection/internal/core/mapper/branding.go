@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+)
+
+func ToBrandingEntityFromReq(tenantID int, req *dto.UpdateBrandingRequest) *entity.Branding {
+	return &entity.Branding{
+		TenantID:        tenantID,
+		Name:            req.Name,
+		LogoURL:         req.LogoURL,
+		PrimaryColor:    req.PrimaryColor,
+		BackgroundColor: req.BackgroundColor,
+		FallbackURL:     req.FallbackURL,
+	}
+}
+
+func ToBrandingResponse(b *entity.Branding) *dto.BrandingResponse {
+	return &dto.BrandingResponse{
+		TenantID:        b.TenantID,
+		Name:            b.Name,
+		LogoURL:         b.LogoURL,
+		PrimaryColor:    b.PrimaryColor,
+		BackgroundColor: b.BackgroundColor,
+		FallbackURL:     b.FallbackURL,
+	}
+}
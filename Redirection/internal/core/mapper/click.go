@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+// ToLinkClickedEvent builds the click event for a resolved redirect.
+// The client IP is never emitted as is, only hashed with ipSalt and as the location looked up from it.
+func ToLinkClickedEvent(req *dto.RedirectRequest, l *entity.Link, traffic entity.TrafficClass, at time.Time, clickID string, geo *entity.GeoLocation, ipSalt string) *linkv1.LinkClickedEvent {
+	evt := &linkv1.LinkClickedEvent{
+		EventID:        newEventID(),
+		ShortCode:      l.ID,
+		TenantID:       l.TenantID,
+		Timestamp:      at.UnixMilli(),
+		IPHash:         hashIP(ipSalt, req.ClientIP),
+		UserAgent:      req.UserAgent,
+		Referrer:       req.Referrer,
+		AcceptLanguage: req.AcceptLanguage,
+		Traffic:        string(traffic),
+		Variant:        ToVariant(req, l),
+		Campaign:       l.Campaign(),
+		UTMSource:      firstNonEmpty(req.UTMSource, l.UTMSource()),
+		UTMMedium:      firstNonEmpty(req.UTMMedium, l.UTMMedium()),
+		ClickID:        clickID,
+	}
+
+	if geo != nil {
+		evt.Country = geo.CountryCode
+		evt.Region = geo.Region
+		evt.City = geo.City
+		if geo.HasLocation {
+			evt.Latitude = geo.Latitude
+			evt.Longitude = geo.Longitude
+		}
+	}
+	return evt
+}
+
+// ToVariant returns the A/B variant served by this visit: the utm_content of the short link visit, else of the destination
+func ToVariant(req *dto.RedirectRequest, l *entity.Link) string {
+	return firstNonEmpty(req.UTMContent, l.Variant())
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+func hashIP(salt, ip string) string {
+	if ip == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(salt + ip))
+	return hex.EncodeToString(sum[:16])
+}
+
+func newEventID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"time"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+)
+
+func ToHotLinksResponse(links []*entity.HotLink, window time.Duration) *dto.HotLinksResponse {
+	res := &dto.HotLinksResponse{
+		WindowSeconds: int64(window.Seconds()),
+		Links:         make([]*dto.HotLinkResponse, len(links)),
+	}
+	for i, h := range links {
+		res.Links[i] = &dto.HotLinkResponse{
+			ShortCode:  h.ShortCode,
+			Clicks:     h.Clicks,
+			PrevClicks: h.PrevClicks,
+		}
+	}
+	return res
+}
@@ -1,6 +1,14 @@
 package mapper
 
 import (
+	"net/url"
+	"time"
+
+	generationv1 "go-link/common/gen/go/generation/v1"
+	"go-link/common/pkg/cdc"
+	"go-link/common/pkg/security"
+	"go-link/common/pkg/utils"
+
 	"go-link/redirection/internal/constant"
 	"go-link/redirection/internal/core/dto"
 	"go-link/redirection/internal/core/entity"
@@ -11,3 +19,65 @@ func ToLinkResponse(l *entity.Link) *dto.LinkResponse {
 		ShortLink: constant.URL + "/" + l.ID,
 	}
 }
+
+func ToRedirectResponse(l *entity.Link, traffic entity.TrafficClass, clickID string) *dto.RedirectResponse {
+	res := &dto.RedirectResponse{
+		URL:     l.OriginalURL,
+		Preview: traffic == entity.TrafficPreview,
+	}
+	if clickID != "" {
+		// A destination we can't parse is still followed, just without attribution
+		if u, err := utils.SetQueryParams(l.OriginalURL, url.Values{security.ClickIDParam: {clickID}}); err == nil {
+			res.URL = u
+		}
+	}
+	return res
+}
+
+// ToShortLinkPreview builds a preview card that points at the short link instead of the destination,
+// for links whose destination must only be revealed to the visitor holding the signed URL
+func ToShortLinkPreview(l *entity.Link) *dto.RedirectResponse {
+	return &dto.RedirectResponse{
+		URL:     "https://" + constant.URL + "/" + l.ID,
+		Preview: true,
+	}
+}
+
+// ToLinkFromProto converts a link read from Generation
+func ToLinkFromProto(l *generationv1.Link) *entity.Link {
+	link := &entity.Link{
+		ID:               l.Id,
+		OriginalURL:      l.OriginalUrl,
+		UserID:           int(l.UserId),
+		TenantID:         int(l.TenantId),
+		Disabled:         l.Disabled,
+		PasswordHash:     l.PasswordHash,
+		RequireSignature: l.RequireSignature,
+		Visibility:       l.Visibility,
+		AppendClickID:    l.AppendClickId,
+		CreatedAt:        time.UnixMilli(l.CreatedAt),
+		UpdatedAt:        time.UnixMilli(l.UpdatedAt),
+	}
+	if l.ExpiresAt > 0 {
+		expiresAt := time.UnixMilli(l.ExpiresAt)
+		link.ExpiresAt = &expiresAt
+	}
+	return link
+}
+
+// ToLinkChange converts a CDC event into a versioned change, or nil if it carries no row
+func ToLinkChange(payload *cdc.DebeziumPayload[entity.Link]) *entity.LinkChange {
+	switch payload.Op {
+	case cdc.OpCreate, cdc.OpUpdate, cdc.OpRead:
+		if payload.After == nil {
+			return nil
+		}
+		return &entity.LinkChange{Link: payload.After, Version: payload.TsMs}
+	case cdc.OpDelete:
+		if payload.Before == nil {
+			return nil
+		}
+		return &entity.LinkChange{Link: payload.Before, Deleted: true, Version: payload.TsMs}
+	}
+	return nil
+}
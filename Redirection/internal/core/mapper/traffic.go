@@ -0,0 +1,13 @@
+package mapper
+
+import (
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+)
+
+func ToTrafficSettingsResponse(s *entity.TrafficSettings) *dto.TrafficSettingsResponse {
+	return &dto.TrafficSettingsResponse{
+		TenantID:    s.TenantID,
+		ExcludeBots: s.ExcludeBots,
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	identityv1 "go-link/common/gen/go/identity/v1"
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/database/widecolumn"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/core/mapper"
+	"go-link/redirection/internal/ports"
+)
+
+const brandingServiceName = "BrandingService"
+
+type brandingService struct {
+	brandingRepo   ports.BrandingRepository
+	brandingCache  ports.BrandingCacheRepository
+	identityClient identityv1.IdentityServiceClient
+}
+
+func NewBrandingService(
+	brandingRepo ports.BrandingRepository,
+	brandingCache ports.BrandingCacheRepository,
+	identityClient identityv1.IdentityServiceClient,
+) ports.BrandingService {
+	return &brandingService{
+		brandingRepo:   brandingRepo,
+		brandingCache:  brandingCache,
+		identityClient: identityClient,
+	}
+}
+
+// GetBranding returns the branding of the caller's tenant
+func (s *brandingService) GetBranding(ctx context.Context, _ *dto.GetBrandingRequest) (*dto.BrandingResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(brandingServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	branding, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(brandingServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToBrandingResponse(branding), nil
+}
+
+// UpdateBranding saves the branding of the caller's tenant
+func (s *brandingService) UpdateBranding(ctx context.Context, req *dto.UpdateBrandingRequest) (*dto.BrandingResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(brandingServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	branding := mapper.ToBrandingEntityFromReq(tenantID, req)
+	if err := s.brandingRepo.Save(ctx, branding); err != nil {
+		return nil, apperr.NewError(brandingServiceName, response.CodeDatabaseError, apperr.MsgSaveFailed, http.StatusInternalServerError, err)
+	}
+
+	if err := s.brandingCache.Delete(ctx, tenantID); err != nil {
+		global.LoggerZap.Error("Failed to invalidate branding cache", zap.Int("tenantID", tenantID), zap.Error(err))
+	}
+
+	return mapper.ToBrandingResponse(branding), nil
+}
+
+// ForHost resolves the request host to a tenant through Identity and returns its branding.
+// Any failure falls back to the default pages rather than failing the request.
+func (s *brandingService) ForHost(ctx context.Context, host string) *entity.Branding {
+	host = normalizeHost(host)
+	if host == "" || host == constant.URL {
+		return nil
+	}
+
+	tenantID := s.resolveDomain(ctx, host)
+	if tenantID == 0 {
+		return nil
+	}
+
+	branding, err := s.load(ctx, tenantID)
+	if err != nil {
+		global.LoggerZap.Warn("Failed to load branding", zap.Int("tenantID", tenantID), zap.Error(err))
+		return nil
+	}
+
+	return branding
+}
+
+// resolveDomain maps a custom domain to its tenant; unknown and unverified domains resolve to 0
+func (s *brandingService) resolveDomain(ctx context.Context, host string) int {
+	if tenantID, ok := s.brandingCache.GetDomainTenant(ctx, host); ok {
+		return tenantID
+	}
+
+	if s.identityClient == nil {
+		return 0
+	}
+
+	rctx, cancel := context.WithTimeout(ctx, constant.DomainResolveTimeout)
+	defer cancel()
+
+	tenantID := 0
+	resp, err := s.identityClient.ResolveDomain(rctx, &identityv1.ResolveDomainRequest{Domain: host})
+	switch {
+	case err != nil:
+		// Identity reports unknown domains as errors too; cache them briefly either way
+		global.LoggerZap.Debug("Failed to resolve domain", zap.String("host", host), zap.Error(err))
+	case resp.IsVerified:
+		tenantID = int(resp.TenantId)
+	}
+
+	if err := s.brandingCache.SetDomainTenant(ctx, host, tenantID); err != nil {
+		global.LoggerZap.Error("Failed to set domain tenant in cache", zap.Error(err))
+	}
+
+	return tenantID
+}
+
+// load reads the branding through the cache; tenants without a row get an empty branding
+func (s *brandingService) load(ctx context.Context, tenantID int) (*entity.Branding, error) {
+	if branding, err := s.brandingCache.Get(ctx, tenantID); err == nil {
+		return branding, nil
+	}
+
+	branding, err := s.brandingRepo.Get(ctx, tenantID)
+	if err != nil {
+		if !errors.Is(err, widecolumn.ErrNotFound) {
+			return nil, err
+		}
+		branding = &entity.Branding{TenantID: tenantID}
+	}
+
+	if err := s.brandingCache.Set(ctx, branding); err != nil {
+		global.LoggerZap.Error("Failed to set branding in cache", zap.Error(err))
+	}
+
+	return branding, nil
+}
+
+// normalizeHost strips the port and lower-cases the Host header
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/core/mapper"
+	"go-link/redirection/internal/ports"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+type hotLinkService struct {
+	linkRepo       ports.LinkRepository
+	clickRepo      ports.LinkClickRepository
+	linkCache      ports.LinkCacheRepository
+	tracker        ports.HotLinkTracker
+	pinned         ports.PinnedLinks
+	spikePublisher ports.SpikePublisher
+	alertedAt      map[string]time.Time // only touched by Rotate
+}
+
+func NewHotLinkService(
+	linkRepo ports.LinkRepository,
+	clickRepo ports.LinkClickRepository,
+	linkCache ports.LinkCacheRepository,
+	tracker ports.HotLinkTracker,
+	pinned ports.PinnedLinks,
+	spikePublisher ports.SpikePublisher,
+) ports.HotLinkService {
+	return &hotLinkService{
+		linkRepo:       linkRepo,
+		clickRepo:      clickRepo,
+		linkCache:      linkCache,
+		tracker:        tracker,
+		pinned:         pinned,
+		spikePublisher: spikePublisher,
+		alertedAt:      make(map[string]time.Time),
+	}
+}
+
+// GetTop returns the busiest links of the last tracking window
+func (s *hotLinkService) GetTop(ctx context.Context, req *dto.HotLinksRequest) (*dto.HotLinksResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = constant.HotLinkDefaultLimit
+	}
+	return mapper.ToHotLinksResponse(s.tracker.Top(limit), constant.HotLinkWindow), nil
+}
+
+// Rotate closes the tracking window, re-pins the hot links with fresh data and alerts on spikes
+func (s *hotLinkService) Rotate(ctx context.Context) error {
+	window := s.tracker.Rotate()
+
+	if err := s.clickRepo.AddClicks(ctx, window.Top); err != nil {
+		global.LoggerZap.Warn("Failed to record link clicks", zap.Error(err))
+	}
+
+	loaded := make(map[string]*entity.Link)
+	pinned := make([]*entity.Link, 0, len(window.Top))
+	for _, h := range window.Top {
+		if h.Clicks < constant.HotLinkPinMinClicks {
+			break
+		}
+		link := s.refresh(ctx, h.ShortCode)
+		if link == nil {
+			continue
+		}
+		loaded[h.ShortCode] = link
+		pinned = append(pinned, link)
+	}
+	s.pinned.Replace(pinned)
+
+	s.alertSpikes(ctx, window.Spikes, loaded)
+	return nil
+}
+
+// refresh reloads a hot link from the database so pinned and cached copies never go stale
+func (s *hotLinkService) refresh(ctx context.Context, shortCode string) *entity.Link {
+	link, err := s.linkRepo.GetOriginalURL(ctx, shortCode)
+	if err != nil {
+		global.LoggerZap.Warn("Failed to refresh hot link", zap.String("shortCode", shortCode), zap.Error(err))
+		return nil
+	}
+
+	if err := s.linkCache.Set(ctx, link); err != nil {
+		global.LoggerZap.Error("Failed to set link in cache", zap.Error(err))
+	}
+
+	return link
+}
+
+func (s *hotLinkService) alertSpikes(ctx context.Context, spikes []*entity.HotLink, loaded map[string]*entity.Link) {
+	now := time.Now()
+
+	for code, at := range s.alertedAt {
+		if now.Sub(at) >= constant.HotLinkSpikeCooldown {
+			delete(s.alertedAt, code)
+		}
+	}
+
+	for _, h := range spikes {
+		if _, cooling := s.alertedAt[h.ShortCode]; cooling {
+			continue
+		}
+
+		link, ok := loaded[h.ShortCode]
+		if !ok {
+			if link = s.refresh(ctx, h.ShortCode); link == nil {
+				continue
+			}
+		}
+
+		s.spikePublisher.Publish(ctx, &linkv1.LinkSpikeEvent{
+			ShortCode:     h.ShortCode,
+			TenantID:      link.TenantID,
+			Clicks:        h.Clicks,
+			PrevClicks:    h.PrevClicks,
+			WindowSeconds: int64(constant.HotLinkWindow.Seconds()),
+			Timestamp:     now.UnixMilli(),
+		})
+		s.alertedAt[h.ShortCode] = now
+
+		global.LoggerZap.Warn("Link traffic spike detected",
+			zap.String("shortCode", h.ShortCode),
+			zap.Int64("clicks", h.Clicks),
+			zap.Int64("prevClicks", h.PrevClicks),
+		)
+	}
+}
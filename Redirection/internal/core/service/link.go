@@ -2,85 +2,591 @@ package service
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/url"
+	"sync"
+	"time"
 
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	generationv1 "go-link/common/gen/go/generation/v1"
 	"go-link/common/pkg/cdc"
 	"go-link/common/pkg/common/apperr"
 	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/database/widecolumn"
+	"go-link/common/pkg/security"
 
 	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/dto"
 	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/core/mapper"
 	"go-link/redirection/internal/ports"
 
 	"go.uber.org/zap"
 )
 
+const serviceName = "LinkService"
+
+// Options contains optional behaviour of the link service.
+type Options struct {
+	LoadLock bool
+
+	GenerationClient generationv1.GenerationServiceClient
+	FallbackLimiter  ports.FallbackLimiter
+
+	GeoLocator ports.GeoLocator
+}
+
+// WithGenerationFallback looks up misses in Generation, within the limiter's budget,
+// so links are redirectable before CDC has delivered them.
+func WithGenerationFallback(client generationv1.GenerationServiceClient, limiter ports.FallbackLimiter) Option {
+	return func(o *Options) {
+		o.GenerationClient = client
+		o.FallbackLimiter = limiter
+	}
+}
+
+// WithGeoLocator adds the client's country and city to click events
+func WithGeoLocator(locator ports.GeoLocator) Option {
+	return func(o *Options) {
+		o.GeoLocator = locator
+	}
+}
+
+// Option is a function that configures Options.
+type Option func(*Options)
+
+// WithLoadLock enables the cross-instance Redis lock around database loads,
+// so only one instance fills the cache for a short code while the others wait briefly.
+func WithLoadLock() Option {
+	return func(o *Options) {
+		o.LoadLock = true
+	}
+}
+
 type linkService struct {
-	linkRepo  ports.LinkRepository
-	linkCache ports.LinkCacheRepository
+	linkRepo       ports.LinkRepository
+	linkCache      ports.LinkCacheRepository
+	linkFilter     ports.LinkFilter
+	missLimiter    ports.MissLimiter
+	clickPublisher ports.ClickPublisher
+	hotTracker     ports.HotLinkTracker
+	pinned         ports.PinnedLinks
+	classifier     ports.BotClassifier
+	trafficService ports.TrafficService
+	membership     ports.MembershipService
+	metrics        ports.LinkMetrics
+	options        *Options
+	loadGroup      singleflight.Group
+	refreshing     sync.Map
 }
 
-func NewLinkService(linkRepo ports.LinkRepository, linkCache ports.LinkCacheRepository) ports.LinkService {
+func NewLinkService(
+	linkRepo ports.LinkRepository,
+	linkCache ports.LinkCacheRepository,
+	linkFilter ports.LinkFilter,
+	missLimiter ports.MissLimiter,
+	clickPublisher ports.ClickPublisher,
+	hotTracker ports.HotLinkTracker,
+	pinned ports.PinnedLinks,
+	classifier ports.BotClassifier,
+	trafficService ports.TrafficService,
+	membership ports.MembershipService,
+	metrics ports.LinkMetrics,
+	opts ...Option,
+) ports.LinkService {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	return &linkService{
-		linkRepo:  linkRepo,
-		linkCache: linkCache,
+		linkRepo:       linkRepo,
+		linkCache:      linkCache,
+		linkFilter:     linkFilter,
+		missLimiter:    missLimiter,
+		clickPublisher: clickPublisher,
+		hotTracker:     hotTracker,
+		pinned:         pinned,
+		classifier:     classifier,
+		trafficService: trafficService,
+		membership:     membership,
+		metrics:        metrics,
+		options:        options,
 	}
 }
 
 // GetOriginalURL retrieves the original URL
-func (s *linkService) GetOriginalURL(ctx context.Context, shortCode string) (string, error) {
-	entity, err := s.linkCache.Get(ctx, shortCode)
+func (s *linkService) GetOriginalURL(ctx context.Context, req *dto.RedirectRequest) (*dto.RedirectResponse, error) {
+	shortCode := req.ShortCode
+	traffic := s.classifier.Classify(&entity.ClickSignal{
+		ClientIP:       req.ClientIP,
+		UserAgent:      req.UserAgent,
+		AcceptLanguage: req.AcceptLanguage,
+	})
+
+	if link, ok := s.pinned.Get(shortCode); ok {
+		return s.resolve(ctx, req, link, traffic)
+	}
+
+	entity, stale, _ := s.linkCache.Get(ctx, shortCode)
 
 	if entity != nil {
-		global.LoggerZap.Info("Link found in cache", zap.String("shortCode", shortCode), zap.String("originalURL", entity.OriginalURL))
-		return entity.OriginalURL, nil
+		if stale {
+			s.refreshAsync(shortCode)
+		}
+		global.LoggerZap.Info("Link found in cache", zap.String("shortCode", shortCode), zap.String("originalURL", entity.OriginalURL), zap.Bool("stale", stale))
+		return s.resolve(ctx, req, entity, traffic)
+	}
+
+	if s.missLimiter.Blocked(ctx, req.ClientIP) {
+		return nil, apperr.NewError(serviceName, response.CodeTooManyRequests, constant.MsgTooManyMisses, http.StatusTooManyRequests, nil)
+	}
+
+	missed := s.linkCache.IsMiss(ctx, shortCode)
+	if missed || !s.linkFilter.MightContain(shortCode) {
+		if !missed {
+			if link, ok := s.fallback(ctx, shortCode); ok {
+				return s.resolve(ctx, req, link, traffic)
+			}
+		}
+		return nil, s.notFound(ctx, req, nil)
+	}
+
+	entity, err := s.load(ctx, shortCode)
+	if err != nil {
+		if errors.Is(err, widecolumn.ErrNotFound) {
+			if link, ok := s.fallback(ctx, shortCode); ok {
+				return s.resolve(ctx, req, link, traffic)
+			}
+			return nil, s.notFound(ctx, req, err)
+		}
+		return nil, apperr.NewError(serviceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	global.LoggerZap.Info("Link found in database", zap.String("shortCode", shortCode), zap.String("originalURL", entity.OriginalURL))
+	return s.resolve(ctx, req, entity, traffic)
+}
+
+// resolve checks that the link can be followed, then records the click and builds the redirect response
+func (s *linkService) resolve(ctx context.Context, req *dto.RedirectRequest, link *entity.Link, traffic entity.TrafficClass) (*dto.RedirectResponse, error) {
+	if err := s.checkAccess(ctx, req, link, traffic); err != nil {
+		return nil, err
+	}
+
+	clickID := s.issueClickID(req, link, traffic)
+	s.trackClick(ctx, req, link, traffic, clickID)
+
+	if traffic == entity.TrafficPreview && link.NeedsSignature(req.Signature) {
+		return mapper.ToShortLinkPreview(link), nil
+	}
+	return mapper.ToRedirectResponse(link, traffic, clickID), nil
+}
+
+// issueClickID mints the signed glclid for links with conversion tracking.
+// Unfurlers never follow the link, so they get none.
+func (s *linkService) issueClickID(req *dto.RedirectRequest, link *entity.Link, traffic entity.TrafficClass) string {
+	secret := global.Config.Conversion.ClickIDSecret
+	if !link.AppendClickID || traffic == entity.TrafficPreview || secret == "" {
+		return ""
+	}
+
+	click, err := security.NewClickID(link.TenantID, link.ID, mapper.ToVariant(req, link), link.Campaign(), time.Now())
+	if err != nil {
+		global.LoggerZap.Warn("Failed to issue click id", zap.String("shortCode", link.ID), zap.Error(err))
+		return ""
+	}
+	return security.EncodeClickID([]byte(secret), click)
+}
+
+// checkAccess rejects disabled and expired links, keeps private links to tenant members,
+// verifies signed-link queries and enforces the link password.
+// Wrong passwords count against the same per-client budget as unknown codes.
+// A single-use nonce is only spent once every other check has passed, and never by link unfurlers.
+func (s *linkService) checkAccess(ctx context.Context, req *dto.RedirectRequest, link *entity.Link, traffic entity.TrafficClass) error {
+	now := time.Now()
+
+	if link.Disabled {
+		return apperr.NewError(serviceName, response.CodeForbidden, constant.MsgLinkDisabled, http.StatusGone, constant.ErrLinkDisabled)
+	}
+
+	if link.IsExpired(now) {
+		return apperr.NewError(serviceName, response.CodeForbidden, constant.MsgLinkExpired, http.StatusGone, constant.ErrLinkExpired)
+	}
+
+	if link.IsPrivate() {
+		if err := s.checkMembership(ctx, req, link); err != nil {
+			return err
+		}
+	}
+
+	var signed security.SignedLink
+	if link.NeedsSignature(req.Signature) {
+		var err error
+		if signed, err = s.verifySignature(req, link, now); err != nil {
+			return err
+		}
+	}
+
+	if err := s.checkPassword(ctx, req, link); err != nil {
+		return err
+	}
+
+	if signed.Nonce != "" && traffic != entity.TrafficPreview {
+		return s.consumeNonce(ctx, link, signed, now)
+	}
+
+	return nil
+}
+
+// checkMembership requires a valid Identity access token whose holder belongs to the link's tenant.
+// A missing or unusable token asks the visitor to sign in again.
+func (s *linkService) checkMembership(ctx context.Context, req *dto.RedirectRequest, link *entity.Link) error {
+	loginRequired := apperr.NewError(serviceName, response.CodeUnauthorized, constant.MsgLoginRequired, http.StatusUnauthorized, constant.ErrLoginRequired)
+
+	if req.AccessToken == "" {
+		return loginRequired
 	}
 
-	entity, err = s.linkRepo.GetOriginalURL(ctx, shortCode)
+	claims, err := s.membership.ParseAccessToken(req.AccessToken)
 	if err != nil {
-		return "", err
+		return loginRequired
 	}
 
-	err = s.linkCache.Set(ctx, entity)
+	member, err := s.membership.IsMember(ctx, claims, link.TenantID)
 	if err != nil {
+		global.LoggerZap.Error("Failed to check tenant membership", zap.String("shortCode", link.ID), zap.Int("userID", claims.UserID), zap.Error(err))
+		return apperr.NewError(serviceName, response.CodeInternalServer, constant.MsgServiceUnavailable, http.StatusServiceUnavailable, err)
+	}
+
+	if !member {
+		return apperr.NewError(serviceName, response.CodeForbidden, constant.MsgNotTenantMember, http.StatusForbidden, constant.ErrNotTenantMember)
+	}
+
+	return nil
+}
+
+// verifySignature checks the signed-link query against the tenant's derived key.
+// It needs nothing beyond the link already resolved, so no extra lookup is made.
+func (s *linkService) verifySignature(req *dto.RedirectRequest, link *entity.Link, now time.Time) (security.SignedLink, error) {
+	invalid := apperr.NewError(serviceName, response.CodeForbidden, constant.MsgInvalidSignature, http.StatusForbidden, constant.ErrInvalidSignature)
+
+	secret := global.Config.SignedLink.Secret
+	if secret == "" || link.TenantID == 0 {
+		return security.SignedLink{}, invalid
+	}
+
+	signed, sig, err := security.ParseSignedLink(url.Values{
+		security.SignedLinkParamExpiry:    {req.Expiry},
+		security.SignedLinkParamNonce:     {req.Nonce},
+		security.SignedLinkParamSignature: {req.Signature},
+	})
+	if err != nil {
+		return security.SignedLink{}, invalid
+	}
+
+	key := security.DeriveTenantKey([]byte(secret), link.TenantID)
+	switch err := security.VerifyLink(key, link.ID, signed, sig, now); {
+	case errors.Is(err, security.ErrSignedLinkExpired):
+		return security.SignedLink{}, apperr.NewError(serviceName, response.CodeForbidden, constant.MsgLinkExpired, http.StatusGone, constant.ErrLinkExpired)
+	case err != nil:
+		return security.SignedLink{}, invalid
+	}
+
+	return signed, nil
+}
+
+// consumeNonce spends the nonce of a single-use signed link.
+// It fails closed: when Redis can't confirm the nonce is fresh, the visit is refused.
+func (s *linkService) consumeNonce(ctx context.Context, link *entity.Link, signed security.SignedLink, now time.Time) error {
+	fresh, err := s.linkCache.ConsumeNonce(ctx, link.ID, signed.Nonce, signed.ExpiresAt.Sub(now))
+	if err != nil {
+		global.LoggerZap.Error("Failed to consume link nonce", zap.String("shortCode", link.ID), zap.Error(err))
+		return apperr.NewError(serviceName, response.CodeInternalServer, constant.MsgConsumeNonce, http.StatusServiceUnavailable, err)
+	}
+
+	if !fresh {
+		return apperr.NewError(serviceName, response.CodeForbidden, constant.MsgLinkUsed, http.StatusGone, constant.ErrLinkUsed)
+	}
+
+	return nil
+}
+
+// checkPassword enforces the link password, if any
+func (s *linkService) checkPassword(ctx context.Context, req *dto.RedirectRequest, link *entity.Link) error {
+	if !link.HasPassword() {
+		return nil
+	}
+
+	if req.Password == "" {
+		return apperr.NewError(serviceName, response.CodeUnauthorized, constant.MsgPasswordRequired, http.StatusUnauthorized, constant.ErrPasswordRequired)
+	}
+
+	if s.missLimiter.Blocked(ctx, req.ClientIP) {
+		return apperr.NewError(serviceName, response.CodeTooManyRequests, constant.MsgTooManyMisses, http.StatusTooManyRequests, nil)
+	}
+
+	if err := security.ComparePassword(link.PasswordHash, req.Password); err != nil {
+		s.missLimiter.Record(ctx, req.ClientIP)
+		return apperr.NewError(serviceName, response.CodeInvalidPassword, constant.MsgInvalidPassword, http.StatusUnauthorized, constant.ErrInvalidPassword)
+	}
+
+	return nil
+}
+
+// trackClick emits the click event for a resolved redirect.
+// Automated clicks are dropped when the tenant keeps them out of analytics.
+func (s *linkService) trackClick(ctx context.Context, req *dto.RedirectRequest, link *entity.Link, traffic entity.TrafficClass, clickID string) {
+	s.hotTracker.Record(link.ID)
+
+	if !traffic.IsHuman() && s.trafficService.ExcludesBots(ctx, link.TenantID) {
+		return
+	}
+
+	var geo *entity.GeoLocation
+	if s.options.GeoLocator != nil {
+		geo = s.options.GeoLocator.Locate(req.ClientIP)
+	}
+
+	s.clickPublisher.Publish(mapper.ToLinkClickedEvent(req, link, traffic, time.Now(), clickID, geo, global.Config.Click.IPHashSalt))
+}
+
+// notFound counts the miss against the client and builds the not found error
+func (s *linkService) notFound(ctx context.Context, req *dto.RedirectRequest, cause error) error {
+	s.missLimiter.Record(ctx, req.ClientIP)
+	return apperr.NewError(serviceName, response.CodeNotFound, constant.MsgLinkNotFound, http.StatusNotFound, cause)
+}
+
+// load coalesces concurrent loads of the same short code into a single database read
+func (s *linkService) load(ctx context.Context, shortCode string) (*entity.Link, error) {
+	v, err, _ := s.loadGroup.Do(shortCode, func() (any, error) {
+		return s.loadFromDB(context.WithoutCancel(ctx), shortCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*entity.Link), nil
+}
+
+// loadFromDB reads the link from the database and fills the cache.
+// With the load lock enabled, an instance that loses the lock waits for the winner to fill the cache first.
+func (s *linkService) loadFromDB(ctx context.Context, shortCode string) (*entity.Link, error) {
+	if s.options.LoadLock {
+		token, err := security.NewNonce()
+		if err != nil {
+			return nil, err
+		}
+		acquired, err := s.linkCache.AcquireLoadLock(ctx, shortCode, token)
+		switch {
+		case err != nil:
+			global.LoggerZap.Warn("Failed to acquire link load lock", zap.String("shortCode", shortCode), zap.Error(err))
+		case acquired:
+			defer func() {
+				if err := s.linkCache.ReleaseLoadLock(ctx, shortCode, token); err != nil {
+					global.LoggerZap.Warn("Failed to release link load lock", zap.String("shortCode", shortCode), zap.Error(err))
+				}
+			}()
+		default:
+			if link := s.waitForCache(ctx, shortCode); link != nil {
+				return link, nil
+			}
+		}
+	}
+
+	link, err := s.linkRepo.GetOriginalURL(ctx, shortCode)
+	if err != nil {
+		if errors.Is(err, widecolumn.ErrNotFound) {
+			if err := s.linkCache.SetMiss(ctx, shortCode); err != nil {
+				global.LoggerZap.Error("Failed to set link miss in cache", zap.Error(err))
+			}
+		}
+		return nil, err
+	}
+
+	if err := s.linkCache.Set(ctx, link); err != nil {
 		global.LoggerZap.Error("Failed to set link in cache", zap.Error(err))
 	}
 
-	global.LoggerZap.Info("Link found in database", zap.String("shortCode", shortCode), zap.String("originalURL", entity.OriginalURL))
-	return entity.OriginalURL, nil
+	return link, nil
 }
 
-func (s *linkService) HandleLinkBatchChange(ctx context.Context, batch []*cdc.DebeziumPayload[entity.Link]) error {
-	var (
-		linksToCreate []*entity.Link
-		idsToDelete   []string
-	)
+// fallback asks Generation for a code CDC may not have delivered yet,
+// and backfills the store, cache and filter with the answer.
+// Any miss qualifies, since a code's age says nothing about when its link was created; clients that keep missing
+// are throttled before they get here. Concurrent misses for one code share a single lookup, the lookups share
+// a budget across instances, and a confirmed miss is cached like any other.
+func (s *linkService) fallback(ctx context.Context, shortCode string) (*entity.Link, bool) {
+	if s.options.GenerationClient == nil {
+		return nil, false
+	}
 
-	for _, payload := range batch {
-		switch payload.Op {
-		case cdc.OpCreate:
-			if payload.After != nil {
-				linksToCreate = append(linksToCreate, payload.After)
+	v, err, _ := s.loadGroup.Do(constant.LinkFallbackKey+shortCode, func() (any, error) {
+		return s.fetchFromGeneration(context.WithoutCancel(ctx), shortCode)
+	})
+	if err != nil {
+		return nil, false
+	}
+	return v.(*entity.Link), true
+}
+
+func (s *linkService) fetchFromGeneration(ctx context.Context, shortCode string) (*entity.Link, error) {
+	if s.options.FallbackLimiter != nil && !s.options.FallbackLimiter.Allow(ctx) {
+		s.metrics.RecordFallback(constant.FallbackOutcomeLimited)
+		return nil, widecolumn.ErrNotFound
+	}
+
+	rctx, cancel := context.WithTimeout(ctx, constant.LinkFallbackTimeout)
+	defer cancel()
+
+	resp, err := s.options.GenerationClient.GetLink(rctx, &generationv1.GetLinkRequest{Id: shortCode})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			s.metrics.RecordFallback(constant.FallbackOutcomeMiss)
+			if err := s.linkCache.SetMiss(ctx, shortCode); err != nil {
+				global.LoggerZap.Error("Failed to set link miss in cache", zap.Error(err))
 			}
-		case cdc.OpDelete:
-			if payload.Before != nil {
-				idsToDelete = append(idsToDelete, payload.Before.ID)
+			return nil, widecolumn.ErrNotFound
+		}
+		s.metrics.RecordFallback(constant.FallbackOutcomeError)
+		global.LoggerZap.Warn("Generation fallback lookup failed", zap.String("shortCode", shortCode), zap.Error(err))
+		return nil, err
+	}
+
+	s.metrics.RecordFallback(constant.FallbackOutcomeHit)
+	link := mapper.ToLinkFromProto(resp.Link)
+
+	// Version the backfill with the row's own update time, so the CDC event that follows still applies
+	change := &entity.LinkChange{Link: link, Version: link.UpdatedAt.UnixMilli()}
+	if err := s.linkRepo.ApplyChanges(ctx, []*entity.LinkChange{change}); err != nil {
+		global.LoggerZap.Error("Failed to backfill link from Generation", zap.String("shortCode", shortCode), zap.Error(err))
+	}
+
+	s.linkFilter.Add(shortCode)
+	if err := s.linkCache.DeleteMissBulk(ctx, []string{shortCode}); err != nil {
+		global.LoggerZap.Error("Failed to clear link miss in cache", zap.Error(err))
+	}
+	if err := s.linkCache.Set(ctx, link); err != nil {
+		global.LoggerZap.Error("Failed to set link in cache", zap.Error(err))
+	}
+
+	global.LoggerZap.Info("Link found in Generation", zap.String("shortCode", shortCode))
+	return link, nil
+}
+
+// waitForCache polls the cache while another instance holds the load lock.
+// A stale entry does not count, so a refresh that loses the lock still ends with a fresh link.
+func (s *linkService) waitForCache(ctx context.Context, shortCode string) *entity.Link {
+	deadline := time.Now().Add(constant.LinkLoadWaitTimeout)
+	ticker := time.NewTicker(constant.LinkLoadWaitInterval)
+	defer ticker.Stop()
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ticker.C:
+			if link, stale, _ := s.linkCache.Get(ctx, shortCode); link != nil && !stale {
+				return link
 			}
+		case <-ctx.Done():
+			return nil
 		}
 	}
 
-	if len(linksToCreate) > 0 {
-		if err := s.linkRepo.CreateBulk(ctx, linksToCreate); err != nil {
-			return apperr.Wrap(err, response.CodeInternalServer, "failed to batch save link", http.StatusInternalServerError)
+	return nil
+}
+
+// refreshAsync reloads a stale cache entry in the background, at most once per short code at a time
+func (s *linkService) refreshAsync(shortCode string) {
+	if _, running := s.refreshing.LoadOrStore(shortCode, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer s.refreshing.Delete(shortCode)
+
+		ctx, cancel := context.WithTimeout(context.Background(), constant.LinkRefreshTimeout)
+		defer cancel()
+
+		if _, err := s.load(ctx, shortCode); err != nil {
+			if errors.Is(err, widecolumn.ErrNotFound) {
+				_ = s.linkCache.DeleteBulk(ctx, []string{shortCode})
+				return
+			}
+			global.LoggerZap.Warn("Failed to refresh stale link", zap.String("shortCode", shortCode), zap.Error(err))
 		}
+	}()
+}
+
+// RebuildFilter repopulates the existence filter from the database
+func (s *linkService) RebuildFilter(ctx context.Context) error {
+	return s.linkFilter.Rebuild(ctx, s.linkRepo.ScanIDs)
+}
+
+// HandleLinkBatchChange applies a batch of CDC events in order.
+// Events are reduced to the latest change per short code and written with their Debezium
+// timestamp as version, which makes redelivered or out-of-order batches idempotent.
+func (s *linkService) HandleLinkBatchChange(ctx context.Context, batch []*cdc.DebeziumPayload[entity.Link]) error {
+	latest := make(map[string]*entity.LinkChange, len(batch))
+	order := make([]string, 0, len(batch))
+
+	var newestMs int64
+	for _, payload := range batch {
+		newestMs = max(newestMs, payload.SourceTsMs())
+
+		change := mapper.ToLinkChange(payload)
+		if change == nil {
+			continue
+		}
+
+		id := change.Link.ID
+		prev, seen := latest[id]
+		if !seen {
+			order = append(order, id)
+		} else if prev.Version > change.Version {
+			continue
+		}
+		latest[id] = change
+	}
+
+	// CDC lag: how long after the commit at the source the newest change reached us
+	if newestMs > 0 {
+		s.metrics.ObserveCDCLag(time.Since(time.UnixMilli(newestMs)))
+	}
+
+	if len(order) == 0 {
+		return nil
+	}
+
+	changes := make([]*entity.LinkChange, len(order))
+	var upsertIDs, deleteIDs []string
+	for i, id := range order {
+		changes[i] = latest[id]
+		if changes[i].Deleted {
+			deleteIDs = append(deleteIDs, id)
+		} else {
+			upsertIDs = append(upsertIDs, id)
+		}
+	}
+
+	if err := s.linkRepo.ApplyChanges(ctx, changes); err != nil {
+		return apperr.Wrap(err, response.CodeInternalServer, "failed to batch apply link changes", http.StatusInternalServerError)
+	}
+
+	// Drop every cached copy so the next lookup sees the new state
+	s.pinned.Remove(order)
+	if err := s.linkCache.DeleteBulk(ctx, order); err != nil {
+		return apperr.Wrap(err, response.CodeInternalServer, "failed to batch invalidate link", http.StatusInternalServerError)
 	}
 
-	if len(idsToDelete) > 0 {
-		if err := s.linkRepo.DeleteBulk(ctx, idsToDelete); err != nil {
-			return apperr.Wrap(err, response.CodeInternalServer, "failed to batch remove link", http.StatusInternalServerError)
+	if len(upsertIDs) > 0 {
+		for _, id := range upsertIDs {
+			s.linkFilter.Add(id)
 		}
-		if err := s.linkCache.DeleteBulk(ctx, idsToDelete); err != nil {
-			return apperr.Wrap(err, response.CodeInternalServer, "failed to batch remove link", http.StatusInternalServerError)
+		if err := s.linkCache.DeleteMissBulk(ctx, upsertIDs); err != nil {
+			global.LoggerZap.Error("Failed to clear link misses in cache", zap.Error(err))
 		}
 	}
 
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	generationv1 "go-link/common/gen/go/generation/v1"
+	"go-link/common/pkg/encoding"
+	"go-link/common/pkg/logger"
+	"go-link/common/pkg/settings"
+	"go-link/common/pkg/unique"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time { return c.now }
+func (c *fixedClock) Stop()          {}
+
+// fakeGeneration answers GetLink from the links created so far
+type fakeGeneration struct {
+	generationv1.GenerationServiceClient
+	links map[string]*generationv1.Link
+	calls int
+}
+
+func (f *fakeGeneration) GetLink(_ context.Context, req *generationv1.GetLinkRequest, _ ...grpc.CallOption) (*generationv1.GetLinkResponse, error) {
+	f.calls++
+	if l, ok := f.links[req.Id]; ok {
+		return &generationv1.GetLinkResponse{Link: l}, nil
+	}
+	return nil, status.Error(codes.NotFound, "link not found")
+}
+
+type fakeLinkCache struct {
+	ports.LinkCacheRepository
+	links  map[string]*entity.Link
+	misses map[string]bool
+}
+
+func (f *fakeLinkCache) Set(_ context.Context, link *entity.Link) error {
+	f.links[link.ID] = link
+	return nil
+}
+
+func (f *fakeLinkCache) SetMiss(_ context.Context, id string) error {
+	f.misses[id] = true
+	return nil
+}
+
+func (f *fakeLinkCache) DeleteMissBulk(_ context.Context, ids []string) error {
+	for _, id := range ids {
+		delete(f.misses, id)
+	}
+	return nil
+}
+
+type fakeLinkRepo struct {
+	ports.LinkRepository
+	applied []*entity.LinkChange
+}
+
+func (f *fakeLinkRepo) ApplyChanges(_ context.Context, changes []*entity.LinkChange) error {
+	f.applied = append(f.applied, changes...)
+	return nil
+}
+
+type fakeFilter struct {
+	ports.LinkFilter
+	added []string
+}
+
+func (f *fakeFilter) Add(id string) { f.added = append(f.added, id) }
+
+type fakeMetrics struct {
+	outcomes []string
+}
+
+func (f *fakeMetrics) ObserveCDCLag(time.Duration)   {}
+func (f *fakeMetrics) RecordFallback(outcome string) { f.outcomes = append(f.outcomes, outcome) }
+
+type fakeFallbackLimiter struct {
+	allow bool
+}
+
+func (f *fakeFallbackLimiter) Allow(context.Context) bool { return f.allow }
+
+// mintCode draws a short code the way Generation fills its pool, with the clock at the given time
+func mintCode(t *testing.T, at time.Time) string {
+	t.Helper()
+	node, err := unique.NewSnowflakeNode(settings.SnowflakeNode{
+		Config: settings.Snowflake{Epoch: 1767225600000, Node: 2, Step: 10, TotalBits: 42},
+	}, &fixedClock{now: at})
+	if err != nil {
+		t.Fatalf("NewSnowflakeNode: %v", err)
+	}
+	return encoding.Base62Encode(node.Generate())
+}
+
+func TestFallback(t *testing.T) {
+	global.LoggerZap = &logger.LoggerZap{Logger: zap.NewNop()}
+
+	now := time.Now()
+	// The code waited in the pool for two days before a link took it
+	pooled := mintCode(t, now.Add(-48*time.Hour))
+	unknown := mintCode(t, now.Add(-47*time.Hour))
+
+	tests := []struct {
+		name    string
+		code    string
+		allow   bool
+		found   bool
+		calls   int
+		missed  bool
+		outcome string
+	}{
+		{name: "code minted long before its link", code: pooled, allow: true, found: true, calls: 1, outcome: constant.FallbackOutcomeHit},
+		{name: "unknown code", code: unknown, allow: true, calls: 1, missed: true, outcome: constant.FallbackOutcomeMiss},
+		{name: "budget spent", code: pooled, calls: 0, outcome: constant.FallbackOutcomeLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generation := &fakeGeneration{links: map[string]*generationv1.Link{
+				pooled: {Id: pooled, OriginalUrl: "https://example.com", CreatedAt: now.UnixMilli(), UpdatedAt: now.UnixMilli()},
+			}}
+			linkCache := &fakeLinkCache{links: map[string]*entity.Link{}, misses: map[string]bool{}}
+			linkRepo := &fakeLinkRepo{}
+			linkFilter := &fakeFilter{}
+			metrics := &fakeMetrics{}
+
+			s := NewLinkService(linkRepo, linkCache, linkFilter, nil, nil, nil, nil, nil, nil, nil, metrics,
+				WithGenerationFallback(generation, &fakeFallbackLimiter{allow: tt.allow}),
+			).(*linkService)
+
+			link, found := s.fallback(context.Background(), tt.code)
+			if found != tt.found {
+				t.Fatalf("expected found=%v, got %v", tt.found, found)
+			}
+			if generation.calls != tt.calls {
+				t.Errorf("expected %d Generation lookups, got %d", tt.calls, generation.calls)
+			}
+			if linkCache.misses[tt.code] != tt.missed {
+				t.Errorf("expected cached miss=%v, got %v", tt.missed, linkCache.misses[tt.code])
+			}
+			if len(metrics.outcomes) != 1 || metrics.outcomes[0] != tt.outcome {
+				t.Errorf("expected outcome %q, got %v", tt.outcome, metrics.outcomes)
+			}
+			if !tt.found {
+				return
+			}
+
+			if link.OriginalURL != "https://example.com" {
+				t.Errorf("unexpected link %+v", link)
+			}
+			if linkCache.links[tt.code] == nil || len(linkRepo.applied) != 1 || len(linkFilter.added) != 1 {
+				t.Error("expected the link backfilled into the cache, store and filter")
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	identityv1 "go-link/common/gen/go/identity/v1"
+	"go-link/common/pkg/utils"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/ports"
+)
+
+type membershipService struct {
+	membershipCache ports.MembershipCacheRepository
+	identityClient  identityv1.IdentityServiceClient
+}
+
+func NewMembershipService(
+	membershipCache ports.MembershipCacheRepository,
+	identityClient identityv1.IdentityServiceClient,
+) ports.MembershipService {
+	return &membershipService{
+		membershipCache: membershipCache,
+		identityClient:  identityClient,
+	}
+}
+
+// ParseAccessToken validates the token signature and expiry and rejects refresh tokens
+func (s *membershipService) ParseAccessToken(token string) (*utils.Claims, error) {
+	claims, err := utils.ParseToken(token, global.Config.JWT.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Type != utils.AccessToken {
+		return nil, errors.New(constant.MsgNotAccessToken)
+	}
+
+	return claims, nil
+}
+
+// LoginURL builds the Identity login URL carrying the callback as redirect_uri and the state nonce
+func (s *membershipService) LoginURL(callbackURL, state string) (string, bool) {
+	loginURL := global.Config.PrivateLink.LoginURL
+	if loginURL == "" {
+		return "", false
+	}
+
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		global.LoggerZap.Error("Invalid private link login URL", zap.String("loginURL", loginURL), zap.Error(err))
+		return "", false
+	}
+
+	q := u.Query()
+	q.Set(constant.AuthRedirectURIParam, callbackURL)
+	q.Set(constant.AuthStateField, state)
+	u.RawQuery = q.Encode()
+
+	return u.String(), true
+}
+
+// TokenCookie returns the configured access token cookie name
+func (s *membershipService) TokenCookie() string {
+	if name := global.Config.PrivateLink.TokenCookie; name != "" {
+		return name
+	}
+	return constant.DefaultAccessTokenCookie
+}
+
+// IsMember reports whether the token holder belongs to the tenant.
+// Tokens issued for the tenant and super admins pass straight away; anyone else is
+// looked up in Identity, where a missing membership comes back as NotFound.
+func (s *membershipService) IsMember(ctx context.Context, claims *utils.Claims, tenantID int) (bool, error) {
+	if claims.IsAdmin || (claims.TenantID != 0 && claims.TenantID == tenantID) {
+		return true, nil
+	}
+
+	if member, found := s.membershipCache.Get(ctx, tenantID, claims.UserID); found {
+		return member, nil
+	}
+
+	rctx, cancel := context.WithTimeout(ctx, constant.MembershipCheckTimeout)
+	defer cancel()
+
+	resp, err := s.identityClient.GetUserRole(rctx, &identityv1.GetUserRoleRequest{
+		UserId:   int64(claims.UserID),
+		TenantId: int64(tenantID),
+	})
+
+	var member bool
+	switch {
+	case status.Code(err) == codes.NotFound:
+		member = false
+	case err != nil:
+		return false, err
+	default:
+		member = resp.Role != nil
+	}
+
+	if err := s.membershipCache.Set(ctx, tenantID, claims.UserID, member); err != nil {
+		global.LoggerZap.Error("Failed to set membership in cache", zap.Error(err))
+	}
+
+	return member, nil
+}
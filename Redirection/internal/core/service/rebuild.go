@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"go-link/common/pkg/database/widecolumn"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+type rebuildService struct {
+	source     ports.LinkRepository
+	target     ports.LinkRepository
+	linkCache  ports.LinkCacheRepository
+	clickRepo  ports.LinkClickRepository
+	checkpoint ports.RebuildCheckpointStore
+}
+
+// NewRebuildService copies links from source (Generation's keyspace) into target (the redirect store)
+func NewRebuildService(
+	source ports.LinkRepository,
+	target ports.LinkRepository,
+	linkCache ports.LinkCacheRepository,
+	clickRepo ports.LinkClickRepository,
+	checkpoint ports.RebuildCheckpointStore,
+) ports.RebuildService {
+	return &rebuildService{
+		source:     source,
+		target:     target,
+		linkCache:  linkCache,
+		clickRepo:  clickRepo,
+		checkpoint: checkpoint,
+	}
+}
+
+// Rebuild streams every source link into the target range by range, then optionally pre-warms the cache.
+// Writes are upserts versioned by updated_at, so re-copying part of a range after a resume is harmless.
+// Links that only exist in the target are left alone; run Diff to find them.
+func (s *rebuildService) Rebuild(ctx context.Context, req *dto.RebuildRequest) (*dto.RebuildReport, error) {
+	startedAt := time.Now()
+	applyRebuildDefaults(req)
+
+	cp, resumed, err := s.startCheckpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	global.LoggerZap.Info("Starting link store rebuild",
+		zap.Int("ranges", len(cp.Ranges)), zap.Int("pending", cp.Pending()), zap.Bool("resumed", resumed))
+
+	progress := &rebuildProgress{store: s.checkpoint, checkpoint: cp}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(req.Workers)
+	for _, rng := range cp.Ranges {
+		if rng.Done {
+			continue
+		}
+		g.Go(func() error {
+			return s.copyRange(gctx, rng, req.BatchSize, progress)
+		})
+	}
+	runErr := g.Wait()
+
+	// Always persist where we got to, so a failed run can pick up from here
+	if err := progress.flush(ctx); err != nil {
+		global.LoggerZap.Error("Failed to save rebuild checkpoint", zap.Error(err))
+	}
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	warmed, err := s.warm(ctx, req.WarmTop)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkpoint.Clear(ctx); err != nil {
+		global.LoggerZap.Warn("Failed to clear rebuild checkpoint", zap.Error(err))
+	}
+
+	return &dto.RebuildReport{
+		Ranges:   len(cp.Ranges),
+		Resumed:  resumed,
+		Loaded:   cp.Loaded,
+		Warmed:   warmed,
+		Duration: time.Since(startedAt).Round(time.Millisecond).String(),
+	}, nil
+}
+
+// Diff compares source and target range by range and reports links that are missing, stale or unexpected in the target
+func (s *rebuildService) Diff(ctx context.Context, req *dto.DiffRequest) (*dto.DiffReport, error) {
+	startedAt := time.Now()
+	if req.Ranges <= 0 {
+		req.Ranges = constant.RebuildDefaultRanges
+	}
+	if req.Workers <= 0 {
+		req.Workers = constant.RebuildDefaultWorkers
+	}
+	if req.SampleSize < 0 {
+		req.SampleSize = 0
+	}
+
+	d := &diffCollector{sampleSize: req.SampleSize}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(req.Workers)
+	for _, rng := range widecolumn.SplitTokenRing(req.Ranges) {
+		g.Go(func() error {
+			return s.diffRange(gctx, rng, d)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := d.report()
+	report.Duration = time.Since(startedAt).Round(time.Millisecond).String()
+	return report, nil
+}
+
+// startCheckpoint resumes the saved run when asked to, or lays out a fresh set of ranges
+func (s *rebuildService) startCheckpoint(ctx context.Context, req *dto.RebuildRequest) (*entity.RebuildCheckpoint, bool, error) {
+	if req.Resume {
+		cp, err := s.checkpoint.Load(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("load rebuild checkpoint: %w", err)
+		}
+		if cp != nil {
+			return cp, true, nil
+		}
+	}
+
+	now := time.Now()
+	ranges := widecolumn.SplitTokenRing(req.Ranges)
+	cp := &entity.RebuildCheckpoint{
+		Ranges:    make([]*entity.RebuildRange, len(ranges)),
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	for i, rng := range ranges {
+		cp.Ranges[i] = &entity.RebuildRange{Start: rng.Start, End: rng.End, Next: rng.Start}
+	}
+	return cp, false, nil
+}
+
+// copyRange copies one token range from Next onwards in batches, recording progress after each batch
+func (s *rebuildService) copyRange(ctx context.Context, rng *entity.RebuildRange, batchSize int, progress *rebuildProgress) error {
+	batch := make([]*entity.Link, 0, batchSize)
+	var lastToken int64
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		// Stamp each copy with the source row's update time, so a newer CDC write to the same link is never overwritten
+		changes := make([]*entity.LinkChange, len(batch))
+		ids := make([]string, len(batch))
+		for i, link := range batch {
+			changes[i] = &entity.LinkChange{Link: link, Version: link.UpdatedAt.UnixMilli()}
+			ids[i] = link.ID
+		}
+		if err := s.target.ApplyChanges(ctx, changes); err != nil {
+			return fmt.Errorf("write links in range [%d, %d]: %w", rng.Start, rng.End, err)
+		}
+		// Drop "not found" markers left over from before the rebuild
+		if err := s.linkCache.DeleteMissBulk(ctx, ids); err != nil {
+			global.LoggerZap.Warn("Failed to clear miss markers", zap.Error(err))
+		}
+
+		// Resume from the last token written; rows sharing it are simply rewritten
+		progress.advance(ctx, rng, lastToken, int64(len(batch)))
+		batch = batch[:0]
+		return nil
+	}
+
+	scan := widecolumn.TokenRange{Start: rng.Next, End: rng.End}
+	err := s.source.ScanRange(ctx, scan, func(token int64, link *entity.Link) error {
+		batch = append(batch, link)
+		lastToken = token
+		if len(batch) >= batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan links in range [%d, %d]: %w", rng.Start, rng.End, err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	progress.done(ctx, rng)
+	return nil
+}
+
+// warm loads the most clicked links into the cache so the first requests after a rebuild don't all miss
+func (s *rebuildService) warm(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	ids, err := s.clickRepo.Top(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("load most clicked links: %w", err)
+	}
+
+	warmed := 0
+	for _, id := range ids {
+		link, err := s.target.GetOriginalURL(ctx, id)
+		if err != nil {
+			global.LoggerZap.Debug("Skipping cache warm-up for link", zap.String("shortCode", id), zap.Error(err))
+			continue
+		}
+		if err := s.linkCache.Set(ctx, link); err != nil {
+			global.LoggerZap.Warn("Failed to warm link cache", zap.String("shortCode", id), zap.Error(err))
+			continue
+		}
+		warmed++
+	}
+	return warmed, nil
+}
+
+// diffRange holds the target side of one range in memory and checks the source side against it
+func (s *rebuildService) diffRange(ctx context.Context, rng widecolumn.TokenRange, d *diffCollector) error {
+	target := make(map[string]*entity.Link)
+	err := s.target.ScanRange(ctx, rng, func(_ int64, link *entity.Link) error {
+		target[link.ID] = link
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan target range [%d, %d]: %w", rng.Start, rng.End, err)
+	}
+	d.target.Add(int64(len(target)))
+
+	err = s.source.ScanRange(ctx, rng, func(_ int64, link *entity.Link) error {
+		d.source.Add(1)
+		existing, ok := target[link.ID]
+		switch {
+		case !ok:
+			d.record(&d.missing, &d.missingSample, link.ID)
+		case !link.SameState(existing):
+			d.record(&d.changed, &d.changedSample, link.ID)
+		}
+		delete(target, link.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan source range [%d, %d]: %w", rng.Start, rng.End, err)
+	}
+
+	for id := range target {
+		d.record(&d.extra, &d.extraSample, id)
+	}
+	return nil
+}
+
+func applyRebuildDefaults(req *dto.RebuildRequest) {
+	if req.Ranges <= 0 {
+		req.Ranges = constant.RebuildDefaultRanges
+	}
+	if req.Workers <= 0 {
+		req.Workers = constant.RebuildDefaultWorkers
+	}
+	if req.BatchSize <= 0 {
+		req.BatchSize = constant.RebuildDefaultBatchSize
+	}
+}
+
+// rebuildProgress serialises checkpoint updates from the range workers and throttles how often they hit disk
+type rebuildProgress struct {
+	mu         sync.Mutex
+	store      ports.RebuildCheckpointStore
+	checkpoint *entity.RebuildCheckpoint
+	savedAt    time.Time
+}
+
+func (p *rebuildProgress) advance(ctx context.Context, rng *entity.RebuildRange, next int64, loaded int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rng.Next = next
+	p.checkpoint.Loaded += loaded
+	if time.Since(p.savedAt) >= constant.RebuildCheckpointEvery {
+		p.saveLocked(ctx)
+	}
+}
+
+func (p *rebuildProgress) done(ctx context.Context, rng *entity.RebuildRange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rng.Done = true
+	p.saveLocked(ctx)
+}
+
+func (p *rebuildProgress) flush(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checkpoint.UpdatedAt = time.Now()
+	return p.store.Save(ctx, p.checkpoint)
+}
+
+func (p *rebuildProgress) saveLocked(ctx context.Context) {
+	p.checkpoint.UpdatedAt = time.Now()
+	if err := p.store.Save(ctx, p.checkpoint); err != nil {
+		global.LoggerZap.Warn("Failed to save rebuild checkpoint", zap.Error(err))
+		return
+	}
+	p.savedAt = p.checkpoint.UpdatedAt
+}
+
+// diffCollector aggregates per-range diff results
+type diffCollector struct {
+	source, target          atomic.Int64
+	missing, changed, extra atomic.Int64
+
+	mu            sync.Mutex
+	sampleSize    int
+	missingSample []string
+	changedSample []string
+	extraSample   []string
+}
+
+func (d *diffCollector) record(counter *atomic.Int64, sample *[]string, id string) {
+	counter.Add(1)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(*sample) < d.sampleSize {
+		*sample = append(*sample, id)
+	}
+}
+
+func (d *diffCollector) report() *dto.DiffReport {
+	return &dto.DiffReport{
+		Source:        d.source.Load(),
+		Target:        d.target.Load(),
+		Missing:       d.missing.Load(),
+		Changed:       d.changed.Load(),
+		Extra:         d.extra.Load(),
+		MissingSample: d.missingSample,
+		ChangedSample: d.changedSample,
+		ExtraSample:   d.extraSample,
+	}
+}
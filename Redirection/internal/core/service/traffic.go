@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go-link/common/pkg/common/apperr"
+	"go-link/common/pkg/common/http/response"
+	"go-link/common/pkg/constraints"
+	"go-link/common/pkg/database/widecolumn"
+
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/core/mapper"
+	"go-link/redirection/internal/ports"
+)
+
+const trafficServiceName = "TrafficService"
+
+// localSettings is a tenant's settings as last read by this instance
+type localSettings struct {
+	settings *entity.TrafficSettings
+	loadedAt time.Time
+}
+
+type trafficService struct {
+	settingsRepo  ports.TrafficSettingsRepository
+	settingsCache ports.TrafficSettingsCacheRepository
+	local         sync.Map // tenant ID -> *localSettings
+}
+
+func NewTrafficService(settingsRepo ports.TrafficSettingsRepository, settingsCache ports.TrafficSettingsCacheRepository) ports.TrafficService {
+	return &trafficService{
+		settingsRepo:  settingsRepo,
+		settingsCache: settingsCache,
+	}
+}
+
+// GetSettings returns the traffic settings of the caller's tenant
+func (s *trafficService) GetSettings(ctx context.Context, _ *dto.GetTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(trafficServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	settings, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, apperr.NewError(trafficServiceName, response.CodeDatabaseError, apperr.MsgGetFailed, http.StatusInternalServerError, err)
+	}
+
+	return mapper.ToTrafficSettingsResponse(settings), nil
+}
+
+// UpdateSettings saves the traffic settings of the caller's tenant
+func (s *trafficService) UpdateSettings(ctx context.Context, req *dto.UpdateTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error) {
+	tenantID, ok := ctx.Value(constraints.ContextKeyTenantID).(int)
+	if !ok || tenantID == 0 {
+		return nil, apperr.NewError(trafficServiceName, response.CodeBadRequest, constant.MsgTenantRequired, http.StatusBadRequest, nil)
+	}
+
+	settings := &entity.TrafficSettings{
+		TenantID:    tenantID,
+		ExcludeBots: *req.ExcludeBots,
+	}
+
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, apperr.NewError(trafficServiceName, response.CodeDatabaseError, apperr.MsgSaveFailed, http.StatusInternalServerError, err)
+	}
+
+	if err := s.settingsCache.Delete(ctx, tenantID); err != nil {
+		global.LoggerZap.Error("Failed to invalidate traffic settings cache", zap.Int("tenantID", tenantID), zap.Error(err))
+	}
+	// Other instances pick the change up when their local copy expires
+	s.local.Delete(tenantID)
+
+	return mapper.ToTrafficSettingsResponse(settings), nil
+}
+
+// ExcludesBots reports whether the tenant keeps automated clicks out of analytics.
+// It runs on the redirect path, so the settings are kept in process for a short while.
+// Lookup errors fall back to keeping the clicks, tagged with their class.
+func (s *trafficService) ExcludesBots(ctx context.Context, tenantID int) bool {
+	if tenantID == 0 {
+		return false
+	}
+
+	if v, ok := s.local.Load(tenantID); ok {
+		if cached := v.(*localSettings); time.Since(cached.loadedAt) < constant.TrafficSettingsLocalTTL {
+			return cached.settings.ExcludeBots
+		}
+	}
+
+	settings, err := s.load(ctx, tenantID)
+	if err != nil {
+		global.LoggerZap.Warn("Failed to load traffic settings", zap.Int("tenantID", tenantID), zap.Error(err))
+		return false
+	}
+
+	s.local.Store(tenantID, &localSettings{settings: settings, loadedAt: time.Now()})
+	return settings.ExcludeBots
+}
+
+// load reads the settings through the cache; tenants without a row get the defaults
+func (s *trafficService) load(ctx context.Context, tenantID int) (*entity.TrafficSettings, error) {
+	if settings, err := s.settingsCache.Get(ctx, tenantID); err == nil {
+		return settings, nil
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		if !errors.Is(err, widecolumn.ErrNotFound) {
+			return nil, err
+		}
+		settings = &entity.TrafficSettings{TenantID: tenantID}
+	}
+
+	if err := s.settingsCache.Set(ctx, settings); err != nil {
+		global.LoggerZap.Error("Failed to set traffic settings in cache", zap.Error(err))
+	}
+
+	return settings, nil
+}
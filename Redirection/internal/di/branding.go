@@ -0,0 +1,34 @@
+package di
+
+import (
+	"go-link/redirection/global"
+	"go-link/redirection/internal/adapters/driven/cache"
+	db "go-link/redirection/internal/adapters/driven/db"
+	driverHttp "go-link/redirection/internal/adapters/driver/http"
+	"go-link/redirection/internal/core/service"
+	"go-link/redirection/internal/ports"
+)
+
+type BrandingContainer struct {
+	Service ports.BrandingService
+	Handler driverHttp.BrandingHandler
+}
+
+func InitBrandingDependencies(clientContainer *ClientContainer) *BrandingContainer {
+	// Cache
+	brandingCache := cache.NewBranding(global.Redis)
+
+	// Repository
+	brandingRepo := db.NewTenantBrandingRepository()
+
+	// Service
+	service := service.NewBrandingService(brandingRepo, brandingCache, clientContainer.IdentityClient)
+
+	// Handler
+	handler := driverHttp.NewBrandingHandler(service)
+
+	return &BrandingContainer{
+		Service: service,
+		Handler: handler,
+	}
+}
@@ -0,0 +1,36 @@
+package di
+
+import (
+	"go.uber.org/zap"
+
+	generationv1 "go-link/common/gen/go/generation/v1"
+	identityv1 "go-link/common/gen/go/identity/v1"
+	common_grpc "go-link/common/pkg/grpc"
+	"go-link/redirection/global"
+)
+
+type ClientContainer struct {
+	IdentityClient   identityv1.IdentityServiceClient
+	GenerationClient generationv1.GenerationServiceClient
+}
+
+func InitClients() *ClientContainer {
+	// Identity Client
+	identityConn, err := common_grpc.NewClientConn(global.Config.Services.IdentityService)
+	if err != nil {
+		global.LoggerZap.Fatal("Failed to connect to Identity Service", zap.Error(err))
+	}
+	identityClient := identityv1.NewIdentityServiceClient(identityConn)
+
+	// Generation Client
+	generationConn, err := common_grpc.NewClientConn(global.Config.Services.GenerationService)
+	if err != nil {
+		global.LoggerZap.Fatal("Failed to connect to Generation Service", zap.Error(err))
+	}
+	generationClient := generationv1.NewGenerationServiceClient(generationConn)
+
+	return &ClientContainer{
+		IdentityClient:   identityClient,
+		GenerationClient: generationClient,
+	}
+}
@@ -1,7 +1,12 @@
 package di
 
 type Container struct {
-	LinkContainer *LinkContainer
+	LinkContainer       *LinkContainer
+	HotLinkContainer    *HotLinkContainer
+	TrafficContainer    *TrafficContainer
+	BrandingContainer   *BrandingContainer
+	MembershipContainer *MembershipContainer
+	ClientContainer     *ClientContainer
 }
 
 var GlobalContainer *Container
@@ -0,0 +1,40 @@
+package di
+
+import (
+	"go-link/redirection/global"
+	"go-link/redirection/internal/adapters/driven/cache"
+	"go-link/redirection/internal/adapters/driven/producer"
+	driverHttp "go-link/redirection/internal/adapters/driver/http"
+	"go-link/redirection/internal/adapters/driver/worker"
+	"go-link/redirection/internal/core/service"
+	"go-link/redirection/internal/ports"
+)
+
+type HotLinkContainer struct {
+	Service ports.HotLinkService
+	Worker  ports.HotLinkWorker
+	Handler driverHttp.HotLinkHandler
+}
+
+func InitHotLinkDependencies(link *LinkContainer) *HotLinkContainer {
+	// Cache
+	clickRepo := cache.NewLinkClicks(global.Redis)
+
+	// Producer
+	spikePublisher := producer.NewSpikePublisher(link.Producer)
+
+	// Service
+	service := service.NewHotLinkService(link.Repository, clickRepo, link.Cache, link.HotTracker, link.Pinned, spikePublisher)
+
+	// Handler
+	handler := driverHttp.NewHotLinkHandler(service)
+
+	// Worker
+	hotLinkWorker := worker.NewHotLinkWorker(service)
+
+	return &HotLinkContainer{
+		Service: service,
+		Worker:  hotLinkWorker,
+		Handler: handler,
+	}
+}
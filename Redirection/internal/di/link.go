@@ -1,6 +1,9 @@
 package di
 
 import (
+	"time"
+
+	"go-link/common/pkg/geoip"
 	"go-link/common/pkg/mq/kafka"
 
 	"go.uber.org/zap"
@@ -8,31 +11,108 @@ import (
 	"go-link/redirection/global"
 	"go-link/redirection/internal/adapters/driven/cache"
 	db "go-link/redirection/internal/adapters/driven/db"
+	"go-link/redirection/internal/adapters/driven/geo"
+	"go-link/redirection/internal/adapters/driven/producer"
 	linkconsumer "go-link/redirection/internal/adapters/driver/consumer/link"
 	driverHttp "go-link/redirection/internal/adapters/driver/http"
+	"go-link/redirection/internal/adapters/driver/worker"
+	"go-link/redirection/internal/constant"
 	"go-link/redirection/internal/core/service"
+	"go-link/redirection/internal/infrastructure/filter"
+	"go-link/redirection/internal/infrastructure/hotlink"
+	"go-link/redirection/internal/infrastructure/metrics"
 	"go-link/redirection/internal/ports"
 )
 
 type LinkContainer struct {
-	Repository ports.LinkRepository
-	Service    ports.LinkService
-	Consumer   ports.LinkConsumer
-	Handler    driverHttp.LinkHandler
+	Repository     ports.LinkRepository
+	Cache          ports.LinkCacheRepository
+	HotTracker     ports.HotLinkTracker
+	Pinned         ports.PinnedLinks
+	Metrics        ports.LinkMetrics
+	Producer       kafka.Producer
+	DLQProducer    kafka.SyncProducer
+	Service        ports.LinkService
+	Consumer       ports.LinkConsumer
+	FilterWorker   ports.LinkFilterWorker
+	ClickPublisher ports.ClickPublisher
+	GeoDatabase    *geoip.Database // nil when GeoIP is not configured
+	Handler        driverHttp.LinkHandler
 }
 
-func InitLinkDependencies() *LinkContainer {
+func InitLinkDependencies(clients *ClientContainer, traffic *TrafficContainer, branding *BrandingContainer, membership *MembershipContainer) *LinkContainer {
 	// Cache
+	missLimiter := cache.NewMissLimiter(global.Redis)
+	fallbackLimiter := cache.NewFallbackLimiter(global.Redis)
 	cache := cache.NewLink(global.Redis)
 
+	// Filter
+	linkFilter, err := filter.NewLink(constant.LinkFilterCapacity, constant.LinkFilterFPRate)
+	if err != nil {
+		global.LoggerZap.Fatal("failed to create link filter", zap.Error(err))
+	}
+
 	// Repository
 	repository := db.NewLinkRepository()
 
+	// Hot links
+	hotTracker := hotlink.NewTracker()
+	pinned := hotlink.NewPinned()
+
+	// Producer
+	eventProducer, err := kafka.NewProducer(&kafka.Config{
+		Brokers:  global.Config.Kafka.Brokers,
+		ClientID: "link-events",
+		ProducerInfo: kafka.ProducerConfig{
+			FlushFrequency:  global.Config.Kafka.FlushFrequency,
+			FlushBytes:      global.Config.Kafka.FlushBytes,
+			MaxMessageBytes: global.Config.Kafka.MaxMessageBytes,
+			MaxRetries:      global.Config.Kafka.MaxRetries,
+			RetryBackoff:    global.Config.Kafka.RetryBackoff,
+		},
+	})
+	if err != nil {
+		global.LoggerZap.Fatal("failed to create link event producer", zap.Error(err))
+	}
+	clickPublisher := producer.NewClickPublisher(eventProducer)
+
+	// Metrics
+	linkMetrics := metrics.NewLink()
+
+	// Options
+	opts := []service.Option{
+		service.WithGenerationFallback(clients.GenerationClient, fallbackLimiter),
+	}
+	if global.Config.LinkCache.LoadLock {
+		opts = append(opts, service.WithLoadLock())
+	}
+	// GeoIP
+	geoDatabase := newGeoDatabase()
+	if geoDatabase != nil {
+		opts = append(opts, service.WithGeoLocator(geo.NewLocator(geoDatabase)))
+	}
+
 	// Service
-	service := service.NewLinkService(repository, cache)
+	service := service.NewLinkService(
+		repository,
+		cache,
+		linkFilter,
+		missLimiter,
+		clickPublisher,
+		hotTracker,
+		pinned,
+		traffic.Classifier,
+		traffic.Service,
+		membership.Service,
+		linkMetrics,
+		opts...,
+	)
 
 	// Handler
-	handler := driverHttp.NewLinkHandler(service)
+	handler := driverHttp.NewLinkHandler(service, branding.Service, membership.Service)
+
+	// Worker
+	filterWorker := worker.NewFilterWorker(service)
 
 	// Consumer
 	kafkaCfg := &kafka.Config{
@@ -52,15 +132,46 @@ func InitLinkDependencies() *LinkContainer {
 		},
 	}
 
-	consumer, err := linkconsumer.NewCDCConsumer(kafkaCfg, service)
+	dlqProducer, err := kafka.NewSyncProducer(kafkaCfg)
+	if err != nil {
+		global.LoggerZap.Fatal("failed to create link cdc dlq producer", zap.Error(err))
+	}
+
+	consumer, err := linkconsumer.NewCDCConsumer(kafkaCfg, service, dlqProducer)
 	if err != nil {
 		global.LoggerZap.Fatal("failed to create link cdc consumer", zap.Error(err))
 	}
 
 	return &LinkContainer{
-		Repository: repository,
-		Service:    service,
-		Consumer:   consumer,
-		Handler:    handler,
+		Repository:     repository,
+		Cache:          cache,
+		HotTracker:     hotTracker,
+		Pinned:         pinned,
+		Metrics:        linkMetrics,
+		Producer:       eventProducer,
+		DLQProducer:    dlqProducer,
+		Service:        service,
+		Consumer:       consumer,
+		FilterWorker:   filterWorker,
+		ClickPublisher: clickPublisher,
+		GeoDatabase:    geoDatabase,
+		Handler:        handler,
+	}
+}
+
+// newGeoDatabase opens the GeoIP database; clicks go without a location when it is not configured or cannot be read
+func newGeoDatabase() *geoip.Database {
+	cfg := global.Config.GeoIP
+	if cfg.DatabasePath == "" {
+		return nil
+	}
+
+	database, err := geoip.NewDatabase(cfg.DatabasePath, time.Duration(cfg.ReloadInterval)*time.Second, func(err error) {
+		global.LoggerZap.Warn("failed to reload geoip database", zap.Error(err))
+	})
+	if err != nil {
+		global.LoggerZap.Error("failed to open geoip database", zap.String("path", cfg.DatabasePath), zap.Error(err))
+		return nil
 	}
+	return database
 }
@@ -0,0 +1,24 @@
+package di
+
+import (
+	"go-link/redirection/global"
+	"go-link/redirection/internal/adapters/driven/cache"
+	"go-link/redirection/internal/core/service"
+	"go-link/redirection/internal/ports"
+)
+
+type MembershipContainer struct {
+	Service ports.MembershipService
+}
+
+func InitMembershipDependencies(clientContainer *ClientContainer) *MembershipContainer {
+	// Cache
+	membershipCache := cache.NewMembership(global.Redis)
+
+	// Service
+	service := service.NewMembershipService(membershipCache, clientContainer.IdentityClient)
+
+	return &MembershipContainer{
+		Service: service,
+	}
+}
@@ -0,0 +1,37 @@
+package di
+
+import (
+	"github.com/gocql/gocql"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/adapters/driven/cache"
+	db "go-link/redirection/internal/adapters/driven/db"
+	"go-link/redirection/internal/core/service"
+	"go-link/redirection/internal/infrastructure/rebuild"
+	"go-link/redirection/internal/ports"
+)
+
+type RebuildContainer struct {
+	Service ports.RebuildService
+}
+
+// InitRebuildDependencies wires the offline rebuild tool; source is a session bound to Generation's keyspace
+func InitRebuildDependencies(source *gocql.Session, checkpointPath string) *RebuildContainer {
+	// Cache
+	linkCache := cache.NewLink(global.Redis)
+	clickRepo := cache.NewLinkClicks(global.Redis)
+
+	// Repository
+	sourceRepo := db.NewLinkRepositoryWithSession(source)
+	targetRepo := db.NewLinkRepository()
+
+	// Checkpoint
+	checkpoint := rebuild.NewFileCheckpoint(checkpointPath)
+
+	// Service
+	service := service.NewRebuildService(sourceRepo, targetRepo, linkCache, clickRepo, checkpoint)
+
+	return &RebuildContainer{
+		Service: service,
+	}
+}
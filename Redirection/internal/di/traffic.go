@@ -0,0 +1,52 @@
+package di
+
+import (
+	"go.uber.org/zap"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/adapters/driven/cache"
+	db "go-link/redirection/internal/adapters/driven/db"
+	driverHttp "go-link/redirection/internal/adapters/driver/http"
+	"go-link/redirection/internal/adapters/driver/worker"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/service"
+	"go-link/redirection/internal/infrastructure/botdetect"
+	"go-link/redirection/internal/ports"
+)
+
+type TrafficContainer struct {
+	Classifier ports.BotClassifier
+	Service    ports.TrafficService
+	Worker     ports.BotRulesWorker
+	Handler    driverHttp.TrafficHandler
+}
+
+func InitTrafficDependencies() *TrafficContainer {
+	// Classifier
+	classifier, err := botdetect.NewClassifier(constant.BotRulesPath)
+	if err != nil {
+		global.LoggerZap.Fatal("failed to create bot classifier", zap.Error(err))
+	}
+
+	// Cache
+	settingsCache := cache.NewTrafficSettings(global.Redis)
+
+	// Repository
+	settingsRepo := db.NewTenantSettingRepository()
+
+	// Service
+	service := service.NewTrafficService(settingsRepo, settingsCache)
+
+	// Handler
+	handler := driverHttp.NewTrafficHandler(service)
+
+	// Worker
+	rulesWorker := worker.NewBotRulesWorker(classifier)
+
+	return &TrafficContainer{
+		Classifier: classifier,
+		Service:    service,
+		Worker:     rulesWorker,
+		Handler:    handler,
+	}
+}
@@ -1,8 +1,19 @@
 package di
 
 func SetupDependencies() *Container {
+	clientContainer := InitClients()
+	trafficContainer := InitTrafficDependencies()
+	brandingContainer := InitBrandingDependencies(clientContainer)
+	membershipContainer := InitMembershipDependencies(clientContainer)
+	linkContainer := InitLinkDependencies(clientContainer, trafficContainer, brandingContainer, membershipContainer)
+
 	container := &Container{
-		LinkContainer: InitLinkDependencies(),
+		LinkContainer:       linkContainer,
+		HotLinkContainer:    InitHotLinkDependencies(linkContainer),
+		TrafficContainer:    trafficContainer,
+		BrandingContainer:   brandingContainer,
+		MembershipContainer: membershipContainer,
+		ClientContainer:     clientContainer,
 	}
 	GlobalContainer = container
 	return container
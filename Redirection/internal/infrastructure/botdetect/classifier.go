@@ -0,0 +1,135 @@
+package botdetect
+
+import (
+	"math/bits"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-link/common/pkg/datastructs/sketch"
+	"go-link/common/pkg/hash"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+// Classifier tags redirects as human, bot or link preview.
+// Signatures come first, then crawler IP ranges, then behavioral heuristics:
+// a missing or non-browser User-Agent and bursts of redirects from a single IP.
+type Classifier struct {
+	path    string
+	rules   atomic.Pointer[ruleSet]
+	modTime time.Time
+
+	bursts [constant.BotBurstShards]burstShard
+}
+
+// burstShard counts redirects for the client IPs hashed to it, so concurrent redirects rarely share a lock
+type burstShard struct {
+	mu     sync.Mutex
+	sketch *sketch.Sketch
+}
+
+// NewClassifier creates a classifier from the embedded rules.
+// When path points to a rules file, Reload replaces the embedded rules with it.
+func NewClassifier(path string) (ports.BotClassifier, error) {
+	rs, err := parseRules(strings.NewReader(defaultRules))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Classifier{path: path}
+	for i := range c.bursts {
+		c.bursts[i].sketch = sketch.New(constant.BotBurstCounters / constant.BotBurstShards)
+	}
+	c.rules.Store(rs)
+	return c, nil
+}
+
+// Classify decides the traffic class of a single redirect.
+func (c *Classifier) Classify(sig *entity.ClickSignal) entity.TrafficClass {
+	rs := c.rules.Load()
+	ua := strings.ToLower(sig.UserAgent)
+
+	if class, ok := rs.matchAgent(ua); ok {
+		return class
+	}
+
+	if ip := net.ParseIP(sig.ClientIP); ip != nil {
+		if class, ok := rs.matchIP(ip); ok {
+			return class
+		}
+	}
+
+	// Every mainstream browser sends a Mozilla token and an Accept-Language header
+	if ua == "" || (!strings.Contains(ua, "mozilla") && sig.AcceptLanguage == "") {
+		return entity.TrafficBot
+	}
+
+	if sig.ClientIP != "" && c.countBurst(sig.ClientIP) > constant.BotBurstThreshold {
+		return entity.TrafficBot
+	}
+
+	return entity.TrafficHuman
+}
+
+func (c *Classifier) countBurst(clientIP string) int64 {
+	_, h := hash.KeyToHash(clientIP)
+	// The sketch indexes with the low bits, so the shard is picked with the high ones
+	s := &c.bursts[h>>(64-bits.TrailingZeros(constant.BotBurstShards))]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sketch.Increment(h)
+	return s.sketch.Estimate(h)
+}
+
+// ResetWindow starts a new burst window.
+func (c *Classifier) ResetWindow() {
+	for i := range c.bursts {
+		s := &c.bursts[i]
+		s.mu.Lock()
+		s.sketch.Clear()
+		s.mu.Unlock()
+	}
+}
+
+// Reload swaps in the rules file when it changed since the last load.
+// A missing file keeps the current rules; a malformed one is rejected as a whole.
+func (c *Classifier) Reload() (bool, error) {
+	if c.path == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !info.ModTime().After(c.modTime) {
+		return false, nil
+	}
+
+	f, err := os.Open(c.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	rs, err := parseRules(f)
+	if err != nil {
+		return false, err
+	}
+
+	c.rules.Store(rs)
+	c.modTime = info.ModTime()
+	return true, nil
+}
@@ -0,0 +1,97 @@
+package botdetect
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"go-link/redirection/internal/core/entity"
+)
+
+//go:embed rules.txt
+var defaultRules string
+
+type uaRule struct {
+	pattern string
+	class   entity.TrafficClass
+}
+
+type netRule struct {
+	network *net.IPNet
+	class   entity.TrafficClass
+}
+
+// ruleSet is an immutable, parsed rules file.
+type ruleSet struct {
+	agents   []uaRule
+	networks []netRule
+}
+
+// parseRules reads rules in the "<kind> <class> <pattern>" line format.
+// Blank lines and lines starting with # are ignored.
+func parseRules(r io.Reader) (*ruleSet, error) {
+	rs := &ruleSet{}
+	scanner := bufio.NewScanner(r)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(text, " ", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected <kind> <class> <pattern>", line)
+		}
+
+		class := entity.TrafficClass(fields[1])
+		if class != entity.TrafficBot && class != entity.TrafficPreview {
+			return nil, fmt.Errorf("line %d: unknown class %q", line, fields[1])
+		}
+
+		pattern := strings.TrimSpace(fields[2])
+		switch fields[0] {
+		case "ua":
+			rs.agents = append(rs.agents, uaRule{pattern: strings.ToLower(pattern), class: class})
+		case "ip":
+			_, network, err := net.ParseCIDR(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			rs.networks = append(rs.networks, netRule{network: network, class: class})
+		default:
+			return nil, fmt.Errorf("line %d: unknown kind %q", line, fields[0])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// matchAgent returns the class of the first rule found in the lower-cased User-Agent.
+func (rs *ruleSet) matchAgent(ua string) (entity.TrafficClass, bool) {
+	for _, rule := range rs.agents {
+		if strings.Contains(ua, rule.pattern) {
+			return rule.class, true
+		}
+	}
+	return "", false
+}
+
+// matchIP returns the class of the first range containing the IP.
+func (rs *ruleSet) matchIP(ip net.IP) (entity.TrafficClass, bool) {
+	for _, rule := range rs.networks {
+		if rule.network.Contains(ip) {
+			return rule.class, true
+		}
+	}
+	return "", false
+}
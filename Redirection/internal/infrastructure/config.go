@@ -23,6 +23,9 @@ func LoadConfig() {
 	viper.SetConfigFile(configFile)
 	viper.SetConfigType("yaml")
 
+	// Defaults for settings an environment may leave out
+	viper.SetDefault("link_cache.load_lock", true)
+
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
 		panic(fmt.Sprintf("Failed to read config file: %v", err))
@@ -0,0 +1,102 @@
+package filter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go-link/common/pkg/datastructs/bloom"
+	"go-link/common/pkg/hash"
+
+	"go-link/redirection/internal/ports"
+)
+
+// Link is a Bloom filter over known short codes.
+// It answers "maybe" for everything until the first full rebuild has completed,
+// so a cold instance never rejects a code that exists.
+type Link struct {
+	mu       sync.RWMutex
+	current  *bloom.Bloom
+	next     *bloom.Bloom // non-nil while a rebuild is in progress
+	ready    atomic.Bool
+	capacity uint64
+	fpRate   float64
+}
+
+// NewLink creates an empty link filter sized for capacity codes at the given false positive rate.
+func NewLink(capacity uint64, fpRate float64) (ports.LinkFilter, error) {
+	b, err := bloom.New(capacity, fpRate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Link{
+		current:  b,
+		capacity: capacity,
+		fpRate:   fpRate,
+	}, nil
+}
+
+func (f *Link) hash(id string) uint64 {
+	_, h := hash.KeyToHash(id)
+	return h
+}
+
+// Add records a short code as existing.
+func (f *Link) Add(id string) {
+	h := f.hash(id)
+
+	f.mu.Lock()
+	f.current.Add(h)
+	if f.next != nil {
+		f.next.Add(h)
+	}
+	f.mu.Unlock()
+}
+
+// MightContain returns false only when the code definitely does not exist.
+func (f *Link) MightContain(id string) bool {
+	if !f.ready.Load() {
+		return true
+	}
+
+	h := f.hash(id)
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.current.Has(h)
+}
+
+// Rebuild fills a fresh filter from scan and swaps it in on success.
+// Codes added concurrently are written to both filters so none are lost.
+func (f *Link) Rebuild(ctx context.Context, scan func(ctx context.Context, fn func(id string)) error) error {
+	next, err := bloom.New(f.capacity, f.fpRate)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.next = next
+	f.mu.Unlock()
+
+	err = scan(ctx, func(id string) {
+		h := f.hash(id)
+		f.mu.Lock()
+		next.Add(h)
+		f.mu.Unlock()
+	})
+
+	f.mu.Lock()
+	f.next = nil
+	if err == nil {
+		f.current = next
+	}
+	f.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	f.ready.Store(true)
+	return nil
+}
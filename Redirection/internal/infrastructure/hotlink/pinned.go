@@ -0,0 +1,57 @@
+package hotlink
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+// Pinned keeps hot links in process memory, outside of any eviction policy.
+// Reads are lock-free; writers copy the map.
+type Pinned struct {
+	mu    sync.Mutex
+	links atomic.Pointer[map[string]*entity.Link]
+}
+
+// NewPinned creates an empty pinned link store.
+func NewPinned() ports.PinnedLinks {
+	p := &Pinned{}
+	p.links.Store(&map[string]*entity.Link{})
+	return p
+}
+
+// Get returns the pinned link for the short code, if any.
+func (p *Pinned) Get(id string) (*entity.Link, bool) {
+	link, ok := (*p.links.Load())[id]
+	return link, ok
+}
+
+// Replace swaps the pinned set for the given links.
+func (p *Pinned) Replace(links []*entity.Link) {
+	next := make(map[string]*entity.Link, len(links))
+	for _, link := range links {
+		next[link.ID] = link
+	}
+
+	p.mu.Lock()
+	p.links.Store(&next)
+	p.mu.Unlock()
+}
+
+// Remove unpins the given short codes.
+func (p *Pinned) Remove(ids []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current := *p.links.Load()
+	next := make(map[string]*entity.Link, len(current))
+	for id, link := range current {
+		next[id] = link
+	}
+	for _, id := range ids {
+		delete(next, id)
+	}
+	p.links.Store(&next)
+}
@@ -0,0 +1,115 @@
+package hotlink
+
+import (
+	"math/bits"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go-link/common/pkg/datastructs/sketch"
+	"go-link/common/pkg/hash"
+
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+// Tracker finds heavy hitters over fixed windows.
+// A count-min sketch absorbs the long tail; only codes whose estimate crosses the
+// admission threshold within a window get an exact counter.
+// Codes are sharded by hash, so concurrent redirects of different links rarely share a lock.
+type Tracker struct {
+	shards [constant.HotLinkShards]trackerShard
+	top    atomic.Pointer[[]*entity.HotLink]
+}
+
+type trackerShard struct {
+	mu      sync.Mutex
+	sketch  *sketch.Sketch
+	current map[string]int64
+	prev    map[string]int64
+}
+
+// NewTracker creates an empty heavy-hitters tracker.
+func NewTracker() ports.HotLinkTracker {
+	t := &Tracker{}
+	for i := range t.shards {
+		t.shards[i] = trackerShard{
+			sketch:  sketch.New(constant.HotLinkSketchCounters / constant.HotLinkShards),
+			current: make(map[string]int64),
+			prev:    make(map[string]int64),
+		}
+	}
+	t.top.Store(&[]*entity.HotLink{})
+	return t
+}
+
+// Record counts one click on the short code.
+func (t *Tracker) Record(shortCode string) {
+	_, h := hash.KeyToHash(shortCode)
+	// The sketch indexes with the low bits, so the shard is picked with the high ones
+	s := &t.shards[h>>(64-bits.TrailingZeros(constant.HotLinkShards))]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n, ok := s.current[shortCode]; ok {
+		s.current[shortCode] = n + 1
+		return
+	}
+
+	s.sketch.Increment(h)
+	if est := s.sketch.Estimate(h); est >= constant.HotLinkAdmitThreshold && len(s.current) < constant.HotLinkMaxCandidates/constant.HotLinkShards {
+		s.current[shortCode] = est
+	}
+}
+
+// Rotate closes the current window, publishes its top-K and reports links
+// whose clicks jumped by the spike factor over the previous window.
+func (t *Tracker) Rotate() *entity.HotLinkWindow {
+	var all []*entity.HotLink
+	for i := range t.shards {
+		s := &t.shards[i]
+
+		s.mu.Lock()
+		counts, prev := s.current, s.prev
+		s.current = make(map[string]int64, len(counts))
+		s.prev = counts
+		s.sketch.Clear()
+		s.mu.Unlock()
+
+		for code, clicks := range counts {
+			all = append(all, &entity.HotLink{
+				ShortCode:  code,
+				Clicks:     clicks,
+				PrevClicks: prev[code],
+			})
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Clicks > all[j].Clicks })
+
+	window := &entity.HotLinkWindow{}
+	for _, h := range all {
+		if h.Clicks >= constant.HotLinkSpikeMinClicks && h.Clicks >= h.PrevClicks*constant.HotLinkSpikeFactor {
+			window.Spikes = append(window.Spikes, h)
+		}
+	}
+
+	if len(all) > constant.HotLinkTopK {
+		all = all[:constant.HotLinkTopK]
+	}
+	window.Top = all
+	t.top.Store(&all)
+
+	return window
+}
+
+// Top returns up to limit links from the last closed window, busiest first.
+func (t *Tracker) Top(limit int) []*entity.HotLink {
+	top := *t.top.Load()
+	if limit > 0 && limit < len(top) {
+		top = top[:limit]
+	}
+	return top
+}
@@ -0,0 +1,22 @@
+package infrastructure
+
+import (
+	"log"
+	"os"
+
+	"go-link/common/pkg/utils"
+	"go-link/redirection/global"
+)
+
+// SetupKeys loads the RSA public key used to verify JWT tokens on admin routes.
+func SetupKeys() {
+	pubBytes, err := os.ReadFile(global.Config.JWT.PublicKeyPath)
+	if err != nil {
+		log.Fatalf("failed to read public key: %v", err)
+	}
+
+	global.Config.JWT.PublicKey, err = utils.ParseRSAPublicKey(pubBytes)
+	if err != nil {
+		log.Fatalf("failed to parse public key: %v", err)
+	}
+}
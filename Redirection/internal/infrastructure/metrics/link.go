@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"expvar"
+	"time"
+
+	"go-link/redirection/internal/ports"
+)
+
+// Link publishes link pipeline health through expvar, served at /admin/debug/vars.
+type Link struct {
+	cdcLagMs      *expvar.Int
+	cdcBatches    *expvar.Int
+	fallbackCount *expvar.Map
+}
+
+// NewLink registers the link metrics; it must be called once per process.
+func NewLink() ports.LinkMetrics {
+	return &Link{
+		cdcLagMs:      expvar.NewInt("link_cdc_lag_ms"),
+		cdcBatches:    expvar.NewInt("link_cdc_batches_total"),
+		fallbackCount: expvar.NewMap("link_fallback_total"),
+	}
+}
+
+// ObserveCDCLag records how far behind the source database the last applied batch was.
+func (m *Link) ObserveCDCLag(lag time.Duration) {
+	ms := lag.Milliseconds()
+	if ms < 0 {
+		ms = 0
+	}
+
+	m.cdcLagMs.Set(ms)
+	m.cdcBatches.Add(1)
+}
+
+// RecordFallback counts a Generation lookup by outcome.
+func (m *Link) RecordFallback(outcome string) {
+	m.fallbackCount.Add(outcome, 1)
+}
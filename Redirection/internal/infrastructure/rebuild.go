@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go-link/common/pkg/database/widecolumn"
+
+	"go-link/redirection/global"
+	"go-link/redirection/internal/constant"
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/di"
+)
+
+// RebuildOptions are the command-line settings of the rebuild tool
+type RebuildOptions struct {
+	Mode           string
+	SourceKeyspace string
+	CheckpointPath string
+	Rebuild        dto.RebuildRequest
+	Diff           dto.DiffRequest
+}
+
+// RunRebuild repopulates (or diffs) the redirect store against Generation and prints the report as JSON
+func RunRebuild(opts *RebuildOptions) error {
+	LoadConfig()
+	SetupLogger()
+	SetupRedis()
+	SetupWideColumn()
+	defer global.WideColumnClient.Close()
+
+	sourceConfig := global.Config.WideColumn
+	sourceConfig.Keyspace = opts.SourceKeyspace
+	source := widecolumn.NewClient(&sourceConfig)
+	if err := source.Connect(); err != nil {
+		return fmt.Errorf("connect to source keyspace %q: %w", opts.SourceKeyspace, err)
+	}
+	defer source.Close()
+
+	container := di.InitRebuildDependencies(source.GetSession(), opts.CheckpointPath)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var (
+		report any
+		err    error
+	)
+	switch opts.Mode {
+	case constant.RebuildModeRebuild:
+		report, err = container.Service.Rebuild(ctx, &opts.Rebuild)
+	case constant.RebuildModeDiff:
+		report, err = container.Service.Diff(ctx, &opts.Diff)
+	default:
+		return fmt.Errorf("unknown mode %q", opts.Mode)
+	}
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,81 @@
+package rebuild
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"go-link/redirection/internal/core/entity"
+	"go-link/redirection/internal/ports"
+)
+
+// FileCheckpoint keeps rebuild progress in a JSON file.
+// Writes go to a temp file that is renamed over the old one, so a crash never leaves a torn checkpoint.
+type FileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint creates a checkpoint store backed by the file at path
+func NewFileCheckpoint(path string) ports.RebuildCheckpointStore {
+	return &FileCheckpoint{path: path}
+}
+
+// Load reads the checkpoint, or returns nil if no run is in progress
+func (f *FileCheckpoint) Load(_ context.Context) (*entity.RebuildCheckpoint, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint entity.RebuildCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Save atomically replaces the checkpoint file
+func (f *FileCheckpoint) Save(_ context.Context, checkpoint *entity.RebuildCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// Clear removes the checkpoint once a run has finished
+func (f *FileCheckpoint) Clear(_ context.Context) error {
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -1,33 +1,67 @@
 package infrastructure
 
 import (
+	"expvar"
 	"net/http"
 
+	"go-link/common/pkg/common/http/handler"
 	"go-link/common/pkg/common/http/middlewares"
+	"go-link/common/pkg/permissions"
 
 	"github.com/gin-gonic/gin"
 
 	"go-link/redirection/global"
 	driverHttp "go-link/redirection/internal/adapters/driver/http"
+	"go-link/redirection/internal/constant"
 )
 
 // RouterGroup contains all routes
 type RouterGroup struct {
-	LinkHandler driverHttp.LinkHandler
+	LinkHandler     driverHttp.LinkHandler
+	HotLinkHandler  driverHttp.HotLinkHandler
+	TrafficHandler  driverHttp.TrafficHandler
+	BrandingHandler driverHttp.BrandingHandler
 }
 
 // NewRouterGroup creates a new RouterGroup
 func NewRouterGroup(
 	linkHandler driverHttp.LinkHandler,
+	hotLinkHandler driverHttp.HotLinkHandler,
+	trafficHandler driverHttp.TrafficHandler,
+	brandingHandler driverHttp.BrandingHandler,
 ) *RouterGroup {
 	return &RouterGroup{
-		LinkHandler: linkHandler,
+		LinkHandler:     linkHandler,
+		HotLinkHandler:  hotLinkHandler,
+		TrafficHandler:  trafficHandler,
+		BrandingHandler: brandingHandler,
 	}
 }
 
 // registerRoutes registers all routes
 func (rg *RouterGroup) registerRoutes(r *gin.Engine) {
+	// Admin Routes
+	admin := r.Group("/admin")
+	admin.Use(middlewares.Authentication(global.Config.JWT.PublicKey), middlewares.RequireAdmin())
+	{
+		admin.GET("/links/hot", rg.HotLinkHandler.GetTop)
+		admin.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+	}
+
+	// Tenant Routes
+	tenant := r.Group("/tenant")
+	tenant.Use(middlewares.Authentication(global.Config.JWT.PublicKey))
+	{
+		tenant.GET("/traffic-settings", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeRead), handler.Wrap(rg.TrafficHandler.GetSettings))
+		tenant.PUT("/traffic-settings", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeUpdate), handler.Wrap(rg.TrafficHandler.UpdateSettings))
+		tenant.GET("/branding", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeRead), handler.Wrap(rg.BrandingHandler.GetBranding))
+		tenant.PUT("/branding", middlewares.RequirePermission(permissions.ResourceKeyTenant, permissions.PermissionScopeUpdate), handler.Wrap(rg.BrandingHandler.UpdateBranding))
+	}
+
+	r.POST(constant.AuthCallbackPath, rg.LinkHandler.AuthCallback)
+
 	r.GET("/:shortCode", rg.LinkHandler.Redirect)
+	r.POST("/:shortCode", rg.LinkHandler.Redirect)
 }
 
 // Ping
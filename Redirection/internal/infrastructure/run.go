@@ -14,14 +14,71 @@ func Run() error {
 	SetupLogger()
 	SetupRedis()
 	SetupWideColumn()
+	SetupKeys()
 	di.SetupDependencies()
 	http := NewHTTPServer()
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dlqProducer := di.GlobalContainer.LinkContainer.DLQProducer
+	defer func() {
+		if err := dlqProducer.Close(); err != nil {
+			global.LoggerZap.Error("Failed to close dlq producer", zap.Error(err))
+		}
+	}()
 
 	if err := di.GlobalContainer.LinkContainer.Consumer.Start(ctx); err != nil {
 		global.LoggerZap.Error("Link CDC Consumer failed", zap.Error(err))
 	}
 
+	filterWorker := di.GlobalContainer.LinkContainer.FilterWorker
+	go func() {
+		if err := filterWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Link filter worker stopped", zap.Error(err))
+		}
+	}()
+	defer filterWorker.Stop()
+
+	producer := di.GlobalContainer.LinkContainer.Producer
+	defer func() {
+		if err := producer.Close(); err != nil {
+			global.LoggerZap.Error("Failed to close producer", zap.Error(err))
+		}
+	}()
+
+	clickPublisher := di.GlobalContainer.LinkContainer.ClickPublisher
+	go func() {
+		if err := clickPublisher.Start(ctx); err != nil {
+			global.LoggerZap.Error("Click publisher stopped", zap.Error(err))
+		}
+	}()
+	defer clickPublisher.Stop()
+
+	if geoDatabase := di.GlobalContainer.LinkContainer.GeoDatabase; geoDatabase != nil {
+		go func() {
+			if err := geoDatabase.Start(ctx); err != nil {
+				global.LoggerZap.Error("GeoIP reloader stopped", zap.Error(err))
+			}
+		}()
+		defer geoDatabase.Stop()
+	}
+
+	hotLinkWorker := di.GlobalContainer.HotLinkContainer.Worker
+	go func() {
+		if err := hotLinkWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Hot link worker stopped", zap.Error(err))
+		}
+	}()
+	defer hotLinkWorker.Stop()
+
+	botRulesWorker := di.GlobalContainer.TrafficContainer.Worker
+	go func() {
+		if err := botRulesWorker.Start(ctx); err != nil {
+			global.LoggerZap.Error("Bot rules worker stopped", zap.Error(err))
+		}
+	}()
+	defer botRulesWorker.Stop()
+
 	return http.Run()
 }
@@ -31,7 +31,12 @@ func NewServer(engine *gin.Engine) *Server {
 // NewHTTPServer creates the HTTP server using global dependencies
 func NewHTTPServer() *Server {
 	// Create router group with dependencies
-	routerGroup := NewRouterGroup(di.GlobalContainer.LinkContainer.Handler)
+	routerGroup := NewRouterGroup(
+		di.GlobalContainer.LinkContainer.Handler,
+		di.GlobalContainer.HotLinkContainer.Handler,
+		di.GlobalContainer.TrafficContainer.Handler,
+		di.GlobalContainer.BrandingContainer.Handler,
+	)
 
 	// Create Gin engine
 	engine := NewEngine(routerGroup)
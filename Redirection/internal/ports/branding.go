@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+)
+
+type BrandingRepository interface {
+	Get(ctx context.Context, tenantID int) (*entity.Branding, error)
+	Save(ctx context.Context, branding *entity.Branding) error
+}
+
+type BrandingCacheRepository interface {
+	Get(ctx context.Context, tenantID int) (*entity.Branding, error)
+	Set(ctx context.Context, branding *entity.Branding) error
+	Delete(ctx context.Context, tenantID int) error
+	GetDomainTenant(ctx context.Context, host string) (int, bool)
+	SetDomainTenant(ctx context.Context, host string, tenantID int) error
+}
+
+type BrandingService interface {
+	GetBranding(ctx context.Context, req *dto.GetBrandingRequest) (*dto.BrandingResponse, error)
+	UpdateBranding(ctx context.Context, req *dto.UpdateBrandingRequest) (*dto.BrandingResponse, error)
+	// ForHost returns the branding for a request host, or nil when the host is not a tenant's verified custom domain.
+	ForHost(ctx context.Context, host string) *entity.Branding
+}
@@ -0,0 +1,8 @@
+package ports
+
+import "go-link/redirection/internal/core/entity"
+
+// GeoLocator places a client IP at city level; it returns nil when the IP is unknown.
+type GeoLocator interface {
+	Locate(ip string) *entity.GeoLocation
+}
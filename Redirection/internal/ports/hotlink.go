@@ -0,0 +1,44 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
+)
+
+// HotLinkTracker detects the busiest short codes over fixed windows.
+type HotLinkTracker interface {
+	Record(shortCode string)
+	Rotate() *entity.HotLinkWindow
+	Top(limit int) []*entity.HotLink
+}
+
+// PinnedLinks holds hot links in process memory so they never miss.
+type PinnedLinks interface {
+	Get(id string) (*entity.Link, bool)
+	Replace(links []*entity.Link)
+	Remove(ids []string)
+}
+
+// LinkClickRepository keeps running click totals so offline tools, like the rebuild, can find the most visited links.
+type LinkClickRepository interface {
+	AddClicks(ctx context.Context, links []*entity.HotLink) error
+	Top(ctx context.Context, limit int) ([]string, error)
+}
+
+type SpikePublisher interface {
+	Publish(ctx context.Context, evt *linkv1.LinkSpikeEvent)
+}
+
+type HotLinkService interface {
+	GetTop(ctx context.Context, req *dto.HotLinksRequest) (*dto.HotLinksResponse, error)
+	Rotate(ctx context.Context) error
+}
+
+type HotLinkWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
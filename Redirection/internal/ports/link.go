@@ -2,30 +2,83 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"go-link/common/pkg/cdc"
+	"go-link/common/pkg/database/widecolumn"
 
+	"go-link/redirection/internal/core/dto"
 	"go-link/redirection/internal/core/entity"
+
+	linkv1 "github.com/huynhanx03/GoLink/events-contract/link/v1"
 )
 
 type LinkRepository interface {
 	GetOriginalURL(ctx context.Context, shortCode string) (*entity.Link, error)
 	CreateBulk(ctx context.Context, links []*entity.Link) error
 	DeleteBulk(ctx context.Context, ids []string) error
+	ScanIDs(ctx context.Context, fn func(id string)) error
+	ScanRange(ctx context.Context, rng widecolumn.TokenRange, fn func(token int64, link *entity.Link) error) error
+	ApplyChanges(ctx context.Context, changes []*entity.LinkChange) error
 }
 
 type LinkCacheRepository interface {
 	Set(ctx context.Context, link *entity.Link) error
-	Get(ctx context.Context, id string) (*entity.Link, error)
+	// Get returns the cached link and whether it is past its soft TTL and should be refreshed.
+	Get(ctx context.Context, id string) (*entity.Link, bool, error)
 	DeleteBulk(ctx context.Context, ids []string) error
+	SetMiss(ctx context.Context, id string) error
+	IsMiss(ctx context.Context, id string) bool
+	DeleteMissBulk(ctx context.Context, ids []string) error
+	AcquireLoadLock(ctx context.Context, id, token string) (bool, error)
+	ReleaseLoadLock(ctx context.Context, id, token string) error
+	ConsumeNonce(ctx context.Context, id, nonce string, ttl time.Duration) (bool, error)
+}
+
+// FallbackLimiter caps the lookups all instances together send to Generation for codes missing here.
+type FallbackLimiter interface {
+	Allow(ctx context.Context) bool
+}
+
+// LinkMetrics exposes the health of the link pipeline.
+type LinkMetrics interface {
+	ObserveCDCLag(lag time.Duration)
+	RecordFallback(outcome string)
+}
+
+// LinkFilter is a probabilistic existence index of short codes.
+// MightContain never returns false for a code that exists.
+type LinkFilter interface {
+	Add(id string)
+	MightContain(id string) bool
+	Rebuild(ctx context.Context, scan func(ctx context.Context, fn func(id string)) error) error
+}
+
+// MissLimiter throttles clients that keep requesting unknown short codes.
+type MissLimiter interface {
+	Blocked(ctx context.Context, clientIP string) bool
+	Record(ctx context.Context, clientIP string)
+}
+
+// ClickPublisher emits click events without blocking the redirect path.
+type ClickPublisher interface {
+	Publish(evt *linkv1.LinkClickedEvent)
+	Start(ctx context.Context) error
+	Stop()
 }
 
 type LinkService interface {
-	GetOriginalURL(ctx context.Context, shortCode string) (string, error)
+	GetOriginalURL(ctx context.Context, req *dto.RedirectRequest) (*dto.RedirectResponse, error)
 	HandleLinkBatchChange(ctx context.Context, batch []*cdc.DebeziumPayload[entity.Link]) error
+	RebuildFilter(ctx context.Context) error
 }
 
 type LinkConsumer interface {
 	Start(ctx context.Context) error
 	Stop() error
 }
+
+type LinkFilterWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/common/pkg/utils"
+)
+
+type MembershipCacheRepository interface {
+	Get(ctx context.Context, tenantID, userID int) (member bool, found bool)
+	Set(ctx context.Context, tenantID, userID int, member bool) error
+}
+
+// MembershipService decides whether the holder of an access token belongs to a tenant.
+type MembershipService interface {
+	// ParseAccessToken validates an Identity access token with the same key as the Authentication middleware.
+	ParseAccessToken(token string) (*utils.Claims, error)
+	IsMember(ctx context.Context, claims *utils.Claims, tenantID int) (bool, error)
+	// LoginURL returns the Identity login page that posts the token and state back to callbackURL, or false when none is configured.
+	LoginURL(callbackURL, state string) (string, bool)
+	// TokenCookie names the cookie that carries the access token between visits.
+	TokenCookie() string
+}
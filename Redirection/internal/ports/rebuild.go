@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+)
+
+// RebuildCheckpointStore persists rebuild progress between runs. Load returns nil when there is nothing to resume.
+type RebuildCheckpointStore interface {
+	Load(ctx context.Context) (*entity.RebuildCheckpoint, error)
+	Save(ctx context.Context, checkpoint *entity.RebuildCheckpoint) error
+	Clear(ctx context.Context) error
+}
+
+// RebuildService repopulates the redirect store from Generation and reports where the two have drifted.
+type RebuildService interface {
+	Rebuild(ctx context.Context, req *dto.RebuildRequest) (*dto.RebuildReport, error)
+	Diff(ctx context.Context, req *dto.DiffRequest) (*dto.DiffReport, error)
+}
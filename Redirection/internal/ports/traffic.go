@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"context"
+
+	"go-link/redirection/internal/core/dto"
+	"go-link/redirection/internal/core/entity"
+)
+
+// BotClassifier tags redirects as human, bot or link preview.
+type BotClassifier interface {
+	Classify(sig *entity.ClickSignal) entity.TrafficClass
+	ResetWindow()
+	// Reload picks up a changed rules file and reports whether the rules were replaced.
+	Reload() (bool, error)
+}
+
+type TrafficSettingsRepository interface {
+	Get(ctx context.Context, tenantID int) (*entity.TrafficSettings, error)
+	Save(ctx context.Context, settings *entity.TrafficSettings) error
+}
+
+type TrafficSettingsCacheRepository interface {
+	Get(ctx context.Context, tenantID int) (*entity.TrafficSettings, error)
+	Set(ctx context.Context, settings *entity.TrafficSettings) error
+	Delete(ctx context.Context, tenantID int) error
+}
+
+type TrafficService interface {
+	GetSettings(ctx context.Context, req *dto.GetTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error)
+	UpdateSettings(ctx context.Context, req *dto.UpdateTrafficSettingsRequest) (*dto.TrafficSettingsResponse, error)
+	// ExcludesBots reports whether the tenant keeps bot and preview clicks out of analytics.
+	ExcludesBots(ctx context.Context, tenantID int) bool
+}
+
+type BotRulesWorker interface {
+	Start(ctx context.Context) error
+	Stop()
+}
@@ -0,0 +1,35 @@
+package linkv1
+
+// LinkClickedEvent represents a single resolved redirect.
+type LinkClickedEvent struct {
+	EventID        string `json:"event_id"`
+	ShortCode      string `json:"short_code"`
+	TenantID       int    `json:"tenant_id"`
+	Timestamp      int64  `json:"timestamp"` // Unix milliseconds
+	IPHash         string `json:"ip_hash"`
+	UserAgent      string `json:"user_agent"`
+	Referrer       string `json:"referrer"`
+	AcceptLanguage string `json:"accept_language"`
+	Traffic        string `json:"traffic"` // human, bot or preview
+	Variant        string `json:"variant,omitempty"`
+	Campaign       string `json:"campaign,omitempty"`   // utm_campaign of the destination
+	UTMSource      string `json:"utm_source,omitempty"` // utm_source of the short link visit, else of the destination
+	UTMMedium      string `json:"utm_medium,omitempty"` // utm_medium of the short link visit, else of the destination
+	ClickID        string `json:"click_id,omitempty"`   // glclid appended to the destination, if enabled
+	// Location of the client IP, looked up before the IP is hashed; coordinates are the city's, rounded
+	Country   string  `json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Region    string  `json:"region,omitempty"`
+	City      string  `json:"city,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+}
+
+// LinkSpikeEvent is published when a single link's traffic jumps sharply within one tracking window.
+type LinkSpikeEvent struct {
+	ShortCode     string `json:"short_code"`
+	TenantID      int    `json:"tenant_id"`
+	Clicks        int64  `json:"clicks"`
+	PrevClicks    int64  `json:"prev_clicks"`
+	WindowSeconds int64  `json:"window_seconds"`
+	Timestamp     int64  `json:"timestamp"` // Unix milliseconds
+}
@@ -9,6 +9,7 @@ type NotificationSendEvent struct {
 	Priority       string            `json:"priority"`
 	Recipient      Recipient         `json:"recipient"`
 	TemplateData   map[string]string `json:"template_data"`
+	CollapseKey    string            `json:"collapse_key,omitempty"` // Repeats within the collapse window are folded into a digest
 	RetryCount     int               `json:"retry_count"`
 	LastError      string            `json:"last_error"`
 }
@@ -1,7 +1,10 @@
 package topics
 
 const (
-	LinkCDC = "golink.links.generation_ks.links"
+	LinkCDC     = "golink.links.generation_ks.links"
+	LinkClicked = "link.clicked"
+	LinkCDCDLQ  = "golink.links.generation_ks.links.dlq"
+	LinkSpike   = "link.spike"
 
 	NotificationSend   = "notification.send"
 	NotificationRetry  = "notification.send.retry"